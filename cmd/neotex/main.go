@@ -30,10 +30,29 @@ Environment variables:
 
 	rootCmd.AddCommand(client.InitCmd())
 	rootCmd.AddCommand(client.PullCmd())
+	rootCmd.AddCommand(client.SyncCmd())
 	rootCmd.AddCommand(client.SearchCmd())
 	rootCmd.AddCommand(client.GetCmd())
 	rootCmd.AddCommand(client.AddCmd())
+	rootCmd.AddCommand(client.NewCmd())
+	rootCmd.AddCommand(client.UpdateCmd())
 	rootCmd.AddCommand(client.DeleteCmd())
+	rootCmd.AddCommand(client.MvCmd())
+	rootCmd.AddCommand(client.MergeCmd())
+	rootCmd.AddCommand(client.ImportCmd())
+	rootCmd.AddCommand(client.ExportCmd())
+	rootCmd.AddCommand(client.RestoreCmd())
+	rootCmd.AddCommand(client.ReviewCmd())
+	rootCmd.AddCommand(client.StaleCmd())
+	rootCmd.AddCommand(client.HistoryCmd())
+	rootCmd.AddCommand(client.DiffCmd())
+	rootCmd.AddCommand(client.RevertCmd())
+	rootCmd.AddCommand(client.RelateCmd())
+	rootCmd.AddCommand(client.TypesCmd())
+	rootCmd.AddCommand(client.LintCmd())
+	rootCmd.AddCommand(client.LintConfigCmd())
+	rootCmd.AddCommand(client.ChecklistCmd())
+	rootCmd.AddCommand(client.CommentCmd())
 	rootCmd.AddCommand(client.AssetCmd())
 	rootCmd.AddCommand(client.EvalCmd())
 	rootCmd.AddCommand(client.AuthCmd())
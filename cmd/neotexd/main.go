@@ -13,13 +13,16 @@ func main() {
 	rootCmd := &cobra.Command{
 		Use:   "neotexd",
 		Short: "Neotex daemon and CLI",
-		Long:  "Neotex daemon for running the API server and managing organizations and API keys",
+		Long:  "Neotex daemon for running the API server and managing organizations, API keys, knowledge purges and archives",
 	}
 
 	cli.AddHelpJSONFlag(rootCmd)
 	rootCmd.AddCommand(admin.ServeCmd())
 	rootCmd.AddCommand(admin.OrgCmd())
 	rootCmd.AddCommand(admin.APIKeyCmd())
+	rootCmd.AddCommand(admin.KnowledgeCmd())
+	rootCmd.AddCommand(admin.ExportCmd())
+	rootCmd.AddCommand(admin.RestoreCmd())
 
 	if len(os.Args) == 1 {
 		os.Args = append(os.Args, "serve")
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type PurgeService interface {
+	PurgeKnowledge(ctx context.Context, input service.PurgeKnowledgeInput) (*domain.KnowledgeTombstone, error)
+	ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error)
+}
+
+// AdminHandler serves the operator endpoints. Requests are authorized by the admin
+// token instead of an org API key, so they are not scoped to an org.
+type AdminHandler struct {
+	purgeSvc PurgeService
+}
+
+func NewAdminHandler(purgeSvc PurgeService) *AdminHandler {
+	return &AdminHandler{purgeSvc: purgeSvc}
+}
+
+type PurgeKnowledgeRequest struct {
+	By     string `json:"by"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type KnowledgeTombstoneResponse struct {
+	ID                string `json:"id"`
+	KnowledgeID       string `json:"knowledge_id"`
+	OrgID             string `json:"org_id"`
+	ProjectID         string `json:"project_id,omitempty"`
+	Type              string `json:"type"`
+	PurgedBy          string `json:"purged_by"`
+	Reason            string `json:"reason,omitempty"`
+	PurgedAt          string `json:"purged_at"`
+	VersionCount      int    `json:"version_count"`
+	ChunkCount        int    `json:"chunk_count"`
+	AssetLinkCount    int    `json:"asset_link_count"`
+	EmbeddingJobCount int    `json:"embedding_job_count"`
+	SearchLogCount    int    `json:"search_log_count"`
+}
+
+type KnowledgeTombstoneListResponse struct {
+	Tombstones []*KnowledgeTombstoneResponse `json:"tombstones"`
+}
+
+func tombstoneToResponse(t *domain.KnowledgeTombstone) *KnowledgeTombstoneResponse {
+	return &KnowledgeTombstoneResponse{
+		ID:                t.ID,
+		KnowledgeID:       t.KnowledgeID,
+		OrgID:             t.OrgID,
+		ProjectID:         t.ProjectID,
+		Type:              string(t.Type),
+		PurgedBy:          t.PurgedBy,
+		Reason:            t.Reason,
+		PurgedAt:          t.PurgedAt.Format("2006-01-02T15:04:05Z"),
+		VersionCount:      t.Versions,
+		ChunkCount:        t.Chunks,
+		AssetLinkCount:    t.AssetLinks,
+		EmbeddingJobCount: t.EmbeddingJobs,
+		SearchLogCount:    t.SearchLogs,
+	}
+}
+
+func (h *AdminHandler) PurgeKnowledge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req PurgeKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.By == "" {
+		api.Error(w, http.StatusBadRequest, "by is required")
+		return
+	}
+
+	tombstone, err := h.purgeSvc.PurgeKnowledge(r.Context(), service.PurgeKnowledgeInput{
+		KnowledgeID: id,
+		By:          req.By,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, tombstoneToResponse(tombstone))
+}
+
+func (h *AdminHandler) ListTombstones(w http.ResponseWriter, r *http.Request) {
+	orgID := r.URL.Query().Get("org_id")
+	if orgID == "" {
+		api.Error(w, http.StatusBadRequest, "org_id is required")
+		return
+	}
+
+	tombstones, err := h.purgeSvc.ListTombstones(r.Context(), orgID)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	responses := make([]*KnowledgeTombstoneResponse, len(tombstones))
+	for i, t := range tombstones {
+		responses[i] = tombstoneToResponse(t)
+	}
+
+	api.Success(w, http.StatusOK, KnowledgeTombstoneListResponse{Tombstones: responses})
+}
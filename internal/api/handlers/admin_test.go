@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPurgeService struct {
+	mock.Mock
+}
+
+func (m *MockPurgeService) PurgeKnowledge(ctx context.Context, input service.PurgeKnowledgeInput) (*domain.KnowledgeTombstone, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeTombstone), args.Error(1)
+}
+
+func (m *MockPurgeService) ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeTombstone), args.Error(1)
+}
+
+func newTestTombstone() *domain.KnowledgeTombstone {
+	tombstone := domain.NewKnowledgeTombstone("t-1", "k-123", "dpo@example.com", "ERASURE-42", time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+	tombstone.OrgID = "org-456"
+	tombstone.Type = domain.KnowledgeTypeLearning
+	tombstone.Versions = 2
+	tombstone.Chunks = 5
+	tombstone.SearchLogs = 1
+	return tombstone
+}
+
+func TestAdminHandler_PurgeKnowledge_Success(t *testing.T) {
+	mockSvc := new(MockPurgeService)
+	handler := NewAdminHandler(mockSvc)
+
+	mockSvc.On("PurgeKnowledge", mock.Anything, service.PurgeKnowledgeInput{
+		KnowledgeID: "k-123",
+		By:          "dpo@example.com",
+		Reason:      "ERASURE-42",
+	}).Return(newTestTombstone(), nil)
+
+	body := `{"by":"dpo@example.com","reason":"ERASURE-42"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/knowledge/k-123/purge", bytes.NewBufferString(body))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.PurgeKnowledge(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "k-123", data["knowledge_id"])
+	assert.Equal(t, "2026-03-04T05:06:07Z", data["purged_at"])
+	assert.Equal(t, float64(5), data["chunk_count"])
+	assert.NotContains(t, data, "title")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestAdminHandler_PurgeKnowledge_MissingBy(t *testing.T) {
+	mockSvc := new(MockPurgeService)
+	handler := NewAdminHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/knowledge/k-123/purge", bytes.NewBufferString(`{"reason":"ERASURE-42"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.PurgeKnowledge(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "by is required")
+}
+
+func TestAdminHandler_PurgeKnowledge_NotFound(t *testing.T) {
+	mockSvc := new(MockPurgeService)
+	handler := NewAdminHandler(mockSvc)
+
+	mockSvc.On("PurgeKnowledge", mock.Anything, mock.Anything).Return(nil, domain.ErrKnowledgeNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/knowledge/k-404/purge", bytes.NewBufferString(`{"by":"dpo@example.com"}`))
+	req = withURLParams(req, map[string]string{"id": "k-404"})
+	w := httptest.NewRecorder()
+
+	handler.PurgeKnowledge(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminHandler_ListTombstones(t *testing.T) {
+	mockSvc := new(MockPurgeService)
+	handler := NewAdminHandler(mockSvc)
+
+	mockSvc.On("ListTombstones", mock.Anything, "org-456").Return([]*domain.KnowledgeTombstone{newTestTombstone()}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/tombstones?org_id=org-456", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTombstones(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	tombstones := resp["data"].(map[string]interface{})["tombstones"].([]interface{})
+	assert.Len(t, tombstones, 1)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestAdminHandler_ListTombstones_MissingOrg(t *testing.T) {
+	mockSvc := new(MockPurgeService)
+	handler := NewAdminHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/tombstones", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTombstones(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
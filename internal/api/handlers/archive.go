@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ArchiveService interface {
+	Snapshot(ctx context.Context, orgID string) (*service.OrgSnapshot, error)
+	WriteArchive(ctx context.Context, snapshot *service.OrgSnapshot, w io.Writer) error
+	Restore(ctx context.Context, input service.RestoreInput) (*service.RestoreResult, error)
+}
+
+// ArchiveHandler exports the org of the API key as a gzipped tarball and restores such
+// archives into an org named by an operator
+type ArchiveHandler struct {
+	svc ArchiveService
+}
+
+func NewArchiveHandler(svc ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc}
+}
+
+// Export streams the archive. The snapshot is read before anything is written so that
+// its errors still get a JSON response; a failure while streaming truncates the archive.
+func (h *ArchiveHandler) Export(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	snapshot, err := h.svc.Snapshot(r.Context(), orgID)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("neotex-%s-%s.tar.gz", orgID, time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.svc.WriteArchive(r.Context(), snapshot, w); err != nil {
+		log.Printf("export of org %s failed: %v", orgID, err)
+	}
+}
+
+// Restore reads an archive from the request body into the org in the path. remap_ids=true
+// gives the restored items new IDs and embeddings=true queues embedding jobs for them.
+func (h *ArchiveHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	orgID := chi.URLParam(r, "orgID")
+	if orgID == "" {
+		api.Error(w, http.StatusBadRequest, "org id is required")
+		return
+	}
+
+	remapIDs, err := parseBoolQuery(r, "remap_ids")
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "remap_ids must be a boolean")
+		return
+	}
+	queueEmbeddings, err := parseBoolQuery(r, "embeddings")
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "embeddings must be a boolean")
+		return
+	}
+
+	result, err := h.svc.Restore(r.Context(), service.RestoreInput{
+		OrgID:           orgID,
+		Archive:         r.Body,
+		RemapIDs:        remapIDs,
+		QueueEmbeddings: queueEmbeddings,
+	})
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			api.Error(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, result)
+}
+
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
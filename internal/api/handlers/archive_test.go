@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockArchiveService struct {
+	mock.Mock
+}
+
+func (m *MockArchiveService) Snapshot(ctx context.Context, orgID string) (*service.OrgSnapshot, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.OrgSnapshot), args.Error(1)
+}
+
+func (m *MockArchiveService) WriteArchive(ctx context.Context, snapshot *service.OrgSnapshot, w io.Writer) error {
+	args := m.Called(ctx, snapshot, w)
+	_, _ = w.Write([]byte("archive"))
+	return args.Error(0)
+}
+
+func (m *MockArchiveService) Restore(ctx context.Context, input service.RestoreInput) (*service.RestoreResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RestoreResult), args.Error(1)
+}
+
+func TestArchiveHandler_Export_Success(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	snapshot := &service.OrgSnapshot{OrgID: "org-456"}
+	mockSvc.On("Snapshot", mock.Anything, "org-456").Return(snapshot, nil)
+	mockSvc.On("WriteArchive", mock.Anything, snapshot, mock.Anything).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler.Export(rr, requestWithOrgID(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/gzip", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), `filename="neotex-org-456-`)
+	assert.Equal(t, "archive", rr.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestArchiveHandler_Export_SnapshotError(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	mockSvc.On("Snapshot", mock.Anything, "org-456").Return(nil, domain.ErrOrganizationNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.Export(rr, requestWithOrgID(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	mockSvc.AssertNotCalled(t, "WriteArchive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func restoreRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/admin/orgs/org-456/restore"+query, bytes.NewReader([]byte("archive")))
+	return withURLParams(req, map[string]string{"orgID": "org-456"})
+}
+
+func TestArchiveHandler_Restore_Success(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	mockSvc.On("Restore", mock.Anything, mock.MatchedBy(func(input service.RestoreInput) bool {
+		return input.OrgID == "org-456" && input.RemapIDs && !input.QueueEmbeddings && input.Archive != nil
+	})).Return(&service.RestoreResult{Knowledge: 2, Versions: 3}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Restore(rr, restoreRequest("?remap_ids=true"))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var resp struct {
+		Data service.RestoreResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Data.Knowledge)
+	assert.Equal(t, 3, resp.Data.Versions)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestArchiveHandler_Restore_InvalidFlag(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	rr := httptest.NewRecorder()
+	handler.Restore(rr, restoreRequest("?embeddings=maybe"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSvc.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestArchiveHandler_Restore_IDConflict(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	mockSvc.On("Restore", mock.Anything, mock.Anything).Return(nil, domain.ErrArchiveIDConflict)
+
+	rr := httptest.NewRecorder()
+	handler.Restore(rr, restoreRequest(""))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestArchiveHandler_Restore_TooLarge(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	mockSvc.On("Restore", mock.Anything, mock.Anything).Return(nil,
+		domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", &http.MaxBytesError{Limit: 10}))
+
+	rr := httptest.NewRecorder()
+	handler.Restore(rr, restoreRequest(""))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
+func TestArchiveHandler_Unauthorized(t *testing.T) {
+	handler := NewArchiveHandler(new(MockArchiveService))
+
+	rr := httptest.NewRecorder()
+	handler.Export(rr, httptest.NewRequest(http.MethodGet, "/export", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+}
+
+func TestArchiveHandler_Restore_MissingOrg(t *testing.T) {
+	mockSvc := new(MockArchiveService)
+	handler := NewArchiveHandler(mockSvc)
+
+	rr := httptest.NewRecorder()
+	handler.Restore(rr, httptest.NewRequest(http.MethodPost, "/admin/orgs//restore", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockSvc.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ChecklistService interface {
+	GetChecklist(ctx context.Context, orgID, knowledgeID string, version int64) (*domain.Checklist, error)
+	StartRun(ctx context.Context, input service.StartChecklistRunInput) (*domain.ChecklistRun, error)
+	GetRun(ctx context.Context, orgID, runID string) (*domain.ChecklistRun, error)
+	ListRuns(ctx context.Context, orgID, knowledgeID string) ([]*domain.ChecklistRun, error)
+	CheckItem(ctx context.Context, input service.CheckChecklistItemInput) (*domain.ChecklistRun, error)
+	CompleteRun(ctx context.Context, input service.CompleteChecklistRunInput) (*domain.ChecklistRun, error)
+}
+
+type ChecklistHandler struct {
+	svc ChecklistService
+}
+
+func NewChecklistHandler(svc ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{svc: svc}
+}
+
+type StartChecklistRunRequest struct {
+	Version   int64  `json:"version,omitempty"`
+	StartedBy string `json:"started_by"`
+	Note      string `json:"note,omitempty"`
+}
+
+type CheckChecklistItemRequest struct {
+	Checked *bool  `json:"checked"`
+	By      string `json:"by"`
+	Note    string `json:"note,omitempty"`
+}
+
+type CompleteChecklistRunRequest struct {
+	By string `json:"by"`
+}
+
+type ChecklistItemResponse struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Section string `json:"section,omitempty"`
+	Checked bool   `json:"checked"`
+}
+
+type ChecklistResponse struct {
+	KnowledgeID   string                   `json:"knowledge_id"`
+	VersionNumber int64                    `json:"version_number"`
+	Title         string                   `json:"title"`
+	Items         []*ChecklistItemResponse `json:"items"`
+}
+
+type ChecklistRunItemResponse struct {
+	Number    int    `json:"number"`
+	Text      string `json:"text"`
+	Section   string `json:"section,omitempty"`
+	Checked   bool   `json:"checked"`
+	CheckedBy string `json:"checked_by,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+type ChecklistRunResponse struct {
+	ID            string                      `json:"id"`
+	KnowledgeID   string                      `json:"knowledge_id"`
+	VersionNumber int64                       `json:"version_number"`
+	Status        string                      `json:"status"`
+	StartedBy     string                      `json:"started_by"`
+	StartedAt     string                      `json:"started_at"`
+	CompletedBy   string                      `json:"completed_by,omitempty"`
+	CompletedAt   string                      `json:"completed_at,omitempty"`
+	Note          string                      `json:"note,omitempty"`
+	Unchecked     []int                       `json:"unchecked"`
+	Items         []*ChecklistRunItemResponse `json:"items"`
+}
+
+type ChecklistRunListResponse struct {
+	Runs []*ChecklistRunResponse `json:"runs"`
+}
+
+func checklistToResponse(c *domain.Checklist) *ChecklistResponse {
+	items := make([]*ChecklistItemResponse, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = &ChecklistItemResponse{
+			Number:  item.Number,
+			Text:    item.Text,
+			Section: item.Section,
+			Checked: item.Checked,
+		}
+	}
+	return &ChecklistResponse{
+		KnowledgeID:   c.KnowledgeID,
+		VersionNumber: c.VersionNumber,
+		Title:         c.Title,
+		Items:         items,
+	}
+}
+
+func checklistRunToResponse(run *domain.ChecklistRun) *ChecklistRunResponse {
+	items := make([]*ChecklistRunItemResponse, len(run.Items))
+	for i, item := range run.Items {
+		items[i] = &ChecklistRunItemResponse{
+			Number:    item.Number,
+			Text:      item.Text,
+			Section:   item.Section,
+			Checked:   item.IsChecked(),
+			CheckedBy: item.CheckedBy,
+			CheckedAt: formatOptionalTime(item.CheckedAt),
+			Note:      item.Note,
+		}
+	}
+
+	unchecked := run.UncheckedItems()
+	if unchecked == nil {
+		unchecked = []int{}
+	}
+
+	return &ChecklistRunResponse{
+		ID:            run.ID,
+		KnowledgeID:   run.KnowledgeID,
+		VersionNumber: run.VersionNumber,
+		Status:        string(run.Status),
+		StartedBy:     run.StartedBy,
+		StartedAt:     run.StartedAt.Format("2006-01-02T15:04:05Z"),
+		CompletedBy:   run.CompletedBy,
+		CompletedAt:   formatOptionalTime(run.CompletedAt),
+		Note:          run.Note,
+		Unchecked:     unchecked,
+		Items:         items,
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05Z")
+}
+
+func (h *ChecklistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	version, ok := parseVersionParam(r.URL.Query().Get("version"))
+	if !ok {
+		api.Error(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	checklist, err := h.svc.GetChecklist(r.Context(), orgID, id, version)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, checklistToResponse(checklist))
+}
+
+func (h *ChecklistHandler) StartRun(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req StartChecklistRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.StartedBy == "" {
+		api.Error(w, http.StatusBadRequest, "started_by is required")
+		return
+	}
+	if req.Version < 0 {
+		api.Error(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	run, err := h.svc.StartRun(r.Context(), service.StartChecklistRunInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		Version:     req.Version,
+		StartedBy:   req.StartedBy,
+		Note:        req.Note,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, checklistRunToResponse(run))
+}
+
+func (h *ChecklistHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	runs, err := h.svc.ListRuns(r.Context(), orgID, id)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	responses := make([]*ChecklistRunResponse, len(runs))
+	for i, run := range runs {
+		responses[i] = checklistRunToResponse(run)
+	}
+
+	api.Success(w, http.StatusOK, ChecklistRunListResponse{Runs: responses})
+}
+
+func (h *ChecklistHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	runID := chi.URLParam(r, "runID")
+	if runID == "" {
+		api.Error(w, http.StatusBadRequest, "run id is required")
+		return
+	}
+
+	run, err := h.svc.GetRun(r.Context(), orgID, runID)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, checklistRunToResponse(run))
+}
+
+func (h *ChecklistHandler) CheckItem(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	runID := chi.URLParam(r, "runID")
+	if runID == "" {
+		api.Error(w, http.StatusBadRequest, "run id is required")
+		return
+	}
+
+	number, err := strconv.Atoi(chi.URLParam(r, "number"))
+	if err != nil || number <= 0 {
+		api.Error(w, http.StatusBadRequest, "invalid item number")
+		return
+	}
+
+	var req CheckChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	checked := true
+	if req.Checked != nil {
+		checked = *req.Checked
+	}
+	if checked && req.By == "" {
+		api.Error(w, http.StatusBadRequest, "by is required")
+		return
+	}
+
+	run, err := h.svc.CheckItem(r.Context(), service.CheckChecklistItemInput{
+		OrgID:   orgID,
+		RunID:   runID,
+		Number:  number,
+		Checked: checked,
+		By:      req.By,
+		Note:    req.Note,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, checklistRunToResponse(run))
+}
+
+func (h *ChecklistHandler) CompleteRun(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	runID := chi.URLParam(r, "runID")
+	if runID == "" {
+		api.Error(w, http.StatusBadRequest, "run id is required")
+		return
+	}
+
+	var req CompleteChecklistRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.By == "" {
+		api.Error(w, http.StatusBadRequest, "by is required")
+		return
+	}
+
+	run, err := h.svc.CompleteRun(r.Context(), service.CompleteChecklistRunInput{
+		OrgID: orgID,
+		RunID: runID,
+		By:    req.By,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, checklistRunToResponse(run))
+}
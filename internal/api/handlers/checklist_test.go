@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockChecklistService struct {
+	mock.Mock
+}
+
+func (m *MockChecklistService) GetChecklist(ctx context.Context, orgID, knowledgeID string, version int64) (*domain.Checklist, error) {
+	args := m.Called(ctx, orgID, knowledgeID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Checklist), args.Error(1)
+}
+
+func (m *MockChecklistService) StartRun(ctx context.Context, input service.StartChecklistRunInput) (*domain.ChecklistRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistService) GetRun(ctx context.Context, orgID, runID string) (*domain.ChecklistRun, error) {
+	args := m.Called(ctx, orgID, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistService) ListRuns(ctx context.Context, orgID, knowledgeID string) ([]*domain.ChecklistRun, error) {
+	args := m.Called(ctx, orgID, knowledgeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistService) CheckItem(ctx context.Context, input service.CheckChecklistItemInput) (*domain.ChecklistRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistService) CompleteRun(ctx context.Context, input service.CompleteChecklistRunInput) (*domain.ChecklistRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChecklistRun), args.Error(1)
+}
+
+func newTestChecklistRun() *domain.ChecklistRun {
+	return domain.NewChecklistRun("run-1", "org-456", &domain.Checklist{
+		KnowledgeID:   "k-123",
+		VersionNumber: 2,
+		Items: []domain.ChecklistItem{
+			{Number: 1, Text: "Freeze main", Section: "Before"},
+			{Number: 2, Text: "Tag the release", Section: "After"},
+		},
+	}, "alice", "v1.2.0", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func TestChecklistHandler_Get(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("GetChecklist", mock.Anything, "org-456", "k-123", int64(1)).Return(&domain.Checklist{
+		KnowledgeID:   "k-123",
+		VersionNumber: 1,
+		Title:         "Release checklist",
+		Items: []domain.ChecklistItem{
+			{Number: 1, Text: "Freeze main", Section: "Before", Checked: true},
+		},
+	}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/checklist?version=1", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, float64(1), data["version_number"])
+	items := data["items"].([]interface{})
+	require.Len(t, items, 1)
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, "Freeze main", item["text"])
+	assert.Equal(t, "Before", item["section"])
+	assert.Equal(t, true, item["checked"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestChecklistHandler_Get_NotAChecklist(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("GetChecklist", mock.Anything, "org-456", "k-123", int64(0)).Return(nil, domain.ErrNotAChecklist)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/checklist", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "not a checklist")
+}
+
+func TestChecklistHandler_StartRun(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("StartRun", mock.Anything, service.StartChecklistRunInput{
+		OrgID:       "org-456",
+		KnowledgeID: "k-123",
+		Version:     2,
+		StartedBy:   "alice",
+		Note:        "v1.2.0",
+	}).Return(newTestChecklistRun(), nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/checklist/runs", []byte(`{"version":2,"started_by":"alice","note":"v1.2.0"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.StartRun(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "run-1", data["id"])
+	assert.Equal(t, "in_progress", data["status"])
+	assert.Equal(t, float64(2), data["version_number"])
+	assert.Equal(t, "2026-01-02T03:04:05Z", data["started_at"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, data["unchecked"])
+	assert.Len(t, data["items"], 2)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestChecklistHandler_StartRun_RequiresStartedBy(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/checklist/runs", []byte(`{}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.StartRun(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "started_by is required")
+	mockSvc.AssertNotCalled(t, "StartRun", mock.Anything, mock.Anything)
+}
+
+func TestChecklistHandler_ListRuns(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("ListRuns", mock.Anything, "org-456", "k-123").Return([]*domain.ChecklistRun{newTestChecklistRun()}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/checklist/runs", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.ListRuns(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Len(t, data["runs"], 1)
+}
+
+func TestChecklistHandler_CheckItem(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	run := newTestChecklistRun()
+	checkedAt := time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)
+	run.Items[0].CheckedBy = "bob"
+	run.Items[0].CheckedAt = &checkedAt
+	mockSvc.On("CheckItem", mock.Anything, service.CheckChecklistItemInput{
+		OrgID:   "org-456",
+		RunID:   "run-1",
+		Number:  1,
+		Checked: true,
+		By:      "bob",
+	}).Return(run, nil)
+
+	req := requestWithOrgID(http.MethodPut, "/checklist-runs/run-1/items/1", []byte(`{"by":"bob"}`))
+	req = withURLParams(req, map[string]string{"runID": "run-1", "number": "1"})
+	w := httptest.NewRecorder()
+
+	handler.CheckItem(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	item := data["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, item["checked"])
+	assert.Equal(t, "bob", item["checked_by"])
+	assert.Equal(t, "2026-01-02T04:00:00Z", item["checked_at"])
+	assert.Equal(t, []interface{}{float64(2)}, data["unchecked"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestChecklistHandler_CheckItem_Uncheck(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("CheckItem", mock.Anything, service.CheckChecklistItemInput{
+		OrgID:  "org-456",
+		RunID:  "run-1",
+		Number: 2,
+	}).Return(newTestChecklistRun(), nil)
+
+	req := requestWithOrgID(http.MethodPut, "/checklist-runs/run-1/items/2", []byte(`{"checked":false}`))
+	req = withURLParams(req, map[string]string{"runID": "run-1", "number": "2"})
+	w := httptest.NewRecorder()
+
+	handler.CheckItem(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestChecklistHandler_CheckItem_InvalidInput(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	tests := []struct {
+		number string
+		body   string
+		want   string
+	}{
+		{"abc", `{"by":"bob"}`, "invalid item number"},
+		{"0", `{"by":"bob"}`, "invalid item number"},
+		{"1", `{}`, "by is required"},
+		{"1", `not json`, "invalid request body"},
+	}
+
+	for _, tt := range tests {
+		req := requestWithOrgID(http.MethodPut, "/checklist-runs/run-1/items/"+tt.number, []byte(tt.body))
+		req = withURLParams(req, map[string]string{"runID": "run-1", "number": tt.number})
+		w := httptest.NewRecorder()
+
+		handler.CheckItem(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), tt.want)
+	}
+	mockSvc.AssertNotCalled(t, "CheckItem", mock.Anything, mock.Anything)
+}
+
+func TestChecklistHandler_CompleteRun_Incomplete(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("CompleteRun", mock.Anything, service.CompleteChecklistRunInput{
+		OrgID: "org-456",
+		RunID: "run-1",
+		By:    "carol",
+	}).Return(nil, domain.NewChecklistRunIncompleteError([]int{2}))
+
+	req := requestWithOrgID(http.MethodPost, "/checklist-runs/run-1/complete", []byte(`{"by":"carol"}`))
+	req = withURLParams(req, map[string]string{"runID": "run-1"})
+	w := httptest.NewRecorder()
+
+	handler.CompleteRun(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "checklist items not checked: 2")
+}
+
+func TestChecklistHandler_GetRun_NotFound(t *testing.T) {
+	mockSvc := new(MockChecklistService)
+	handler := NewChecklistHandler(mockSvc)
+
+	mockSvc.On("GetRun", mock.Anything, "org-456", "run-1").Return(nil, domain.ErrChecklistRunNotFound)
+
+	req := requestWithOrgID(http.MethodGet, "/checklist-runs/run-1", nil)
+	req = withURLParams(req, map[string]string{"runID": "run-1"})
+	w := httptest.NewRecorder()
+
+	handler.GetRun(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type CommentService interface {
+	Create(ctx context.Context, input service.CreateCommentInput) (*domain.KnowledgeComment, error)
+	List(ctx context.Context, input service.ListCommentsInput) ([]*domain.CommentThread, error)
+	Resolve(ctx context.Context, input service.ResolveCommentInput) (*domain.KnowledgeComment, error)
+	Delete(ctx context.Context, input service.DeleteCommentInput) error
+}
+
+type CommentHandler struct {
+	svc CommentService
+}
+
+func NewCommentHandler(svc CommentService) *CommentHandler {
+	return &CommentHandler{svc: svc}
+}
+
+// CreateCommentRequest opens a thread, or replies to one when parent_id is set. Threads can
+// be anchored to body lines [start_line, end_line) or to a chunk of the item.
+type CreateCommentRequest struct {
+	Body      string `json:"body"`
+	ParentID  string `json:"parent_id,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	ChunkID   string `json:"chunk_id,omitempty"`
+}
+
+// ResolveCommentRequest resolves a thread; resolved false reopens it
+type ResolveCommentRequest struct {
+	Resolved *bool `json:"resolved"`
+}
+
+type CommentLinesResponse struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+type CommentResponse struct {
+	ID              string                `json:"id"`
+	KnowledgeID     string                `json:"knowledge_id"`
+	ParentID        string                `json:"parent_id,omitempty"`
+	VersionNumber   int64                 `json:"version_number"`
+	Body            string                `json:"body"`
+	Author          string                `json:"author,omitempty"`
+	AuthorKeyID     string                `json:"author_key_id,omitempty"`
+	Lines           *CommentLinesResponse `json:"lines,omitempty"`
+	ChunkID         string                `json:"chunk_id,omitempty"`
+	Resolved        bool                  `json:"resolved"`
+	ResolvedBy      string                `json:"resolved_by,omitempty"`
+	ResolvedByKeyID string                `json:"resolved_by_key_id,omitempty"`
+	ResolvedAt      string                `json:"resolved_at,omitempty"`
+	CreatedAt       string                `json:"created_at"`
+}
+
+type CommentThreadResponse struct {
+	*CommentResponse
+	Replies []*CommentResponse `json:"replies"`
+}
+
+type CommentListResponse struct {
+	Threads []*CommentThreadResponse `json:"threads"`
+}
+
+func commentToResponse(c *domain.KnowledgeComment) *CommentResponse {
+	resp := &CommentResponse{
+		ID:              c.ID,
+		KnowledgeID:     c.KnowledgeID,
+		ParentID:        c.ParentID,
+		VersionNumber:   c.VersionNumber,
+		Body:            c.Body,
+		Author:          c.Author.Name,
+		AuthorKeyID:     c.Author.APIKeyID,
+		ChunkID:         c.ChunkID,
+		Resolved:        c.IsResolved(),
+		ResolvedBy:      c.ResolvedBy.Name,
+		ResolvedByKeyID: c.ResolvedBy.APIKeyID,
+		ResolvedAt:      formatOptionalTime(c.ResolvedAt),
+		CreatedAt:       c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if c.HasLineRange() {
+		resp.Lines = &CommentLinesResponse{StartLine: c.LineStart, EndLine: c.LineEnd}
+	}
+	return resp
+}
+
+func commentThreadsToResponse(threads []*domain.CommentThread) []*CommentThreadResponse {
+	responses := make([]*CommentThreadResponse, len(threads))
+	for i, thread := range threads {
+		replies := make([]*CommentResponse, len(thread.Replies))
+		for j, reply := range thread.Replies {
+			replies[j] = commentToResponse(reply)
+		}
+		responses[i] = &CommentThreadResponse{
+			CommentResponse: commentToResponse(thread.KnowledgeComment),
+			Replies:         replies,
+		}
+	}
+	return responses
+}
+
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Body == "" {
+		api.Error(w, http.StatusBadRequest, "body is required")
+		return
+	}
+
+	comment, err := h.svc.Create(r.Context(), service.CreateCommentInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		ParentID:    req.ParentID,
+		Body:        req.Body,
+		LineStart:   req.StartLine,
+		LineEnd:     req.EndLine,
+		ChunkID:     req.ChunkID,
+		Author:      middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, commentToResponse(comment))
+}
+
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	threads, err := h.svc.List(r.Context(), service.ListCommentsInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		Status:      r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, CommentListResponse{Threads: commentThreadsToResponse(threads)})
+}
+
+func (h *CommentHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	commentID := chi.URLParam(r, "commentID")
+	if commentID == "" {
+		api.Error(w, http.StatusBadRequest, "comment id is required")
+		return
+	}
+
+	// The body is optional; without one the thread is resolved
+	var req ResolveCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resolved := true
+	if req.Resolved != nil {
+		resolved = *req.Resolved
+	}
+
+	comment, err := h.svc.Resolve(r.Context(), service.ResolveCommentInput{
+		OrgID:     orgID,
+		CommentID: commentID,
+		Resolved:  resolved,
+		Author:    middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, commentToResponse(comment))
+}
+
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	commentID := chi.URLParam(r, "commentID")
+	if commentID == "" {
+		api.Error(w, http.StatusBadRequest, "comment id is required")
+		return
+	}
+
+	err := h.svc.Delete(r.Context(), service.DeleteCommentInput{
+		OrgID:     orgID,
+		CommentID: commentID,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, map[string]string{"id": commentID, "status": "deleted"})
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) Create(ctx context.Context, input service.CreateCommentInput) (*domain.KnowledgeComment, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeComment), args.Error(1)
+}
+
+func (m *MockCommentService) List(ctx context.Context, input service.ListCommentsInput) ([]*domain.CommentThread, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CommentThread), args.Error(1)
+}
+
+func (m *MockCommentService) Resolve(ctx context.Context, input service.ResolveCommentInput) (*domain.KnowledgeComment, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeComment), args.Error(1)
+}
+
+func (m *MockCommentService) Delete(ctx context.Context, input service.DeleteCommentInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func newTestComment() *domain.KnowledgeComment {
+	c := domain.NewKnowledgeComment("c-1", "org-456", "k-123", "", 2, "Why 30 seconds?",
+		domain.Author{APIKeyID: "key-1", Name: "alice"}, time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+	c.LineStart, c.LineEnd = 0, 3
+	return c
+}
+
+func TestCommentHandler_Create_Success(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	author := domain.Author{APIKeyID: "key-1", Name: "alice"}
+	mockSvc.On("Create", mock.Anything, service.CreateCommentInput{
+		OrgID:       "org-456",
+		KnowledgeID: "k-123",
+		Body:        "Why 30 seconds?",
+		LineStart:   0,
+		LineEnd:     3,
+		Author:      author,
+	}).Return(newTestComment(), nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/comments", []byte(`{"body":"Why 30 seconds?","end_line":3}`))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, author))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "c-1", data["id"])
+	assert.Equal(t, "alice", data["author"])
+	assert.Equal(t, false, data["resolved"])
+	assert.Equal(t, map[string]interface{}{"start_line": float64(0), "end_line": float64(3)}, data["lines"])
+	assert.Equal(t, "2026-03-04T05:06:07Z", data["created_at"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCommentHandler_Create_MissingBody(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/comments", []byte(`{"chunk_id":"chunk-1"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "body is required")
+}
+
+func TestCommentHandler_Create_InvalidAnchor(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCommentAnchor)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/comments", []byte(`{"body":"Here","start_line":40,"end_line":50}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCommentHandler_List(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	reply := domain.NewKnowledgeComment("c-2", "org-456", "k-123", "c-1", 2, "Load balancer timeout", domain.Author{Name: "bob"}, time.Now())
+	mockSvc.On("List", mock.Anything, service.ListCommentsInput{
+		OrgID:       "org-456",
+		KnowledgeID: "k-123",
+		Status:      "all",
+	}).Return([]*domain.CommentThread{{KnowledgeComment: newTestComment(), Replies: []*domain.KnowledgeComment{reply}}}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/comments?status=all", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	threads := resp["data"].(map[string]interface{})["threads"].([]interface{})
+	require.Len(t, threads, 1)
+	thread := threads[0].(map[string]interface{})
+	assert.Equal(t, "c-1", thread["id"])
+	replies := thread["replies"].([]interface{})
+	require.Len(t, replies, 1)
+	assert.Equal(t, "c-1", replies[0].(map[string]interface{})["parent_id"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCommentHandler_Resolve(t *testing.T) {
+	t.Run("resolves without a body", func(t *testing.T) {
+		mockSvc := new(MockCommentService)
+		handler := NewCommentHandler(mockSvc)
+
+		resolved := newTestComment()
+		now := time.Now()
+		resolved.ResolvedAt = &now
+		resolved.ResolvedBy = domain.Author{Name: "carol"}
+		mockSvc.On("Resolve", mock.Anything, mock.MatchedBy(func(input service.ResolveCommentInput) bool {
+			return input.OrgID == "org-456" && input.CommentID == "c-1" && input.Resolved
+		})).Return(resolved, nil)
+
+		req := requestWithOrgID(http.MethodPost, "/comments/c-1/resolve", nil)
+		req = withURLParams(req, map[string]string{"commentID": "c-1"})
+		w := httptest.NewRecorder()
+
+		handler.Resolve(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"resolved_by":"carol"`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("reopens a thread", func(t *testing.T) {
+		mockSvc := new(MockCommentService)
+		handler := NewCommentHandler(mockSvc)
+
+		mockSvc.On("Resolve", mock.Anything, mock.MatchedBy(func(input service.ResolveCommentInput) bool {
+			return !input.Resolved
+		})).Return(newTestComment(), nil)
+
+		req := requestWithOrgID(http.MethodPost, "/comments/c-1/resolve", []byte(`{"resolved":false}`))
+		req = withURLParams(req, map[string]string{"commentID": "c-1"})
+		w := httptest.NewRecorder()
+
+		handler.Resolve(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects replies", func(t *testing.T) {
+		mockSvc := new(MockCommentService)
+		handler := NewCommentHandler(mockSvc)
+
+		mockSvc.On("Resolve", mock.Anything, mock.Anything).Return(nil, domain.ErrCommentIsReply)
+
+		req := requestWithOrgID(http.MethodPost, "/comments/c-2/resolve", nil)
+		req = withURLParams(req, map[string]string{"commentID": "c-2"})
+		w := httptest.NewRecorder()
+
+		handler.Resolve(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCommentHandler_Delete(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	mockSvc.On("Delete", mock.Anything, service.DeleteCommentInput{OrgID: "org-456", CommentID: "c-1"}).Return(nil)
+
+	req := requestWithOrgID(http.MethodDelete, "/comments/c-1", nil)
+	req = withURLParams(req, map[string]string{"commentID": "c-1"})
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"deleted"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCommentHandler_Delete_NotFound(t *testing.T) {
+	mockSvc := new(MockCommentService)
+	handler := NewCommentHandler(mockSvc)
+
+	mockSvc.On("Delete", mock.Anything, mock.Anything).Return(domain.ErrCommentNotFound)
+
+	req := requestWithOrgID(http.MethodDelete, "/comments/c-404", nil)
+	req = withURLParams(req, map[string]string{"commentID": "c-404"})
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
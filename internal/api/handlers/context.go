@@ -39,11 +39,12 @@ func NewContextHandlerWithVFS(svc ContextService, vfs VFSService, logRepo servic
 }
 
 type ManifestItemResponse struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Summary string `json:"summary"`
-	Type    string `json:"type"`
-	Scope   string `json:"scope,omitempty"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Summary  string `json:"summary"`
+	Type     string `json:"type"`
+	TypeName string `json:"type_name,omitempty"`
+	Scope    string `json:"scope,omitempty"`
 }
 
 type ManifestResponse struct {
@@ -51,29 +52,52 @@ type ManifestResponse struct {
 }
 
 type SearchRequest struct {
-	Query      string `json:"query"`
-	ProjectID  string `json:"project_id"`
-	Type       string `json:"type,omitempty"`
-	Status     string `json:"status,omitempty"`
-	PathPrefix string `json:"path_prefix,omitempty"`
-	SourceType string `json:"source_type,omitempty"`
-	Mode       string `json:"mode,omitempty"`
-	Exact      bool   `json:"exact,omitempty"`
-	Limit      int    `json:"limit,omitempty"`
-	Cursor     string `json:"cursor,omitempty"`
+	Query                string   `json:"query"`
+	ProjectID            string   `json:"project_id"`
+	Type                 string   `json:"type,omitempty"`
+	Status               string   `json:"status,omitempty"`
+	PathPrefix           string   `json:"path_prefix,omitempty"`
+	SourceType           string   `json:"source_type,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	TagMatch             string   `json:"tag_match,omitempty"`
+	Language             string   `json:"language,omitempty"`
+	Author               string   `json:"author,omitempty"`
+	Mode                 string   `json:"mode,omitempty"`
+	Exact                bool     `json:"exact,omitempty"`
+	Limit                int      `json:"limit,omitempty"`
+	Cursor               string   `json:"cursor,omitempty"`
+	SubstituteSuperseded bool     `json:"substitute_superseded,omitempty"`
+	ExpandRelations      bool     `json:"expand_relations,omitempty"`
+	DemoteStale          bool     `json:"demote_stale,omitempty"`
 }
 
 type SearchResultResponse struct {
-	ID         string  `json:"id"`
-	Title      string  `json:"title"`
-	Summary    string  `json:"summary,omitempty"`
-	Scope      string  `json:"scope,omitempty"`
-	Snippet    string  `json:"snippet,omitempty"`
-	UpdatedAt  string  `json:"updated_at,omitempty"`
-	Score      float32 `json:"score"`
-	SourceType string  `json:"source_type"`
-	ChunkID    string  `json:"chunk_id,omitempty"`
-	ChunkIndex int     `json:"chunk_index,omitempty"`
+	ID           string                      `json:"id"`
+	Title        string                      `json:"title"`
+	Summary      string                      `json:"summary,omitempty"`
+	Scope        string                      `json:"scope,omitempty"`
+	Snippet      string                      `json:"snippet,omitempty"`
+	UpdatedAt    string                      `json:"updated_at,omitempty"`
+	Score        float32                     `json:"score"`
+	SourceType   string                      `json:"source_type"`
+	ChunkID      string                      `json:"chunk_id,omitempty"`
+	ChunkIndex   int                         `json:"chunk_index,omitempty"`
+	SupersededBy *SupersessionResponse       `json:"superseded_by,omitempty"`
+	Replaces     string                      `json:"replaces,omitempty"`
+	Related      []*RelatedKnowledgeResponse `json:"related,omitempty"`
+	Stale        bool                        `json:"stale,omitempty"`
+}
+
+type SupersessionResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func supersessionToResponse(ref *service.SupersessionRef) *SupersessionResponse {
+	if ref == nil {
+		return nil
+	}
+	return &SupersessionResponse{ID: ref.ID, Title: ref.Title}
 }
 
 type SearchResponse struct {
@@ -90,11 +114,12 @@ type SearchFeedbackRequest struct {
 }
 
 type OpenRequest struct {
-	ID         string        `json:"id"`
-	SourceType string        `json:"source_type,omitempty"`
-	ChunkID    string        `json:"chunk_id,omitempty"`
-	Range      *ContentRange `json:"range,omitempty"`
-	IncludeURL bool          `json:"include_url,omitempty"`
+	ID              string        `json:"id"`
+	SourceType      string        `json:"source_type,omitempty"`
+	ChunkID         string        `json:"chunk_id,omitempty"`
+	Range           *ContentRange `json:"range,omitempty"`
+	IncludeURL      bool          `json:"include_url,omitempty"`
+	IncludeComments bool          `json:"include_comments,omitempty"`
 }
 
 type ContentRange struct {
@@ -104,46 +129,58 @@ type ContentRange struct {
 }
 
 type OpenResponse struct {
-	ID          string   `json:"id"`
-	SourceType  string   `json:"source_type"`
-	Title       string   `json:"title"`
-	Content     string   `json:"content,omitempty"`
-	TotalLines  int      `json:"total_lines,omitempty"`
-	TotalChars  int      `json:"total_chars,omitempty"`
-	ChunkID     string   `json:"chunk_id,omitempty"`
-	ChunkIndex  int      `json:"chunk_index,omitempty"`
-	ChunkCount  int      `json:"chunk_count,omitempty"`
-	UpdatedAt   string   `json:"updated_at,omitempty"`
-	Filename    string   `json:"filename,omitempty"`
-	MimeType    string   `json:"mime_type,omitempty"`
-	SizeBytes   int64    `json:"size_bytes,omitempty"`
-	Description string   `json:"description,omitempty"`
-	Keywords    []string `json:"keywords,omitempty"`
-	DownloadURL string   `json:"download_url,omitempty"`
+	ID           string                `json:"id"`
+	SourceType   string                `json:"source_type"`
+	Title        string                `json:"title"`
+	Content      string                `json:"content,omitempty"`
+	TotalLines   int                   `json:"total_lines,omitempty"`
+	TotalChars   int                   `json:"total_chars,omitempty"`
+	ChunkID      string                `json:"chunk_id,omitempty"`
+	ChunkIndex   int                   `json:"chunk_index,omitempty"`
+	ChunkCount   int                   `json:"chunk_count,omitempty"`
+	UpdatedAt    string                `json:"updated_at,omitempty"`
+	Filename     string                `json:"filename,omitempty"`
+	MimeType     string                `json:"mime_type,omitempty"`
+	SizeBytes    int64                 `json:"size_bytes,omitempty"`
+	Description  string                `json:"description,omitempty"`
+	Keywords     []string              `json:"keywords,omitempty"`
+	DownloadURL  string                `json:"download_url,omitempty"`
+	SupersededBy *SupersessionResponse `json:"superseded_by,omitempty"`
+	Stale        bool                  `json:"stale,omitempty"`
+	StaleSince   string                `json:"stale_since,omitempty"`
+	Owner        string                `json:"owner,omitempty"`
+	// Comments lists the unresolved threads on the item when include_comments was set
+	Comments []*CommentThreadResponse `json:"comments,omitempty"`
 }
 
 type ListRequest struct {
-	ProjectID    string `json:"project_id,omitempty"`
-	PathPrefix   string `json:"path_prefix,omitempty"`
-	Type         string `json:"type,omitempty"`
-	Status       string `json:"status,omitempty"`
-	SourceType   string `json:"source_type,omitempty"`
-	UpdatedSince string `json:"updated_since,omitempty"`
-	Limit        int    `json:"limit,omitempty"`
-	Cursor       string `json:"cursor,omitempty"`
+	ProjectID    string   `json:"project_id,omitempty"`
+	PathPrefix   string   `json:"path_prefix,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	SourceType   string   `json:"source_type,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	TagMatch     string   `json:"tag_match,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	UpdatedSince string   `json:"updated_since,omitempty"`
+	Limit        int      `json:"limit,omitempty"`
+	Cursor       string   `json:"cursor,omitempty"`
 }
 
 type ListItemResponse struct {
-	ID         string `json:"id"`
-	Title      string `json:"title"`
-	Scope      string `json:"scope,omitempty"`
-	Type       string `json:"type,omitempty"`
-	Status     string `json:"status,omitempty"`
-	SourceType string `json:"source_type"`
-	UpdatedAt  string `json:"updated_at,omitempty"`
-	ChunkCount int    `json:"chunk_count,omitempty"`
-	Filename   string `json:"filename,omitempty"`
-	MimeType   string `json:"mime_type,omitempty"`
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Scope      string   `json:"scope,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	SourceType string   `json:"source_type"`
+	CreatedBy  string   `json:"created_by,omitempty"`
+	UpdatedBy  string   `json:"updated_by,omitempty"`
+	UpdatedAt  string   `json:"updated_at,omitempty"`
+	ChunkCount int      `json:"chunk_count,omitempty"`
+	Filename   string   `json:"filename,omitempty"`
+	MimeType   string   `json:"mime_type,omitempty"`
 }
 
 type ListResponse struct {
@@ -170,11 +207,12 @@ func (h *ContextHandler) GetManifest(w http.ResponseWriter, r *http.Request) {
 	responses := make([]*ManifestItemResponse, len(items))
 	for i, item := range items {
 		responses[i] = &ManifestItemResponse{
-			ID:      item.ID,
-			Title:   item.Title,
-			Summary: item.Summary,
-			Type:    string(item.Type),
-			Scope:   item.Scope,
+			ID:       item.ID,
+			Title:    item.Title,
+			Summary:  item.Summary,
+			Type:     string(item.Type),
+			TypeName: item.TypeName,
+			Scope:    item.Scope,
 		}
 	}
 
@@ -217,6 +255,15 @@ func (h *ContextHandler) Search(w http.ResponseWriter, r *http.Request) {
 	if req.SourceType != "" {
 		filters.SourceType = req.SourceType
 	}
+	if !isValidTagMatch(req.TagMatch) {
+		api.Error(w, http.StatusBadRequest, "tag_match must be any or all")
+		return
+	}
+	filters.Tags = req.Tags
+	filters.TagMatch = service.TagMatch(req.TagMatch)
+	filters.Language = req.Language
+	filters.Author = req.Author
+	filters.DemoteStale = req.DemoteStale
 
 	limit := req.Limit
 	if limit <= 0 {
@@ -224,12 +271,14 @@ func (h *ContextHandler) Search(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := service.SearchInput{
-		Query:   req.Query,
-		Filters: filters,
-		Mode:    service.SearchMode(req.Mode),
-		Exact:   req.Exact,
-		Limit:   limit,
-		Cursor:  req.Cursor,
+		Query:                req.Query,
+		Filters:              filters,
+		Mode:                 service.SearchMode(req.Mode),
+		Exact:                req.Exact,
+		Limit:                limit,
+		Cursor:               req.Cursor,
+		SubstituteSuperseded: req.SubstituteSuperseded,
+		ExpandRelations:      req.ExpandRelations,
 	}
 
 	output, err := h.svc.Search(r.Context(), input)
@@ -245,16 +294,22 @@ func (h *ContextHandler) Search(w http.ResponseWriter, r *http.Request) {
 			updatedAt = result.UpdatedAt.UTC().Format(time.RFC3339Nano)
 		}
 		responses[i] = &SearchResultResponse{
-			ID:         result.ID,
-			Title:      result.Title,
-			Summary:    result.Summary,
-			Scope:      result.Scope,
-			Snippet:    result.Snippet,
-			UpdatedAt:  updatedAt,
-			Score:      result.Score,
-			SourceType: result.SourceType,
-			ChunkID:    result.ChunkID,
-			ChunkIndex: result.ChunkIndex,
+			ID:           result.ID,
+			Title:        result.Title,
+			Summary:      result.Summary,
+			Scope:        result.Scope,
+			Snippet:      result.Snippet,
+			UpdatedAt:    updatedAt,
+			Score:        result.Score,
+			SourceType:   result.SourceType,
+			ChunkID:      result.ChunkID,
+			ChunkIndex:   result.ChunkIndex,
+			SupersededBy: supersessionToResponse(result.SupersededBy),
+			Replaces:     result.Replaces,
+			Stale:        result.Stale,
+		}
+		if len(result.Related) > 0 {
+			responses[i].Related = relatedToResponse(result.Related)
 		}
 	}
 
@@ -349,10 +404,11 @@ func (h *ContextHandler) Open(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := service.OpenInput{
-		ID:         req.ID,
-		SourceType: req.SourceType,
-		ChunkID:    req.ChunkID,
-		IncludeURL: req.IncludeURL,
+		ID:              req.ID,
+		SourceType:      req.SourceType,
+		ChunkID:         req.ChunkID,
+		IncludeURL:      req.IncludeURL,
+		IncludeComments: req.IncludeComments,
 	}
 
 	if req.Range != nil {
@@ -375,22 +431,31 @@ func (h *ContextHandler) Open(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := OpenResponse{
-		ID:          result.ID,
-		SourceType:  result.SourceType,
-		Title:       result.Title,
-		Content:     result.Content,
-		TotalLines:  result.TotalLines,
-		TotalChars:  result.TotalChars,
-		ChunkID:     result.ChunkID,
-		ChunkIndex:  result.ChunkIndex,
-		ChunkCount:  result.ChunkCount,
-		UpdatedAt:   updatedAt,
-		Filename:    result.Filename,
-		MimeType:    result.MimeType,
-		SizeBytes:   result.SizeBytes,
-		Description: result.Description,
-		Keywords:    result.Keywords,
-		DownloadURL: result.DownloadURL,
+		ID:           result.ID,
+		SourceType:   result.SourceType,
+		Title:        result.Title,
+		Content:      result.Content,
+		TotalLines:   result.TotalLines,
+		TotalChars:   result.TotalChars,
+		ChunkID:      result.ChunkID,
+		ChunkIndex:   result.ChunkIndex,
+		ChunkCount:   result.ChunkCount,
+		UpdatedAt:    updatedAt,
+		Filename:     result.Filename,
+		MimeType:     result.MimeType,
+		SizeBytes:    result.SizeBytes,
+		Description:  result.Description,
+		Keywords:     result.Keywords,
+		DownloadURL:  result.DownloadURL,
+		SupersededBy: supersessionToResponse(result.SupersededBy),
+		Owner:        result.Owner,
+	}
+	if result.StaleSince != nil {
+		resp.Stale = true
+		resp.StaleSince = result.StaleSince.UTC().Format(time.RFC3339Nano)
+	}
+	if result.Comments != nil {
+		resp.Comments = commentThreadsToResponse(result.Comments)
 	}
 
 	api.Success(w, http.StatusOK, resp)
@@ -420,10 +485,18 @@ func (h *ContextHandler) List(w http.ResponseWriter, r *http.Request) {
 		ProjectID:  req.ProjectID,
 		PathPrefix: req.PathPrefix,
 		SourceType: req.SourceType,
+		Tags:       req.Tags,
+		TagMatch:   service.TagMatch(req.TagMatch),
+		Author:     req.Author,
 		Limit:      req.Limit,
 		Cursor:     req.Cursor,
 	}
 
+	if !isValidTagMatch(req.TagMatch) {
+		api.Error(w, http.StatusBadRequest, "tag_match must be any or all")
+		return
+	}
+
 	if req.Type != "" {
 		input.Type = domain.KnowledgeType(req.Type)
 	}
@@ -455,7 +528,10 @@ func (h *ContextHandler) List(w http.ResponseWriter, r *http.Request) {
 			Scope:      item.Scope,
 			Type:       string(item.Type),
 			Status:     string(item.Status),
+			Tags:       item.Tags,
 			SourceType: item.SourceType,
+			CreatedBy:  item.CreatedBy,
+			UpdatedBy:  item.UpdatedBy,
 			UpdatedAt:  updatedAt,
 			ChunkCount: item.ChunkCount,
 			Filename:   item.Filename,
@@ -470,6 +546,14 @@ func (h *ContextHandler) List(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func isValidTagMatch(value string) bool {
+	switch service.TagMatch(value) {
+	case "", service.TagMatchAny, service.TagMatchAll:
+		return true
+	}
+	return false
+}
+
 func normalizeSourceType(value string) string {
 	value = strings.TrimSpace(strings.ToLower(value))
 	if value == "asset" {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/cloo-solutions/neotexai/internal/domain"
 	"github.com/cloo-solutions/neotexai/internal/service"
@@ -144,6 +145,78 @@ func TestContextHandler_Search_WithTypeFilter(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestContextHandler_Search_WithTags(t *testing.T) {
+	mockSvc := new(MockContextService)
+	handler := NewContextHandler(mockSvc, nil)
+
+	mockSvc.On("Search", mock.Anything, mock.MatchedBy(func(input service.SearchInput) bool {
+		return assert.ObjectsAreEqual([]string{"deploy", "ci"}, input.Filters.Tags) &&
+			input.Filters.TagMatch == service.TagMatchAll
+	})).Return(&service.SearchOutput{Results: []*service.SearchResult{}}, nil)
+
+	body := `{"query":"test","tags":["deploy","ci"],"tag_match":"all"}`
+	req := requestWithOrgID(http.MethodPost, "/search", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestContextHandler_Search_WithAuthor(t *testing.T) {
+	mockSvc := new(MockContextService)
+	handler := NewContextHandler(mockSvc, nil)
+
+	mockSvc.On("Search", mock.Anything, mock.MatchedBy(func(input service.SearchInput) bool {
+		return input.Filters.Author == "release-bot"
+	})).Return(&service.SearchOutput{Results: []*service.SearchResult{}}, nil)
+
+	body := `{"query":"test","author":"release-bot"}`
+	req := requestWithOrgID(http.MethodPost, "/search", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestContextHandler_Search_InvalidTagMatch(t *testing.T) {
+	mockSvc := new(MockContextService)
+	handler := NewContextHandler(mockSvc, nil)
+
+	body := `{"query":"test","tags":["deploy"],"tag_match":"some"}`
+	req := requestWithOrgID(http.MethodPost, "/search", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestContextHandler_Search_DemoteStale(t *testing.T) {
+	mockSvc := new(MockContextService)
+	handler := NewContextHandler(mockSvc, nil)
+
+	mockSvc.On("Search", mock.Anything, mock.MatchedBy(func(input service.SearchInput) bool {
+		return input.Filters.DemoteStale
+	})).Return(&service.SearchOutput{Results: []*service.SearchResult{
+		{ID: "k-1", Title: "Old runbook", SourceType: "knowledge", Stale: true},
+	}}, nil)
+
+	body := `{"query":"runbook","demote_stale":true}`
+	req := requestWithOrgID(http.MethodPost, "/search", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"stale":true`)
+	mockSvc.AssertExpectations(t)
+}
+
 func TestContextHandler_Search_CustomLimit(t *testing.T) {
 	mockSvc := new(MockContextService)
 	handler := NewContextHandler(mockSvc, nil)
@@ -248,6 +321,36 @@ func TestContextHandler_Open_Knowledge(t *testing.T) {
 	mockVFS.AssertExpectations(t)
 }
 
+func TestContextHandler_Open_IncludeComments(t *testing.T) {
+	mockSvc := new(MockContextService)
+	mockVFS := new(MockVFSService)
+	handler := NewContextHandlerWithVFS(mockSvc, mockVFS, nil)
+
+	thread := domain.NewKnowledgeComment("c-1", "org-456", "k-123", "", 1, "Is this still true?", domain.Author{Name: "alice"}, time.Now())
+	mockVFS.On("Open", mock.Anything, mock.MatchedBy(func(input service.OpenInput) bool {
+		return input.ID == "k-123" && input.IncludeComments
+	})).Return(&service.OpenResult{
+		ID:         "k-123",
+		SourceType: "knowledge",
+		Title:      "Test Knowledge",
+		ChunkIndex: -1,
+		Comments:   []*domain.CommentThread{{KnowledgeComment: thread, Replies: []*domain.KnowledgeComment{}}},
+	}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/context/open", []byte(`{"id":"k-123","include_comments":true}`))
+	w := httptest.NewRecorder()
+
+	handler.Open(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	comments := resp["data"].(map[string]interface{})["comments"].([]interface{})
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Is this still true?", comments[0].(map[string]interface{})["body"])
+	mockVFS.AssertExpectations(t)
+}
+
 func TestContextHandler_Open_Chunk(t *testing.T) {
 	mockSvc := new(MockContextService)
 	mockVFS := new(MockVFSService)
@@ -469,16 +572,21 @@ func TestContextHandler_List_WithFilters(t *testing.T) {
 		return input.PathPrefix == "/docs" &&
 			input.Type == domain.KnowledgeTypeGuideline &&
 			input.SourceType == "knowledge" &&
+			assert.ObjectsAreEqual([]string{"deploy"}, input.Tags) &&
+			input.Author == "release-bot" &&
 			input.Limit == 25
-	})).Return(&service.ListOutput{Items: []*service.ListItem{}}, nil)
+	})).Return(&service.ListOutput{Items: []*service.ListItem{
+		{ID: "k-1", Title: "Deploy", SourceType: "knowledge", CreatedBy: "alice", UpdatedBy: "release-bot"},
+	}}, nil)
 
-	body := `{"path_prefix":"/docs","type":"guideline","source_type":"knowledge","limit":25}`
+	body := `{"path_prefix":"/docs","type":"guideline","source_type":"knowledge","tags":["deploy"],"author":"release-bot","limit":25}`
 	req := requestWithOrgID(http.MethodPost, "/context/list", []byte(body))
 	w := httptest.NewRecorder()
 
 	handler.List(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"updated_by":"release-bot"`)
 	mockVFS.AssertExpectations(t)
 }
 
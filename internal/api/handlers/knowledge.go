@@ -3,8 +3,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cloo-solutions/neotexai/internal/api"
 	"github.com/cloo-solutions/neotexai/internal/api/middleware"
@@ -14,13 +18,29 @@ import (
 )
 
 type KnowledgeService interface {
-	Create(ctx context.Context, input service.CreateInput) (*domain.Knowledge, error)
+	Create(ctx context.Context, input service.CreateInput) (*service.CreateOutput, error)
 	GetByID(ctx context.Context, id string) (*domain.Knowledge, error)
-	Update(ctx context.Context, input service.UpdateInput) (*domain.Knowledge, *domain.KnowledgeVersion, error)
-	Deprecate(ctx context.Context, knowledgeID string) (*domain.Knowledge, error)
+	GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error)
+	Update(ctx context.Context, input service.UpdateInput) (*service.UpdateOutput, error)
+	Deprecate(ctx context.Context, input service.DeprecateInput) (*domain.Knowledge, error)
 	ListByOrg(ctx context.Context, orgID string) ([]*domain.Knowledge, error)
 	ListByProject(ctx context.Context, projectID string) ([]*domain.Knowledge, error)
 	ListKnowledge(ctx context.Context, input service.ListKnowledgeInput) (*service.ListKnowledgeOutput, error)
+	Approve(ctx context.Context, input service.ReviewInput) (*domain.Knowledge, error)
+	Reject(ctx context.Context, input service.ReviewInput) (*domain.Knowledge, error)
+	ListPendingReview(ctx context.Context, input service.ListKnowledgeInput) (*service.ListKnowledgeOutput, error)
+	ListStale(ctx context.Context, input service.ListStaleInput) (*service.ListKnowledgeOutput, error)
+	ListVersions(ctx context.Context, orgID, knowledgeID string) ([]*domain.KnowledgeVersion, error)
+	GetVersion(ctx context.Context, orgID, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error)
+	DiffVersions(ctx context.Context, input service.VersionDiffInput) (*service.VersionDiff, error)
+	Revert(ctx context.Context, input service.RevertInput) (*domain.Knowledge, *domain.KnowledgeVersion, error)
+	RenderTemplate(ctx context.Context, input service.RenderTemplateInput) (*service.RenderedTemplate, error)
+	Instantiate(ctx context.Context, input service.InstantiateInput) (*domain.Knowledge, error)
+	BatchCreate(ctx context.Context, input service.BatchCreateInput) (*service.BatchOutput, error)
+	BatchDeprecate(ctx context.Context, input service.BatchDeprecateInput) (*service.BatchOutput, error)
+	MoveScope(ctx context.Context, input service.MoveScopeInput) (*service.MoveScopeOutput, error)
+	ListSources(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error)
+	Merge(ctx context.Context, input service.MergeInput) (*service.MergeOutput, error)
 }
 
 type KnowledgeHandler struct {
@@ -32,51 +52,206 @@ func NewKnowledgeHandler(svc KnowledgeService) *KnowledgeHandler {
 }
 
 type CreateKnowledgeRequest struct {
-	Type      string `json:"type"`
-	Title     string `json:"title"`
-	Summary   string `json:"summary"`
-	BodyMD    string `json:"body_md"`
-	ProjectID string `json:"project_id"`
-	Scope     string `json:"scope"`
+	Type        string   `json:"type"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	BodyMD      string   `json:"body_md"`
+	ProjectID   string   `json:"project_id"`
+	Scope       string   `json:"scope"`
+	Tags        []string `json:"tags,omitempty"`
+	ReviewAfter string   `json:"review_after,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	// Force creates the item even when similar knowledge already exists
+	Force bool `json:"force,omitempty"`
+	// SourcePath and SourceHash record the file an imported item was read from
+	SourcePath string `json:"source_path,omitempty"`
+	SourceHash string `json:"source_hash,omitempty"`
 }
 
+// UpdateKnowledgeRequest carries the new content; omitting tags, review_after, owner,
+// language or the source keeps the current values and an empty review_after removes the
+// review-by date
 type UpdateKnowledgeRequest struct {
-	Title   string `json:"title"`
-	Summary string `json:"summary"`
-	BodyMD  string `json:"body_md"`
-	Scope   string `json:"scope"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	BodyMD      string   `json:"body_md"`
+	Scope       string   `json:"scope"`
+	Tags        []string `json:"tags"`
+	ReviewAfter *string  `json:"review_after"`
+	Owner       *string  `json:"owner"`
+	Language    *string  `json:"language"`
+	SourcePath  string   `json:"source_path,omitempty"`
+	SourceHash  string   `json:"source_hash,omitempty"`
+}
+
+// ReviewKnowledgeRequest carries the review note; the reviewer is the API key of the request
+type ReviewKnowledgeRequest struct {
+	Note string `json:"note"`
+}
+
+type RevertKnowledgeRequest struct {
+	Version int64 `json:"version"`
 }
 
 type KnowledgeResponse struct {
-	ID        string `json:"id"`
-	OrgID     string `json:"org_id"`
-	ProjectID string `json:"project_id"`
-	Type      string `json:"type"`
-	Status    string `json:"status"`
-	Title     string `json:"title"`
-	Summary   string `json:"summary"`
-	BodyMD    string `json:"body_md"`
-	Scope     string `json:"scope"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID           string   `json:"id"`
+	OrgID        string   `json:"org_id"`
+	ProjectID    string   `json:"project_id"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status"`
+	Title        string   `json:"title"`
+	Summary      string   `json:"summary"`
+	BodyMD       string   `json:"body_md"`
+	Scope        string   `json:"scope"`
+	Tags         []string `json:"tags,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	ReviewedBy   string   `json:"reviewed_by,omitempty"`
+	ReviewedAt   string   `json:"reviewed_at,omitempty"`
+	ReviewNote   string   `json:"review_note,omitempty"`
+	Version      int64    `json:"version,omitempty"`
+	SupersededBy string   `json:"superseded_by,omitempty"`
+	ReviewAfter  string   `json:"review_after,omitempty"`
+	Owner        string   `json:"owner,omitempty"`
+	StaleSince   string   `json:"stale_since,omitempty"`
+	Stale        bool     `json:"stale,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	// CreatedBy and UpdatedBy name the authors of the first and latest versions; the key IDs
+	// are the API keys they were written with
+	CreatedBy      string `json:"created_by,omitempty"`
+	CreatedByKeyID string `json:"created_by_key_id,omitempty"`
+	UpdatedBy      string `json:"updated_by,omitempty"`
+	UpdatedByKeyID string `json:"updated_by_key_id,omitempty"`
+	// ReviewedByKeyID is the API key the review was made with, which unlike ReviewedBy
+	// cannot be chosen by the client
+	ReviewedByKeyID string `json:"reviewed_by_key_id,omitempty"`
+	// TemplateID and TemplateVersion are set on items instantiated from a template
+	TemplateID      string `json:"template_id,omitempty"`
+	TemplateVersion int64  `json:"template_version,omitempty"`
+	// SourcePath and SourceHash are set on items imported from files
+	SourcePath string `json:"source_path,omitempty"`
+	SourceHash string `json:"source_hash,omitempty"`
+	// SummaryGeneratedBy names the model that wrote a machine-generated summary and
+	// SuggestedTitle is a machine-generated title that was not applied
+	SummaryGeneratedBy string `json:"summary_generated_by,omitempty"`
+	SuggestedTitle     string `json:"suggested_title,omitempty"`
+	// LintFindings are the lint rules the item breaks, reported on create, update and merge
+	LintFindings []*LintFindingResponse `json:"lint_findings,omitempty"`
+}
+
+// VersionConflictResponse is returned with 412 when If-Match does not match the current version
+type VersionConflictResponse struct {
+	Error string             `json:"error"`
+	Data  *KnowledgeResponse `json:"data"`
+}
+
+type DuplicateCandidateResponse struct {
+	ID         string  `json:"id"`
+	Type       string  `json:"type"`
+	Title      string  `json:"title"`
+	Summary    string  `json:"summary,omitempty"`
+	Scope      string  `json:"scope,omitempty"`
+	Similarity float64 `json:"similarity"`
+}
+
+type DuplicatesResponse struct {
+	Duplicates []*DuplicateCandidateResponse `json:"duplicates"`
+}
+
+// DuplicateKnowledgeResponse is returned with 409 when a new item is similar to existing ones
+type DuplicateKnowledgeResponse struct {
+	Error string              `json:"error"`
+	Data  *DuplicatesResponse `json:"data"`
 }
 
 func knowledgeToResponse(k *domain.Knowledge) *KnowledgeResponse {
-	return &KnowledgeResponse{
-		ID:        k.ID,
-		OrgID:     k.OrgID,
-		ProjectID: k.ProjectID,
-		Type:      string(k.Type),
-		Status:    string(k.Status),
-		Title:     k.Title,
-		Summary:   k.Summary,
-		BodyMD:    k.BodyMD,
-		Scope:     k.Scope,
-		CreatedAt: k.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: k.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	resp := &KnowledgeResponse{
+		ID:           k.ID,
+		OrgID:        k.OrgID,
+		ProjectID:    k.ProjectID,
+		Type:         string(k.Type),
+		Status:       string(k.Status),
+		Title:        k.Title,
+		Summary:      k.Summary,
+		BodyMD:       k.BodyMD,
+		Scope:        k.Scope,
+		Tags:         k.Tags,
+		CreatedAt:    k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    k.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ReviewedBy:   k.ReviewedBy.Name,
+		ReviewNote:   k.ReviewNote,
+		SupersededBy: k.SupersededBy,
+		Owner:        k.Owner,
+		Stale:        k.IsStale(),
+		Language:     k.Language,
+
+		CreatedBy:      k.CreatedBy.Name,
+		CreatedByKeyID: k.CreatedBy.APIKeyID,
+		UpdatedBy:      k.UpdatedBy.Name,
+		UpdatedByKeyID: k.UpdatedBy.APIKeyID,
+
+		ReviewedByKeyID: k.ReviewedBy.APIKeyID,
+
+		TemplateID:      k.TemplateID,
+		TemplateVersion: k.TemplateVersion,
+
+		SourcePath: k.SourcePath,
+		SourceHash: k.SourceHash,
+
+		SummaryGeneratedBy: k.SummaryGeneratedBy,
+		SuggestedTitle:     k.SuggestedTitle,
+	}
+	if k.ReviewedAt != nil {
+		resp.ReviewedAt = k.ReviewedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if k.ReviewAfter != nil {
+		resp.ReviewAfter = k.ReviewAfter.Format("2006-01-02T15:04:05Z")
+	}
+	if k.StaleSince != nil {
+		resp.StaleSince = k.StaleSince.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+type KnowledgeVersionResponse struct {
+	ID             string `json:"id"`
+	KnowledgeID    string `json:"knowledge_id"`
+	VersionNumber  int64  `json:"version_number"`
+	Title          string `json:"title"`
+	Summary        string `json:"summary"`
+	BodyMD         string `json:"body_md,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	CreatedBy      string `json:"created_by,omitempty"`
+	CreatedByKeyID string `json:"created_by_key_id,omitempty"`
+}
+
+func versionToResponse(v *domain.KnowledgeVersion) *KnowledgeVersionResponse {
+	return &KnowledgeVersionResponse{
+		ID:             v.ID,
+		KnowledgeID:    v.KnowledgeID,
+		VersionNumber:  v.VersionNumber,
+		Title:          v.Title,
+		Summary:        v.Summary,
+		BodyMD:         v.BodyMD,
+		CreatedAt:      v.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		CreatedBy:      v.CreatedBy.Name,
+		CreatedByKeyID: v.CreatedBy.APIKeyID,
 	}
 }
 
+type VersionDiffResponse struct {
+	KnowledgeID string `json:"knowledge_id"`
+	From        int64  `json:"from"`
+	To          int64  `json:"to"`
+	Diff        string `json:"diff"`
+}
+
+type RevertResponse struct {
+	Knowledge *KnowledgeResponse        `json:"knowledge"`
+	Version   *KnowledgeVersionResponse `json:"version"`
+}
+
 func (h *KnowledgeHandler) Create(w http.ResponseWriter, r *http.Request) {
 	orgID := middleware.GetOrgID(r.Context())
 	if orgID == "" {
@@ -104,28 +279,55 @@ func (h *KnowledgeHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	knowledgeType := domain.KnowledgeType(req.Type)
-	if !isValidKnowledgeType(knowledgeType) {
-		api.Error(w, http.StatusBadRequest, "invalid knowledge type")
-		return
+
+	var reviewAfter *time.Time
+	if req.ReviewAfter != "" {
+		parsed, err := parseReviewAfter(req.ReviewAfter)
+		if err != nil {
+			api.Error(w, http.StatusBadRequest, "invalid review_after")
+			return
+		}
+		reviewAfter = &parsed
 	}
 
 	input := service.CreateInput{
-		OrgID:     orgID,
-		ProjectID: req.ProjectID,
-		Type:      knowledgeType,
-		Title:     req.Title,
-		Summary:   req.Summary,
-		BodyMD:    req.BodyMD,
-		Scope:     req.Scope,
+		OrgID:       orgID,
+		ProjectID:   req.ProjectID,
+		Type:        knowledgeType,
+		Title:       req.Title,
+		Summary:     req.Summary,
+		BodyMD:      req.BodyMD,
+		Scope:       req.Scope,
+		Tags:        req.Tags,
+		ReviewAfter: reviewAfter,
+		Owner:       req.Owner,
+		Language:    req.Language,
+		Author:      middleware.GetAuthor(r.Context()),
+		Force:       req.Force,
+		SourcePath:  req.SourcePath,
+		SourceHash:  req.SourceHash,
 	}
 
-	knowledge, err := h.svc.Create(r.Context(), input)
+	out, err := h.svc.Create(r.Context(), input)
 	if err != nil {
+		var dupErr *service.DuplicateKnowledgeError
+		if errors.As(err, &dupErr) {
+			writeDuplicates(w, dupErr)
+			return
+		}
+		var lintErr *service.LintError
+		if errors.As(err, &lintErr) {
+			writeLintFailed(w, lintErr)
+			return
+		}
 		api.HandleError(w, err)
 		return
 	}
 
-	api.Success(w, http.StatusCreated, knowledgeToResponse(knowledge))
+	resp := knowledgeToResponse(out.Knowledge)
+	resp.LintFindings = lintFindingsToResponse(out.LintFindings)
+
+	api.Success(w, http.StatusCreated, resp)
 }
 
 func (h *KnowledgeHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -141,7 +343,13 @@ func (h *KnowledgeHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	api.Success(w, http.StatusOK, knowledgeToResponse(knowledge))
+	resp := knowledgeToResponse(knowledge)
+	if version, err := h.svc.GetLatestVersion(r.Context(), id); err == nil {
+		resp.Version = version.VersionNumber
+		w.Header().Set("ETag", formatETag(version.VersionNumber))
+	}
+
+	api.Success(w, http.StatusOK, resp)
 }
 
 func (h *KnowledgeHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -172,21 +380,107 @@ func (h *KnowledgeHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid If-Match header")
+		return
+	}
+
+	var reviewAfter *time.Time
+	if req.ReviewAfter != nil {
+		// An empty value is passed through as the zero time, which removes the date
+		var parsed time.Time
+		if *req.ReviewAfter != "" {
+			parsed, err = parseReviewAfter(*req.ReviewAfter)
+			if err != nil {
+				api.Error(w, http.StatusBadRequest, "invalid review_after")
+				return
+			}
+		}
+		reviewAfter = &parsed
+	}
+
 	input := service.UpdateInput{
-		KnowledgeID: id,
-		Title:       req.Title,
-		Summary:     req.Summary,
-		BodyMD:      req.BodyMD,
-		Scope:       req.Scope,
+		KnowledgeID:     id,
+		Title:           req.Title,
+		Summary:         req.Summary,
+		BodyMD:          req.BodyMD,
+		Scope:           req.Scope,
+		Tags:            req.Tags,
+		ReviewAfter:     reviewAfter,
+		Owner:           req.Owner,
+		Language:        req.Language,
+		SourcePath:      req.SourcePath,
+		SourceHash:      req.SourceHash,
+		ExpectedVersion: expectedVersion,
+		Author:          middleware.GetAuthor(r.Context()),
 	}
 
-	knowledge, _, err := h.svc.Update(r.Context(), input)
+	out, err := h.svc.Update(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			h.writeVersionConflict(w, r, id, err)
+			return
+		}
+		var lintErr *service.LintError
+		if errors.As(err, &lintErr) {
+			writeLintFailed(w, lintErr)
+			return
+		}
 		api.HandleError(w, err)
 		return
 	}
 
-	api.Success(w, http.StatusOK, knowledgeToResponse(knowledge))
+	resp := knowledgeToResponse(out.Knowledge)
+	resp.Version = out.Version.VersionNumber
+	resp.LintFindings = lintFindingsToResponse(out.LintFindings)
+	w.Header().Set("ETag", formatETag(out.Version.VersionNumber))
+
+	api.Success(w, http.StatusOK, resp)
+}
+
+// writeVersionConflict responds 412 with the current state so the caller can diff and retry.
+func (h *KnowledgeHandler) writeVersionConflict(w http.ResponseWriter, r *http.Request, id string, conflictErr error) {
+	knowledge, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	resp := knowledgeToResponse(knowledge)
+	if version, err := h.svc.GetLatestVersion(r.Context(), id); err == nil {
+		resp.Version = version.VersionNumber
+		w.Header().Set("ETag", formatETag(version.VersionNumber))
+	}
+
+	api.JSON(w, http.StatusPreconditionFailed, VersionConflictResponse{
+		Error: conflictErr.Error(),
+		Data:  resp,
+	})
+}
+
+// writeDuplicates responds 409 with the similar items so the caller can update one of them
+// or retry with force.
+func writeDuplicates(w http.ResponseWriter, dupErr *service.DuplicateKnowledgeError) {
+	api.JSON(w, http.StatusConflict, DuplicateKnowledgeResponse{
+		Error: dupErr.Error(),
+		Data:  &DuplicatesResponse{Duplicates: duplicatesToResponse(dupErr.Candidates)},
+	})
+}
+
+func duplicatesToResponse(candidates []*service.DuplicateCandidate) []*DuplicateCandidateResponse {
+	duplicates := make([]*DuplicateCandidateResponse, len(candidates))
+	for i, c := range candidates {
+		duplicates[i] = &DuplicateCandidateResponse{
+			ID:         c.ID,
+			Type:       string(c.Type),
+			Title:      c.Title,
+			Summary:    c.Summary,
+			Scope:      c.Scope,
+			Similarity: c.Similarity,
+		}
+	}
+	return duplicates
 }
 
 func (h *KnowledgeHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -202,7 +496,52 @@ func (h *KnowledgeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	knowledge, err := h.svc.Deprecate(r.Context(), id)
+	// An optional successor links the deprecated item to what replaces it
+	knowledge, err := h.svc.Deprecate(r.Context(), service.DeprecateInput{
+		KnowledgeID:  id,
+		SupersededBy: r.URL.Query().Get("superseded_by"),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, knowledgeToResponse(knowledge))
+}
+
+func (h *KnowledgeHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.svc.Approve)
+}
+
+func (h *KnowledgeHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.svc.Reject)
+}
+
+func (h *KnowledgeHandler) review(w http.ResponseWriter, r *http.Request, fn func(context.Context, service.ReviewInput) (*domain.Knowledge, error)) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req ReviewKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	knowledge, err := fn(r.Context(), service.ReviewInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		Reviewer:    middleware.GetAuthor(r.Context()),
+		Note:        req.Note,
+	})
 	if err != nil {
 		api.HandleError(w, err)
 		return
@@ -259,11 +598,305 @@ func (h *KnowledgeHandler) List(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func isValidKnowledgeType(t domain.KnowledgeType) bool {
-	switch t {
-	case domain.KnowledgeTypeGuideline, domain.KnowledgeTypeLearning, domain.KnowledgeTypeDecision,
-		domain.KnowledgeTypeTemplate, domain.KnowledgeTypeChecklist, domain.KnowledgeTypeSnippet:
-		return true
+func (h *KnowledgeHandler) ListPending(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	output, err := h.svc.ListPendingReview(r.Context(), service.ListKnowledgeInput{
+		OrgID:     orgID,
+		ProjectID: r.URL.Query().Get("project_id"),
+		Cursor:    r.URL.Query().Get("cursor"),
+		Limit:     limit,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	responses := make([]*KnowledgeResponse, len(output.Items))
+	for i, k := range output.Items {
+		responses[i] = knowledgeToResponse(k)
+	}
+
+	api.Success(w, http.StatusOK, KnowledgeListResponse{
+		Items:   responses,
+		Cursor:  output.Cursor,
+		HasMore: output.HasMore,
+	})
+}
+
+// ListStale lists knowledge whose review-by date has passed, optionally for one owner
+func (h *KnowledgeHandler) ListStale(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	output, err := h.svc.ListStale(r.Context(), service.ListStaleInput{
+		OrgID:     orgID,
+		ProjectID: r.URL.Query().Get("project_id"),
+		Owner:     r.URL.Query().Get("owner"),
+		Cursor:    r.URL.Query().Get("cursor"),
+		Limit:     limit,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	responses := make([]*KnowledgeResponse, len(output.Items))
+	for i, k := range output.Items {
+		responses[i] = knowledgeToResponse(k)
+	}
+
+	api.Success(w, http.StatusOK, KnowledgeListResponse{
+		Items:   responses,
+		Cursor:  output.Cursor,
+		HasMore: output.HasMore,
+	})
+}
+
+// KnowledgeSourceResponse describes an item imported from a file
+type KnowledgeSourceResponse struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Title      string `json:"title"`
+	SourcePath string `json:"source_path"`
+	SourceHash string `json:"source_hash,omitempty"`
+}
+
+type KnowledgeSourcesResponse struct {
+	Items []*KnowledgeSourceResponse `json:"items"`
+}
+
+// ListSources lists the items of a project that were imported from files, so that an
+// import can match files to the items it created before.
+func (h *KnowledgeHandler) ListSources(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	items, err := h.svc.ListSources(r.Context(), orgID, r.URL.Query().Get("project_id"))
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	responses := make([]*KnowledgeSourceResponse, len(items))
+	for i, k := range items {
+		responses[i] = &KnowledgeSourceResponse{
+			ID:         k.ID,
+			Type:       string(k.Type),
+			Status:     string(k.Status),
+			Title:      k.Title,
+			SourcePath: k.SourcePath,
+			SourceHash: k.SourceHash,
+		}
+	}
+
+	api.Success(w, http.StatusOK, KnowledgeSourcesResponse{Items: responses})
+}
+
+func (h *KnowledgeHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	versions, err := h.svc.ListVersions(r.Context(), orgID, id)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	// Bodies are omitted from the listing; fetch a single version for content
+	responses := make([]*KnowledgeVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = versionToResponse(v)
+		responses[i].BodyMD = ""
+	}
+
+	api.Success(w, http.StatusOK, responses)
+}
+
+func (h *KnowledgeHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	versionNumber, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 64)
+	if err != nil || versionNumber <= 0 {
+		api.Error(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	version, err := h.svc.GetVersion(r.Context(), orgID, id, versionNumber)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, versionToResponse(version))
+}
+
+func (h *KnowledgeHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	from, ok := parseVersionParam(r.URL.Query().Get("from"))
+	if !ok {
+		api.Error(w, http.StatusBadRequest, "invalid from version")
+		return
+	}
+	to, ok := parseVersionParam(r.URL.Query().Get("to"))
+	if !ok {
+		api.Error(w, http.StatusBadRequest, "invalid to version")
+		return
+	}
+
+	diff, err := h.svc.DiffVersions(r.Context(), service.VersionDiffInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, VersionDiffResponse{
+		KnowledgeID: diff.KnowledgeID,
+		From:        diff.From,
+		To:          diff.To,
+		Diff:        diff.Diff,
+	})
+}
+
+func (h *KnowledgeHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req RevertKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Version <= 0 {
+		api.Error(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	knowledge, version, err := h.svc.Revert(r.Context(), service.RevertInput{
+		OrgID:         orgID,
+		KnowledgeID:   id,
+		VersionNumber: req.Version,
+		Author:        middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, RevertResponse{
+		Knowledge: knowledgeToResponse(knowledge),
+		Version:   versionToResponse(version),
+	})
+}
+
+// formatETag renders a version number as a strong entity tag.
+func formatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseIfMatch extracts the expected version from an If-Match header.
+// An empty header or "*" means no precondition and returns 0.
+func parseIfMatch(header string) (int64, error) {
+	value := strings.TrimSpace(header)
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid version %q", header)
+	}
+	return version, nil
+}
+
+// parseVersionParam parses an optional positive version number; empty means unset.
+func parseVersionParam(value string) (int64, bool) {
+	if value == "" {
+		return 0, true
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// parseReviewAfter accepts a plain date (YYYY-MM-DD) or a full RFC 3339 timestamp.
+func parseReviewAfter(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
 	}
-	return false
+	return time.Parse(time.RFC3339, value)
 }
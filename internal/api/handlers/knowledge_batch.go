@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+)
+
+// BatchCreateKnowledgeRequest creates up to service.MaxBatchSize items in one transaction.
+// Mode is atomic (all-or-nothing) or best_effort, the default. Items similar to existing
+// knowledge fail unless Force is set for the batch or the item.
+type BatchCreateKnowledgeRequest struct {
+	Mode  string                   `json:"mode"`
+	Force bool                     `json:"force,omitempty"`
+	Items []CreateKnowledgeRequest `json:"items"`
+}
+
+type BatchDeprecateItemRequest struct {
+	ID           string `json:"id"`
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+// BatchDeprecateKnowledgeRequest deprecates up to service.MaxBatchSize items in one transaction
+type BatchDeprecateKnowledgeRequest struct {
+	Mode  string                      `json:"mode"`
+	Items []BatchDeprecateItemRequest `json:"items"`
+}
+
+type BatchItemResponse struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Title  string `json:"title,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Duplicates lists the similar items of an item that failed as a duplicate
+	Duplicates []*DuplicateCandidateResponse `json:"duplicates,omitempty"`
+}
+
+// BatchResponse lists one result per item in input order. RolledBack is set when an
+// atomic batch failed and nothing was written.
+type BatchResponse struct {
+	Mode       string               `json:"mode"`
+	Results    []*BatchItemResponse `json:"results"`
+	Total      int                  `json:"total"`
+	Succeeded  int                  `json:"succeeded"`
+	Failed     int                  `json:"failed"`
+	RolledBack bool                 `json:"rolled_back"`
+}
+
+// batchToResponse reports each item with its written ID and title, falling back to the
+// ID or title of the request item when nothing was written
+func batchToResponse(out *service.BatchOutput, ids, titles []string) *BatchResponse {
+	resp := &BatchResponse{
+		Mode:       out.Mode,
+		Results:    make([]*BatchItemResponse, len(out.Results)),
+		Total:      len(out.Results),
+		Succeeded:  out.Succeeded,
+		Failed:     out.Failed,
+		RolledBack: out.RolledBack,
+	}
+	for i, result := range out.Results {
+		item := &BatchItemResponse{Index: result.Index, Status: result.Status}
+		if result.Knowledge != nil {
+			item.ID = result.Knowledge.ID
+			item.Title = result.Knowledge.Title
+		} else {
+			if ids != nil {
+				item.ID = ids[i]
+			}
+			if titles != nil {
+				item.Title = titles[i]
+			}
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+			var dupErr *service.DuplicateKnowledgeError
+			if errors.As(result.Err, &dupErr) {
+				item.Duplicates = duplicatesToResponse(dupErr.Candidates)
+			}
+		}
+		resp.Results[i] = item
+	}
+	return resp
+}
+
+func (h *KnowledgeHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req BatchCreateKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	author := middleware.GetAuthor(r.Context())
+	items := make([]service.CreateInput, len(req.Items))
+	titles := make([]string, len(req.Items))
+	for i, item := range req.Items {
+		var reviewAfter *time.Time
+		if item.ReviewAfter != "" {
+			parsed, err := parseReviewAfter(item.ReviewAfter)
+			if err != nil {
+				api.Error(w, http.StatusBadRequest, fmt.Sprintf("items[%d]: invalid review_after", i))
+				return
+			}
+			reviewAfter = &parsed
+		}
+
+		items[i] = service.CreateInput{
+			ProjectID:   item.ProjectID,
+			Type:        domain.KnowledgeType(item.Type),
+			Title:       item.Title,
+			Summary:     item.Summary,
+			BodyMD:      item.BodyMD,
+			Scope:       item.Scope,
+			Tags:        item.Tags,
+			ReviewAfter: reviewAfter,
+			Owner:       item.Owner,
+			Language:    item.Language,
+			Author:      author,
+			Force:       item.Force,
+			SourcePath:  item.SourcePath,
+			SourceHash:  item.SourceHash,
+		}
+		titles[i] = item.Title
+	}
+
+	out, err := h.svc.BatchCreate(r.Context(), service.BatchCreateInput{
+		OrgID: orgID,
+		Mode:  req.Mode,
+		Force: req.Force,
+		Items: items,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, batchToResponse(out, nil, titles))
+}
+
+func (h *KnowledgeHandler) BatchDeprecate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req BatchDeprecateKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	items := make([]service.DeprecateInput, len(req.Items))
+	ids := make([]string, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = service.DeprecateInput{
+			KnowledgeID:  item.ID,
+			SupersededBy: item.SupersededBy,
+		}
+		ids[i] = item.ID
+	}
+
+	out, err := h.svc.BatchDeprecate(r.Context(), service.BatchDeprecateInput{
+		OrgID: orgID,
+		Mode:  req.Mode,
+		Items: items,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, batchToResponse(out, ids, nil))
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeHandler_BatchCreate(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	author := domain.Author{APIKeyID: "key-1", Name: "importer"}
+	mockSvc.On("BatchCreate", mock.Anything, mock.MatchedBy(func(input service.BatchCreateInput) bool {
+		return input.OrgID == "org-456" && input.Mode == "atomic" && len(input.Items) == 2 &&
+			input.Items[0].Title == "Test Knowledge" && input.Items[1].ReviewAfter != nil &&
+			input.Items[0].Author == author
+	})).Return(&service.BatchOutput{
+		Mode: service.BatchModeAtomic,
+		Results: []*service.BatchItemResult{
+			{Index: 0, Status: service.BatchItemRolledBack},
+			{Index: 1, Status: service.BatchItemFailed, Err: domain.ErrInvalidKnowledgeType},
+		},
+		Failed:     1,
+		RolledBack: true,
+	}, nil)
+
+	body := `{"mode":"atomic","items":[
+		{"type":"guideline","title":"Test Knowledge","body_md":"# Test"},
+		{"type":"bogus","title":"Second","body_md":"# Test","review_after":"2026-07-01"}
+	]}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/batch", []byte(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, author))
+	w := httptest.NewRecorder()
+
+	handler.BatchCreate(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data BatchResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Data.RolledBack)
+	assert.Equal(t, 2, resp.Data.Total)
+	assert.Equal(t, 1, resp.Data.Failed)
+	require.Len(t, resp.Data.Results, 2)
+	assert.Equal(t, "rolled_back", resp.Data.Results[0].Status)
+	assert.Equal(t, "Test Knowledge", resp.Data.Results[0].Title)
+	assert.Equal(t, 1, resp.Data.Results[1].Index)
+	assert.Contains(t, resp.Data.Results[1].Error, "invalid knowledge type")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_BatchCreate_Duplicates(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("BatchCreate", mock.Anything, mock.MatchedBy(func(input service.BatchCreateInput) bool {
+		return !input.Force && len(input.Items) == 2 && !input.Items[0].Force && input.Items[1].Force
+	})).Return(&service.BatchOutput{
+		Mode: service.BatchModeBestEffort,
+		Results: []*service.BatchItemResult{
+			{Index: 0, Status: service.BatchItemFailed, Err: &service.DuplicateKnowledgeError{Candidates: []*service.DuplicateCandidate{
+				{ID: "k-1", Type: domain.KnowledgeTypeLearning, Title: "Retry flaky tests once", Similarity: 0.95},
+			}}},
+			{Index: 1, Status: service.BatchItemCreated, Knowledge: newTestKnowledge()},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	}, nil)
+
+	body := `{"items":[
+		{"type":"learning","title":"Retry flaky tests","body_md":"Retry once"},
+		{"type":"learning","title":"Retry flaky tests twice","body_md":"Retry twice","force":true}
+	]}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/batch", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.BatchCreate(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data BatchResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Results, 2)
+	assert.Contains(t, resp.Data.Results[0].Error, "similar knowledge already exists")
+	require.Len(t, resp.Data.Results[0].Duplicates, 1)
+	assert.Equal(t, "k-1", resp.Data.Results[0].Duplicates[0].ID)
+	assert.Empty(t, resp.Data.Results[1].Duplicates)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_BatchCreate_InvalidReviewAfter(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	body := `{"items":[{"type":"guideline","title":"A","body_md":"B"},{"type":"guideline","title":"C","body_md":"D","review_after":"soon"}]}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/batch", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.BatchCreate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "items[1]: invalid review_after")
+	mockSvc.AssertNotCalled(t, "BatchCreate", mock.Anything, mock.Anything)
+}
+
+func TestKnowledgeHandler_BatchCreate_TooLarge(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("BatchCreate", mock.Anything, mock.Anything).Return(nil, domain.ErrBatchTooLarge)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/batch", []byte(`{"items":[]}`))
+	w := httptest.NewRecorder()
+
+	handler.BatchCreate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKnowledgeHandler_BatchDeprecate(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	deprecated := newTestKnowledge()
+	deprecated.Status = domain.KnowledgeStatusDeprecated
+	mockSvc.On("BatchDeprecate", mock.Anything, service.BatchDeprecateInput{
+		OrgID: "org-456",
+		Items: []service.DeprecateInput{{KnowledgeID: "k-123", SupersededBy: "k-456"}, {KnowledgeID: "k-404"}},
+	}).Return(&service.BatchOutput{
+		Mode: service.BatchModeBestEffort,
+		Results: []*service.BatchItemResult{
+			{Index: 0, Status: service.BatchItemDeprecated, Knowledge: deprecated},
+			{Index: 1, Status: service.BatchItemFailed, Err: domain.ErrKnowledgeNotFound},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	}, nil)
+
+	body := `{"items":[{"id":"k-123","superseded_by":"k-456"},{"id":"k-404"}]}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/batch/deprecate", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.BatchDeprecate(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data BatchResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "best_effort", resp.Data.Mode)
+	assert.Equal(t, "deprecated", resp.Data.Results[0].Status)
+	assert.Equal(t, "Test Knowledge", resp.Data.Results[0].Title)
+	assert.Equal(t, "k-404", resp.Data.Results[1].ID)
+	assert.Equal(t, "failed", resp.Data.Results[1].Status)
+	mockSvc.AssertExpectations(t)
+}
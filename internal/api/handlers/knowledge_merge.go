@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// MergeKnowledgeRequest folds SourceID into the item in the URL.
+// Mode is append (default) or replace.
+type MergeKnowledgeRequest struct {
+	SourceID string `json:"source_id"`
+	Mode     string `json:"mode,omitempty"`
+}
+
+type MovedReferencesResponse struct {
+	AssetLinks int `json:"asset_links"`
+	Relations  int `json:"relations"`
+	SearchLogs int `json:"search_logs"`
+}
+
+type MergeKnowledgeResponse struct {
+	Knowledge *KnowledgeResponse       `json:"knowledge"`
+	Source    *KnowledgeResponse       `json:"source"`
+	Moved     *MovedReferencesResponse `json:"moved"`
+}
+
+func (h *KnowledgeHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req MergeKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.SourceID == "" {
+		api.Error(w, http.StatusBadRequest, "source_id is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid If-Match header")
+		return
+	}
+
+	out, err := h.svc.Merge(r.Context(), service.MergeInput{
+		OrgID:           orgID,
+		TargetID:        id,
+		SourceID:        req.SourceID,
+		Mode:            service.MergeMode(req.Mode),
+		ExpectedVersion: expectedVersion,
+		Author:          middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			h.writeVersionConflict(w, r, id, err)
+			return
+		}
+		var lintErr *service.LintError
+		if errors.As(err, &lintErr) {
+			writeLintFailed(w, lintErr)
+			return
+		}
+		api.HandleError(w, err)
+		return
+	}
+
+	resp := knowledgeToResponse(out.Target)
+	resp.Version = out.Version.VersionNumber
+	resp.LintFindings = lintFindingsToResponse(out.LintFindings)
+	w.Header().Set("ETag", formatETag(out.Version.VersionNumber))
+
+	api.Success(w, http.StatusOK, MergeKnowledgeResponse{
+		Knowledge: resp,
+		Source:    knowledgeToResponse(out.Source),
+		Moved: &MovedReferencesResponse{
+			AssetLinks: out.Moved.AssetLinks,
+			Relations:  out.Moved.Relations,
+			SearchLogs: out.Moved.SearchLogs,
+		},
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeHandler_Merge(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	target := newTestKnowledge()
+	source := newTestKnowledge()
+	source.ID = "k-999"
+	source.Status = domain.KnowledgeStatusDeprecated
+	source.SupersededBy = target.ID
+
+	mockSvc.On("Merge", mock.Anything, mock.MatchedBy(func(input service.MergeInput) bool {
+		return input.OrgID == "org-456" && input.TargetID == "k-123" && input.SourceID == "k-999" &&
+			input.Mode == service.MergeModeReplace && input.ExpectedVersion == 2
+	})).Return(&service.MergeOutput{
+		Target:  target,
+		Version: &domain.KnowledgeVersion{VersionNumber: 3},
+		Source:  source,
+		Moved:   service.MovedReferences{AssetLinks: 1, Relations: 2, SearchLogs: 4},
+	}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/merge", []byte(`{"source_id":"k-999","mode":"replace"}`))
+	req.Header.Set("If-Match", `"2"`)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Merge(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	var resp struct {
+		Data MergeKnowledgeResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(3), resp.Data.Knowledge.Version)
+	assert.Equal(t, "k-123", resp.Data.Source.SupersededBy)
+	assert.Equal(t, 2, resp.Data.Moved.Relations)
+	assert.Equal(t, 4, resp.Data.Moved.SearchLogs)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Merge_Validation(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	req := withURLParams(requestWithOrgID(http.MethodPost, "/knowledge/k-123/merge", []byte(`{}`)), map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+	handler.Merge(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "source_id is required")
+
+	mockSvc.On("Merge", mock.Anything, mock.Anything).Return(nil, domain.ErrMergeSelf)
+
+	req = withURLParams(requestWithOrgID(http.MethodPost, "/knowledge/k-123/merge", []byte(`{"source_id":"k-123"}`)), map[string]string{"id": "k-123"})
+	w = httptest.NewRecorder()
+	handler.Merge(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKnowledgeHandler_Merge_VersionConflict(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Merge", mock.Anything, mock.Anything).Return(nil, domain.ErrVersionConflict)
+	mockSvc.On("GetByID", mock.Anything, "k-123").Return(newTestKnowledge(), nil)
+	mockSvc.On("GetLatestVersion", mock.Anything, "k-123").Return(&domain.KnowledgeVersion{VersionNumber: 5}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/merge", []byte(`{"source_id":"k-999"}`))
+	req.Header.Set("If-Match", `"4"`)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Merge(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+}
+
+func TestKnowledgeHandler_Merge_LintFailed(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Merge", mock.Anything, mock.Anything).Return(nil, &service.LintError{Findings: []domain.LintFinding{
+		{Rule: domain.LintRuleRequiredSections, Severity: domain.LintSeverityError, Message: "decision is missing sections: Decision"},
+	}})
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/merge", []byte(`{"source_id":"k-999","mode":"replace"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Merge(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp LintFailedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Findings, 1)
+	assert.Equal(t, "required-sections", resp.Data.Findings[0].Rule)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/service"
+)
+
+// MoveKnowledgeRequest rewrites the scope prefix of every item at or below From to To.
+// ProjectID optionally limits the move to one project; DryRun only lists the affected items.
+type MoveKnowledgeRequest struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	ProjectID string `json:"project_id,omitempty"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+type ScopeMoveResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	OldScope string `json:"old_scope"`
+	NewScope string `json:"new_scope"`
+	Version  int64  `json:"version,omitempty"`
+}
+
+type MoveKnowledgeResponse struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	DryRun bool                 `json:"dry_run"`
+	Total  int                  `json:"total"`
+	Items  []*ScopeMoveResponse `json:"items"`
+}
+
+func moveToResponse(out *service.MoveScopeOutput) *MoveKnowledgeResponse {
+	resp := &MoveKnowledgeResponse{
+		From:   out.From,
+		To:     out.To,
+		DryRun: out.DryRun,
+		Total:  len(out.Moves),
+		Items:  make([]*ScopeMoveResponse, len(out.Moves)),
+	}
+	for i, move := range out.Moves {
+		resp.Items[i] = &ScopeMoveResponse{
+			ID:       move.Knowledge.ID,
+			Title:    move.Knowledge.Title,
+			OldScope: move.OldScope,
+			NewScope: move.NewScope,
+			Version:  move.Version,
+		}
+	}
+	return resp
+}
+
+func (h *KnowledgeHandler) Move(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req MoveKnowledgeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.From == "" || req.To == "" {
+		api.Error(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+
+	out, err := h.svc.MoveScope(r.Context(), service.MoveScopeInput{
+		OrgID:     orgID,
+		ProjectID: req.ProjectID,
+		From:      req.From,
+		To:        req.To,
+		DryRun:    req.DryRun,
+		Author:    middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, moveToResponse(out))
+}
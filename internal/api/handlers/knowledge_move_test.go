@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeHandler_Move(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	author := domain.Author{APIKeyID: "key-1", Name: "alice"}
+	knowledge := newTestKnowledge()
+	knowledge.Scope = "platform/identity/handlers"
+	mockSvc.On("MoveScope", mock.Anything, service.MoveScopeInput{
+		OrgID:     "org-456",
+		ProjectID: "proj-1",
+		From:      "services/auth",
+		To:        "platform/identity",
+		Author:    author,
+	}).Return(&service.MoveScopeOutput{
+		From: "services/auth",
+		To:   "platform/identity",
+		Moves: []*service.ScopeMove{
+			{Knowledge: knowledge, OldScope: "services/auth/handlers", NewScope: "platform/identity/handlers", Version: 3},
+		},
+	}, nil)
+
+	body := `{"from":"services/auth","to":"platform/identity","project_id":"proj-1"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/move", []byte(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, author))
+	w := httptest.NewRecorder()
+
+	handler.Move(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data MoveKnowledgeResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Data.DryRun)
+	assert.Equal(t, 1, resp.Data.Total)
+	require.Len(t, resp.Data.Items, 1)
+	assert.Equal(t, knowledge.ID, resp.Data.Items[0].ID)
+	assert.Equal(t, "services/auth/handlers", resp.Data.Items[0].OldScope)
+	assert.Equal(t, "platform/identity/handlers", resp.Data.Items[0].NewScope)
+	assert.Equal(t, int64(3), resp.Data.Items[0].Version)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Move_DryRunWithoutMatches(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("MoveScope", mock.Anything, mock.MatchedBy(func(input service.MoveScopeInput) bool {
+		return input.DryRun
+	})).Return(&service.MoveScopeOutput{From: "a", To: "b", DryRun: true}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/move", []byte(`{"from":"a","to":"b","dry_run":true}`))
+	w := httptest.NewRecorder()
+
+	handler.Move(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"items":[]`)
+	assert.Contains(t, w.Body.String(), `"dry_run":true`)
+}
+
+func TestKnowledgeHandler_Move_Validation(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/move", []byte(`{"from":"a"}`))
+	w := httptest.NewRecorder()
+	handler.Move(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "from and to are required")
+
+	mockSvc.On("MoveScope", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidScopeMove)
+
+	req = requestWithOrgID(http.MethodPost, "/knowledge/move", []byte(`{"from":"a","to":"a/"}`))
+	w = httptest.NewRecorder()
+	handler.Move(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
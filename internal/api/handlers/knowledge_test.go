@@ -22,12 +22,12 @@ type MockKnowledgeService struct {
 	mock.Mock
 }
 
-func (m *MockKnowledgeService) Create(ctx context.Context, input service.CreateInput) (*domain.Knowledge, error) {
+func (m *MockKnowledgeService) Create(ctx context.Context, input service.CreateInput) (*service.CreateOutput, error) {
 	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Knowledge), args.Error(1)
+	return args.Get(0).(*service.CreateOutput), args.Error(1)
 }
 
 func (m *MockKnowledgeService) GetByID(ctx context.Context, id string) (*domain.Knowledge, error) {
@@ -38,16 +38,24 @@ func (m *MockKnowledgeService) GetByID(ctx context.Context, id string) (*domain.
 	return args.Get(0).(*domain.Knowledge), args.Error(1)
 }
 
-func (m *MockKnowledgeService) Update(ctx context.Context, input service.UpdateInput) (*domain.Knowledge, *domain.KnowledgeVersion, error) {
+func (m *MockKnowledgeService) GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error) {
+	args := m.Called(ctx, knowledgeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeVersion), args.Error(1)
+}
+
+func (m *MockKnowledgeService) Update(ctx context.Context, input service.UpdateInput) (*service.UpdateOutput, error) {
 	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
-		return nil, nil, args.Error(2)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.Knowledge), args.Get(1).(*domain.KnowledgeVersion), args.Error(2)
+	return args.Get(0).(*service.UpdateOutput), args.Error(1)
 }
 
-func (m *MockKnowledgeService) Deprecate(ctx context.Context, knowledgeID string) (*domain.Knowledge, error) {
-	args := m.Called(ctx, knowledgeID)
+func (m *MockKnowledgeService) Deprecate(ctx context.Context, input service.DeprecateInput) (*domain.Knowledge, error) {
+	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -78,6 +86,126 @@ func (m *MockKnowledgeService) ListKnowledge(ctx context.Context, input service.
 	return args.Get(0).(*service.ListKnowledgeOutput), args.Error(1)
 }
 
+func (m *MockKnowledgeService) Approve(ctx context.Context, input service.ReviewInput) (*domain.Knowledge, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockKnowledgeService) Reject(ctx context.Context, input service.ReviewInput) (*domain.Knowledge, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockKnowledgeService) ListPendingReview(ctx context.Context, input service.ListKnowledgeInput) (*service.ListKnowledgeOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ListKnowledgeOutput), args.Error(1)
+}
+
+func (m *MockKnowledgeService) ListStale(ctx context.Context, input service.ListStaleInput) (*service.ListKnowledgeOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ListKnowledgeOutput), args.Error(1)
+}
+
+func (m *MockKnowledgeService) ListVersions(ctx context.Context, orgID, knowledgeID string) ([]*domain.KnowledgeVersion, error) {
+	args := m.Called(ctx, orgID, knowledgeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeVersion), args.Error(1)
+}
+
+func (m *MockKnowledgeService) GetVersion(ctx context.Context, orgID, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error) {
+	args := m.Called(ctx, orgID, knowledgeID, versionNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeVersion), args.Error(1)
+}
+
+func (m *MockKnowledgeService) DiffVersions(ctx context.Context, input service.VersionDiffInput) (*service.VersionDiff, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.VersionDiff), args.Error(1)
+}
+
+func (m *MockKnowledgeService) Revert(ctx context.Context, input service.RevertInput) (*domain.Knowledge, *domain.KnowledgeVersion, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.Knowledge), args.Get(1).(*domain.KnowledgeVersion), args.Error(2)
+}
+
+func (m *MockKnowledgeService) RenderTemplate(ctx context.Context, input service.RenderTemplateInput) (*service.RenderedTemplate, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RenderedTemplate), args.Error(1)
+}
+
+func (m *MockKnowledgeService) Instantiate(ctx context.Context, input service.InstantiateInput) (*domain.Knowledge, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockKnowledgeService) BatchCreate(ctx context.Context, input service.BatchCreateInput) (*service.BatchOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BatchOutput), args.Error(1)
+}
+
+func (m *MockKnowledgeService) BatchDeprecate(ctx context.Context, input service.BatchDeprecateInput) (*service.BatchOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BatchOutput), args.Error(1)
+}
+
+func (m *MockKnowledgeService) MoveScope(ctx context.Context, input service.MoveScopeInput) (*service.MoveScopeOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MoveScopeOutput), args.Error(1)
+}
+
+func (m *MockKnowledgeService) ListSources(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error) {
+	args := m.Called(ctx, orgID, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockKnowledgeService) Merge(ctx context.Context, input service.MergeInput) (*service.MergeOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MergeOutput), args.Error(1)
+}
+
 func newTestKnowledge() *domain.Knowledge {
 	now := time.Now().UTC()
 	return &domain.Knowledge{
@@ -108,7 +236,7 @@ func TestKnowledgeHandler_Create_Success(t *testing.T) {
 	expectedKnowledge := newTestKnowledge()
 	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
 		return input.OrgID == "org-456" && input.Title == "Test Knowledge"
-	})).Return(expectedKnowledge, nil)
+	})).Return(&service.CreateOutput{Knowledge: expectedKnowledge}, nil)
 
 	body := `{"type":"guideline","title":"Test Knowledge","summary":"A test summary","body_md":"# Test\nBody content","project_id":"proj-789","scope":"src/"}`
 	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
@@ -170,6 +298,10 @@ func TestKnowledgeHandler_Create_InvalidType(t *testing.T) {
 	mockSvc := new(MockKnowledgeService)
 	handler := NewKnowledgeHandler(mockSvc)
 
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.Type == "invalid"
+	})).Return(nil, domain.ErrInvalidKnowledgeType)
+
 	body := `{"type":"invalid","title":"Test","body_md":"# Test"}`
 	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
 	w := httptest.NewRecorder()
@@ -178,6 +310,7 @@ func TestKnowledgeHandler_Create_InvalidType(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "invalid knowledge type")
+	mockSvc.AssertExpectations(t)
 }
 
 func TestKnowledgeHandler_Get_Success(t *testing.T) {
@@ -186,6 +319,34 @@ func TestKnowledgeHandler_Get_Success(t *testing.T) {
 
 	expectedKnowledge := newTestKnowledge()
 	mockSvc.On("GetByID", mock.Anything, "k-123").Return(expectedKnowledge, nil)
+	mockSvc.On("GetLatestVersion", mock.Anything, "k-123").Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/knowledge/k-123", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, float64(3), data["version"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Get_GeneratedMetadata(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	expectedKnowledge := newTestKnowledge()
+	expectedKnowledge.SummaryGeneratedBy = "gpt-4o-mini"
+	expectedKnowledge.SuggestedTitle = "Tuesday deploys"
+	mockSvc.On("GetByID", mock.Anything, "k-123").Return(expectedKnowledge, nil)
+	mockSvc.On("GetLatestVersion", mock.Anything, "k-123").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/knowledge/k-123", nil)
 	rctx := chi.NewRouteContext()
@@ -196,6 +357,11 @@ func TestKnowledgeHandler_Get_Success(t *testing.T) {
 	handler.Get(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "gpt-4o-mini", data["summary_generated_by"])
+	assert.Equal(t, "Tuesday deploys", data["suggested_title"])
 	mockSvc.AssertExpectations(t)
 }
 
@@ -225,7 +391,7 @@ func TestKnowledgeHandler_Update_Success(t *testing.T) {
 	expectedVersion := &domain.KnowledgeVersion{ID: "v-1", KnowledgeID: "k-123", VersionNumber: 2}
 	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateInput) bool {
 		return input.KnowledgeID == "k-123" && input.Title == "Updated Title"
-	})).Return(expectedKnowledge, expectedVersion, nil)
+	})).Return(&service.UpdateOutput{Knowledge: expectedKnowledge, Version: expectedVersion}, nil)
 
 	body := `{"title":"Updated Title","summary":"Updated summary","body_md":"# Updated"}`
 	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
@@ -246,7 +412,7 @@ func TestKnowledgeHandler_Delete_Success(t *testing.T) {
 
 	expectedKnowledge := newTestKnowledge()
 	expectedKnowledge.Status = domain.KnowledgeStatusDeprecated
-	mockSvc.On("Deprecate", mock.Anything, "k-123").Return(expectedKnowledge, nil)
+	mockSvc.On("Deprecate", mock.Anything, service.DeprecateInput{KnowledgeID: "k-123"}).Return(expectedKnowledge, nil)
 
 	req := requestWithOrgID(http.MethodDelete, "/knowledge/k-123", nil)
 	rctx := chi.NewRouteContext()
@@ -264,6 +430,49 @@ func TestKnowledgeHandler_Delete_Success(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestKnowledgeHandler_Delete_WithSuccessor(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	expectedKnowledge := newTestKnowledge()
+	expectedKnowledge.Status = domain.KnowledgeStatusDeprecated
+	expectedKnowledge.SupersededBy = "k-789"
+	mockSvc.On("Deprecate", mock.Anything, service.DeprecateInput{KnowledgeID: "k-123", SupersededBy: "k-789"}).Return(expectedKnowledge, nil)
+
+	req := requestWithOrgID(http.MethodDelete, "/knowledge/k-123?superseded_by=k-789", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "k-789", data["superseded_by"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Delete_InvalidSuccessor(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Deprecate", mock.Anything, service.DeprecateInput{KnowledgeID: "k-123", SupersededBy: "k-123"}).Return(nil, domain.ErrSupersedeSelf)
+
+	req := requestWithOrgID(http.MethodDelete, "/knowledge/k-123?superseded_by=k-123", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
 func TestKnowledgeHandler_List_ByOrg(t *testing.T) {
 	mockSvc := new(MockKnowledgeService)
 	handler := NewKnowledgeHandler(mockSvc)
@@ -305,3 +514,571 @@ func TestKnowledgeHandler_List_ByProject(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestKnowledgeHandler_Approve_Success(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	reviewer := domain.Author{APIKeyID: "key-1", Name: "alice"}
+	reviewedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	expectedKnowledge := newTestKnowledge()
+	expectedKnowledge.Status = domain.KnowledgeStatusApproved
+	expectedKnowledge.ReviewedBy = reviewer
+	expectedKnowledge.ReviewedAt = &reviewedAt
+	mockSvc.On("Approve", mock.Anything, service.ReviewInput{OrgID: "org-456", KnowledgeID: "k-123", Reviewer: reviewer}).Return(expectedKnowledge, nil)
+
+	// A reviewer name in the body is ignored; the reviewer is the authenticated key
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/approve", []byte(`{"reviewer":"mallory"}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, reviewer))
+	w := httptest.NewRecorder()
+
+	handler.Approve(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "approved", data["status"])
+	assert.Equal(t, "alice", data["reviewed_by"])
+	assert.Equal(t, "key-1", data["reviewed_by_key_id"])
+	assert.Equal(t, "2024-01-02T00:00:00Z", data["reviewed_at"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Approve_SelfApproval(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Approve", mock.Anything, mock.Anything).Return(nil, domain.ErrSelfApproval)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/approve", []byte(`{}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Approve(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Reject_NotPending(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	reviewer := domain.Author{APIKeyID: "key-1", Name: "alice"}
+	mockSvc.On("Reject", mock.Anything, service.ReviewInput{OrgID: "org-456", KnowledgeID: "k-123", Reviewer: reviewer, Note: "outdated"}).Return(nil, domain.ErrKnowledgeNotPending)
+
+	body, _ := json.Marshal(ReviewKnowledgeRequest{Note: "outdated"})
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/reject", body)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, reviewer))
+	w := httptest.NewRecorder()
+
+	handler.Reject(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_ListPending(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	expectedOutput := &service.ListKnowledgeOutput{
+		Items:   []*domain.Knowledge{newTestKnowledge()},
+		HasMore: false,
+	}
+	mockSvc.On("ListPendingReview", mock.Anything, mock.MatchedBy(func(input service.ListKnowledgeInput) bool {
+		return input.OrgID == "org-456" && input.ProjectID == "proj-789" && input.Limit == 5
+	})).Return(expectedOutput, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/pending?project_id=proj-789&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListPending(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_ListStale(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	staleSince := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	stale := newTestKnowledge()
+	stale.Owner = "platform-team"
+	stale.StaleSince = &staleSince
+	mockSvc.On("ListStale", mock.Anything, service.ListStaleInput{
+		OrgID:     "org-456",
+		ProjectID: "proj-789",
+		Owner:     "platform-team",
+		Limit:     20,
+	}).Return(&service.ListKnowledgeOutput{Items: []*domain.Knowledge{stale}}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/stale?project_id=proj-789&owner=platform-team", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListStale(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	items := resp["data"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 1)
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, true, item["stale"])
+	assert.Equal(t, "2026-03-01T09:00:00Z", item["stale_since"])
+	assert.Equal(t, "platform-team", item["owner"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_ListSources(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	imported := newTestKnowledge()
+	imported.SourcePath = "docs/deploys.md"
+	imported.SourceHash = "abc123"
+	mockSvc.On("ListSources", mock.Anything, "org-456", "proj-789").Return([]*domain.Knowledge{imported}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/sources?project_id=proj-789", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListSources(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data KnowledgeSourcesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Items, 1)
+	assert.Equal(t, imported.ID, resp.Data.Items[0].ID)
+	assert.Equal(t, "docs/deploys.md", resp.Data.Items[0].SourcePath)
+	assert.Equal(t, "abc123", resp.Data.Items[0].SourceHash)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_Source(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.SourcePath == "docs/deploys.md" && input.SourceHash == "abc123"
+	})).Return(&service.CreateOutput{Knowledge: newTestKnowledge()}, nil)
+
+	body := `{"type":"guideline","title":"Deploys","body_md":"# Deploys","source_path":"docs/deploys.md","source_hash":"abc123"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_ReviewAfter(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.ReviewAfter != nil &&
+			input.ReviewAfter.Equal(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)) &&
+			input.Owner == "platform-team"
+	})).Return(&service.CreateOutput{Knowledge: newTestKnowledge()}, nil)
+
+	body := `{"type":"guideline","title":"Test Knowledge","body_md":"# Test","review_after":"2026-06-30","owner":"platform-team"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_InvalidReviewAfter(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	body := `{"type":"guideline","title":"Test","body_md":"# Test","review_after":"next month"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid review_after")
+}
+
+func TestKnowledgeHandler_Update_ClearReviewAfter(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateInput) bool {
+		return input.ReviewAfter != nil && input.ReviewAfter.IsZero() && input.Owner == nil
+	})).Return(&service.UpdateOutput{Knowledge: newTestKnowledge(), Version: &domain.KnowledgeVersion{VersionNumber: 2}}, nil)
+
+	body := `{"title":"Updated Title","body_md":"# Updated","review_after":""}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_Language(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	snippet := newTestKnowledge()
+	snippet.Type = domain.KnowledgeTypeSnippet
+	snippet.Language = "go"
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.Type == domain.KnowledgeTypeSnippet && input.Language == "golang"
+	})).Return(&service.CreateOutput{Knowledge: snippet}, nil)
+
+	body := `{"type":"snippet","title":"Retry loop","body_md":"for {}","language":"golang"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "go", resp["data"].(map[string]interface{})["language"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Update_Language(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateInput) bool {
+		return input.Language != nil && *input.Language == "python"
+	})).Return(&service.UpdateOutput{Knowledge: newTestKnowledge(), Version: &domain.KnowledgeVersion{VersionNumber: 2}}, nil)
+
+	body := `{"title":"Updated Title","body_md":"# Updated","language":"python"}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_RecordsAuthor(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	author := domain.Author{APIKeyID: "key-1", Name: "release-bot"}
+	created := newTestKnowledge()
+	created.CreatedBy = author
+	created.UpdatedBy = author
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.Author == author
+	})).Return(&service.CreateOutput{Knowledge: created}, nil)
+
+	body := `{"type":"guideline","title":"Test Knowledge","body_md":"# Test"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, author))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "release-bot", data["created_by"])
+	assert.Equal(t, "key-1", data["created_by_key_id"])
+	assert.Equal(t, "release-bot", data["updated_by"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_Duplicates(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return !input.Force
+	})).Return(nil, &service.DuplicateKnowledgeError{Candidates: []*service.DuplicateCandidate{
+		{ID: "k-1", Type: domain.KnowledgeTypeLearning, Title: "Retry flaky tests once", Similarity: 0.94},
+	}})
+
+	body := `{"type":"learning","title":"Retry flaky tests","body_md":"# Retry"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp DuplicateKnowledgeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Error, "similar knowledge already exists")
+	require.Len(t, resp.Data.Duplicates, 1)
+	assert.Equal(t, "k-1", resp.Data.Duplicates[0].ID)
+	assert.Equal(t, "learning", resp.Data.Duplicates[0].Type)
+	assert.Equal(t, 0.94, resp.Data.Duplicates[0].Similarity)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_Force(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input service.CreateInput) bool {
+		return input.Force
+	})).Return(&service.CreateOutput{Knowledge: newTestKnowledge()}, nil)
+
+	body := `{"type":"learning","title":"Retry flaky tests","body_md":"# Retry","force":true}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Update_RecordsAuthor(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	author := domain.Author{APIKeyID: "key-2", Name: "bob"}
+	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateInput) bool {
+		return input.Author == author
+	})).Return(&service.UpdateOutput{Knowledge: newTestKnowledge(), Version: &domain.KnowledgeVersion{VersionNumber: 2, CreatedBy: author}}, nil)
+
+	body := `{"title":"Updated Title","body_md":"# Updated"}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.AuthorKey, author))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_ListVersions(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	versions := []*domain.KnowledgeVersion{
+		{ID: "v-2", KnowledgeID: "k-123", VersionNumber: 2, Title: "Second", BodyMD: "body", CreatedAt: time.Now()},
+		{ID: "v-1", KnowledgeID: "k-123", VersionNumber: 1, Title: "First", BodyMD: "body", CreatedAt: time.Now()},
+	}
+	mockSvc.On("ListVersions", mock.Anything, "org-456", "k-123").Return(versions, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/versions", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.ListVersions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].([]interface{})
+	require.Len(t, data, 2)
+	first := data[0].(map[string]interface{})
+	assert.Equal(t, float64(2), first["version_number"])
+	assert.NotContains(t, first, "body_md")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_GetVersion_InvalidNumber(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/versions/abc", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	rctx.URLParams.Add("version", "abc")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.GetVersion(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKnowledgeHandler_Diff(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("DiffVersions", mock.Anything, service.VersionDiffInput{OrgID: "org-456", KnowledgeID: "k-123", From: 1, To: 3}).Return(&service.VersionDiff{
+		KnowledgeID: "k-123",
+		From:        1,
+		To:          3,
+		Diff:        "--- v1\n+++ v3\n",
+	}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/diff?from=1&to=3", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Diff(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "--- v1\n+++ v3\n", data["diff"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Revert(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	version := &domain.KnowledgeVersion{ID: "v-4", KnowledgeID: "k-123", VersionNumber: 4, Title: "Test Knowledge", CreatedAt: time.Now()}
+	mockSvc.On("Revert", mock.Anything, service.RevertInput{OrgID: "org-456", KnowledgeID: "k-123", VersionNumber: 2}).Return(newTestKnowledge(), version, nil)
+
+	body, _ := json.Marshal(RevertKnowledgeRequest{Version: 2})
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/revert", body)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Revert(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, float64(4), data["version"].(map[string]interface{})["version_number"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Update_IfMatch(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	expectedVersion := &domain.KnowledgeVersion{ID: "v-3", KnowledgeID: "k-123", VersionNumber: 3}
+	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateInput) bool {
+		return input.ExpectedVersion == 2
+	})).Return(&service.UpdateOutput{Knowledge: newTestKnowledge(), Version: expectedVersion}, nil)
+
+	body := `{"title":"Updated Title","body_md":"# Updated"}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	req.Header.Set("If-Match", `"2"`)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Update_VersionConflict(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	current := newTestKnowledge()
+	current.Title = "Someone else's edit"
+	mockSvc.On("Update", mock.Anything, mock.Anything).Return(nil, domain.ErrVersionConflict)
+	mockSvc.On("GetByID", mock.Anything, "k-123").Return(current, nil)
+	mockSvc.On("GetLatestVersion", mock.Anything, "k-123").Return(&domain.KnowledgeVersion{VersionNumber: 4}, nil)
+
+	body := `{"title":"Updated Title","body_md":"# Updated"}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	req.Header.Set("If-Match", `"2"`)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	var resp map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NotEmpty(t, resp["error"])
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, float64(4), data["version"])
+	assert.Equal(t, "Someone else's edit", data["title"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Update_InvalidIfMatch(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	body := `{"title":"Updated Title","body_md":"# Updated"}`
+	req := requestWithOrgID(http.MethodPut, "/knowledge/k-123", []byte(body))
+	req.Header.Set("If-Match", `"abc"`)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "k-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"*", 0, false},
+		{`"3"`, 3, false},
+		{`W/"3"`, 3, false},
+		{"3", 3, false},
+		{`"0"`, 0, true},
+		{`"v3"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := parseIfMatch(tt.header)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
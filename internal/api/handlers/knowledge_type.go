@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type KnowledgeTypeService interface {
+	Create(ctx context.Context, input service.CreateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error)
+	Get(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error)
+	List(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error)
+	Update(ctx context.Context, input service.UpdateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error)
+	Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error
+}
+
+type KnowledgeTypeHandler struct {
+	svc KnowledgeTypeService
+}
+
+func NewKnowledgeTypeHandler(svc KnowledgeTypeService) *KnowledgeTypeHandler {
+	return &KnowledgeTypeHandler{svc: svc}
+}
+
+type CreateKnowledgeTypeRequest struct {
+	Name             string   `json:"name"`
+	DisplayName      string   `json:"display_name,omitempty"`
+	RequiredSections []string `json:"required_sections,omitempty"`
+	SearchBoost      float32  `json:"search_boost,omitempty"`
+}
+
+// UpdateKnowledgeTypeRequest changes only the fields present; an empty required_sections list removes the schema
+type UpdateKnowledgeTypeRequest struct {
+	DisplayName      *string  `json:"display_name,omitempty"`
+	RequiredSections []string `json:"required_sections"`
+	SearchBoost      *float32 `json:"search_boost,omitempty"`
+}
+
+// KnowledgeTypeResponse describes a type; built-in types the org has not customized have no timestamps
+type KnowledgeTypeResponse struct {
+	Name             string   `json:"name"`
+	DisplayName      string   `json:"display_name"`
+	RequiredSections []string `json:"required_sections"`
+	SearchBoost      float32  `json:"search_boost"`
+	Builtin          bool     `json:"builtin"`
+	Registered       bool     `json:"registered"`
+	CreatedAt        string   `json:"created_at,omitempty"`
+	UpdatedAt        string   `json:"updated_at,omitempty"`
+}
+
+type KnowledgeTypeListResponse struct {
+	Items []*KnowledgeTypeResponse `json:"items"`
+}
+
+func knowledgeTypeToResponse(d *domain.KnowledgeTypeDefinition) *KnowledgeTypeResponse {
+	sections := d.RequiredSections
+	if sections == nil {
+		sections = []string{}
+	}
+	resp := &KnowledgeTypeResponse{
+		Name:             string(d.Name),
+		DisplayName:      d.DisplayName,
+		RequiredSections: sections,
+		SearchBoost:      d.SearchBoost,
+		Builtin:          domain.IsBuiltinKnowledgeType(d.Name),
+		Registered:       d.ID != "",
+	}
+	if !d.CreatedAt.IsZero() {
+		resp.CreatedAt = d.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if !d.UpdatedAt.IsZero() {
+		resp.UpdatedAt = d.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return resp
+}
+
+func (h *KnowledgeTypeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateKnowledgeTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Name == "" {
+		api.Error(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	def, err := h.svc.Create(r.Context(), service.CreateKnowledgeTypeInput{
+		OrgID:            orgID,
+		Name:             domain.KnowledgeType(req.Name),
+		DisplayName:      req.DisplayName,
+		RequiredSections: req.RequiredSections,
+		SearchBoost:      req.SearchBoost,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, knowledgeTypeToResponse(def))
+}
+
+func (h *KnowledgeTypeHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	defs, err := h.svc.List(r.Context(), orgID)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	items := make([]*KnowledgeTypeResponse, len(defs))
+	for i, d := range defs {
+		items[i] = knowledgeTypeToResponse(d)
+	}
+
+	api.Success(w, http.StatusOK, KnowledgeTypeListResponse{Items: items})
+}
+
+func (h *KnowledgeTypeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		api.Error(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	def, err := h.svc.Get(r.Context(), orgID, domain.KnowledgeType(name))
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, knowledgeTypeToResponse(def))
+}
+
+func (h *KnowledgeTypeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		api.Error(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	var req UpdateKnowledgeTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	def, err := h.svc.Update(r.Context(), service.UpdateKnowledgeTypeInput{
+		OrgID:            orgID,
+		Name:             domain.KnowledgeType(name),
+		DisplayName:      req.DisplayName,
+		RequiredSections: req.RequiredSections,
+		SearchBoost:      req.SearchBoost,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, knowledgeTypeToResponse(def))
+}
+
+func (h *KnowledgeTypeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		api.Error(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), orgID, domain.KnowledgeType(name)); err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, map[string]string{"name": name, "status": "deleted"})
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockKnowledgeTypeService struct {
+	mock.Mock
+}
+
+func (m *MockKnowledgeTypeService) Create(ctx context.Context, input service.CreateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeService) Get(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, orgID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeService) List(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeService) Update(ctx context.Context, input service.UpdateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeService) Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error {
+	args := m.Called(ctx, orgID, name)
+	return args.Error(0)
+}
+
+func newTestKnowledgeType() *domain.KnowledgeTypeDefinition {
+	now := time.Now()
+	return &domain.KnowledgeTypeDefinition{
+		ID:               "kt-1",
+		OrgID:            "org-456",
+		Name:             "runbook",
+		DisplayName:      "Runbook",
+		RequiredSections: []string{"Steps", "Rollback"},
+		SearchBoost:      1.5,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+func TestKnowledgeTypeHandler_Create_Success(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, service.CreateKnowledgeTypeInput{
+		OrgID:            "org-456",
+		Name:             "runbook",
+		DisplayName:      "Runbook",
+		RequiredSections: []string{"Steps", "Rollback"},
+		SearchBoost:      1.5,
+	}).Return(newTestKnowledgeType(), nil)
+
+	body := `{"name":"runbook","display_name":"Runbook","required_sections":["Steps","Rollback"],"search_boost":1.5}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge-types", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "runbook", data["name"])
+	assert.Equal(t, []interface{}{"Steps", "Rollback"}, data["required_sections"])
+	assert.Equal(t, 1.5, data["search_boost"])
+	assert.Equal(t, false, data["builtin"])
+	assert.Equal(t, true, data["registered"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeTypeHandler_Create_MissingName(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge-types", []byte(`{"display_name":"Runbook"}`))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "name is required")
+}
+
+func TestKnowledgeTypeHandler_Create_AlreadyExists(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrKnowledgeTypeAlreadyExists)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge-types", []byte(`{"name":"runbook"}`))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestKnowledgeTypeHandler_List(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	mockSvc.On("List", mock.Anything, "org-456").Return([]*domain.KnowledgeTypeDefinition{
+		domain.DefaultKnowledgeTypeDefinition(domain.KnowledgeTypeGuideline),
+		newTestKnowledgeType(),
+	}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge-types", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	items := resp["data"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 2)
+
+	builtin := items[0].(map[string]interface{})
+	assert.Equal(t, "guideline", builtin["name"])
+	assert.Equal(t, true, builtin["builtin"])
+	assert.Equal(t, false, builtin["registered"])
+	assert.Equal(t, []interface{}{}, builtin["required_sections"])
+	assert.NotContains(t, builtin, "created_at")
+}
+
+func TestKnowledgeTypeHandler_Get_NotFound(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	mockSvc.On("Get", mock.Anything, "org-456", domain.KnowledgeType("missing")).Return(nil, domain.ErrKnowledgeTypeNotFound)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge-types/missing", nil)
+	req = withURLParams(req, map[string]string{"name": "missing"})
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestKnowledgeTypeHandler_Update_Success(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	updated := newTestKnowledgeType()
+	updated.SearchBoost = 2
+	updated.RequiredSections = []string{}
+
+	mockSvc.On("Update", mock.Anything, mock.MatchedBy(func(input service.UpdateKnowledgeTypeInput) bool {
+		return input.OrgID == "org-456" && input.Name == "runbook" &&
+			input.DisplayName == nil && input.SearchBoost != nil && *input.SearchBoost == 2 &&
+			input.RequiredSections != nil && len(input.RequiredSections) == 0
+	})).Return(updated, nil)
+
+	req := requestWithOrgID(http.MethodPut, "/knowledge-types/runbook", []byte(`{"search_boost":2,"required_sections":[]}`))
+	req = withURLParams(req, map[string]string{"name": "runbook"})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeTypeHandler_Delete_InUse(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	mockSvc.On("Delete", mock.Anything, "org-456", domain.KnowledgeType("runbook")).Return(domain.ErrKnowledgeTypeInUse)
+
+	req := requestWithOrgID(http.MethodDelete, "/knowledge-types/runbook", nil)
+	req = withURLParams(req, map[string]string{"name": "runbook"})
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKnowledgeTypeHandler_Unauthorized(t *testing.T) {
+	mockSvc := new(MockKnowledgeTypeService)
+	handler := NewKnowledgeTypeHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/knowledge-types", nil)
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
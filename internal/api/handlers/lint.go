@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+)
+
+type LintService interface {
+	GetConfig(ctx context.Context, orgID string) (*domain.LintConfig, error)
+	UpdateConfig(ctx context.Context, orgID string, config *domain.LintConfig) (*domain.LintConfig, error)
+}
+
+type LintHandler struct {
+	svc LintService
+}
+
+func NewLintHandler(svc LintService) *LintHandler {
+	return &LintHandler{svc: svc}
+}
+
+// LintRuleConfig configures one rule; options left out take the rule's default
+type LintRuleConfig struct {
+	Severity     string   `json:"severity,omitempty"`
+	MaxBytes     int      `json:"max_bytes,omitempty"`
+	Placeholders []string `json:"placeholders,omitempty"`
+}
+
+// UpdateLintConfigRequest replaces the org's lint configuration; rules left out keep their defaults
+type UpdateLintConfigRequest struct {
+	Mode  string                    `json:"mode"`
+	Rules map[string]LintRuleConfig `json:"rules,omitempty"`
+}
+
+// LintConfigResponse lists every rule with its effective options
+type LintConfigResponse struct {
+	Mode  string                    `json:"mode"`
+	Rules map[string]LintRuleConfig `json:"rules"`
+}
+
+type LintFindingResponse struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+type LintFindingsResponse struct {
+	Findings []*LintFindingResponse `json:"findings"`
+}
+
+// LintFailedResponse is returned with 400 when an item breaks lint rules in reject mode
+type LintFailedResponse struct {
+	Error string                `json:"error"`
+	Data  *LintFindingsResponse `json:"data"`
+}
+
+func lintConfigToResponse(c *domain.LintConfig) *LintConfigResponse {
+	rules := make(map[string]LintRuleConfig, len(domain.LintRuleNames))
+	for _, name := range domain.LintRuleNames {
+		rule := c.Rule(name)
+		rules[name] = LintRuleConfig{
+			Severity:     string(rule.Severity),
+			MaxBytes:     rule.MaxBytes,
+			Placeholders: rule.Placeholders,
+		}
+	}
+	return &LintConfigResponse{Mode: string(c.Mode), Rules: rules}
+}
+
+func lintFindingsToResponse(findings []domain.LintFinding) []*LintFindingResponse {
+	if len(findings) == 0 {
+		return nil
+	}
+	resp := make([]*LintFindingResponse, len(findings))
+	for i, f := range findings {
+		resp[i] = &LintFindingResponse{
+			Rule:     f.Rule,
+			Severity: string(f.Severity),
+			Message:  f.Message,
+			Line:     f.Line,
+		}
+	}
+	return resp
+}
+
+// writeLintFailed responds 400 with the findings so the caller can fix the item
+func writeLintFailed(w http.ResponseWriter, lintErr *service.LintError) {
+	api.JSON(w, http.StatusBadRequest, LintFailedResponse{
+		Error: lintErr.Error(),
+		Data:  &LintFindingsResponse{Findings: lintFindingsToResponse(lintErr.Findings)},
+	})
+}
+
+func (h *LintHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	config, err := h.svc.GetConfig(r.Context(), orgID)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, lintConfigToResponse(config))
+}
+
+func (h *LintHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req UpdateLintConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Mode == "" {
+		api.Error(w, http.StatusBadRequest, "mode is required")
+		return
+	}
+
+	config := &domain.LintConfig{Mode: domain.LintMode(req.Mode)}
+	if len(req.Rules) > 0 {
+		config.Rules = make(map[string]domain.LintRule, len(req.Rules))
+		for name, rule := range req.Rules {
+			config.Rules[name] = domain.LintRule{
+				Severity:     domain.LintSeverity(rule.Severity),
+				MaxBytes:     rule.MaxBytes,
+				Placeholders: rule.Placeholders,
+			}
+		}
+	}
+
+	config, err := h.svc.UpdateConfig(r.Context(), orgID, config)
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, lintConfigToResponse(config))
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLintService struct {
+	mock.Mock
+}
+
+func (m *MockLintService) GetConfig(ctx context.Context, orgID string) (*domain.LintConfig, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LintConfig), args.Error(1)
+}
+
+func (m *MockLintService) UpdateConfig(ctx context.Context, orgID string, config *domain.LintConfig) (*domain.LintConfig, error) {
+	args := m.Called(ctx, orgID, config)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LintConfig), args.Error(1)
+}
+
+func TestLintHandler_GetConfig(t *testing.T) {
+	mockSvc := new(MockLintService)
+	handler := NewLintHandler(mockSvc)
+
+	mockSvc.On("GetConfig", mock.Anything, "org-456").Return(domain.DefaultLintConfig(), nil)
+
+	req := requestWithOrgID(http.MethodGet, "/lint/config", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetConfig(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data LintConfigResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "warn", resp.Data.Mode)
+	assert.Len(t, resp.Data.Rules, len(domain.LintRuleNames))
+	assert.Equal(t, "error", resp.Data.Rules[domain.LintRuleRequiredSections].Severity)
+	assert.Equal(t, 64*1024, resp.Data.Rules[domain.LintRuleMaxBodySize].MaxBytes)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLintHandler_UpdateConfig(t *testing.T) {
+	mockSvc := new(MockLintService)
+	handler := NewLintHandler(mockSvc)
+
+	expected := &domain.LintConfig{
+		Mode:  domain.LintModeReject,
+		Rules: map[string]domain.LintRule{domain.LintRuleSummaryRequired: {Severity: domain.LintSeverityError}},
+	}
+	mockSvc.On("UpdateConfig", mock.Anything, "org-456", expected).Return(expected, nil)
+
+	body := `{"mode":"reject","rules":{"summary-required":{"severity":"error"}}}`
+	req := requestWithOrgID(http.MethodPut, "/lint/config", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.UpdateConfig(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data LintConfigResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "reject", resp.Data.Mode)
+	assert.Equal(t, "error", resp.Data.Rules[domain.LintRuleSummaryRequired].Severity)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLintHandler_UpdateConfig_Invalid(t *testing.T) {
+	mockSvc := new(MockLintService)
+	handler := NewLintHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPut, "/lint/config", []byte(`{"rules":{}}`))
+	w := httptest.NewRecorder()
+	handler.UpdateConfig(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "mode is required")
+
+	mockSvc.On("UpdateConfig", mock.Anything, "org-456", mock.Anything).
+		Return(nil, domain.NewDomainError(domain.ErrCodeValidation, "unknown lint rule \"no-swearing\""))
+
+	req = requestWithOrgID(http.MethodPut, "/lint/config", []byte(`{"mode":"warn","rules":{"no-swearing":{}}}`))
+	w = httptest.NewRecorder()
+	handler.UpdateConfig(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown lint rule")
+}
+
+func TestKnowledgeHandler_Create_LintFailed(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, &service.LintError{Findings: []domain.LintFinding{
+		{Rule: domain.LintRuleRequiredSections, Severity: domain.LintSeverityError, Message: "decision is missing sections: Consequences"},
+		{Rule: domain.LintRuleNoPlaceholders, Severity: domain.LintSeverityWarning, Message: "placeholder TODO", Line: 3},
+	}})
+
+	body := `{"type":"decision","title":"Use Postgres","body_md":"# Context"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp LintFailedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Error, "knowledge breaks the lint rules of this organization")
+	require.Len(t, resp.Data.Findings, 2)
+	assert.Equal(t, "required-sections", resp.Data.Findings[0].Rule)
+	assert.Equal(t, 3, resp.Data.Findings[1].Line)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Create_LintWarnings(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(&service.CreateOutput{
+		Knowledge: newTestKnowledge(),
+		LintFindings: []domain.LintFinding{
+			{Rule: domain.LintRuleSummaryRequired, Severity: domain.LintSeverityWarning, Message: "summary is empty"},
+		},
+	}, nil)
+
+	body := `{"type":"guideline","title":"Test","body_md":"Body"}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge", []byte(body))
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Data KnowledgeResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.LintFindings, 1)
+	assert.Equal(t, "summary-required", resp.Data.LintFindings[0].Rule)
+	assert.Equal(t, "warning", resp.Data.LintFindings[0].Severity)
+}
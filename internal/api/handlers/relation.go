@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type RelationService interface {
+	Create(ctx context.Context, input service.CreateRelationInput) (*domain.KnowledgeRelation, error)
+	Delete(ctx context.Context, input service.DeleteRelationInput) error
+	ListRelations(ctx context.Context, input service.ListRelationsInput) ([]*service.RelatedKnowledge, error)
+}
+
+type RelationHandler struct {
+	svc RelationService
+}
+
+func NewRelationHandler(svc RelationService) *RelationHandler {
+	return &RelationHandler{svc: svc}
+}
+
+type CreateRelationRequest struct {
+	TargetID string `json:"target_id"`
+	Type     string `json:"type"`
+}
+
+type RelationResponse struct {
+	ID        string `json:"id"`
+	SourceID  string `json:"source_id"`
+	TargetID  string `json:"target_id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// RelatedKnowledgeResponse describes a neighbouring item; direction is "outgoing" or "incoming" (a backlink)
+type RelatedKnowledgeResponse struct {
+	RelationID    string `json:"relation_id"`
+	Type          string `json:"type"`
+	Direction     string `json:"direction"`
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Summary       string `json:"summary,omitempty"`
+	Scope         string `json:"scope,omitempty"`
+	KnowledgeType string `json:"knowledge_type"`
+	Status        string `json:"status"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type RelationListResponse struct {
+	Items []*RelatedKnowledgeResponse `json:"items"`
+}
+
+func relationToResponse(r *domain.KnowledgeRelation) *RelationResponse {
+	return &RelationResponse{
+		ID:        r.ID,
+		SourceID:  r.SourceID,
+		TargetID:  r.TargetID,
+		Type:      string(r.Type),
+		CreatedAt: r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func relatedToResponse(related []*service.RelatedKnowledge) []*RelatedKnowledgeResponse {
+	responses := make([]*RelatedKnowledgeResponse, len(related))
+	for i, r := range related {
+		responses[i] = &RelatedKnowledgeResponse{
+			RelationID:    r.RelationID,
+			Type:          string(r.Type),
+			Direction:     r.Direction,
+			ID:            r.ID,
+			Title:         r.Title,
+			Summary:       r.Summary,
+			Scope:         r.Scope,
+			KnowledgeType: string(r.KnowledgeType),
+			Status:        string(r.Status),
+			UpdatedAt:     r.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+	}
+	return responses
+}
+
+func (h *RelationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req CreateRelationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.TargetID == "" {
+		api.Error(w, http.StatusBadRequest, "target_id is required")
+		return
+	}
+	if req.Type == "" {
+		api.Error(w, http.StatusBadRequest, "type is required")
+		return
+	}
+
+	relation, err := h.svc.Create(r.Context(), service.CreateRelationInput{
+		OrgID:    orgID,
+		SourceID: id,
+		TargetID: req.TargetID,
+		Type:     domain.RelationType(req.Type),
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, relationToResponse(relation))
+}
+
+func (h *RelationHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	direction := r.URL.Query().Get("direction")
+	switch direction {
+	case "", service.RelationDirectionOutgoing, service.RelationDirectionIncoming:
+	default:
+		api.Error(w, http.StatusBadRequest, "direction must be outgoing or incoming")
+		return
+	}
+
+	related, err := h.svc.ListRelations(r.Context(), service.ListRelationsInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		Direction:   direction,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, RelationListResponse{Items: relatedToResponse(related)})
+}
+
+func (h *RelationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	relationID := chi.URLParam(r, "relationID")
+	if id == "" || relationID == "" {
+		api.Error(w, http.StatusBadRequest, "id and relation id are required")
+		return
+	}
+
+	err := h.svc.Delete(r.Context(), service.DeleteRelationInput{
+		OrgID:       orgID,
+		KnowledgeID: id,
+		RelationID:  relationID,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusOK, map[string]string{"id": relationID, "status": "deleted"})
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRelationService struct {
+	mock.Mock
+}
+
+func (m *MockRelationService) Create(ctx context.Context, input service.CreateRelationInput) (*domain.KnowledgeRelation, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeRelation), args.Error(1)
+}
+
+func (m *MockRelationService) Delete(ctx context.Context, input service.DeleteRelationInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockRelationService) ListRelations(ctx context.Context, input service.ListRelationsInput) ([]*service.RelatedKnowledge, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*service.RelatedKnowledge), args.Error(1)
+}
+
+func withURLParams(req *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRelationHandler_Create_Success(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, service.CreateRelationInput{
+		OrgID:    "org-456",
+		SourceID: "k-123",
+		TargetID: "k-456",
+		Type:     domain.RelationTypeDependsOn,
+	}).Return(&domain.KnowledgeRelation{
+		ID:        "r-1",
+		OrgID:     "org-456",
+		SourceID:  "k-123",
+		TargetID:  "k-456",
+		Type:      domain.RelationTypeDependsOn,
+		CreatedAt: time.Now(),
+	}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/relations", []byte(`{"target_id":"k-456","type":"depends_on"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "r-1", data["id"])
+	assert.Equal(t, "depends_on", data["type"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRelationHandler_Create_InvalidType(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidRelationType)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/relations", []byte(`{"target_id":"k-456","type":"blocks"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRelationHandler_Create_Duplicate(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrRelationAlreadyExists)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/relations", []byte(`{"target_id":"k-456","type":"refines"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestRelationHandler_Create_MissingTarget(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-123/relations", []byte(`{"type":"refines"}`))
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRelationHandler_List_Backlinks(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("ListRelations", mock.Anything, service.ListRelationsInput{
+		OrgID:       "org-456",
+		KnowledgeID: "k-123",
+		Direction:   service.RelationDirectionIncoming,
+	}).Return([]*service.RelatedKnowledge{
+		{RelationID: "r-1", Type: domain.RelationTypeRefines, Direction: service.RelationDirectionIncoming, FromID: "k-123", ID: "k-9", Title: "Canary deploys"},
+	}, nil)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/relations?direction=incoming", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	items := resp["data"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 1)
+	item := items[0].(map[string]interface{})
+	assert.Equal(t, "k-9", item["id"])
+	assert.Equal(t, "incoming", item["direction"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRelationHandler_List_InvalidDirection(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	req := requestWithOrgID(http.MethodGet, "/knowledge/k-123/relations?direction=sideways", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123"})
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRelationHandler_Delete(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("Delete", mock.Anything, service.DeleteRelationInput{
+		OrgID:       "org-456",
+		KnowledgeID: "k-123",
+		RelationID:  "r-1",
+	}).Return(nil)
+
+	req := requestWithOrgID(http.MethodDelete, "/knowledge/k-123/relations/r-1", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123", "relationID": "r-1"})
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRelationHandler_Delete_NotFound(t *testing.T) {
+	mockSvc := new(MockRelationService)
+	handler := NewRelationHandler(mockSvc)
+
+	mockSvc.On("Delete", mock.Anything, mock.Anything).Return(domain.ErrRelationNotFound)
+
+	req := requestWithOrgID(http.MethodDelete, "/knowledge/k-123/relations/r-1", nil)
+	req = withURLParams(req, map[string]string{"id": "k-123", "relationID": "r-1"})
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
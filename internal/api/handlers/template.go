@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/api/middleware"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// RenderTemplateRequest fills in a template's {{variables}}; version 0 uses the latest version
+type RenderTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Version   int64             `json:"version,omitempty"`
+}
+
+// InstantiateTemplateRequest creates a knowledge item from a template.
+// Title and summary may use the template's variables too.
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Version   int64             `json:"version,omitempty"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary"`
+	ProjectID string            `json:"project_id"`
+	Scope     string            `json:"scope"`
+	Tags      []string          `json:"tags,omitempty"`
+}
+
+type TemplateVariableResponse struct {
+	Name     string `json:"name"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+}
+
+type RenderedTemplateResponse struct {
+	TemplateID      string                      `json:"template_id"`
+	TemplateVersion int64                       `json:"template_version"`
+	BodyMD          string                      `json:"body_md"`
+	Variables       []*TemplateVariableResponse `json:"variables"`
+}
+
+func (h *KnowledgeHandler) RenderTemplate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req RenderTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rendered, err := h.svc.RenderTemplate(r.Context(), service.RenderTemplateInput{
+		OrgID:      orgID,
+		TemplateID: id,
+		Version:    req.Version,
+		Variables:  req.Variables,
+	})
+	if err != nil {
+		api.HandleError(w, err)
+		return
+	}
+
+	variables := make([]*TemplateVariableResponse, len(rendered.Variables))
+	for i, v := range rendered.Variables {
+		variables[i] = &TemplateVariableResponse{Name: v.Name, Default: v.Default, Required: v.Required}
+	}
+
+	api.Success(w, http.StatusOK, RenderedTemplateResponse{
+		TemplateID:      rendered.TemplateID,
+		TemplateVersion: rendered.TemplateVersion,
+		BodyMD:          rendered.BodyMD,
+		Variables:       variables,
+	})
+}
+
+func (h *KnowledgeHandler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgID(r.Context())
+	if orgID == "" {
+		api.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		api.Error(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req InstantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.Title == "" {
+		api.Error(w, http.StatusBadRequest, "title is required")
+		return
+	}
+	if req.Type == "" {
+		api.Error(w, http.StatusBadRequest, "type is required")
+		return
+	}
+
+	knowledge, err := h.svc.Instantiate(r.Context(), service.InstantiateInput{
+		RenderTemplateInput: service.RenderTemplateInput{
+			OrgID:      orgID,
+			TemplateID: id,
+			Version:    req.Version,
+			Variables:  req.Variables,
+		},
+		ProjectID: req.ProjectID,
+		Type:      domain.KnowledgeType(req.Type),
+		Title:     req.Title,
+		Summary:   req.Summary,
+		Scope:     req.Scope,
+		Tags:      req.Tags,
+		Author:    middleware.GetAuthor(r.Context()),
+	})
+	if err != nil {
+		var lintErr *service.LintError
+		if errors.As(err, &lintErr) {
+			writeLintFailed(w, lintErr)
+			return
+		}
+		api.HandleError(w, err)
+		return
+	}
+
+	api.Success(w, http.StatusCreated, knowledgeToResponse(knowledge))
+}
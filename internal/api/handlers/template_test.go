@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeHandler_RenderTemplate(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("RenderTemplate", mock.Anything, service.RenderTemplateInput{
+		OrgID:      "org-456",
+		TemplateID: "tpl-1",
+		Version:    2,
+		Variables:  map[string]string{"service": "checkout"},
+	}).Return(&service.RenderedTemplate{
+		TemplateID:      "tpl-1",
+		TemplateVersion: 2,
+		BodyMD:          "# checkout outage\nSeverity: SEV3",
+		Variables: []domain.TemplateVariable{
+			{Name: "service", Required: true},
+			{Name: "severity", Default: "SEV3"},
+		},
+	}, nil)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/tpl-1/render", []byte(`{"variables":{"service":"checkout"},"version":2}`))
+	req = withURLParams(req, map[string]string{"id": "tpl-1"})
+	w := httptest.NewRecorder()
+
+	handler.RenderTemplate(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "tpl-1", data["template_id"])
+	assert.Equal(t, float64(2), data["template_version"])
+	assert.Equal(t, "# checkout outage\nSeverity: SEV3", data["body_md"])
+	assert.Len(t, data["variables"], 2)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_RenderTemplate_MissingVariables(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("RenderTemplate", mock.Anything, mock.Anything).
+		Return(nil, domain.NewMissingTemplateVariablesError([]string{"service"}))
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/tpl-1/render", []byte(`{}`))
+	req = withURLParams(req, map[string]string{"id": "tpl-1"})
+	w := httptest.NewRecorder()
+
+	handler.RenderTemplate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "missing template variables: service")
+}
+
+func TestKnowledgeHandler_Instantiate(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	created := newTestKnowledge()
+	created.Type = domain.KnowledgeTypeLearning
+	created.TemplateID = "tpl-1"
+	created.TemplateVersion = 3
+
+	mockSvc.On("Instantiate", mock.Anything, mock.MatchedBy(func(input service.InstantiateInput) bool {
+		return input.OrgID == "org-456" && input.TemplateID == "tpl-1" &&
+			input.Type == domain.KnowledgeTypeLearning && input.Title == "{{service}} outage" &&
+			input.Variables["service"] == "checkout"
+	})).Return(created, nil)
+
+	body := `{"type":"learning","title":"{{service}} outage","variables":{"service":"checkout"}}`
+	req := requestWithOrgID(http.MethodPost, "/knowledge/tpl-1/instantiate", []byte(body))
+	req = withURLParams(req, map[string]string{"id": "tpl-1"})
+	w := httptest.NewRecorder()
+
+	handler.Instantiate(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp["data"].(map[string]interface{})
+	assert.Equal(t, "tpl-1", data["template_id"])
+	assert.Equal(t, float64(3), data["template_version"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestKnowledgeHandler_Instantiate_RequiresTypeAndTitle(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	for body, want := range map[string]string{
+		`{"type":"learning"}`:  "title is required",
+		`{"title":"Incident"}`: "type is required",
+	} {
+		req := requestWithOrgID(http.MethodPost, "/knowledge/tpl-1/instantiate", []byte(body))
+		req = withURLParams(req, map[string]string{"id": "tpl-1"})
+		w := httptest.NewRecorder()
+
+		handler.Instantiate(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), want)
+	}
+	mockSvc.AssertNotCalled(t, "Instantiate", mock.Anything, mock.Anything)
+}
+
+func TestKnowledgeHandler_Instantiate_NotATemplate(t *testing.T) {
+	mockSvc := new(MockKnowledgeService)
+	handler := NewKnowledgeHandler(mockSvc)
+
+	mockSvc.On("Instantiate", mock.Anything, mock.Anything).Return(nil, domain.ErrNotATemplate)
+
+	req := requestWithOrgID(http.MethodPost, "/knowledge/k-1/instantiate", []byte(`{"type":"learning","title":"T"}`))
+	req = withURLParams(req, map[string]string{"id": "k-1"})
+	w := httptest.NewRecorder()
+
+	handler.Instantiate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "not a template")
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+)
+
+// AdminTokenAuth guards operator endpoints with a static admin token. Org API keys are
+// not accepted; with an empty token the endpoints are disabled.
+func AdminTokenAuth(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if adminToken == "" {
+				api.Error(w, http.StatusForbidden, "admin api is disabled")
+				return
+			}
+
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				api.Error(w, http.StatusUnauthorized, "missing authorization header")
+				return
+			}
+
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				api.Error(w, http.StatusUnauthorized, "invalid authorization format")
+				return
+			}
+
+			token := strings.TrimPrefix(authHeader, "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+				api.Error(w, http.StatusUnauthorized, "invalid admin token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
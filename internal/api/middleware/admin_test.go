@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminTokenAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminToken string
+		header     string
+		wantStatus int
+	}{
+		{name: "valid token", adminToken: "admin-secret", header: "Bearer admin-secret", wantStatus: http.StatusOK},
+		{name: "disabled without token", adminToken: "", header: "Bearer anything", wantStatus: http.StatusForbidden},
+		{name: "missing header", adminToken: "admin-secret", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "invalid format", adminToken: "admin-secret", header: "Basic admin-secret", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", adminToken: "admin-secret", header: "Bearer ntx_orgkey", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/knowledge/k-1/purge", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			AdminTokenAuth(tt.adminToken)(handler).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -6,14 +6,22 @@ import (
 	"strings"
 
 	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/domain"
 )
 
 type contextKey string
 
-const OrgIDKey contextKey = "org_id"
+const (
+	OrgIDKey  contextKey = "org_id"
+	AuthorKey contextKey = "author"
+)
+
+// AgentHeader carries an optional agent or user name recorded as the author of writes.
+// It is not verified; the API key the request authenticated with always is.
+const AgentHeader = "X-Neotex-Agent"
 
 type AuthValidator interface {
-	ValidateAPIKey(ctx context.Context, token string) (string, error)
+	AuthenticateAPIKey(ctx context.Context, token string) (*domain.APIKey, error)
 }
 
 func APIKeyAuth(validator AuthValidator) func(http.Handler) http.Handler {
@@ -32,14 +40,17 @@ func APIKeyAuth(validator AuthValidator) func(http.Handler) http.Handler {
 
 			token := strings.TrimPrefix(authHeader, "Bearer ")
 
-			orgID, err := validator.ValidateAPIKey(r.Context(), token)
+			key, err := validator.AuthenticateAPIKey(r.Context(), token)
 			if err != nil {
 				api.Error(w, http.StatusUnauthorized, "invalid api key")
 				return
 			}
 
-			r.Header.Set("X-Org-ID", orgID)
-			ctx := context.WithValue(r.Context(), OrgIDKey, orgID)
+			author := domain.NewAuthor(key.ID, key.Name, r.Header.Get(AgentHeader))
+
+			r.Header.Set("X-Org-ID", key.OrgID)
+			ctx := context.WithValue(r.Context(), OrgIDKey, key.OrgID)
+			ctx = context.WithValue(ctx, AuthorKey, author)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -49,3 +60,9 @@ func GetOrgID(ctx context.Context) string {
 	orgID, _ := ctx.Value(OrgIDKey).(string)
 	return orgID
 }
+
+// GetAuthor returns the author of the authenticated request, or a zero Author
+func GetAuthor(ctx context.Context) domain.Author {
+	author, _ := ctx.Value(AuthorKey).(domain.Author)
+	return author
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/api"
+	"github.com/cloo-solutions/neotexai/internal/domain"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key of a mutating request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response that was replayed from a stored record
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTimeout bounds how long a reservation blocks the key, so a request
+	// that never finished does not hold it until the TTL runs out
+	idempotencyLockTimeout = 5 * time.Minute
+)
+
+// replayedHeaders are the response headers stored with an idempotent response
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyStore persists the responses of requests made with an Idempotency-Key
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	Save(ctx context.Context, rec *domain.IdempotencyRecord) error
+	Release(ctx context.Context, orgID, key string) error
+}
+
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Idempotency replays the stored response when a mutating request is retried with the
+// same Idempotency-Key. Keys are scoped to the organization and kept for ttl; reusing a
+// key for a different request is rejected. Must run after APIKeyAuth.
+func Idempotency(store IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			orgID := GetOrgID(r.Context())
+			if store == nil || key == "" || orgID == "" || !isMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				api.Error(w, http.StatusBadRequest, "idempotency key is too long")
+				return
+			}
+
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						api.Error(w, http.StatusRequestEntityTooLarge, "request body too large")
+						return
+					}
+					api.Error(w, http.StatusBadRequest, "invalid request body")
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			now := time.Now().UTC()
+			rec := &domain.IdempotencyRecord{
+				OrgID:       orgID,
+				Key:         key,
+				Route:       r.Method + " " + r.URL.Path,
+				RequestHash: hashIdempotentRequest(r.URL.RawQuery, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(idempotencyLockTimeout),
+			}
+
+			existing, err := store.Reserve(r.Context(), rec)
+			if err != nil {
+				log.Printf("idempotency: failed to reserve key: %v", err)
+				api.Error(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+			if existing != nil {
+				switch {
+				case !existing.Matches(rec.Route, rec.RequestHash):
+					api.Error(w, http.StatusUnprocessableEntity, "idempotency key was already used for a different request")
+				case !existing.IsCompleted():
+					api.Error(w, http.StatusConflict, "a request with this idempotency key is still being processed")
+				default:
+					replayIdempotentResponse(w, existing)
+				}
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			// Store the outcome even if the client went away; that is when it will retry
+			ctx := context.WithoutCancel(r.Context())
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				if err := store.Release(ctx, orgID, key); err != nil {
+					log.Printf("idempotency: failed to release key: %v", err)
+				}
+				return
+			}
+
+			rec.StatusCode = status
+			rec.Headers = make(map[string]string)
+			for _, name := range replayedHeaders {
+				if value := recorder.Header().Get(name); value != "" {
+					rec.Headers[name] = value
+				}
+			}
+			rec.Body = recorder.body.Bytes()
+			rec.ExpiresAt = time.Now().UTC().Add(ttl)
+			if err := store.Save(ctx, rec); err != nil {
+				log.Printf("idempotency: failed to save response: %v", err)
+			}
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func hashIdempotentRequest(rawQuery string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(rawQuery))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, rec *domain.IdempotencyRecord) {
+	for name, value := range rec.Headers {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(rec.StatusCode)
+	_, _ = w.Write(rec.Body)
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryIdempotencyStore struct {
+	mu         sync.Mutex
+	records    map[string]*domain.IdempotencyRecord
+	reserveErr error
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reserveErr != nil {
+		return nil, s.reserveErr
+	}
+	id := rec.OrgID + "/" + rec.Key
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(rec.CreatedAt) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *rec
+	s.records[id] = &copied
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Save(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *rec
+	s.records[rec.OrgID+"/"+rec.Key] = &copied
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, orgID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[orgID+"/"+key]; ok && !rec.IsCompleted() {
+		delete(s.records, orgID+"/"+key)
+	}
+	return nil
+}
+
+func idempotentRequest(method, path, orgID, key, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if orgID != "" {
+		req = req.WithContext(context.WithValue(req.Context(), OrgIDKey, orgID))
+	}
+	return req
+}
+
+// countingHandler creates a resource on every call and echoes the request body
+func countingHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Call", strings.Repeat("x", *calls))
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	})
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{"title":"A"}`))
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{"title":"A"}`))
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, `{"title":"A"}`, second.Body.String())
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Empty(t, second.Header().Get("X-Call"))
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_RejectsKeyReuseWithDifferentRequest(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{"title":"A"}`))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{"title":"B"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodDelete, "/knowledge/k-1", "org-1", "key-1", `{"title":"A"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_KeysAreScopedToOrg(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusCreated))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodPost, "/knowledge", "org-2", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_RequestInProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	now := time.Now().UTC()
+	_, err := store.Reserve(context.Background(), &domain.IdempotencyRecord{
+		OrgID:       "org-1",
+		Key:         "key-1",
+		Route:       "POST /knowledge",
+		RequestHash: hashIdempotentRequest("", []byte(`{}`)),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusCreated))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusInternalServerError))
+
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`))
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`))
+
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotency_Passthrough(t *testing.T) {
+	tests := []struct {
+		name  string
+		store IdempotencyStore
+		req   *http.Request
+	}{
+		{"no key", newMemoryIdempotencyStore(), idempotentRequest(http.MethodPost, "/knowledge", "org-1", "", `{}`)},
+		{"read request", newMemoryIdempotencyStore(), idempotentRequest(http.MethodGet, "/knowledge", "org-1", "key-1", "")},
+		{"no store", nil, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := Idempotency(tt.store, time.Hour)(countingHandler(&calls, http.StatusOK))
+
+			handler.ServeHTTP(httptest.NewRecorder(), tt.req)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.req.Clone(tt.req.Context()))
+
+			assert.Equal(t, 2, calls)
+			assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+		})
+	}
+}
+
+func TestIdempotency_RejectsLongKey(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyStore(), time.Hour)(countingHandler(&calls, http.StatusOK))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodPost, "/knowledge", "org-1", strings.Repeat("k", 256), `{}`))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_StoreError(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	store.reserveErr = errors.New("connection refused")
+	calls := 0
+	handler := Idempotency(store, time.Hour)(countingHandler(&calls, http.StatusOK))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, idempotentRequest(http.MethodPost, "/knowledge", "org-1", "key-1", `{}`))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func TestIdempotency_RestoresBodyForHandler(t *testing.T) {
+	var received []byte
+	handler := Idempotency(newMemoryIdempotencyStore(), time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	payload := bytes.Repeat([]byte("a"), 4096)
+	handler.ServeHTTP(httptest.NewRecorder(), idempotentRequest(http.MethodPut, "/knowledge/k-1", "org-1", "key-1", string(payload)))
+
+	assert.Equal(t, payload, received)
+}
@@ -60,6 +60,8 @@ func DomainErrorToHTTP(err error) int {
 		return http.StatusForbidden
 	case domain.ErrCodeInvalidOperation:
 		return http.StatusBadRequest
+	case domain.ErrCodePreconditionFailed:
+		return http.StatusPreconditionFailed
 	case domain.ErrCodeInternalError:
 		return http.StatusInternalServerError
 	default:
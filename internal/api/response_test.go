@@ -77,6 +77,7 @@ func TestDomainErrorToHTTP(t *testing.T) {
 		{"unauthorized error", domain.ErrInvalidAPIKey, http.StatusUnauthorized},
 		{"forbidden error", domain.NewDomainError(domain.ErrCodeForbidden, "forbidden"), http.StatusForbidden},
 		{"invalid operation", domain.ErrCannotModifyDeprecated, http.StatusBadRequest},
+		{"precondition failed", domain.ErrVersionConflict, http.StatusPreconditionFailed},
 		{"internal error", domain.NewDomainError(domain.ErrCodeInternalError, "internal"), http.StatusInternalServerError},
 		{"unknown domain error", domain.NewDomainError("UNKNOWN", "unknown"), http.StatusInternalServerError},
 		{"non-domain error", assert.AnError, http.StatusInternalServerError},
@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/config"
+	"github.com/cloo-solutions/neotexai/internal/repository"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/cloo-solutions/neotexai/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func ExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export an organization as an archive",
+		Long: `Export an organization as a gzipped tarball.
+
+The archive holds a markdown file with frontmatter per knowledge item and per version,
+the asset binaries (when S3 is configured) and a manifest.json with projects, types,
+relations and asset links. Deprecated items are included. Comments, checklist runs and
+search logs are not. Restore it with "neotexd restore" or "neotex restore".`,
+		Example: `  neotexd export --org acme
+  neotexd export --org acme -f acme.tar.gz
+  neotexd export --org acme -f - | ssh backup 'cat > acme.tar.gz'`,
+		RunE: runExport,
+	}
+
+	cmd.Flags().StringP("org", "o", "", "Organization ID or name (required)")
+	cmd.Flags().StringP("file", "f", "", "Archive to write, - for stdout (default neotex-<org>-<time>.tar.gz)")
+	cmd.MarkFlagRequired("org")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	orgRef, _ := cmd.Flags().GetString("org")
+	file, _ := cmd.Flags().GetString("file")
+
+	pool, err := getDBPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	orgID, err := resolveOrgID(ctx, repository.NewOrgRepository(pool), orgRef)
+	if err != nil {
+		return err
+	}
+
+	archiveStorage, err := getArchiveStorage(ctx)
+	if err != nil {
+		return err
+	}
+	if archiveStorage == nil {
+		fmt.Fprintln(os.Stderr, "S3 is not configured; asset binaries are not exported")
+	}
+
+	archiveSvc := service.NewArchiveService(repository.NewOrgArchiveRepository(pool), archiveStorage)
+	snapshot, err := archiveSvc.Snapshot(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to read organization: %w", err)
+	}
+
+	if file == "" {
+		file = fmt.Sprintf("neotex-%s-%s.tar.gz", orgRef, time.Now().UTC().Format("20060102-150405"))
+	}
+	if file == "-" {
+		return archiveSvc.WriteArchive(ctx, snapshot, os.Stdout)
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	if err := archiveSvc.WriteArchive(ctx, snapshot, out); err != nil {
+		out.Close()
+		os.Remove(file)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d knowledge items, %d versions and %d assets to %s\n",
+		len(snapshot.Knowledge), len(snapshot.Versions), len(snapshot.Assets), file)
+	return nil
+}
+
+func RestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restore <archive>",
+		Aliases: []string{"import"},
+		Short:   "Restore an archive into an organization",
+		Long: `Restore an archive written by "neotexd export" or "neotex export" into an organization.
+
+Knowledge, versions, assets, relations and links are written in a single transaction.
+Projects are matched by ID, then by name, and created when neither matches. Items keep
+their IDs unless --remap-ids is given, which is required to restore next to the
+organization the archive came from. Embedding jobs are only queued with --embed.`,
+		Example: `  neotexd restore acme.tar.gz --org acme-staging --remap-ids --embed
+  neotexd restore - --org acme < acme.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: runRestore,
+	}
+
+	cmd.Flags().StringP("org", "o", "", "Organization ID or name (required)")
+	cmd.Flags().Bool("remap-ids", false, "Give restored items new IDs")
+	cmd.Flags().Bool("embed", false, "Queue embedding jobs for restored knowledge and assets")
+	cmd.Flags().StringP("output", "", "text", "Output format (text or json)")
+	cmd.MarkFlagRequired("org")
+
+	return cmd
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	orgRef, _ := cmd.Flags().GetString("org")
+	remapIDs, _ := cmd.Flags().GetBool("remap-ids")
+	embed, _ := cmd.Flags().GetBool("embed")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	var archive io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer f.Close()
+		archive = f
+	}
+
+	pool, err := getDBPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	orgID, err := resolveOrgID(ctx, repository.NewOrgRepository(pool), orgRef)
+	if err != nil {
+		return err
+	}
+
+	archiveStorage, err := getArchiveStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	archiveSvc := service.NewArchiveService(repository.NewOrgArchiveRepository(pool), archiveStorage)
+	result, err := archiveSvc.Restore(ctx, service.RestoreInput{
+		OrgID:           orgID,
+		Archive:         archive,
+		RemapIDs:        remapIDs,
+		QueueEmbeddings: embed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore archive: %w", err)
+	}
+
+	if outputFormat == "json" {
+		jsonBytes, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	fmt.Printf("Restored into organization %s:\n", orgID)
+	fmt.Printf("  %d knowledge items, %d versions\n", result.Knowledge, result.Versions)
+	fmt.Printf("  %d projects created, %d types\n", result.Projects, result.Types)
+	fmt.Printf("  %d assets, %d links, %d relations\n", result.Assets, result.Links, result.Relations)
+	if result.SkippedAssets > 0 {
+		fmt.Printf("  %d assets skipped (exported without binaries)\n", result.SkippedAssets)
+	}
+	if result.EmbeddingJobs > 0 {
+		fmt.Printf("  %d embedding jobs queued\n", result.EmbeddingJobs)
+	}
+	return nil
+}
+
+// getArchiveStorage connects to S3 when it is configured and returns nil otherwise
+func getArchiveStorage(ctx context.Context) (service.ArchiveStorage, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.HasS3() {
+		return nil, nil
+	}
+
+	s3Client, err := storage.NewS3Client(ctx, storage.S3ClientConfig{
+		Endpoint:        cfg.S3Endpoint,
+		Region:          cfg.S3Region,
+		AccessKeyID:     cfg.S3AccessKey,
+		SecretAccessKey: cfg.S3SecretKey,
+		Bucket:          cfg.S3Bucket,
+		UsePathStyle:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	return &S3StorageAdapter{client: s3Client}, nil
+}
@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/repository"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/spf13/cobra"
+)
+
+func KnowledgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "knowledge",
+		Short: "Manage knowledge items",
+		Long:  "Purge knowledge items for erasure requests and list the purge audit trail",
+	}
+
+	cmd.AddCommand(KnowledgePurgeCmd())
+	cmd.AddCommand(KnowledgeTombstonesCmd())
+
+	return cmd
+}
+
+func KnowledgePurgeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge <id>",
+		Short: "Permanently delete a knowledge item",
+		Long: `Permanently delete a knowledge item for a compliance erasure request.
+
+Removes the item with all of its versions, search chunks, asset links,
+comments and embedding jobs, and drops it from logged search results. Only a tombstone with
+the item's ID, org, type, who purged it and the number of removed rows is kept.
+This cannot be undone; use deprecation for everything else.`,
+		Example: `  neotexd knowledge purge <id> --by dpo@example.com --reason ERASURE-42 --yes`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runKnowledgePurge,
+	}
+
+	cmd.Flags().String("by", "", "Who requested the purge (required)")
+	cmd.Flags().String("reason", "", "Reference for the erasure request, e.g. a ticket ID (must not contain the purged data)")
+	cmd.Flags().Bool("yes", false, "Confirm the purge")
+	cmd.Flags().StringP("output", "", "text", "Output format (text or json)")
+	cmd.MarkFlagRequired("by")
+
+	return cmd
+}
+
+func runKnowledgePurge(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	by, _ := cmd.Flags().GetString("by")
+	reason, _ := cmd.Flags().GetString("reason")
+	confirmed, _ := cmd.Flags().GetBool("yes")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	if !confirmed {
+		return fmt.Errorf("purging %s cannot be undone; pass --yes to confirm", args[0])
+	}
+
+	pool, err := getDBPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	purgeSvc := service.NewPurgeService(repository.NewKnowledgePurgeRepository(pool))
+
+	tombstone, err := purgeSvc.PurgeKnowledge(ctx, service.PurgeKnowledgeInput{
+		KnowledgeID: args[0],
+		By:          by,
+		Reason:      reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge knowledge: %w", err)
+	}
+
+	if outputFormat == "json" {
+		jsonBytes, _ := json.MarshalIndent(tombstoneToMap(tombstone), "", "  ")
+		fmt.Println(string(jsonBytes))
+	} else {
+		fmt.Printf("Knowledge %s purged (tombstone %s)\n", tombstone.KnowledgeID, tombstone.ID)
+		fmt.Printf("Removed: %d versions, %d chunks, %d asset links, %d embedding jobs; %d search logs cleaned\n",
+			tombstone.Versions, tombstone.Chunks, tombstone.AssetLinks, tombstone.EmbeddingJobs, tombstone.SearchLogs)
+	}
+
+	return nil
+}
+
+func KnowledgeTombstonesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tombstones",
+		Short: "List purged knowledge items",
+		Long:  "List the tombstones left by purges for an organization, newest first",
+		RunE:  runKnowledgeTombstones,
+	}
+
+	cmd.Flags().StringP("org", "o", "", "Organization ID or name (required)")
+	cmd.Flags().StringP("output", "", "text", "Output format (text or json)")
+	cmd.MarkFlagRequired("org")
+
+	return cmd
+}
+
+func runKnowledgeTombstones(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	orgRef, _ := cmd.Flags().GetString("org")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	pool, err := getDBPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	orgID, err := resolveOrgID(ctx, repository.NewOrgRepository(pool), orgRef)
+	if err != nil {
+		return err
+	}
+
+	purgeSvc := service.NewPurgeService(repository.NewKnowledgePurgeRepository(pool))
+	tombstones, err := purgeSvc.ListTombstones(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list tombstones: %w", err)
+	}
+
+	if outputFormat == "json" {
+		data := make([]map[string]interface{}, len(tombstones))
+		for i, tombstone := range tombstones {
+			data[i] = tombstoneToMap(tombstone)
+		}
+		jsonBytes, _ := json.MarshalIndent(data, "", "  ")
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	if len(tombstones) == 0 {
+		fmt.Printf("No purged knowledge for organization %s\n", orgID)
+		return nil
+	}
+	fmt.Printf("Purged knowledge for organization %s:\n", orgID)
+	for _, tombstone := range tombstones {
+		fmt.Printf("  %s: %s by %s at %s", tombstone.KnowledgeID, tombstone.Type, tombstone.PurgedBy, tombstone.PurgedAt.Format("2006-01-02 15:04:05"))
+		if tombstone.Reason != "" {
+			fmt.Printf(" (%s)", tombstone.Reason)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func tombstoneToMap(t *domain.KnowledgeTombstone) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                  t.ID,
+		"knowledge_id":        t.KnowledgeID,
+		"org_id":              t.OrgID,
+		"project_id":          t.ProjectID,
+		"type":                t.Type,
+		"purged_by":           t.PurgedBy,
+		"reason":              t.Reason,
+		"purged_at":           t.PurgedAt,
+		"version_count":       t.Versions,
+		"chunk_count":         t.Chunks,
+		"asset_link_count":    t.AssetLinks,
+		"embedding_job_count": t.EmbeddingJobs,
+		"search_log_count":    t.SearchLogs,
+	}
+}
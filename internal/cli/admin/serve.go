@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -82,6 +83,11 @@ func runServe(cmd *cobra.Command, args []string) error {
 		cfg.Port = portFlag
 	}
 
+	textGenerator, err := newTextGenerator(cfg)
+	if err != nil {
+		return err
+	}
+
 	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
@@ -110,6 +116,13 @@ func runServe(cmd *cobra.Command, args []string) error {
 	contextRepo := repository.NewContextRepository(pool)
 	searchLogRepo := repository.NewSearchLogRepository(pool)
 	projectRepo := repository.NewProjectRepository(pool)
+	relationRepo := repository.NewKnowledgeRelationRepository(pool)
+	checklistRunRepo := repository.NewChecklistRunRepository(pool)
+	commentRepo := repository.NewKnowledgeCommentRepository(pool)
+	purgeRepo := repository.NewKnowledgePurgeRepository(pool)
+	knowledgeTypeRepo := repository.NewKnowledgeTypeRepository(pool)
+	lintConfigRepo := repository.NewLintConfigRepository(pool)
+	archiveRepo := repository.NewOrgArchiveRepository(pool)
 	txRunner := repository.NewTxRunner(pool)
 
 	if cfg.InitOrgName != "" {
@@ -119,6 +132,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 	}
 
 	var storageClient service.StorageClientInterface
+	var archiveStorage service.ArchiveStorage
 	if cfg.HasS3() {
 		s3Config := storage.S3ClientConfig{
 			Endpoint:        cfg.S3Endpoint,
@@ -136,7 +150,9 @@ func runServe(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to ensure S3 bucket: %w", err)
 		}
 		log.Printf("S3 bucket '%s' ready", cfg.S3Bucket)
-		storageClient = &S3StorageAdapter{client: s3Client}
+		adapter := &S3StorageAdapter{client: s3Client}
+		storageClient = adapter
+		archiveStorage = adapter
 	}
 
 	var embeddingClient service.EmbeddingClient
@@ -157,9 +173,37 @@ func runServe(cmd *cobra.Command, args []string) error {
 		log.Printf("embedding workers started: %d", workerCount)
 	}
 
+	staleInterval := cfg.StaleCheckInterval
+	if staleInterval <= 0 {
+		staleInterval = time.Hour
+	}
+	staleWorker := jobs.NewWorker(jobs.NewStaleKnowledgeJob(knowledgeRepo), staleInterval)
+	go staleWorker.Start(ctx)
+
+	idempotencyRepo := repository.NewIdempotencyRepository(pool)
+	idempotencyWorker := jobs.NewWorker(jobs.NewIdempotencyCleanupJob(idempotencyRepo), time.Hour)
+	go idempotencyWorker.Start(ctx)
+
+	var summaryWorker *jobs.Worker
+	if textGenerator != nil {
+		summaryInterval := cfg.SummaryInterval
+		if summaryInterval <= 0 {
+			summaryInterval = 5 * time.Minute
+		}
+		summaryWorker = jobs.NewWorker(jobs.NewSummaryGenerationJob(knowledgeRepo, textGenerator), summaryInterval)
+		go summaryWorker.Start(ctx)
+		log.Printf("summary generation started with %s model %s", cfg.TextProvider, textGenerator.Model())
+	}
+
 	uuidGen := &service.DefaultUUIDGenerator{}
 
-	knowledgeSvc := service.NewKnowledgeServiceWithTx(knowledgeRepo, embeddingJobRepo, txRunner)
+	knowledgeTypeSvc := service.NewKnowledgeTypeService(knowledgeTypeRepo)
+	var duplicateDetector *service.DuplicateDetector
+	if embeddingClient != nil && cfg.DuplicateThreshold > 0 {
+		duplicateDetector = service.NewDuplicateDetector(embeddingClient, knowledgeRepo, cfg.DuplicateThreshold)
+	}
+	lintSvc := service.NewLintService(lintConfigRepo, knowledgeRepo, assetRepo, knowledgeTypeSvc)
+	knowledgeSvc := service.NewKnowledgeServiceWithLint(knowledgeRepo, embeddingJobRepo, txRunner, knowledgeTypeSvc, duplicateDetector, lintSvc)
 	var assetSvc *service.AssetService
 	if storageClient != nil {
 		assetSvc = service.NewAssetServiceWithEmbeddingsAndTx(assetRepo, storageClient, embeddingJobRepo, txRunner)
@@ -175,23 +219,41 @@ func runServe(cmd *cobra.Command, args []string) error {
 	}
 	authHandler := handlers.NewAuthHandler(authSvc)
 	projectHandler := handlers.NewProjectHandler(projectRepo)
+	relationHandler := handlers.NewRelationHandler(service.NewRelationService(relationRepo, knowledgeRepo))
+	knowledgeTypeHandler := handlers.NewKnowledgeTypeHandler(knowledgeTypeSvc)
+	lintHandler := handlers.NewLintHandler(lintSvc)
+	checklistHandler := handlers.NewChecklistHandler(service.NewChecklistService(checklistRunRepo, knowledgeRepo))
+	commentHandler := handlers.NewCommentHandler(service.NewCommentService(commentRepo, knowledgeRepo, knowledgeChunkRepo))
+	adminHandler := handlers.NewAdminHandler(service.NewPurgeService(purgeRepo))
+	archiveHandler := handlers.NewArchiveHandler(service.NewArchiveService(archiveRepo, archiveStorage))
 
 	var contextHandler *handlers.ContextHandler
 	if embeddingClient != nil {
-		contextSvc := service.NewContextService(contextRepo, embeddingClient)
-		vfsSvc := service.NewVFSService(knowledgeRepo, knowledgeChunkRepo, assetRepo, storageClient, contextRepo)
+		contextSvc := service.NewContextServiceWithTypes(contextRepo, embeddingClient, service.DefaultContextServiceConfig(), knowledgeTypeSvc)
+		vfsSvc := service.NewVFSServiceWithComments(knowledgeRepo, knowledgeChunkRepo, assetRepo, storageClient, contextRepo, commentRepo)
 		contextHandler = handlers.NewContextHandlerWithVFS(contextSvc, vfsSvc, searchLogRepo)
 	} else {
 		contextHandler = handlers.NewContextHandler(&NoOpContextService{}, searchLogRepo)
 	}
 
 	routerCfg := server.RouterConfig{
-		AuthValidator:    authSvc,
-		KnowledgeHandler: knowledgeHandler,
-		AssetHandler:     assetHandler,
-		ContextHandler:   contextHandler,
-		AuthHandler:      authHandler,
-		ProjectHandler:   projectHandler,
+		AuthValidator:        authSvc,
+		KnowledgeHandler:     knowledgeHandler,
+		AssetHandler:         assetHandler,
+		ContextHandler:       contextHandler,
+		AuthHandler:          authHandler,
+		ProjectHandler:       projectHandler,
+		RelationHandler:      relationHandler,
+		KnowledgeTypeHandler: knowledgeTypeHandler,
+		LintHandler:          lintHandler,
+		ChecklistHandler:     checklistHandler,
+		CommentHandler:       commentHandler,
+		AdminHandler:         adminHandler,
+		ArchiveHandler:       archiveHandler,
+		AdminToken:           cfg.AdminToken,
+		IdempotencyStore:     idempotencyRepo,
+		IdempotencyTTL:       cfg.IdempotencyTTL,
+		MaxArchiveBytes:      cfg.MaxArchiveBytes,
 	}
 
 	router := server.NewRouter(routerCfg)
@@ -218,6 +280,11 @@ func runServe(cmd *cobra.Command, args []string) error {
 			worker.Stop()
 		}
 	}
+	staleWorker.Stop()
+	idempotencyWorker.Stop()
+	if summaryWorker != nil {
+		summaryWorker.Stop()
+	}
 
 	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -246,6 +313,14 @@ func (a *S3StorageAdapter) DeleteObject(ctx context.Context, key string) error {
 	return a.client.DeleteObject(ctx, key)
 }
 
+func (a *S3StorageAdapter) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	return a.client.GetObject(ctx, key)
+}
+
+func (a *S3StorageAdapter) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	return a.client.PutObject(ctx, key, contentType, body, size)
+}
+
 func (a *S3StorageAdapter) HeadObject(ctx context.Context, key string) (*service.ObjectMetadata, error) {
 	meta, err := a.client.HeadObject(ctx, key)
 	if err != nil {
@@ -286,6 +361,24 @@ func (s *NoOpContextService) Search(ctx context.Context, input service.SearchInp
 	return nil, fmt.Errorf("context service not configured: embedding provider required")
 }
 
+// newTextGenerator returns the text generator selected by the configuration, or nil when
+// summary generation is disabled
+func newTextGenerator(cfg *config.Config) (openai.TextGenerator, error) {
+	switch cfg.TextProvider {
+	case "":
+		return nil, nil
+	case "openai":
+		return openai.NewChatGenerator(openai.TextConfig{
+			BaseURL: cfg.TextAPIURL,
+			APIKey:  cfg.TextGenerationKey(),
+			Model:   cfg.TextModel,
+		}), nil
+	case "stub":
+		return openai.StubTextGenerator{}, nil
+	}
+	return nil, fmt.Errorf("unknown text provider %q, expected openai or stub", cfg.TextProvider)
+}
+
 func bootstrapInitialOrg(ctx context.Context, cfg *config.Config, orgRepo *repository.OrgRepository, apiKeyRepo *repository.APIKeyRepository) error {
 	org, err := orgRepo.GetByName(ctx, cfg.InitOrgName)
 	if err != nil && err != domain.ErrOrganizationNotFound {
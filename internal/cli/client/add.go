@@ -3,40 +3,79 @@ package client
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
 	"github.com/spf13/cobra"
 )
 
 // CreateKnowledgeRequest represents the create knowledge API request.
 type CreateKnowledgeRequest struct {
-	Type      string `json:"type"`
-	Title     string `json:"title"`
-	Summary   string `json:"summary,omitempty"`
-	BodyMD    string `json:"body_md"`
-	ProjectID string `json:"project_id,omitempty"`
-	Scope     string `json:"scope,omitempty"`
+	Type        string   `json:"type"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary,omitempty"`
+	BodyMD      string   `json:"body_md"`
+	ProjectID   string   `json:"project_id,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	ReviewAfter string   `json:"review_after,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	Force       bool     `json:"force,omitempty"`
+	SourcePath  string   `json:"source_path,omitempty"`
+	SourceHash  string   `json:"source_hash,omitempty"`
+}
+
+// DuplicateCandidate is an existing item reported as similar to one being added.
+type DuplicateCandidate struct {
+	ID         string  `json:"id"`
+	Type       string  `json:"type"`
+	Title      string  `json:"title"`
+	Summary    string  `json:"summary,omitempty"`
+	Scope      string  `json:"scope,omitempty"`
+	Similarity float64 `json:"similarity"`
 }
 
 // BatchResult represents a single result in a batch operation.
 type BatchResult struct {
+	Index  int    `json:"index"`
 	ID     string `json:"id,omitempty"`
 	Status string `json:"status"`
 	Error  string `json:"error,omitempty"`
 	Title  string `json:"title,omitempty"`
+	// Duplicates lists the similar items of an item that failed as a duplicate
+	Duplicates []DuplicateCandidate `json:"duplicates,omitempty"`
 }
 
 // BatchResponse represents the response for a batch operation.
 type BatchResponse struct {
-	Results   []BatchResult `json:"results"`
-	Total     int           `json:"total"`
-	Succeeded int           `json:"succeeded"`
-	Failed    int           `json:"failed"`
+	Mode       string        `json:"mode,omitempty"`
+	Results    []BatchResult `json:"results"`
+	Total      int           `json:"total"`
+	Succeeded  int           `json:"succeeded"`
+	Failed     int           `json:"failed"`
+	RolledBack bool          `json:"rolled_back,omitempty"`
+}
+
+// BatchCreateRequest represents the batch create knowledge API request.
+type BatchCreateRequest struct {
+	Mode  string                   `json:"mode"`
+	Force bool                     `json:"force,omitempty"`
+	Items []CreateKnowledgeRequest `json:"items"`
 }
 
+// Batch modes accepted by the batch endpoints.
+const (
+	batchModeAtomic     = "atomic"
+	batchModeBestEffort = "best_effort"
+)
+
 const maxBatchSize = 100
 
 // AddCmd creates the add command.
@@ -47,11 +86,16 @@ func AddCmd() *cobra.Command {
 		title          string
 		summary        string
 		scope          string
+		tags           []string
+		reviewAfter    string
+		owner          string
+		language       string
 		batch          bool
 		atomic         bool
 		idempotencyKey string
 		format         string
 		stream         bool
+		force          bool
 	)
 
 	cmd := &cobra.Command{
@@ -59,6 +103,13 @@ func AddCmd() *cobra.Command {
 		Short: "Add knowledge from stdin or file",
 		Long: `Add knowledge from JSON input (stdin or file) or markdown with flags.
 
+Markdown can carry YAML (between --- lines) or TOML (between +++ lines)
+frontmatter with type, title, summary, scope and tags; flags take precedence.
+Without a title the first "# " heading or the file name is used. When a file
+is added, the ID of the new item is written into its frontmatter. Adding a
+file whose frontmatter has an id updates that item instead, so adding the
+same file again is safe.
+
 Examples:
   # Add from JSON on stdin
   echo '{"type":"guideline","title":"Test","body_md":"# Test"}' | neotex add
@@ -69,9 +120,21 @@ Examples:
   # Add from markdown file with flags
   neotex add --file guide.md --type guideline --title "My Guide"
 
+  # Add a markdown file with frontmatter; run it again after editing to update the item
+  neotex add --file deploy.md
+
+  # Add markdown with tags
+  neotex add --file deploy.md --type guideline --title "Deploys" --tag ci --tag release
+
+  # Add markdown that should be reviewed by the platform team before July
+  neotex add --file runbook.md --type guideline --title "Runbook" --review-after 2026-07-01 --owner platform-team
+
   # Batch add from JSON array
   echo '[{"type":"guideline","title":"Test1","body_md":"# Test1"},{"type":"guideline","title":"Test2","body_md":"# Test2"}]' | neotex add --batch
 
+  # Add even though similar knowledge already exists
+  neotex add --file learning.md --type learning --title "Retry flaky tests" --force
+
   # Atomic batch add (all-or-nothing)
   neotex add --batch --atomic --file batch.json
 
@@ -81,29 +144,34 @@ Examples:
 			outputJSON, _ := cmd.Flags().GetBool("output")
 			if batch {
 				if format == "jsonl" || stream {
-					return runStreamingBatchAdd(file, outputJSON, idempotencyKey)
+					return runStreamingBatchAdd(file, outputJSON, force, idempotencyKey)
 				}
-				return runBatchAdd(file, outputJSON, atomic, idempotencyKey)
+				return runBatchAdd(file, outputJSON, atomic, force, idempotencyKey)
 			}
-			return runAdd(file, knowledgeType, title, summary, scope, outputJSON, idempotencyKey)
+			return runAdd(file, knowledgeType, title, summary, scope, tags, reviewAfter, owner, language, force, outputJSON, idempotencyKey)
 		},
 	}
 
 	cmd.Flags().StringVarP(&file, "file", "f", "", "Input file (JSON or markdown)")
-	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Knowledge type (guideline, learning, decision, template, checklist, snippet)")
-	cmd.Flags().StringVar(&title, "title", "", "Title (required with --file for markdown)")
+	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Knowledge type (built-in or org-defined; see neotex types list)")
+	cmd.Flags().StringVar(&title, "title", "", "Title (defaults to the frontmatter or first heading of markdown)")
 	cmd.Flags().StringVar(&summary, "summary", "", "Summary (optional)")
 	cmd.Flags().StringVar(&scope, "scope", "", "Scope (file path pattern)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&reviewAfter, "review-after", "", "Review-by date (YYYY-MM-DD); the item is flagged stale once it passes")
+	cmd.Flags().StringVar(&owner, "owner", "", "Person or team responsible for keeping the item current")
+	cmd.Flags().StringVar(&language, "lang", "", "Code language of a snippet (detected from the first code fence when omitted)")
 	cmd.Flags().BoolVar(&batch, "batch", false, "Enable batch mode (expects JSON array input)")
 	cmd.Flags().BoolVar(&atomic, "atomic", false, "Atomic mode: all-or-nothing (only with --batch)")
 	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency key for request deduplication")
 	cmd.Flags().StringVar(&format, "format", "json", "Input format: json (array) or jsonl (line-delimited)")
 	cmd.Flags().BoolVar(&stream, "stream", false, "Enable streaming mode for memory-efficient batch processing")
+	cmd.Flags().BoolVar(&force, "force", false, "Add even when similar knowledge already exists (every item with --batch)")
 
 	return cmd
 }
 
-func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool, idempotencyKey string) error {
+func runAdd(file, knowledgeType, title, summary, scope string, tags []string, reviewAfter, owner, language string, force, outputJSON bool, idempotencyKey string) error {
 	config, err := LoadConfig()
 	if err != nil {
 		return err
@@ -115,6 +183,7 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 	}
 
 	var req CreateKnowledgeRequest
+	var knowledgeID string
 	req.ProjectID = config.ProjectID
 	req.Scope = scope
 
@@ -149,18 +218,25 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 		if jsonReq.Scope != "" {
 			req.Scope = jsonReq.Scope
 		}
+		req.Tags = jsonReq.Tags
+		req.ReviewAfter = jsonReq.ReviewAfter
+		req.Owner = jsonReq.Owner
+		req.Language = jsonReq.Language
 	} else {
-		// Treat as markdown
-		if title == "" {
-			return fmt.Errorf("--title is required when adding markdown content")
+		// Treat as markdown; its frontmatter can provide the fields and the ID of an existing item
+		mdReq, id, err := markdownRequest(input, file)
+		if err != nil {
+			return err
 		}
-		if knowledgeType == "" {
-			return fmt.Errorf("--type is required when adding markdown content")
+		knowledgeID = id
+		req.Type = mdReq.Type
+		req.Title = mdReq.Title
+		req.Summary = mdReq.Summary
+		req.BodyMD = mdReq.BodyMD
+		if req.Scope == "" {
+			req.Scope = mdReq.Scope
 		}
-		req.Type = knowledgeType
-		req.Title = title
-		req.Summary = summary
-		req.BodyMD = string(input)
+		req.Tags = mdReq.Tags
 	}
 
 	// Override with flags if provided
@@ -173,10 +249,26 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 	if summary != "" {
 		req.Summary = summary
 	}
+	if len(tags) > 0 {
+		req.Tags = tags
+	}
+	if reviewAfter != "" {
+		req.ReviewAfter = reviewAfter
+	}
+	if owner != "" {
+		req.Owner = owner
+	}
+	if language != "" {
+		req.Language = language
+	}
+
+	if knowledgeID != "" {
+		return updateFromFile(api, knowledgeID, req, outputJSON)
+	}
 
 	// Validate
 	if req.Type == "" {
-		return fmt.Errorf("type is required")
+		return fmt.Errorf("type is required (set it in the frontmatter or pass --type)")
 	}
 	if req.Title == "" {
 		return fmt.Errorf("title is required")
@@ -185,9 +277,25 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 		return fmt.Errorf("body is required")
 	}
 
+	req.Force = force
+
 	opts := RequestOptions{IdempotencyKey: idempotencyKey}
 	resp, err := api.PostWithOptions("/knowledge", req, opts)
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			var conflict struct {
+				Duplicates []DuplicateCandidate `json:"duplicates"`
+			}
+			if json.Unmarshal(apiErr.Data, &conflict) == nil && len(conflict.Duplicates) > 0 {
+				return reportDuplicates(api, req, conflict.Duplicates, outputJSON)
+			}
+		}
+		if errors.As(err, &apiErr) {
+			if lintErr := reportLintFailure(apiErr, outputJSON); lintErr != nil {
+				return lintErr
+			}
+		}
 		return fmt.Errorf("failed to create knowledge: %w", err)
 	}
 
@@ -197,6 +305,12 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	if file != "" && !isJSONInput(input) {
+		if err := recordKnowledgeID(file, input, knowledge.ID); err != nil {
+			return fmt.Errorf("created knowledge %s but %w", knowledge.ID, err)
+		}
+	}
+
 	if outputJSON {
 		output, _ := json.MarshalIndent(knowledge, "", "  ")
 		fmt.Println(string(output))
@@ -204,17 +318,239 @@ func runAdd(file, knowledgeType, title, summary, scope string, outputJSON bool,
 		fmt.Printf("Created knowledge: %s\n", knowledge.ID)
 		fmt.Printf("Title: %s\n", knowledge.Title)
 		fmt.Printf("Type: %s\n", knowledge.Type)
+		printLintFindings(knowledge.LintFindings)
+	}
+
+	return nil
+}
+
+// markdownRequest reads a markdown document with optional YAML or TOML frontmatter into
+// a create request and returns the id its frontmatter names, if any. Without a title
+// the first heading of a file or its name is used.
+func markdownRequest(input []byte, file string) (CreateKnowledgeRequest, string, error) {
+	meta, body, err := frontmatter.Parse(input)
+	if err != nil {
+		return CreateKnowledgeRequest{}, "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	req := CreateKnowledgeRequest{
+		Type:    meta.Type,
+		Title:   meta.Title,
+		Summary: meta.Summary,
+		BodyMD:  body,
+		Scope:   meta.Scope,
+		Tags:    meta.Tags,
+	}
+	if req.Title == "" && file != "" {
+		req.Title = markdownTitle(body, file)
+	}
+	return req, meta.ID, nil
+}
+
+// recordKnowledgeID writes the ID of a created item into the frontmatter of its file,
+// so that adding the file again updates the item
+func recordKnowledgeID(file string, input []byte, knowledgeID string) error {
+	content, err := frontmatter.SetID(input, knowledgeID)
+	if err != nil {
+		return fmt.Errorf("failed to record its id in %s: %w", file, err)
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(file); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(file, content, mode); err != nil {
+		return fmt.Errorf("failed to record its id in %s: %w", file, err)
+	}
+	return nil
+}
+
+// updateFromFile applies a markdown file whose frontmatter names an existing item.
+// Fields the file and flags leave empty keep their current value, and a file that
+// matches the item is not sent again.
+func updateFromFile(api *APIClient, knowledgeID string, req CreateKnowledgeRequest, outputJSON bool) error {
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s", knowledgeID))
+	if err != nil {
+		return fmt.Errorf("failed to get knowledge: %w", err)
+	}
+
+	var current Knowledge
+	if err := json.Unmarshal(resp.Data, &current); err != nil {
+		return fmt.Errorf("failed to parse knowledge: %w", err)
+	}
+
+	update := UpdateKnowledgeRequest{
+		Title:   current.Title,
+		Summary: current.Summary,
+		BodyMD:  req.BodyMD,
+		Scope:   current.Scope,
+		Tags:    req.Tags,
+	}
+	if req.Title != "" {
+		update.Title = req.Title
+	}
+	if req.Summary != "" {
+		update.Summary = req.Summary
+	}
+	if req.Scope != "" {
+		update.Scope = req.Scope
+	}
+	if req.ReviewAfter != "" {
+		update.ReviewAfter = &req.ReviewAfter
+	}
+	if req.Owner != "" {
+		update.Owner = &req.Owner
+	}
+	if req.Language != "" {
+		update.Language = &req.Language
+	}
+
+	if update.BodyMD == "" {
+		return fmt.Errorf("body is required")
+	}
+
+	unchanged := update.Title == current.Title && update.Summary == current.Summary &&
+		update.BodyMD == current.BodyMD && update.Scope == current.Scope &&
+		(update.Tags == nil || strings.Join(update.Tags, ",") == strings.Join(current.Tags, ",")) &&
+		update.ReviewAfter == nil && update.Owner == nil && update.Language == nil
+	knowledge := &current
+
+	if !unchanged {
+		opts := RequestOptions{}
+		if current.Version > 0 {
+			opts.IfMatch = strconv.Quote(strconv.FormatInt(current.Version, 10))
+		}
+		resp, err = api.PutWithOptions(fmt.Sprintf("/knowledge/%s", knowledgeID), update, opts)
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+				return reportVersionConflict(knowledgeID, current.Version, apiErr, outputJSON)
+			}
+			if errors.As(err, &apiErr) {
+				if lintErr := reportLintFailure(apiErr, outputJSON); lintErr != nil {
+					return lintErr
+				}
+			}
+			return fmt.Errorf("failed to update knowledge: %w", err)
+		}
+
+		knowledge = &Knowledge{}
+		if err := json.Unmarshal(resp.Data, knowledge); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(knowledge, "", "  ")
+		fmt.Println(string(output))
+	} else if unchanged {
+		fmt.Printf("Unchanged knowledge: %s (v%d)\n", knowledge.ID, knowledge.Version)
+	} else {
+		fmt.Printf("Updated knowledge: %s (v%d)\n", knowledge.ID, knowledge.Version)
+		fmt.Printf("Title: %s\n", knowledge.Title)
+		printLintFindings(knowledge.LintFindings)
 	}
 
 	return nil
 }
 
+// reportDuplicates lists the items similar to the one being added and, when interactive,
+// offers to update one of them with the new content instead.
+func reportDuplicates(api *APIClient, req CreateKnowledgeRequest, duplicates []DuplicateCandidate, outputJSON bool) error {
+	notCreated := fmt.Errorf("knowledge not created: similar items exist (use --force to add it anyway)")
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]interface{}{
+			"error":      "duplicate",
+			"duplicates": duplicates,
+		}, "", "  ")
+		fmt.Println(string(output))
+		return notCreated
+	}
+
+	fmt.Println("Similar knowledge already exists:")
+	for i, d := range duplicates {
+		fmt.Printf("  %d. %s  %s [%s] (%.0f%% similar)\n", i+1, d.ID, d.Title, d.Type, d.Similarity*100)
+	}
+
+	if !isInteractive() {
+		fmt.Printf("Run 'neotex update %s --file <file>' to update it instead.\n", duplicates[0].ID)
+		return notCreated
+	}
+
+	fmt.Print("Update one of these with your content instead? Enter its number, or press Enter to cancel: ")
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return notCreated
+	}
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(duplicates) {
+		return fmt.Errorf("invalid choice %q", answer)
+	}
+
+	return updateDuplicate(api, duplicates[choice-1].ID, req)
+}
+
+// updateDuplicate replaces the content of an existing item with the one that was being
+// added, keeping its summary and scope when the new item has none
+func updateDuplicate(api *APIClient, knowledgeID string, req CreateKnowledgeRequest) error {
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s", knowledgeID))
+	if err != nil {
+		return fmt.Errorf("failed to get knowledge: %w", err)
+	}
+
+	var current Knowledge
+	if err := json.Unmarshal(resp.Data, &current); err != nil {
+		return fmt.Errorf("failed to parse knowledge: %w", err)
+	}
+
+	update := UpdateKnowledgeRequest{
+		Title:   req.Title,
+		Summary: current.Summary,
+		BodyMD:  req.BodyMD,
+		Scope:   current.Scope,
+	}
+	if req.Summary != "" {
+		update.Summary = req.Summary
+	}
+	if req.Scope != "" {
+		update.Scope = req.Scope
+	}
+	if len(req.Tags) > 0 {
+		update.Tags = req.Tags
+	}
+
+	opts := RequestOptions{}
+	if current.Version > 0 {
+		opts.IfMatch = strconv.Quote(strconv.FormatInt(current.Version, 10))
+	}
+	resp, err = api.PutWithOptions(fmt.Sprintf("/knowledge/%s", knowledgeID), update, opts)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+			return reportVersionConflict(knowledgeID, current.Version, apiErr, false)
+		}
+		return fmt.Errorf("failed to update knowledge: %w", err)
+	}
+
+	var knowledge Knowledge
+	if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fmt.Printf("Updated knowledge: %s (v%d)\n", knowledge.ID, knowledge.Version)
+	fmt.Printf("Title: %s\n", knowledge.Title)
+	return nil
+}
+
 func isJSONInput(input []byte) bool {
 	s := strings.TrimSpace(string(input))
 	return len(s) > 0 && (s[0] == '{' || s[0] == '[')
 }
 
-func runBatchAdd(file string, outputJSON, atomic bool, idempotencyKey string) error {
+func runBatchAdd(file string, outputJSON, atomic, force bool, idempotencyKey string) error {
 	config, err := LoadConfig()
 	if err != nil {
 		return err
@@ -257,94 +593,43 @@ func runBatchAdd(file string, outputJSON, atomic bool, idempotencyKey string) er
 		return fmt.Errorf("batch size %d exceeds maximum of %d items", len(items), maxBatchSize)
 	}
 
-	response := BatchResponse{
-		Results: make([]BatchResult, 0, len(items)),
-		Total:   len(items),
+	for i := range items {
+		items[i].ProjectID = config.ProjectID
 	}
 
-	for i, item := range items {
-		item.ProjectID = config.ProjectID
-
-		if item.Type == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "type is required",
-				Title:  item.Title,
-			}
-			if atomic {
-				return reportAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-		if item.Title == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "title is required",
-			}
-			if atomic {
-				return reportAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-		if item.BodyMD == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "body_md is required",
-				Title:  item.Title,
-			}
-			if atomic {
-				return reportAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-
-		resp, err := api.PostWithOptions("/knowledge", item, opts)
-		if err != nil {
-			result := BatchResult{
-				Status: "failed",
-				Error:  err.Error(),
-				Title:  item.Title,
-			}
-			if atomic {
-				return reportAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
+	mode := batchModeBestEffort
+	if atomic {
+		mode = batchModeAtomic
+	}
 
-		var knowledge Knowledge
-		if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
-			result := BatchResult{
-				Status: "failed",
-				Error:  fmt.Sprintf("failed to parse response: %v", err),
-				Title:  item.Title,
-			}
-			if atomic {
-				return reportAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
+	resp, err := api.PostWithOptions("/knowledge/batch", BatchCreateRequest{Mode: mode, Force: force, Items: items}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to add batch: %w", err)
+	}
 
-		response.Results = append(response.Results, BatchResult{
-			ID:     knowledge.ID,
-			Status: "created",
-			Title:  knowledge.Title,
-		})
-		response.Succeeded++
+	var response BatchResponse
+	if err := json.Unmarshal(resp.Data, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	output, _ := json.MarshalIndent(response, "", "  ")
 	fmt.Println(string(output))
 
+	return batchError(response, outputJSON)
+}
+
+// batchError reports a rolled back atomic batch, or the failures of a best-effort batch
+// unless the results were requested as JSON
+func batchError(response BatchResponse, outputJSON bool) error {
+	if response.RolledBack {
+		for _, result := range response.Results {
+			if result.Status == "failed" {
+				return fmt.Errorf("atomic batch rolled back: item %d failed: %s", result.Index, result.Error)
+			}
+		}
+		return fmt.Errorf("atomic batch rolled back")
+	}
+
 	if response.Failed > 0 && !outputJSON {
 		return fmt.Errorf("batch completed with %d failures", response.Failed)
 	}
@@ -352,27 +637,8 @@ func runBatchAdd(file string, outputJSON, atomic bool, idempotencyKey string) er
 	return nil
 }
 
-func reportAtomicFailure(response BatchResponse, failedResult BatchResult, failedIndex int, outputJSON bool) error {
-	response.Results = append(response.Results, failedResult)
-	response.Failed = 1
-	response.Succeeded = failedIndex
-
-	output, _ := json.MarshalIndent(map[string]interface{}{
-		"error":           "atomic batch failed",
-		"failed_at":       failedIndex,
-		"failed_item":     failedResult,
-		"completed":       failedIndex,
-		"total":           response.Total,
-		"rolled_back":     failedIndex,
-		"partial_results": response.Results[:failedIndex],
-	}, "", "  ")
-	fmt.Println(string(output))
-
-	return fmt.Errorf("atomic batch failed at item %d: %s", failedIndex, failedResult.Error)
-}
-
 // runStreamingBatchAdd processes JSONL input line by line for memory efficiency.
-func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) error {
+func runStreamingBatchAdd(file string, outputJSON, force bool, idempotencyKey string) error {
 	config, err := LoadConfig()
 	if err != nil {
 		return err
@@ -405,9 +671,26 @@ func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) e
 	scanner.Buffer(buf, maxScanTokenSize)
 
 	response := BatchResponse{
+		Mode:    batchModeBestEffort,
 		Results: make([]BatchResult, 0),
 	}
 
+	// Items are sent in best-effort batches of up to maxBatchSize items, kept below the
+	// server's request size limit
+	var pending []CreateKnowledgeRequest
+	var pendingLines []int
+	pendingBytes := 0
+	batches := 0
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		err := sendStreamingBatch(api, BatchCreateRequest{Mode: batchModeBestEffort, Force: force, Items: pending}, pendingLines, opts.ForItem(batches), &response, outputJSON)
+		batches++
+		pending, pendingLines, pendingBytes = nil, nil, 0
+		return err
+	}
+
 	lineNum := 0
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -421,6 +704,7 @@ func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) e
 		var item CreateKnowledgeRequest
 		if err := json.Unmarshal([]byte(line), &item); err != nil {
 			result := BatchResult{
+				Index:  lineNum - 1,
 				Status: "failed",
 				Error:  fmt.Sprintf("line %d: failed to parse JSON: %v", lineNum, err),
 			}
@@ -434,82 +718,18 @@ func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) e
 
 		item.ProjectID = config.ProjectID
 
-		// Validate
-		if item.Type == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "type is required",
-				Title:  item.Title,
+		if len(pending) > 0 && pendingBytes+len(line) > maxStreamingBatchBytes {
+			if err := flush(); err != nil {
+				return err
 			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			if !outputJSON {
-				fmt.Fprintf(os.Stderr, "Line %d: type is required\n", lineNum)
-			}
-			continue
 		}
-		if item.Title == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "title is required",
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			if !outputJSON {
-				fmt.Fprintf(os.Stderr, "Line %d: title is required\n", lineNum)
-			}
-			continue
-		}
-		if item.BodyMD == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "body_md is required",
-				Title:  item.Title,
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			if !outputJSON {
-				fmt.Fprintf(os.Stderr, "Line %d: body_md is required\n", lineNum)
-			}
-			continue
-		}
-
-		resp, err := api.PostWithOptions("/knowledge", item, opts)
-		if err != nil {
-			result := BatchResult{
-				Status: "failed",
-				Error:  err.Error(),
-				Title:  item.Title,
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			if !outputJSON {
-				fmt.Fprintf(os.Stderr, "Line %d: %v\n", lineNum, err)
-			}
-			continue
-		}
-
-		var knowledge Knowledge
-		if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
-			result := BatchResult{
-				Status: "failed",
-				Error:  fmt.Sprintf("failed to parse response: %v", err),
-				Title:  item.Title,
+		pending = append(pending, item)
+		pendingLines = append(pendingLines, lineNum)
+		pendingBytes += len(line)
+		if len(pending) == maxBatchSize {
+			if err := flush(); err != nil {
+				return err
 			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-
-		response.Results = append(response.Results, BatchResult{
-			ID:     knowledge.ID,
-			Status: "created",
-			Title:  knowledge.Title,
-		})
-		response.Succeeded++
-
-		if !outputJSON {
-			fmt.Printf("Created: %s - %s\n", knowledge.ID, knowledge.Title)
 		}
 	}
 
@@ -517,6 +737,10 @@ func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) e
 		return fmt.Errorf("error reading input: %w", err)
 	}
 
+	if err := flush(); err != nil {
+		return err
+	}
+
 	if response.Total == 0 {
 		return fmt.Errorf("no items provided")
 	}
@@ -536,3 +760,41 @@ func runStreamingBatchAdd(file string, outputJSON bool, idempotencyKey string) e
 
 	return nil
 }
+
+// maxStreamingBatchBytes bounds the JSONL input sent in one streaming batch request
+const maxStreamingBatchBytes = 4 * 1024 * 1024
+
+// sendStreamingBatch creates one batch of streamed items and adds the results, numbered
+// by input line, to the response
+func sendStreamingBatch(api *APIClient, req BatchCreateRequest, lines []int, opts RequestOptions, response *BatchResponse, outputJSON bool) error {
+	resp, err := api.PostWithOptions("/knowledge/batch", req, opts)
+	if err != nil {
+		return fmt.Errorf("failed to add lines %d-%d: %w", lines[0], lines[len(lines)-1], err)
+	}
+
+	var batch BatchResponse
+	if err := json.Unmarshal(resp.Data, &batch); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, result := range batch.Results {
+		lineNum := lines[result.Index]
+		result.Index = lineNum - 1
+		response.Results = append(response.Results, result)
+
+		if result.Status == "created" {
+			response.Succeeded++
+			if !outputJSON {
+				fmt.Printf("Created: %s - %s\n", result.ID, result.Title)
+			}
+			continue
+		}
+
+		response.Failed++
+		if !outputJSON {
+			fmt.Fprintf(os.Stderr, "Line %d: %s\n", lineNum, result.Error)
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package client
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsJSONInput(t *testing.T) {
@@ -29,3 +35,145 @@ func TestIsJSONInput(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchError(t *testing.T) {
+	rolledBack := BatchResponse{
+		Results: []BatchResult{
+			{Index: 0, Status: "rolled_back"},
+			{Index: 1, Status: "failed", Error: "title is required"},
+		},
+		Failed:     1,
+		RolledBack: true,
+	}
+	assert.EqualError(t, batchError(rolledBack, true), "atomic batch rolled back: item 1 failed: title is required")
+
+	partial := BatchResponse{Succeeded: 1, Failed: 2}
+	assert.EqualError(t, batchError(partial, false), "batch completed with 2 failures")
+	assert.NoError(t, batchError(partial, true))
+	assert.NoError(t, batchError(BatchResponse{Succeeded: 3}, false))
+}
+
+func TestUpdateDuplicate(t *testing.T) {
+	var gotIfMatch string
+	var got UpdateKnowledgeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"data":{"id":"k-1","title":"Old","summary":"Old summary","scope":"ci/","version":3}}`))
+			return
+		}
+		gotIfMatch = r.Header.Get("If-Match")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"data":{"id":"k-1","title":"Retry flaky tests","version":4}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	err = updateDuplicate(api, "k-1", CreateKnowledgeRequest{Title: "Retry flaky tests", BodyMD: "# Retry", Tags: []string{"ci"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, `"3"`, gotIfMatch)
+	assert.Equal(t, "Retry flaky tests", got.Title)
+	assert.Equal(t, "# Retry", got.BodyMD)
+	assert.Equal(t, "Old summary", got.Summary)
+	assert.Equal(t, "ci/", got.Scope)
+	assert.Equal(t, []string{"ci"}, got.Tags)
+}
+
+func TestMarkdownRequest(t *testing.T) {
+	t.Run("reads YAML frontmatter", func(t *testing.T) {
+		req, id, err := markdownRequest([]byte("---\nid: k-1\ntype: guideline\ntitle: Deploys\nsummary: How we ship\nscope: ci/\ntags: [ci, release]\n---\n\nShip on Tuesdays.\n"), "deploy.md")
+		require.NoError(t, err)
+		assert.Equal(t, "k-1", id)
+		assert.Equal(t, CreateKnowledgeRequest{
+			Type: "guideline", Title: "Deploys", Summary: "How we ship", BodyMD: "Ship on Tuesdays.\n",
+			Scope: "ci/", Tags: []string{"ci", "release"},
+		}, req)
+	})
+
+	t.Run("reads TOML frontmatter and falls back to the heading", func(t *testing.T) {
+		req, id, err := markdownRequest([]byte("+++\ntype = \"learning\"\ntags = [\"ci\"]\n+++\n# Retry flaky tests\n\nRetry once.\n"), "retries.md")
+		require.NoError(t, err)
+		assert.Empty(t, id)
+		assert.Equal(t, "learning", req.Type)
+		assert.Equal(t, "Retry flaky tests", req.Title)
+		assert.Equal(t, []string{"ci"}, req.Tags)
+	})
+
+	t.Run("leaves the title of stdin empty", func(t *testing.T) {
+		req, _, err := markdownRequest([]byte("Retry once.\n"), "")
+		require.NoError(t, err)
+		assert.Empty(t, req.Title)
+		assert.Equal(t, "Retry once.\n", req.BodyMD)
+	})
+
+	t.Run("rejects invalid frontmatter", func(t *testing.T) {
+		_, _, err := markdownRequest([]byte("+++\ntitle = \"unclosed\n+++\n"), "bad.md")
+		assert.ErrorContains(t, err, "failed to parse frontmatter")
+	})
+}
+
+func TestRecordKnowledgeID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.md")
+	input := []byte("+++\ntitle = \"Deploys\"\n+++\nShip on Tuesdays.\n")
+	require.NoError(t, os.WriteFile(path, input, 0600))
+
+	require.NoError(t, recordKnowledgeID(path, input, "k-1"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "+++\nid = \"k-1\"\ntitle = \"Deploys\"\n+++\nShip on Tuesdays.\n", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestUpdateFromFile(t *testing.T) {
+	current := `{"data":{"id":"k-1","title":"Deploys","summary":"How we ship","body_md":"Ship on Tuesdays.\n","scope":"ci/","tags":["ci"],"version":3}}`
+
+	t.Run("updates the item based on its current version", func(t *testing.T) {
+		var gotIfMatch string
+		var got UpdateKnowledgeRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(current))
+				return
+			}
+			gotIfMatch = r.Header.Get("If-Match")
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"data":{"id":"k-1","title":"Deploys","version":4}}`))
+		}))
+		defer server.Close()
+
+		api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+		require.NoError(t, err)
+
+		err = updateFromFile(api, "k-1", CreateKnowledgeRequest{BodyMD: "Ship on Wednesdays.\n"}, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, `"3"`, gotIfMatch)
+		assert.Equal(t, "Deploys", got.Title)
+		assert.Equal(t, "How we ship", got.Summary)
+		assert.Equal(t, "Ship on Wednesdays.\n", got.BodyMD)
+		assert.Equal(t, "ci/", got.Scope)
+		assert.Nil(t, got.Tags)
+	})
+
+	t.Run("does not send an unchanged file", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(current))
+		}))
+		defer server.Close()
+
+		api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+		require.NoError(t, err)
+
+		err = updateFromFile(api, "k-1", CreateKnowledgeRequest{Title: "Deploys", BodyMD: "Ship on Tuesdays.\n", Tags: []string{"ci"}}, false)
+		require.NoError(t, err)
+	})
+}
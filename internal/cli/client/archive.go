@@ -0,0 +1,197 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RestoreResult represents the restore API response.
+type RestoreResult struct {
+	Projects      int               `json:"projects"`
+	Types         int               `json:"types"`
+	Knowledge     int               `json:"knowledge"`
+	Versions      int               `json:"versions"`
+	Assets        int               `json:"assets"`
+	SkippedAssets int               `json:"skipped_assets"`
+	Links         int               `json:"links"`
+	Relations     int               `json:"relations"`
+	EmbeddingJobs int               `json:"embedding_jobs"`
+	IDs           map[string]string `json:"ids,omitempty"`
+}
+
+// ExportCmd creates the export command.
+func ExportCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the organization as an archive",
+		Long: `Downloads the whole organization as a gzipped tarball.
+
+The archive holds a markdown file with frontmatter (type, status, scope, tags,
+version) for every knowledge item and each of its versions, the asset binaries
+and a manifest.json with projects, types, relations and asset links. Deprecated
+items are included; comments, checklist runs and search logs are not.
+
+Restore it into any organization with "neotex restore".`,
+		Example: `  neotex export
+  neotex export -f backup.tar.gz
+  neotex export -f - | tar -tz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(file)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Archive to write, - for stdout (default neotex-<time>.tar.gz)")
+
+	return cmd
+}
+
+func runExport(file string) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if file == "-" {
+		return exportArchive(api, os.Stdout)
+	}
+	if file == "" {
+		file = fmt.Sprintf("neotex-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	if err := exportArchive(api, out); err != nil {
+		out.Close()
+		os.Remove(file)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported to %s\n", file)
+	return nil
+}
+
+func exportArchive(api *APIClient, w io.Writer) error {
+	if err := api.GetStream("/export", w); err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+	return nil
+}
+
+// RestoreCmd creates the restore command.
+func RestoreCmd() *cobra.Command {
+	var (
+		orgID           string
+		remapIDs, embed bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore an exported archive into an organization",
+		Long: `Uploads an archive written by "neotex export" into an organization, in a
+single transaction.
+
+Restoring writes a whole organization, so it is an operator task: the request
+is authorized with the server's admin token from NEOTEX_ADMIN_TOKEN instead of
+an API key, and --org names the organization to restore into.
+
+Projects are matched by ID, then by name, and created when neither matches.
+Items keep their IDs unless --remap-ids is given, which is needed to restore an
+archive into an organization that already holds the same items, e.g. to copy it
+into a second organization on the same server. Embedding jobs are queued only
+with --embed, so restoring does not spend embedding credits unless asked to.`,
+		Example: `  NEOTEX_ADMIN_TOKEN=... neotex restore backup.tar.gz --org <org_id>
+  NEOTEX_ADMIN_TOKEN=... neotex restore backup.tar.gz --org <org_id> --remap-ids --embed`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runRestore(args[0], orgID, remapIDs, embed, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&orgID, "org", "", "Organization ID to restore into (required)")
+	cmd.MarkFlagRequired("org")
+
+	cmd.Flags().BoolVar(&remapIDs, "remap-ids", false, "Give restored items new IDs")
+	cmd.Flags().BoolVar(&embed, "embed", false, "Queue embedding jobs for restored knowledge and assets")
+
+	return cmd
+}
+
+func runRestore(path, orgID string, remapIDs, embed, outputJSON bool) error {
+	api, err := newAdminAPIClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := restoreArchive(api, path, orgID, remapIDs, embed)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Restored %d knowledge items with %d versions\n", result.Knowledge, result.Versions)
+	fmt.Printf("  %d projects created, %d types\n", result.Projects, result.Types)
+	fmt.Printf("  %d assets, %d links, %d relations\n", result.Assets, result.Links, result.Relations)
+	if result.SkippedAssets > 0 {
+		fmt.Printf("  %d assets skipped (exported without binaries)\n", result.SkippedAssets)
+	}
+	if result.EmbeddingJobs > 0 {
+		fmt.Printf("  %d embedding jobs queued\n", result.EmbeddingJobs)
+	}
+	return nil
+}
+
+func restoreArchive(api *APIClient, path, orgID string, remapIDs, embed bool) (*RestoreResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	query := url.Values{}
+	if remapIDs {
+		query.Set("remap_ids", "true")
+	}
+	if embed {
+		query.Set("embeddings", "true")
+	}
+	endpoint := fmt.Sprintf("/admin/orgs/%s/restore", url.PathEscape(orgID))
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	resp, err := api.PostStream(endpoint, f, stat.Size(), "application/gzip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore archive: %w", err)
+	}
+
+	var result RestoreResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
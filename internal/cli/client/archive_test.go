@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/export", r.URL.Path)
+		assert.Equal(t, "Bearer ntx_test", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write([]byte("archive bytes"))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, exportArchive(api, &buf))
+	assert.Equal(t, "archive bytes", buf.String())
+}
+
+func TestExportArchive_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = exportArchive(api, &buf)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Empty(t, buf.String())
+}
+
+func TestRestoreArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+	require.NoError(t, os.WriteFile(path, []byte("archive bytes"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/admin/orgs/org-1/restore", r.URL.Path)
+		assert.Equal(t, "Bearer admin-secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "true", r.URL.Query().Get("remap_ids"))
+		assert.Empty(t, r.URL.Query().Get("embeddings"))
+		assert.Equal(t, "application/gzip", r.Header.Get("Content-Type"))
+		assert.Equal(t, int64(13), r.ContentLength)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "archive bytes", string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"knowledge":2,"versions":3,"ids":{"old":"new"}}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("admin-secret", server.URL)
+	require.NoError(t, err)
+
+	result, err := restoreArchive(api, path, "org-1", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Knowledge)
+	assert.Equal(t, 3, result.Versions)
+	assert.Equal(t, map[string]string{"old": "new"}, result.IDs)
+}
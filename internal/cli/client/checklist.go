@@ -0,0 +1,498 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// ChecklistItem represents an item parsed from a checklist body.
+type ChecklistItem struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Section string `json:"section,omitempty"`
+	Checked bool   `json:"checked"`
+}
+
+// Checklist represents the structured form of a checklist version.
+type Checklist struct {
+	KnowledgeID   string          `json:"knowledge_id"`
+	VersionNumber int64           `json:"version_number"`
+	Title         string          `json:"title"`
+	Items         []ChecklistItem `json:"items"`
+}
+
+// ChecklistRunItem represents the state of an item within a run.
+type ChecklistRunItem struct {
+	Number    int    `json:"number"`
+	Text      string `json:"text"`
+	Section   string `json:"section,omitempty"`
+	Checked   bool   `json:"checked"`
+	CheckedBy string `json:"checked_by,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// ChecklistRun represents a tracked pass through a checklist version.
+type ChecklistRun struct {
+	ID            string             `json:"id"`
+	KnowledgeID   string             `json:"knowledge_id"`
+	VersionNumber int64              `json:"version_number"`
+	Status        string             `json:"status"`
+	StartedBy     string             `json:"started_by"`
+	StartedAt     string             `json:"started_at"`
+	CompletedBy   string             `json:"completed_by,omitempty"`
+	CompletedAt   string             `json:"completed_at,omitempty"`
+	Note          string             `json:"note,omitempty"`
+	Unchecked     []int              `json:"unchecked"`
+	Items         []ChecklistRunItem `json:"items"`
+}
+
+// ChecklistRunListResponse represents the list checklist runs API response.
+type ChecklistRunListResponse struct {
+	Runs []ChecklistRun `json:"runs"`
+}
+
+// StartChecklistRunRequest represents the start checklist run API request.
+type StartChecklistRunRequest struct {
+	Version   int64  `json:"version,omitempty"`
+	StartedBy string `json:"started_by"`
+	Note      string `json:"note,omitempty"`
+}
+
+// CheckChecklistItemRequest represents the check checklist item API request.
+type CheckChecklistItemRequest struct {
+	Checked bool   `json:"checked"`
+	By      string `json:"by,omitempty"`
+	Note    string `json:"note,omitempty"`
+}
+
+// CompleteChecklistRunRequest represents the complete checklist run API request.
+type CompleteChecklistRunRequest struct {
+	By string `json:"by"`
+}
+
+// ChecklistCmd creates the checklist command.
+func ChecklistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checklist",
+		Short: "Work through checklist knowledge items",
+		Long: `Commands for checklist-type knowledge items.
+
+Markdown task list items ("- [ ] ...") in a checklist body are parsed into
+numbered items. A run is a tracked pass through one version of a checklist:
+start a run, check items off as they are done, then complete it. Runs keep
+the version they were started from, so they stay auditable after the
+checklist is edited.`,
+	}
+
+	cmd.AddCommand(ChecklistShowCmd())
+	cmd.AddCommand(ChecklistStartCmd())
+	cmd.AddCommand(ChecklistRunsCmd())
+	cmd.AddCommand(ChecklistRunCmd())
+	cmd.AddCommand(ChecklistCheckCmd())
+	cmd.AddCommand(ChecklistUncheckCmd())
+	cmd.AddCommand(ChecklistCompleteCmd())
+
+	return cmd
+}
+
+// ChecklistShowCmd creates the checklist show command.
+func ChecklistShowCmd() *cobra.Command {
+	var version int64
+
+	cmd := &cobra.Command{
+		Use:   "show <knowledge_id>",
+		Short: "Show the items of a checklist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistShow(args[0], version, outputJSON)
+		},
+	}
+
+	cmd.Flags().Int64Var(&version, "version", 0, "Checklist version (default: latest)")
+
+	return cmd
+}
+
+// ChecklistStartCmd creates the checklist start command.
+func ChecklistStartCmd() *cobra.Command {
+	var (
+		version int64
+		by      string
+		note    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "start <knowledge_id>",
+		Short: "Start a run of a checklist",
+		Example: `  neotex checklist start <checklist_id> --note "release v1.2.0"
+  neotex checklist start <checklist_id> --version 3 --by release-bot`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistStart(args[0], version, by, note, outputJSON)
+		},
+	}
+
+	cmd.Flags().Int64Var(&version, "version", 0, "Checklist version to run (default: latest)")
+	cmd.Flags().StringVar(&by, "by", defaultReviewer(), "Who is running the checklist")
+	cmd.Flags().StringVar(&note, "note", "", "Optional note, e.g. the release or person being onboarded")
+
+	return cmd
+}
+
+// ChecklistRunsCmd creates the checklist runs command.
+func ChecklistRunsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs <knowledge_id>",
+		Short: "List the runs of a checklist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistRuns(args[0], outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+// ChecklistRunCmd creates the checklist run command.
+func ChecklistRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <run_id>",
+		Short: "Show the progress of a checklist run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistRun(args[0], outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+// ChecklistCheckCmd creates the checklist check command.
+func ChecklistCheckCmd() *cobra.Command {
+	var by, note string
+
+	cmd := &cobra.Command{
+		Use:     "check <run_id> <number>...",
+		Short:   "Check off items of a run",
+		Example: `  neotex checklist check <run_id> 1 2 3`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistCheck(args[0], args[1:], true, by, note, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", defaultReviewer(), "Who checked the items")
+	cmd.Flags().StringVar(&note, "note", "", "Optional note stored with each item")
+
+	return cmd
+}
+
+// ChecklistUncheckCmd creates the checklist uncheck command.
+func ChecklistUncheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uncheck <run_id> <number>...",
+		Short: "Clear checked items of a run",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistCheck(args[0], args[1:], false, "", "", outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+// ChecklistCompleteCmd creates the checklist complete command.
+func ChecklistCompleteCmd() *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "complete <run_id>",
+		Short: "Complete a run once every item is checked",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runChecklistComplete(args[0], by, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", defaultReviewer(), "Who completed the run")
+
+	return cmd
+}
+
+func runChecklistShow(knowledgeID string, version int64, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/knowledge/%s/checklist", knowledgeID)
+	if version > 0 {
+		path += fmt.Sprintf("?version=%d", version)
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to get checklist: %w", err)
+	}
+
+	var checklist Checklist
+	if err := json.Unmarshal(resp.Data, &checklist); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(checklist, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("%s (v%d)\n", checklist.Title, checklist.VersionNumber)
+	if len(checklist.Items) == 0 {
+		fmt.Println("No checklist items.")
+		return nil
+	}
+
+	section := ""
+	for _, item := range checklist.Items {
+		if item.Section != section {
+			section = item.Section
+			fmt.Printf("\n%s\n", section)
+		}
+		fmt.Printf("  %3d. %s\n", item.Number, item.Text)
+	}
+
+	return nil
+}
+
+func runChecklistStart(knowledgeID string, version int64, by, note string, outputJSON bool) error {
+	if by == "" {
+		return fmt.Errorf("--by is required")
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/checklist/runs", knowledgeID), StartChecklistRunRequest{
+		Version:   version,
+		StartedBy: by,
+		Note:      note,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start checklist run: %w", err)
+	}
+
+	run, err := parseChecklistRun(resp.Data)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(run, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Started run: %s\n", run.ID)
+	printChecklistRun(run)
+	return nil
+}
+
+func runChecklistRuns(knowledgeID string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s/checklist/runs", knowledgeID))
+	if err != nil {
+		return fmt.Errorf("failed to list checklist runs: %w", err)
+	}
+
+	var listResp ChecklistRunListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(listResp, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(listResp.Runs) == 0 {
+		fmt.Println("No runs.")
+		return nil
+	}
+
+	fmt.Printf("%-36s  %-11s  %-7s  %-9s  %-20s  %s\n", "ID", "STATUS", "VERSION", "PROGRESS", "STARTED", "BY")
+	for _, run := range listResp.Runs {
+		progress := fmt.Sprintf("%d/%d", len(run.Items)-len(run.Unchecked), len(run.Items))
+		fmt.Printf("%-36s  %-11s  v%-6d  %-9s  %-20s  %s\n", run.ID, run.Status, run.VersionNumber, progress, run.StartedAt, run.StartedBy)
+	}
+
+	return nil
+}
+
+func runChecklistRun(runID string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Get(fmt.Sprintf("/checklist-runs/%s", runID))
+	if err != nil {
+		return fmt.Errorf("failed to get checklist run: %w", err)
+	}
+
+	run, err := parseChecklistRun(resp.Data)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(run, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	printChecklistRun(run)
+	return nil
+}
+
+func runChecklistCheck(runID string, numberArgs []string, checked bool, by, note string, outputJSON bool) error {
+	numbers, err := parseItemNumbers(numberArgs)
+	if err != nil {
+		return err
+	}
+	if checked && by == "" {
+		return fmt.Errorf("--by is required")
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	var run *ChecklistRun
+	for _, number := range numbers {
+		resp, err := api.Put(fmt.Sprintf("/checklist-runs/%s/items/%d", runID, number), CheckChecklistItemRequest{
+			Checked: checked,
+			By:      by,
+			Note:    note,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update item %d: %w", number, err)
+		}
+
+		run, err = parseChecklistRun(resp.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(run, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	printChecklistRun(run)
+	return nil
+}
+
+func runChecklistComplete(runID, by string, outputJSON bool) error {
+	if by == "" {
+		return fmt.Errorf("--by is required")
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/checklist-runs/%s/complete", runID), CompleteChecklistRunRequest{By: by})
+	if err != nil {
+		return fmt.Errorf("failed to complete checklist run: %w", err)
+	}
+
+	run, err := parseChecklistRun(resp.Data)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(run, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Completed run: %s\n", run.ID)
+	fmt.Printf("Completed by: %s at %s\n", run.CompletedBy, run.CompletedAt)
+	return nil
+}
+
+func parseChecklistRun(data json.RawMessage) (*ChecklistRun, error) {
+	var run ChecklistRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &run, nil
+}
+
+// parseItemNumbers parses 1-based checklist item numbers given as arguments
+func parseItemNumbers(args []string) ([]int, error) {
+	numbers := make([]int, 0, len(args))
+	for _, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid item number %q", arg)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+func printChecklistRun(run *ChecklistRun) {
+	fmt.Printf("Run %s of %s (v%d): %s, %d/%d checked\n",
+		run.ID, run.KnowledgeID, run.VersionNumber, run.Status, len(run.Items)-len(run.Unchecked), len(run.Items))
+	fmt.Printf("Started by %s at %s\n", run.StartedBy, run.StartedAt)
+	if run.Note != "" {
+		fmt.Printf("Note: %s\n", run.Note)
+	}
+	if run.CompletedAt != "" {
+		fmt.Printf("Completed by %s at %s\n", run.CompletedBy, run.CompletedAt)
+	}
+
+	section := ""
+	for _, item := range run.Items {
+		if item.Section != section {
+			section = item.Section
+			fmt.Printf("\n%s\n", section)
+		}
+		mark := " "
+		if item.Checked {
+			mark = "x"
+		}
+		line := fmt.Sprintf("  [%s] %3d. %s", mark, item.Number, item.Text)
+		if item.Checked {
+			line += fmt.Sprintf(" (%s, %s)", item.CheckedBy, item.CheckedAt)
+		}
+		if item.Note != "" {
+			line += " - " + item.Note
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseItemNumbers(t *testing.T) {
+	numbers, err := parseItemNumbers([]string{"1", "3", "12"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 12}, numbers)
+
+	for _, bad := range []string{"0", "-1", "two"} {
+		_, err := parseItemNumbers([]string{"1", bad})
+		assert.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CommentLines is the line range a comment thread is anchored to.
+type CommentLines struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// Comment represents a comment on a knowledge item.
+type Comment struct {
+	ID            string        `json:"id"`
+	KnowledgeID   string        `json:"knowledge_id"`
+	ParentID      string        `json:"parent_id,omitempty"`
+	VersionNumber int64         `json:"version_number"`
+	Body          string        `json:"body"`
+	Author        string        `json:"author,omitempty"`
+	Lines         *CommentLines `json:"lines,omitempty"`
+	ChunkID       string        `json:"chunk_id,omitempty"`
+	Resolved      bool          `json:"resolved"`
+	ResolvedBy    string        `json:"resolved_by,omitempty"`
+	ResolvedAt    string        `json:"resolved_at,omitempty"`
+	CreatedAt     string        `json:"created_at"`
+}
+
+// CommentThread represents a comment that opened a thread with its replies.
+type CommentThread struct {
+	Comment
+	Replies []Comment `json:"replies"`
+}
+
+// CommentListResponse represents the list comments API response.
+type CommentListResponse struct {
+	Threads []CommentThread `json:"threads"`
+}
+
+// CreateCommentRequest represents the create comment API request.
+type CreateCommentRequest struct {
+	Body      string `json:"body"`
+	ParentID  string `json:"parent_id,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	ChunkID   string `json:"chunk_id,omitempty"`
+}
+
+// ResolveCommentRequest represents the resolve comment API request.
+type ResolveCommentRequest struct {
+	Resolved bool `json:"resolved"`
+}
+
+// CommentCmd creates the comment command.
+func CommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Discuss knowledge items in comment threads",
+		Long: `Commands for comment threads on knowledge items.
+
+A comment opens a thread about an item, optionally anchored to a line range
+(the same start:end form as "context open --lines") or to a search chunk.
+Replies join the thread, and the thread is resolved as a whole. Use
+"context open --comments" to see the unresolved threads with an item.`,
+	}
+
+	cmd.AddCommand(CommentAddCmd())
+	cmd.AddCommand(CommentListCmd())
+	cmd.AddCommand(CommentResolveCmd())
+	cmd.AddCommand(CommentDeleteCmd())
+
+	return cmd
+}
+
+// CommentAddCmd creates the comment add command.
+func CommentAddCmd() *cobra.Command {
+	var lines, chunkID, replyTo string
+
+	cmd := &cobra.Command{
+		Use:   "add <knowledge_id> <text>...",
+		Short: "Comment on a knowledge item",
+		Example: `  neotex comment add <id> "Why 30 seconds and not 60?" --lines 12:14
+  neotex comment add <id> "The load balancer times out at 30s" --reply-to <comment_id>`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runCommentAdd(args[0], strings.Join(args[1:], " "), lines, chunkID, replyTo, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&lines, "lines", "", "Anchor the thread to a line range (e.g., 12:14)")
+	cmd.Flags().StringVar(&chunkID, "chunk", "", "Anchor the thread to a chunk ID")
+	cmd.Flags().StringVar(&replyTo, "reply-to", "", "Reply to the thread of this comment ID")
+
+	return cmd
+}
+
+// CommentListCmd creates the comment list command.
+func CommentListCmd() *cobra.Command {
+	var status string
+
+	cmd := &cobra.Command{
+		Use:   "list <knowledge_id>",
+		Short: "List the comment threads on a knowledge item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runCommentList(args[0], status, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "open", "Threads to list (open|resolved|all)")
+
+	return cmd
+}
+
+// CommentResolveCmd creates the comment resolve command.
+func CommentResolveCmd() *cobra.Command {
+	var reopen bool
+
+	cmd := &cobra.Command{
+		Use:   "resolve <comment_id>",
+		Short: "Resolve a comment thread",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runCommentResolve(args[0], !reopen, outputJSON)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reopen, "reopen", false, "Reopen a resolved thread instead")
+
+	return cmd
+}
+
+// CommentDeleteCmd creates the comment delete command.
+func CommentDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <comment_id>",
+		Short: "Delete a comment, or a whole thread when given its first comment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommentDelete(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runCommentAdd(knowledgeID, body, lines, chunkID, replyTo string, outputJSON bool) error {
+	req := CreateCommentRequest{
+		Body:     body,
+		ParentID: replyTo,
+		ChunkID:  chunkID,
+	}
+	if lines != "" {
+		startLine, endLine, err := parseLineRange(lines)
+		if err != nil {
+			return fmt.Errorf("invalid line range: %w", err)
+		}
+		req.StartLine = startLine
+		req.EndLine = endLine
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/comments", knowledgeID), req)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(resp.Data, &comment); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(comment, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if comment.ParentID != "" {
+		fmt.Printf("Replied: %s (thread %s)\n", comment.ID, comment.ParentID)
+	} else {
+		fmt.Printf("Comment added: %s\n", comment.ID)
+	}
+	return nil
+}
+
+func runCommentList(knowledgeID, status string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/knowledge/%s/comments", knowledgeID)
+	if status != "" {
+		path += "?status=" + url.QueryEscape(status)
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	var listResp CommentListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(listResp, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(listResp.Threads) == 0 {
+		fmt.Println("No comments.")
+		return nil
+	}
+
+	for i, thread := range listResp.Threads {
+		if i > 0 {
+			fmt.Println()
+		}
+		printCommentThread(thread)
+	}
+	return nil
+}
+
+func runCommentResolve(commentID string, resolved, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/comments/%s/resolve", commentID), ResolveCommentRequest{Resolved: resolved})
+	if err != nil {
+		return fmt.Errorf("failed to resolve comment: %w", err)
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(resp.Data, &comment); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(comment, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if comment.Resolved {
+		fmt.Printf("Thread %s resolved\n", comment.ID)
+	} else {
+		fmt.Printf("Thread %s reopened\n", comment.ID)
+	}
+	return nil
+}
+
+func runCommentDelete(commentID string) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := api.Delete(fmt.Sprintf("/comments/%s", commentID)); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	fmt.Printf("Comment %s deleted\n", commentID)
+	return nil
+}
+
+// printCommentThread prints a thread header, its first comment and the replies indented below
+func printCommentThread(thread CommentThread) {
+	fmt.Printf("%s  %s\n", thread.ID, commentThreadHeader(thread.Comment))
+	printCommentBody(thread.Body, "  ")
+	for _, reply := range thread.Replies {
+		fmt.Printf("  > %s (%s):\n", commentAuthor(reply), reply.CreatedAt)
+		printCommentBody(reply.Body, "    ")
+	}
+}
+
+// commentThreadHeader describes who opened a thread, what it is anchored to and its state
+func commentThreadHeader(c Comment) string {
+	parts := []string{commentAuthor(c), fmt.Sprintf("v%d", c.VersionNumber)}
+	if c.Lines != nil {
+		parts = append(parts, fmt.Sprintf("lines %d:%d", c.Lines.StartLine, c.Lines.EndLine))
+	}
+	if c.ChunkID != "" {
+		parts = append(parts, "chunk "+c.ChunkID)
+	}
+	parts = append(parts, c.CreatedAt)
+	if c.Resolved {
+		resolved := "resolved"
+		if c.ResolvedBy != "" {
+			resolved += " by " + c.ResolvedBy
+		}
+		parts = append(parts, resolved)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func commentAuthor(c Comment) string {
+	if c.Author == "" {
+		return "unknown"
+	}
+	return c.Author
+}
+
+func printCommentBody(body, indent string) {
+	for _, line := range strings.Split(body, "\n") {
+		fmt.Printf("%s%s\n", indent, line)
+	}
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentThreadHeader(t *testing.T) {
+	thread := Comment{
+		Author:        "alice",
+		VersionNumber: 3,
+		Lines:         &CommentLines{StartLine: 12, EndLine: 14},
+		CreatedAt:     "2026-03-04T05:06:07Z",
+	}
+	assert.Equal(t, "alice, v3, lines 12:14, 2026-03-04T05:06:07Z", commentThreadHeader(thread))
+
+	thread = Comment{
+		VersionNumber: 1,
+		ChunkID:       "chunk-1",
+		CreatedAt:     "2026-03-04T05:06:07Z",
+		Resolved:      true,
+		ResolvedBy:    "bob",
+	}
+	assert.Equal(t, "unknown, v1, chunk chunk-1, 2026-03-04T05:06:07Z, resolved by bob", commentThreadHeader(thread))
+}
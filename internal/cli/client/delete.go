@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -13,12 +14,25 @@ type DeleteRequest struct {
 	ID string `json:"id"`
 }
 
+// BatchDeprecateItem is one item of the batch deprecate API request.
+type BatchDeprecateItem struct {
+	ID           string `json:"id"`
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+// BatchDeprecateRequest represents the batch deprecate knowledge API request.
+type BatchDeprecateRequest struct {
+	Mode  string               `json:"mode"`
+	Items []BatchDeprecateItem `json:"items"`
+}
+
 func DeleteCmd() *cobra.Command {
 	var (
 		file           string
 		batch          bool
 		atomic         bool
 		idempotencyKey string
+		supersededBy   string
 	)
 
 	cmd := &cobra.Command{
@@ -30,6 +44,9 @@ Examples:
   # Delete single knowledge item
   neotex delete <knowledge_id>
 
+  # Deprecate in favor of a replacement
+  neotex delete <knowledge_id> --superseded-by <new_knowledge_id>
+
   # Batch delete from JSON array of IDs
   echo '["id1","id2","id3"]' | neotex delete --batch
 
@@ -51,9 +68,12 @@ Examples:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outputJSON, _ := cmd.Flags().GetBool("output")
 			if batch {
+				if supersededBy != "" {
+					return fmt.Errorf("--superseded-by cannot be used with --batch")
+				}
 				return runBatchDelete(file, outputJSON, atomic, idempotencyKey)
 			}
-			return runDelete(args[0], outputJSON, idempotencyKey)
+			return runDelete(args[0], supersededBy, outputJSON, idempotencyKey)
 		},
 	}
 
@@ -61,18 +81,24 @@ Examples:
 	cmd.Flags().BoolVar(&batch, "batch", false, "Enable batch mode (expects JSON array of IDs)")
 	cmd.Flags().BoolVar(&atomic, "atomic", false, "Atomic mode: all-or-nothing (only with --batch)")
 	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency key for safe retries")
+	cmd.Flags().StringVar(&supersededBy, "superseded-by", "", "ID of the knowledge item that replaces this one")
 
 	return cmd
 }
 
-func runDelete(knowledgeID string, outputJSON bool, idempotencyKey string) error {
+func runDelete(knowledgeID, supersededBy string, outputJSON bool, idempotencyKey string) error {
 	api, err := NewAPIClient()
 	if err != nil {
 		return err
 	}
 
+	path := fmt.Sprintf("/knowledge/%s", knowledgeID)
+	if supersededBy != "" {
+		path += "?superseded_by=" + url.QueryEscape(supersededBy)
+	}
+
 	opts := RequestOptions{IdempotencyKey: idempotencyKey}
-	resp, err := api.DeleteWithOptions(fmt.Sprintf("/knowledge/%s", knowledgeID), opts)
+	resp, err := api.DeleteWithOptions(path, opts)
 	if err != nil {
 		return fmt.Errorf("failed to delete knowledge: %w", err)
 	}
@@ -83,15 +109,22 @@ func runDelete(knowledgeID string, outputJSON bool, idempotencyKey string) error
 	}
 
 	if outputJSON {
-		output, _ := json.MarshalIndent(map[string]interface{}{
+		result := map[string]interface{}{
 			"id":     knowledge.ID,
 			"status": "deprecated",
 			"title":  knowledge.Title,
-		}, "", "  ")
+		}
+		if knowledge.SupersededBy != "" {
+			result["superseded_by"] = knowledge.SupersededBy
+		}
+		output, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(output))
 	} else {
 		fmt.Printf("Deprecated knowledge: %s\n", knowledge.ID)
 		fmt.Printf("Title: %s\n", knowledge.Title)
+		if knowledge.SupersededBy != "" {
+			fmt.Printf("Superseded by: %s\n", knowledge.SupersededBy)
+		}
 	}
 
 	return nil
@@ -135,88 +168,28 @@ func runBatchDelete(file string, outputJSON, atomic bool, idempotencyKey string)
 		return fmt.Errorf("batch size %d exceeds maximum of %d items", len(ids), maxBatchSize)
 	}
 
-	response := BatchResponse{
-		Results: make([]BatchResult, 0, len(ids)),
-		Total:   len(ids),
-	}
-
+	items := make([]BatchDeprecateItem, len(ids))
 	for i, id := range ids {
-		if id == "" {
-			result := BatchResult{
-				Status: "failed",
-				Error:  "empty ID",
-			}
-			if atomic {
-				return reportDeleteAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-
-		resp, err := api.DeleteWithOptions(fmt.Sprintf("/knowledge/%s", id), opts)
-		if err != nil {
-			result := BatchResult{
-				ID:     id,
-				Status: "failed",
-				Error:  err.Error(),
-			}
-			if atomic {
-				return reportDeleteAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-
-		var knowledge Knowledge
-		if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
-			result := BatchResult{
-				ID:     id,
-				Status: "failed",
-				Error:  fmt.Sprintf("failed to parse response: %v", err),
-			}
-			if atomic {
-				return reportDeleteAtomicFailure(response, result, i, outputJSON)
-			}
-			response.Results = append(response.Results, result)
-			response.Failed++
-			continue
-		}
-
-		response.Results = append(response.Results, BatchResult{
-			ID:     knowledge.ID,
-			Status: "deprecated",
-			Title:  knowledge.Title,
-		})
-		response.Succeeded++
+		items[i] = BatchDeprecateItem{ID: id}
 	}
 
-	output, _ := json.MarshalIndent(response, "", "  ")
-	fmt.Println(string(output))
+	mode := batchModeBestEffort
+	if atomic {
+		mode = batchModeAtomic
+	}
 
-	if response.Failed > 0 && !outputJSON {
-		return fmt.Errorf("batch completed with %d failures", response.Failed)
+	resp, err := api.PostWithOptions("/knowledge/batch/deprecate", BatchDeprecateRequest{Mode: mode, Items: items}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to delete batch: %w", err)
 	}
 
-	return nil
-}
+	var response BatchResponse
+	if err := json.Unmarshal(resp.Data, &response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
 
-func reportDeleteAtomicFailure(response BatchResponse, failedResult BatchResult, failedIndex int, outputJSON bool) error {
-	response.Results = append(response.Results, failedResult)
-	response.Failed = 1
-	response.Succeeded = failedIndex
-
-	output, _ := json.MarshalIndent(map[string]interface{}{
-		"error":           "atomic batch failed",
-		"failed_at":       failedIndex,
-		"failed_item":     failedResult,
-		"completed":       failedIndex,
-		"total":           response.Total,
-		"note":            "previous deletions cannot be rolled back",
-		"partial_results": response.Results[:failedIndex],
-	}, "", "  ")
+	output, _ := json.MarshalIndent(response, "", "  ")
 	fmt.Println(string(output))
 
-	return fmt.Errorf("atomic batch failed at item %d: %s", failedIndex, failedResult.Error)
+	return batchError(response, outputJSON)
 }
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// VersionDiffResponse represents the version diff API response.
+type VersionDiffResponse struct {
+	KnowledgeID string `json:"knowledge_id"`
+	From        int64  `json:"from"`
+	To          int64  `json:"to"`
+	Diff        string `json:"diff"`
+}
+
+// DiffCmd creates the diff command.
+func DiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <knowledge_id> [from] [to]",
+		Short: "Show a unified diff between two versions",
+		Long: `Shows a unified diff between two versions of a knowledge item.
+
+Without version arguments the latest version is compared with the previous one.
+With only [from], it is compared with the latest version.
+
+Examples:
+  neotex diff <knowledge_id>
+  neotex diff <knowledge_id> 2
+  neotex diff <knowledge_id> 1 3`,
+		Args: cobra.RangeArgs(1, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+
+			var versions [2]int64
+			for i, arg := range args[1:] {
+				n, err := strconv.ParseInt(arg, 10, 64)
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid version %q", arg)
+				}
+				versions[i] = n
+			}
+
+			return runDiff(args[0], versions[0], versions[1], outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+func runDiff(knowledgeID string, from, to int64, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	if from > 0 {
+		params.Set("from", strconv.FormatInt(from, 10))
+	}
+	if to > 0 {
+		params.Set("to", strconv.FormatInt(to, 10))
+	}
+
+	path := fmt.Sprintf("/knowledge/%s/diff", knowledgeID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to diff versions: %w", err)
+	}
+
+	var diff VersionDiffResponse
+	if err := json.Unmarshal(resp.Data, &diff); err != nil {
+		return fmt.Errorf("failed to parse diff: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(diff, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if diff.Diff == "" {
+		fmt.Printf("No differences between v%d and v%d.\n", diff.From, diff.To)
+		return nil
+	}
+
+	fmt.Print(diff.Diff)
+
+	return nil
+}
@@ -3,23 +3,56 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // Knowledge represents a knowledge item from the API.
 type Knowledge struct {
-	ID        string `json:"id"`
-	OrgID     string `json:"org_id"`
-	ProjectID string `json:"project_id"`
-	Type      string `json:"type"`
-	Status    string `json:"status"`
-	Title     string `json:"title"`
-	Summary   string `json:"summary"`
-	BodyMD    string `json:"body_md"`
-	Scope     string `json:"scope"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID           string   `json:"id"`
+	OrgID        string   `json:"org_id"`
+	ProjectID    string   `json:"project_id"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status"`
+	Title        string   `json:"title"`
+	Summary      string   `json:"summary"`
+	BodyMD       string   `json:"body_md"`
+	Scope        string   `json:"scope"`
+	Tags         []string `json:"tags,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	ReviewedBy   string   `json:"reviewed_by,omitempty"`
+	ReviewedAt   string   `json:"reviewed_at,omitempty"`
+	ReviewNote   string   `json:"review_note,omitempty"`
+	Version      int64    `json:"version,omitempty"`
+	SupersededBy string   `json:"superseded_by,omitempty"`
+	ReviewAfter  string   `json:"review_after,omitempty"`
+	Owner        string   `json:"owner,omitempty"`
+	StaleSince   string   `json:"stale_since,omitempty"`
+	Stale        bool     `json:"stale,omitempty"`
+	Language     string   `json:"language,omitempty"`
+	// CreatedBy and UpdatedBy name the authors of the first and latest versions
+	CreatedBy      string `json:"created_by,omitempty"`
+	CreatedByKeyID string `json:"created_by_key_id,omitempty"`
+	UpdatedBy      string `json:"updated_by,omitempty"`
+	UpdatedByKeyID string `json:"updated_by_key_id,omitempty"`
+	// ReviewedByKeyID is the API key the review was made with
+	ReviewedByKeyID string `json:"reviewed_by_key_id,omitempty"`
+	// TemplateID and TemplateVersion are set on items created from a template
+	TemplateID      string `json:"template_id,omitempty"`
+	TemplateVersion int64  `json:"template_version,omitempty"`
+	// SourcePath and SourceHash are set on items created by neotex import
+	SourcePath string `json:"source_path,omitempty"`
+	SourceHash string `json:"source_hash,omitempty"`
+	// SummaryGeneratedBy is set when the summary was written by a model; SuggestedTitle
+	// is a generated title that was not applied
+	SummaryGeneratedBy string `json:"summary_generated_by,omitempty"`
+	SuggestedTitle     string `json:"suggested_title,omitempty"`
+	// LintFindings are returned by add and update when the item breaks lint rules
+	LintFindings []LintFinding `json:"lint_findings,omitempty"`
+	// Relations is filled in by neotex get from the relations endpoint
+	Relations []RelatedKnowledge `json:"relations,omitempty"`
 }
 
 // GetCmd creates the get command.
@@ -64,6 +97,11 @@ func runGet(knowledgeID string, outputJSON bool, searchID string) error {
 
 	_ = sendSearchFeedback(api, searchID, knowledgeID, "knowledge")
 
+	// Relations are best-effort so that older servers still return the item
+	if related, err := fetchRelations(api, knowledgeID, ""); err == nil {
+		knowledge.Relations = related
+	}
+
 	if outputJSON {
 		output, _ := json.MarshalIndent(knowledge, "", "  ")
 		fmt.Println(string(output))
@@ -71,14 +109,53 @@ func runGet(knowledgeID string, outputJSON bool, searchID string) error {
 		fmt.Printf("Title: %s\n", knowledge.Title)
 		fmt.Printf("Type: %s\n", knowledge.Type)
 		fmt.Printf("Status: %s\n", knowledge.Status)
+		if knowledge.Version > 0 {
+			fmt.Printf("Version: %d\n", knowledge.Version)
+		}
+		if knowledge.ReviewedBy != "" {
+			fmt.Printf("Reviewed: %s by %s\n", knowledge.ReviewedAt, knowledge.ReviewedBy)
+		}
+		if knowledge.ReviewNote != "" {
+			fmt.Printf("Review note: %s\n", knowledge.ReviewNote)
+		}
+		if knowledge.SupersededBy != "" {
+			fmt.Printf("Superseded by: %s\n", knowledge.SupersededBy)
+		}
+		if knowledge.Stale {
+			fmt.Printf("Stale: overdue for review since %s\n", knowledge.StaleSince)
+		}
+		if knowledge.ReviewAfter != "" {
+			fmt.Printf("Review after: %s\n", knowledge.ReviewAfter)
+		}
+		if knowledge.Owner != "" {
+			fmt.Printf("Owner: %s\n", knowledge.Owner)
+		}
+		if knowledge.Language != "" {
+			fmt.Printf("Language: %s\n", knowledge.Language)
+		}
+		if knowledge.TemplateID != "" {
+			fmt.Printf("Template: %s (v%d)\n", knowledge.TemplateID, knowledge.TemplateVersion)
+		}
 		if knowledge.Scope != "" {
 			fmt.Printf("Scope: %s\n", knowledge.Scope)
 		}
-		if knowledge.Summary != "" {
+		if len(knowledge.Tags) > 0 {
+			fmt.Printf("Tags: %s\n", strings.Join(knowledge.Tags, ", "))
+		}
+		if knowledge.Summary != "" && knowledge.SummaryGeneratedBy != "" {
+			fmt.Printf("Summary (generated by %s): %s\n", knowledge.SummaryGeneratedBy, knowledge.Summary)
+		} else if knowledge.Summary != "" {
 			fmt.Printf("Summary: %s\n", knowledge.Summary)
 		}
-		fmt.Printf("Created: %s\n", knowledge.CreatedAt)
-		fmt.Printf("Updated: %s\n", knowledge.UpdatedAt)
+		if knowledge.SuggestedTitle != "" {
+			fmt.Printf("Suggested title (generated): %s\n", knowledge.SuggestedTitle)
+		}
+		fmt.Printf("Created: %s%s\n", knowledge.CreatedAt, authorSuffix(knowledge.CreatedBy))
+		fmt.Printf("Updated: %s%s\n", knowledge.UpdatedAt, authorSuffix(knowledge.UpdatedBy))
+		if len(knowledge.Relations) > 0 {
+			fmt.Println()
+			printRelations(knowledge.Relations)
+		}
 		fmt.Println()
 		fmt.Println("--- Content ---")
 		fmt.Println(knowledge.BodyMD)
@@ -86,3 +163,11 @@ func runGet(knowledgeID string, outputJSON bool, searchID string) error {
 
 	return nil
 }
+
+// authorSuffix formats an author name for the Created/Updated lines
+func authorSuffix(author string) string {
+	if author == "" {
+		return ""
+	}
+	return " by " + author
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// KnowledgeVersion represents a knowledge version from the API.
+type KnowledgeVersion struct {
+	ID             string `json:"id"`
+	KnowledgeID    string `json:"knowledge_id"`
+	VersionNumber  int64  `json:"version_number"`
+	Title          string `json:"title"`
+	Summary        string `json:"summary"`
+	BodyMD         string `json:"body_md,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	CreatedBy      string `json:"created_by,omitempty"`
+	CreatedByKeyID string `json:"created_by_key_id,omitempty"`
+}
+
+// HistoryCmd creates the history command.
+func HistoryCmd() *cobra.Command {
+	var version int64
+
+	cmd := &cobra.Command{
+		Use:   "history <knowledge_id>",
+		Short: "Show version history of a knowledge item",
+		Long: `Lists all versions of a knowledge item, newest first.
+
+Examples:
+  # List versions
+  neotex history <knowledge_id>
+
+  # Show the full content of version 2
+  neotex history <knowledge_id> --version 2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			if version > 0 {
+				return runShowVersion(args[0], version, outputJSON)
+			}
+			return runHistory(args[0], outputJSON)
+		},
+	}
+
+	cmd.Flags().Int64Var(&version, "version", 0, "Show the full content of a specific version")
+
+	return cmd
+}
+
+func runHistory(knowledgeID string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s/versions", knowledgeID))
+	if err != nil {
+		return fmt.Errorf("failed to get history: %w", err)
+	}
+
+	var versions []KnowledgeVersion
+	if err := json.Unmarshal(resp.Data, &versions); err != nil {
+		return fmt.Errorf("failed to parse versions: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(versions, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(versions) == 0 {
+		fmt.Println("No versions found.")
+		return nil
+	}
+
+	for _, v := range versions {
+		if v.CreatedBy != "" {
+			fmt.Printf("v%d  %s  %s  (%s)\n", v.VersionNumber, v.CreatedAt, v.Title, v.CreatedBy)
+			continue
+		}
+		fmt.Printf("v%d  %s  %s\n", v.VersionNumber, v.CreatedAt, v.Title)
+	}
+
+	return nil
+}
+
+func runShowVersion(knowledgeID string, version int64, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s/versions/%d", knowledgeID, version))
+	if err != nil {
+		return fmt.Errorf("failed to get version: %w", err)
+	}
+
+	var v KnowledgeVersion
+	if err := json.Unmarshal(resp.Data, &v); err != nil {
+		return fmt.Errorf("failed to parse version: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(v, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Version: %d\n", v.VersionNumber)
+	fmt.Printf("Title: %s\n", v.Title)
+	if v.Summary != "" {
+		fmt.Printf("Summary: %s\n", v.Summary)
+	}
+	fmt.Printf("Created: %s\n", v.CreatedAt)
+	if v.CreatedBy != "" {
+		fmt.Printf("Author: %s\n", v.CreatedBy)
+	}
+	fmt.Println()
+	fmt.Println("--- Content ---")
+	fmt.Println(v.BodyMD)
+
+	return nil
+}
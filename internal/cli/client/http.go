@@ -16,6 +16,12 @@ import (
 const (
 	envAPIKey = "NEOTEX_API_KEY"
 	envAPIURL = "NEOTEX_API_URL"
+	// envAgent names the agent or user recorded as the author of knowledge written with this client
+	envAgent = "NEOTEX_AGENT"
+	// envAdminToken is the server's admin token, needed for operator endpoints such as restore
+	envAdminToken = "NEOTEX_ADMIN_TOKEN"
+
+	agentHeader = "X-Neotex-Agent"
 
 	defaultAPIURL = "http://localhost:8080"
 )
@@ -23,6 +29,7 @@ const (
 type APIClient struct {
 	baseURL    string
 	apiKey     string
+	agent      string
 	httpClient *http.Client
 }
 
@@ -83,11 +90,39 @@ func NewAPIClient() (*APIClient, error) {
 	return NewAPIClientWithCmd(nil)
 }
 
+// newAdminAPIClient creates an APIClient for the operator endpoints under /admin. It
+// authenticates with NEOTEX_ADMIN_TOKEN instead of an API key.
+func newAdminAPIClient() (*APIClient, error) {
+	_ = godotenv.Load()
+
+	token := os.Getenv(envAdminToken)
+	if token == "" {
+		return nil, fmt.Errorf("%s not set (the server's admin token is required)", envAdminToken)
+	}
+
+	baseURL := os.Getenv(envAPIURL)
+	if baseURL == "" {
+		globalConfig, err := LoadGlobalConfig()
+		if err != nil {
+			return nil, err
+		}
+		if globalConfig != nil {
+			baseURL = globalConfig.APIURL
+		}
+	}
+	if baseURL == "" {
+		baseURL = defaultAPIURL
+	}
+
+	return NewAPIClientWithConfig(token, baseURL)
+}
+
 // NewAPIClientWithConfig creates an APIClient with explicit config (used by init before .env exists).
 func NewAPIClientWithConfig(apiKey, baseURL string) (*APIClient, error) {
 	return &APIClient{
 		baseURL: baseURL,
 		apiKey:  apiKey,
+		agent:   os.Getenv(envAgent),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -101,9 +136,11 @@ type APIResponse struct {
 }
 
 // APIError represents an error from the API.
+// Data carries the response payload for errors that include one, such as 412 version conflicts.
 type APIError struct {
 	StatusCode int
 	Message    string
+	Data       json.RawMessage
 }
 
 func (e *APIError) Error() string {
@@ -133,6 +170,17 @@ func (c *APIClient) Delete(path string) (*APIResponse, error) {
 // RequestOptions contains optional settings for HTTP requests.
 type RequestOptions struct {
 	IdempotencyKey string
+	IfMatch        string
+}
+
+// ForItem returns the options for one of several requests made by a command. The server
+// rejects a key that is reused for a different request, so each request gets its own key
+// derived from the command's key.
+func (o RequestOptions) ForItem(index int) RequestOptions {
+	if o.IdempotencyKey != "" {
+		o.IdempotencyKey = fmt.Sprintf("%s-%d", o.IdempotencyKey, index)
+	}
+	return o
 }
 
 // PostWithOptions performs a POST request with JSON body and options.
@@ -140,6 +188,11 @@ func (c *APIClient) PostWithOptions(path string, body interface{}, opts RequestO
 	return c.doWithOptions("POST", path, body, opts)
 }
 
+// PutWithOptions performs a PUT request with JSON body and options.
+func (c *APIClient) PutWithOptions(path string, body interface{}, opts RequestOptions) (*APIResponse, error) {
+	return c.doWithOptions("PUT", path, body, opts)
+}
+
 // DeleteWithOptions performs a DELETE request with options.
 func (c *APIClient) DeleteWithOptions(path string, opts RequestOptions) (*APIResponse, error) {
 	return c.doWithOptions("DELETE", path, nil, opts)
@@ -168,10 +221,16 @@ func (c *APIClient) doWithOptions(method, path string, body interface{}, opts Re
 
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
+	if c.agent != "" {
+		req.Header.Set(agentHeader, c.agent)
+	}
 
 	if opts.IdempotencyKey != "" {
 		req.Header.Set("Idempotency-Key", opts.IdempotencyKey)
 	}
+	if opts.IfMatch != "" {
+		req.Header.Set("If-Match", opts.IfMatch)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -179,6 +238,11 @@ func (c *APIClient) doWithOptions(method, path string, body interface{}, opts Re
 	}
 	defer resp.Body.Close()
 
+	return decodeResponse(resp)
+}
+
+// decodeResponse reads a JSON API response, turning error statuses into an APIError.
+func decodeResponse(resp *http.Response) (*APIResponse, error) {
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
@@ -199,12 +263,73 @@ func (c *APIClient) doWithOptions(method, path string, body interface{}, opts Re
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    apiResp.Error,
+			Data:       apiResp.Data,
 		}
 	}
 
 	return &apiResp, nil
 }
 
+// streamingClient returns the HTTP client without its overall timeout, for archive
+// transfers that take as long as their size requires.
+func (c *APIClient) streamingClient() *http.Client {
+	client := *c.httpClient
+	client.Timeout = 0
+	return &client
+}
+
+// GetStream performs a GET request and copies the response body to w. Error responses
+// are decoded as JSON.
+func (c *APIClient) GetStream(path string, w io.Writer) error {
+	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if c.agent != "" {
+		req.Header.Set(agentHeader, c.agent)
+	}
+
+	resp, err := c.streamingClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		_, err := decodeResponse(resp)
+		return err
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	return nil
+}
+
+// PostStream performs a POST request with a raw body of the given content type and
+// decodes the JSON response. size may be -1 when it is unknown.
+func (c *APIClient) PostStream(path string, body io.Reader, size int64, contentType string) (*APIResponse, error) {
+	req, err := http.NewRequest("POST", c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", contentType)
+	if c.agent != "" {
+		req.Header.Set(agentHeader, c.agent)
+	}
+	req.ContentLength = size
+
+	resp, err := c.streamingClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return decodeResponse(resp)
+}
+
 // UploadFile uploads a file to the given presigned URL.
 func (c *APIClient) UploadFile(uploadURL, filePath, contentType string) error {
 	file, err := os.Open(filePath)
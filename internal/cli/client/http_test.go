@@ -2,7 +2,11 @@ package client
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,3 +83,57 @@ func TestProgressReader_SmallReads(t *testing.T) {
 		assert.GreaterOrEqual(t, progressValues[i], progressValues[i-1])
 	}
 }
+
+func TestPutWithOptions_VersionConflict(t *testing.T) {
+	var gotIfMatch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotIfMatch = r.Header.Get("If-Match")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		_, _ = w.Write([]byte(`{"error":"conflict","data":{"id":"k-1","version":4}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	_, err = api.PutWithOptions("/knowledge/k-1", UpdateKnowledgeRequest{Title: "t", BodyMD: "b"}, RequestOptions{IfMatch: `"2"`})
+	require.Error(t, err)
+	assert.Equal(t, `"2"`, gotIfMatch)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+
+	var current Knowledge
+	require.NoError(t, json.Unmarshal(apiErr.Data, &current))
+	assert.Equal(t, int64(4), current.Version)
+}
+
+func TestAPIClient_SendsAgentHeader(t *testing.T) {
+	t.Setenv(envAgent, "release-bot")
+
+	var gotAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAgent = r.Header.Get(agentHeader)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	_, err = api.Get("/knowledge")
+	require.NoError(t, err)
+	assert.Equal(t, "release-bot", gotAgent)
+}
+
+func TestRequestOptions_ForItem(t *testing.T) {
+	opts := RequestOptions{IdempotencyKey: "import-42", IfMatch: `"3"`}
+
+	assert.Equal(t, RequestOptions{IdempotencyKey: "import-42-0", IfMatch: `"3"`}, opts.ForItem(0))
+	assert.Equal(t, "import-42-7", opts.ForItem(7).IdempotencyKey)
+	assert.Equal(t, "import-42", opts.IdempotencyKey)
+	assert.Empty(t, RequestOptions{}.ForItem(3).IdempotencyKey)
+}
@@ -0,0 +1,532 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
+	"github.com/spf13/cobra"
+)
+
+// KnowledgeSource is an item imported from a file, as listed by the sources endpoint.
+type KnowledgeSource struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Title      string `json:"title"`
+	SourcePath string `json:"source_path"`
+	SourceHash string `json:"source_hash,omitempty"`
+}
+
+// KnowledgeSourcesResponse represents the list knowledge sources API response.
+type KnowledgeSourcesResponse struct {
+	Items []KnowledgeSource `json:"items"`
+}
+
+// Actions planned for an imported file.
+const (
+	importActionCreate    = "create"
+	importActionUpdate    = "update"
+	importActionUnchanged = "unchanged"
+	importActionSkip      = "skip"
+)
+
+// ImportItem is the outcome of importing one file. Error is set when the file could
+// not be read or its action failed.
+type ImportItem struct {
+	Path   string `json:"path"`
+	Action string `json:"action,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportReport summarizes an import. With DryRun the counts are the planned actions.
+type ImportReport struct {
+	Dir       string       `json:"dir"`
+	DryRun    bool         `json:"dry_run"`
+	Items     []ImportItem `json:"items"`
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Skipped   int          `json:"skipped"`
+	Failed    int          `json:"failed"`
+}
+
+// importOptions are the settings of one import run.
+type importOptions struct {
+	ProjectID   string
+	DefaultType string
+	DryRun      bool
+	Force       bool
+	Parallel    int
+}
+
+// importFile is a markdown file read for import, with the request it turns into.
+type importFile struct {
+	item     *ImportItem
+	request  CreateKnowledgeRequest
+	status   string
+	existing *KnowledgeSource
+}
+
+// ImportCmd creates the import command.
+func ImportCmd() *cobra.Command {
+	var (
+		knowledgeType string
+		dryRun        bool
+		force         bool
+		parallel      int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Import a folder of markdown files as knowledge",
+		Long: `Creates or updates knowledge from the .md files below a directory.
+
+Each file becomes one item. YAML or TOML frontmatter can set type, title,
+summary, scope, tags and status (draft, approved or deprecated). Without a
+title the first "# " heading or the file name is used, and the scope defaults
+to the file's path relative to the imported directory. Files without a type
+use --type. Files marked approved are imported as drafts and wait for review
+like any other new item.
+
+Imported items remember the file they came from and a hash of its content,
+so running the import again updates the items of changed files, leaves
+unchanged files alone and only creates items for new files. Items that were
+deprecated since the last import are skipped. New files that are similar to
+existing knowledge fail as duplicates unless --force is given.
+
+New files are created through the batch endpoint; --parallel sends several
+batches and updates at once.`,
+		Example: `  # Preview what would be created and updated
+  neotex import docs --dry-run
+
+  # Import files without a type in their frontmatter as guidelines
+  neotex import docs --type guideline
+
+  # Send up to four requests at a time
+  neotex import docs --parallel 4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runImport(args[0], importOptions{
+				DefaultType: knowledgeType,
+				DryRun:      dryRun,
+				Force:       force,
+				Parallel:    parallel,
+			}, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Knowledge type of files whose frontmatter has none")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be created and updated without changing anything")
+	cmd.Flags().BoolVar(&force, "force", false, "Create new files even when similar knowledge already exists")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of requests to send at once")
+
+	return cmd
+}
+
+func runImport(dir string, opts importOptions, outputJSON bool) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	opts.ProjectID = config.ProjectID
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := importDir(api, dir, opts)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		printImportReport(report)
+	}
+
+	if report.Failed > 0 && !outputJSON {
+		return fmt.Errorf("import completed with %d failures", report.Failed)
+	}
+	return nil
+}
+
+// importDir plans the import of the markdown files below dir against the items created
+// by earlier imports and, unless it is a dry run, carries it out
+func importDir(api *APIClient, dir string, opts importOptions) (*ImportReport, error) {
+	if opts.Parallel < 1 {
+		return nil, fmt.Errorf("--parallel must be at least 1")
+	}
+
+	paths, err := collectMarkdownFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no markdown files found in %s", dir)
+	}
+
+	sources, err := fetchSources(api, opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{Dir: dir, DryRun: opts.DryRun, Items: make([]ImportItem, len(paths))}
+	var creates, updates []*importFile
+	for i, path := range paths {
+		file := readImportFile(dir, path, opts, &report.Items[i])
+		if file == nil {
+			continue
+		}
+		file.existing = sources[file.request.SourcePath]
+		planImport(file)
+
+		switch file.item.Action {
+		case importActionCreate:
+			creates = append(creates, file)
+		case importActionUpdate:
+			updates = append(updates, file)
+		}
+	}
+
+	if !opts.DryRun {
+		runImportTasks(api, creates, updates, opts)
+	}
+
+	for _, item := range report.Items {
+		switch {
+		case item.Error != "":
+			report.Failed++
+		case item.Action == importActionCreate:
+			report.Created++
+		case item.Action == importActionUpdate:
+			report.Updated++
+		case item.Action == importActionUnchanged:
+			report.Unchanged++
+		case item.Action == importActionSkip:
+			report.Skipped++
+		}
+	}
+
+	return report, nil
+}
+
+// collectMarkdownFiles lists the .md files below dir in a stable order, skipping hidden
+// directories such as .git
+func collectMarkdownFiles(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".md") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// fetchSources returns the items of earlier imports by source path. When several items
+// came from the same file the one that is not deprecated wins.
+func fetchSources(api *APIClient, projectID string) (map[string]*KnowledgeSource, error) {
+	resp, err := api.Get("/knowledge/sources?project_id=" + url.QueryEscape(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list imported knowledge: %w", err)
+	}
+
+	var list KnowledgeSourcesResponse
+	if err := json.Unmarshal(resp.Data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	sources := make(map[string]*KnowledgeSource, len(list.Items))
+	for i := range list.Items {
+		source := &list.Items[i]
+		if current, ok := sources[source.SourcePath]; ok && current.Status != "deprecated" {
+			continue
+		}
+		sources[source.SourcePath] = source
+	}
+	return sources, nil
+}
+
+// readImportFile reads one markdown file into a create request. Problems with the file
+// are recorded on the item and yield nil.
+func readImportFile(dir, path string, opts importOptions, item *ImportItem) *importFile {
+	relPath, _ := filepath.Rel(dir, path)
+	item.Path = sourcePath(dir, path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		item.Error = fmt.Sprintf("failed to read file: %v", err)
+		return nil
+	}
+
+	meta, body, err := frontmatter.Parse(content)
+	if err != nil {
+		item.Error = err.Error()
+		return nil
+	}
+
+	switch meta.Status {
+	case "", "draft", "approved", "deprecated":
+	default:
+		item.Error = fmt.Sprintf("unsupported status %q (use draft, approved or deprecated)", meta.Status)
+		return nil
+	}
+
+	if strings.TrimSpace(body) == "" {
+		item.Error = "file has no content"
+		return nil
+	}
+
+	req := CreateKnowledgeRequest{
+		Type:       meta.Type,
+		Title:      meta.Title,
+		Summary:    meta.Summary,
+		BodyMD:     body,
+		ProjectID:  opts.ProjectID,
+		Scope:      meta.Scope,
+		Tags:       meta.Tags,
+		SourcePath: item.Path,
+	}
+	if req.Type == "" {
+		req.Type = opts.DefaultType
+	}
+	if req.Title == "" {
+		req.Title = markdownTitle(body, path)
+	}
+	if req.Scope == "" {
+		req.Scope = filepath.ToSlash(relPath)
+	}
+	req.SourceHash = importHash(req)
+
+	item.Title = req.Title
+	item.Status = meta.Status
+	return &importFile{item: item, request: req, status: meta.Status}
+}
+
+// importHash hashes the fields an import pushes, so edits that leave them alone, such as
+// a changed status or reformatted frontmatter, do not count as changes
+func importHash(req CreateKnowledgeRequest) string {
+	data, _ := json.Marshal(struct {
+		Title   string   `json:"title"`
+		Summary string   `json:"summary"`
+		Scope   string   `json:"scope"`
+		Tags    []string `json:"tags"`
+		BodyMD  string   `json:"body_md"`
+	}{req.Title, req.Summary, req.Scope, req.Tags, req.BodyMD})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// sourcePath identifies a file across imports by its path from the project root, the
+// working directory of the CLI. Files outside of it use their path within dir.
+func sourcePath(dir, path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+	rel, _ := filepath.Rel(dir, path)
+	return filepath.ToSlash(rel)
+}
+
+// markdownTitle returns the first top-level heading of body, or the file name
+func markdownTitle(body, path string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			if title := strings.TrimSpace(line[2:]); title != "" {
+				return title
+			}
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// planImport decides what to do with a file given the item an earlier import made of it
+func planImport(file *importFile) {
+	existing := file.existing
+	switch {
+	case existing == nil && file.status == "deprecated":
+		file.item.Action = importActionSkip
+		file.item.Reason = "deprecated in frontmatter"
+	case existing == nil:
+		if file.request.Type == "" {
+			file.item.Error = "type is required (set it in the frontmatter or pass --type)"
+			return
+		}
+		file.item.Action = importActionCreate
+	case existing.Status == "deprecated":
+		file.item.Action = importActionSkip
+		file.item.ID = existing.ID
+		file.item.Reason = "existing item is deprecated"
+	case existing.SourceHash == file.request.SourceHash:
+		file.item.Action = importActionUnchanged
+		file.item.ID = existing.ID
+	default:
+		file.item.Action = importActionUpdate
+		file.item.ID = existing.ID
+	}
+}
+
+// runImportTasks creates the new files in batches and updates the changed ones, running
+// up to opts.Parallel requests at once
+func runImportTasks(api *APIClient, creates, updates []*importFile, opts importOptions) {
+	var tasks []func()
+	for start := 0; start < len(creates); start += maxBatchSize {
+		chunk := creates[start:min(start+maxBatchSize, len(creates))]
+		tasks = append(tasks, func() { createImportBatch(api, chunk, opts) })
+	}
+	for _, file := range updates {
+		tasks = append(tasks, func() { updateImportFile(api, file, opts) })
+	}
+
+	sem := make(chan struct{}, opts.Parallel)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}()
+	}
+	wg.Wait()
+}
+
+// createImportBatch creates one best-effort batch of new files
+func createImportBatch(api *APIClient, files []*importFile, opts importOptions) {
+	items := make([]CreateKnowledgeRequest, len(files))
+	for i, file := range files {
+		items[i] = file.request
+	}
+
+	resp, err := api.Post("/knowledge/batch", BatchCreateRequest{Mode: batchModeBestEffort, Force: opts.Force, Items: items})
+	var batch BatchResponse
+	if err == nil {
+		err = json.Unmarshal(resp.Data, &batch)
+	}
+	if err != nil {
+		for _, file := range files {
+			file.item.Error = fmt.Sprintf("failed to create knowledge: %v", err)
+		}
+		return
+	}
+
+	for _, result := range batch.Results {
+		if result.Index < 0 || result.Index >= len(files) {
+			continue
+		}
+		file := files[result.Index]
+		if result.Status != "created" {
+			file.item.Error = result.Error
+			if len(result.Duplicates) > 0 {
+				ids := make([]string, len(result.Duplicates))
+				for i, duplicate := range result.Duplicates {
+					ids[i] = duplicate.ID
+				}
+				file.item.Error = fmt.Sprintf("%s: %s", result.Error, strings.Join(ids, ", "))
+			}
+			continue
+		}
+		file.item.ID = result.ID
+		applyImportStatus(api, file, "draft", opts)
+	}
+}
+
+// updateImportFile replaces the content of the item imported from a changed file
+func updateImportFile(api *APIClient, file *importFile, opts importOptions) {
+	req := UpdateKnowledgeRequest{
+		Title:      file.request.Title,
+		Summary:    file.request.Summary,
+		BodyMD:     file.request.BodyMD,
+		Scope:      file.request.Scope,
+		Tags:       file.request.Tags,
+		SourcePath: file.request.SourcePath,
+		SourceHash: file.request.SourceHash,
+	}
+
+	resp, err := api.Put(fmt.Sprintf("/knowledge/%s", file.existing.ID), req)
+	if err != nil {
+		file.item.Error = fmt.Sprintf("failed to update knowledge: %v", err)
+		return
+	}
+
+	var knowledge Knowledge
+	if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
+		file.item.Error = fmt.Sprintf("failed to parse response: %v", err)
+		return
+	}
+
+	applyImportStatus(api, file, knowledge.Status, opts)
+}
+
+// applyImportStatus deprecates an imported item when its frontmatter asks for it. Items
+// marked approved are not approved by the import: they wait as drafts for a reviewer
+// with another API key, as every new item does.
+func applyImportStatus(api *APIClient, file *importFile, current string, opts importOptions) {
+	switch {
+	case file.status == "approved" && current == "draft":
+		file.item.Status = current
+	case file.status == "deprecated":
+		if _, err := api.Delete(fmt.Sprintf("/knowledge/%s", file.item.ID)); err != nil {
+			file.item.Error = fmt.Sprintf("failed to set status %s: %v", file.status, err)
+		}
+	}
+}
+
+func printImportReport(report *ImportReport) {
+	for _, item := range report.Items {
+		switch {
+		case item.Error != "":
+			fmt.Printf("failed     %s: %s\n", item.Path, item.Error)
+		case item.Reason != "":
+			fmt.Printf("%-10s %s (%s)\n", item.Action, item.Path, item.Reason)
+		case item.ID != "":
+			fmt.Printf("%-10s %s  %s\n", item.Action, item.Path, item.ID)
+		default:
+			fmt.Printf("%-10s %s\n", item.Action, item.Path)
+		}
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged, %d skipped, %d failed",
+		report.Created, report.Updated, report.Unchanged, report.Skipped, report.Failed)
+	if report.DryRun {
+		fmt.Printf("\nDry run: %s\n", summary)
+	} else {
+		fmt.Printf("\nImported %s: %s\n", report.Dir, summary)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeImportFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"deploys.md":         "---\ntype: guideline\ntitle: Deploys\ntags: [ci, release]\nstatus: approved\n---\n\nShip on Tuesdays.\n",
+		"retries.md":         "# Retry flaky tests\n\nRetry once.\n",
+		"services/api.md":    "---\ntype: decision\nscope: services/api/\n---\n# API style\n\nUse JSON.\n",
+		"services/old.md":    "---\ntype: learning\n---\nChanged content.\n",
+		"services/legacy.md": "---\ntype: learning\n---\nLegacy.\n",
+		".drafts/skip.md":    "---\ntype: learning\n---\nHidden.\n",
+		"notes.txt":          "not markdown",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestImportDir(t *testing.T) {
+	dir := writeImportFixture(t)
+
+	var mu sync.Mutex
+	var created []CreateKnowledgeRequest
+	var updated UpdateKnowledgeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/knowledge/sources":
+			assert.Equal(t, "proj-1", r.URL.Query().Get("project_id"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": KnowledgeSourcesResponse{Items: []KnowledgeSource{
+				{ID: "k-api", Status: "approved", SourcePath: "services/api.md", SourceHash: importHash(CreateKnowledgeRequest{
+					Title: "API style", Scope: "services/api/", BodyMD: "# API style\n\nUse JSON.\n",
+				})},
+				{ID: "k-old", Status: "approved", SourcePath: "services/old.md", SourceHash: "stale"},
+				{ID: "k-legacy", Status: "deprecated", SourcePath: "services/legacy.md", SourceHash: "stale"},
+			}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/knowledge/batch":
+			var req BatchCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, batchModeBestEffort, req.Mode)
+			response := BatchResponse{Total: len(req.Items)}
+			for i, item := range req.Items {
+				created = append(created, item)
+				response.Results = append(response.Results, BatchResult{Index: i, ID: fmt.Sprintf("k-new-%d", i), Status: "created", Title: item.Title})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": response})
+		case r.Method == http.MethodPut && r.URL.Path == "/knowledge/k-old":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			_, _ = w.Write([]byte(`{"data":{"id":"k-old","status":"approved","version":2}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	report, err := importDir(api, dir, importOptions{ProjectID: "proj-1", DefaultType: "learning", Parallel: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Zero(t, report.Failed)
+	assert.Len(t, report.Items, 5)
+
+	require.Len(t, created, 2)
+	deploys, retries := created[0], created[1]
+	assert.Equal(t, "guideline", deploys.Type)
+	assert.Equal(t, "Deploys", deploys.Title)
+	assert.Equal(t, "deploys.md", deploys.Scope)
+	assert.Equal(t, "deploys.md", deploys.SourcePath)
+	assert.Equal(t, importHash(deploys), deploys.SourceHash)
+	assert.Equal(t, []string{"ci", "release"}, deploys.Tags)
+	assert.Equal(t, "Ship on Tuesdays.\n", deploys.BodyMD)
+	assert.Equal(t, "proj-1", deploys.ProjectID)
+	assert.Equal(t, "learning", retries.Type)
+	assert.Equal(t, "Retry flaky tests", retries.Title)
+
+	assert.Equal(t, "Changed content.\n", updated.BodyMD)
+	assert.Equal(t, "old", updated.Title)
+	assert.NotEqual(t, "stale", updated.SourceHash)
+
+	assert.Equal(t, "deploys.md", report.Items[0].Path)
+	assert.Equal(t, "draft", report.Items[0].Status, "approved files are imported for review")
+}
+
+func TestImportHash(t *testing.T) {
+	req := CreateKnowledgeRequest{Type: "guideline", Title: "Deploys", Scope: "deploys.md", Tags: []string{"ci"}, BodyMD: "Ship on Tuesdays.\n"}
+	hash := importHash(req)
+
+	other := req
+	other.Type = "learning"
+	other.SourcePath = "docs/deploys.md"
+	assert.Equal(t, hash, importHash(other), "only pushed content counts")
+
+	other.BodyMD = "Ship on Wednesdays.\n"
+	assert.NotEqual(t, hash, importHash(other))
+}
+
+func TestImportDir_DryRun(t *testing.T) {
+	dir := writeImportFixture(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data":{"items":[]}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+
+	report, err := importDir(api, dir, importOptions{ProjectID: "proj-1", DryRun: true, Parallel: 1})
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.Created)
+	assert.Equal(t, 1, report.Failed, "retries.md has no type and no --type was given")
+	for _, item := range report.Items {
+		assert.Empty(t, item.ID)
+	}
+}
+
+func TestMarkdownTitle(t *testing.T) {
+	assert.Equal(t, "Deploys", markdownTitle("Intro\n# Deploys\n## Steps\n", "docs/deploys.md"))
+	assert.Equal(t, "runbook", markdownTitle("No heading here.\n", "docs/runbook.md"))
+}
@@ -0,0 +1,453 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
+	"github.com/spf13/cobra"
+)
+
+// lintConfigFile and lintTypesFile are where neotex lint-config --pull stores the org's
+// rules and knowledge types for offline use
+const (
+	lintConfigFile = "lint.json"
+	lintTypesFile  = "types.json"
+)
+
+// LintFinding is a lint rule a knowledge item breaks.
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// LintFileReport lists the findings of one file, or why it could not be linted.
+type LintFileReport struct {
+	Path     string        `json:"path"`
+	Findings []LintFinding `json:"findings"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// LintReport is the result of neotex lint.
+type LintReport struct {
+	Files    []LintFileReport `json:"files"`
+	Errors   int              `json:"errors"`
+	Warnings int              `json:"warnings"`
+}
+
+// LintCmd creates the lint command.
+func LintCmd() *cobra.Command {
+	var (
+		configFile  string
+		remote      bool
+		defaultType string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint <file|dir>...",
+		Short: "Check markdown knowledge against the lint rules",
+		Long: `Checks markdown files, and the markdown files below directories, against the
+lint rules the server applies on add and update: the sections their type
+requires, a non-empty summary, a body size limit, well-formed asset:// and
+knowledge:// references, and no placeholders such as TODO outside code.
+
+Type and summary are read from the frontmatter. The rules come from --config,
+from .neotex/lint.json (see neotex lint-config --pull) or, with --remote, from
+the server; without any of them the defaults are used. Required sections come
+from the organization's knowledge types: with --remote from the server,
+otherwise from .neotex/types.json, and without it they are not checked.
+Linting is offline unless --remote is given, so references are only checked
+for their form.
+
+The command fails when any finding has severity error.`,
+		Example: `  neotex lint docs/
+  neotex lint docs/adr/0001-use-postgres.md --output
+  neotex lint docs/ --remote
+  neotex lint notes/ --type learning --config lint.json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+
+			config, err := loadLintConfig(configFile, remote)
+			if err != nil {
+				return err
+			}
+			types, err := loadLintTypes(remote)
+			if err != nil {
+				return err
+			}
+
+			report, err := lintPaths(args, config, types, defaultType)
+			if err != nil {
+				return err
+			}
+
+			if outputJSON {
+				output, _ := json.MarshalIndent(report, "", "  ")
+				fmt.Println(string(output))
+			} else {
+				printLintReport(report)
+			}
+
+			if report.Errors > 0 {
+				return fmt.Errorf("lint found %d errors", report.Errors)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&configFile, "config", "", "Lint configuration file (JSON, as written by neotex lint-config --pull)")
+	cmd.Flags().BoolVar(&remote, "remote", false, "Use the organization's rules from the server")
+	cmd.Flags().StringVarP(&defaultType, "type", "t", "", "Type of files whose frontmatter has none")
+
+	return cmd
+}
+
+// LintConfigCmd creates the lint-config command.
+func LintConfigCmd() *cobra.Command {
+	var (
+		setFile string
+		pull    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint-config",
+		Short: "Show or change the organization's lint rules",
+		Long: `Shows the lint configuration of the organization with every rule and its
+options.
+
+The mode decides what the server does with knowledge that breaks a rule of
+severity error: warn stores it and returns the findings, reject refuses it,
+and off skips linting. Each rule has a severity of error, warning or off. The
+sections each type requires are managed with neotex types.
+
+--set replaces the configuration with a JSON file; rules it leaves out keep
+their defaults. --pull saves the configuration to .neotex/lint.json and the
+knowledge types to .neotex/types.json, where neotex lint reads them.`,
+		Example: `  neotex lint-config
+  neotex lint-config --pull
+  neotex lint-config --set lint.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runLintConfig(setFile, pull, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&setFile, "set", "", "Replace the configuration with this JSON file")
+	cmd.Flags().BoolVar(&pull, "pull", false, "Save the configuration and knowledge types to .neotex for neotex lint")
+
+	return cmd
+}
+
+func runLintConfig(setFile string, pull, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	var resp *APIResponse
+	if setFile != "" {
+		config, err := readLintConfig(setFile)
+		if err != nil {
+			return err
+		}
+		resp, err = api.Put("/lint/config", config)
+		if err != nil {
+			return fmt.Errorf("failed to update lint config: %w", err)
+		}
+	} else {
+		resp, err = api.Get("/lint/config")
+		if err != nil {
+			return fmt.Errorf("failed to get lint config: %w", err)
+		}
+	}
+
+	var config domain.LintConfig
+	if err := json.Unmarshal(resp.Data, &config); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	output, _ := json.MarshalIndent(config, "", "  ")
+
+	if pull {
+		types, err := fetchKnowledgeTypes(api)
+		if err != nil {
+			return err
+		}
+		typesOutput, _ := json.MarshalIndent(types, "", "  ")
+
+		if err := os.MkdirAll(neotexDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", neotexDir, err)
+		}
+		path := filepath.Join(neotexDir, lintConfigFile)
+		if err := os.WriteFile(path, append(output, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		typesPath := filepath.Join(neotexDir, lintTypesFile)
+		if err := os.WriteFile(typesPath, append(typesOutput, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", typesPath, err)
+		}
+		if !outputJSON {
+			fmt.Printf("Saved lint config to %s and knowledge types to %s\n", path, typesPath)
+			return nil
+		}
+	}
+
+	if outputJSON {
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Mode: %s\n", config.Mode)
+	for _, name := range domain.LintRuleNames {
+		rule := config.Rule(name)
+		fmt.Printf("  %-18s %-8s", name, rule.Severity)
+		switch name {
+		case domain.LintRuleRequiredSections:
+			fmt.Printf(" per knowledge type, see neotex types list")
+		case domain.LintRuleMaxBodySize:
+			fmt.Printf(" %d bytes", rule.MaxBytes)
+		case domain.LintRuleNoPlaceholders:
+			fmt.Printf(" %s", strings.Join(rule.Placeholders, ", "))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// loadLintConfig returns the rules neotex lint applies: the file given with --config,
+// the org's rules when remote is set, .neotex/lint.json, or the defaults
+func loadLintConfig(configFile string, remote bool) (*domain.LintConfig, error) {
+	if configFile != "" {
+		return readLintConfig(configFile)
+	}
+
+	if remote {
+		api, err := NewAPIClient()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := api.Get("/lint/config")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lint config: %w", err)
+		}
+		var config domain.LintConfig
+		if err := json.Unmarshal(resp.Data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &config, nil
+	}
+
+	path := filepath.Join(neotexDir, lintConfigFile)
+	if _, err := os.Stat(path); err == nil {
+		return readLintConfig(path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return domain.DefaultLintConfig(), nil
+}
+
+// loadLintTypes returns the knowledge types whose required sections neotex lint checks:
+// the org's types when remote is set, .neotex/types.json, or none
+func loadLintTypes(remote bool) (map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition, error) {
+	var types []KnowledgeTypeInfo
+	if remote {
+		api, err := NewAPIClient()
+		if err != nil {
+			return nil, err
+		}
+		if types, err = fetchKnowledgeTypes(api); err != nil {
+			return nil, err
+		}
+	} else {
+		path := filepath.Join(neotexDir, lintTypesFile)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &types); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return lintTypeDefinitions(types), nil
+}
+
+func lintTypeDefinitions(types []KnowledgeTypeInfo) map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition {
+	defs := make(map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition, len(types))
+	for _, t := range types {
+		name := domain.KnowledgeType(t.Name)
+		defs[name] = &domain.KnowledgeTypeDefinition{Name: name, RequiredSections: t.RequiredSections}
+	}
+	return defs
+}
+
+// readLintConfig reads and validates a JSON lint configuration; a missing mode means warn
+func readLintConfig(path string) (*domain.LintConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+
+	var config domain.LintConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse lint config %s: %w", path, err)
+	}
+	if config.Mode == "" {
+		config.Mode = domain.LintModeWarn
+	}
+	if err := domain.ValidateLintConfig(&config); err != nil {
+		return nil, fmt.Errorf("invalid lint config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// lintPaths lints the given markdown files and the markdown files below the given
+// directories. types supplies the sections each type requires and may be nil.
+func lintPaths(paths []string, config *domain.LintConfig, types map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition, defaultType string) (*LintReport, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		found, err := collectMarkdownFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+
+	report := &LintReport{Files: make([]LintFileReport, 0, len(files))}
+	for _, path := range files {
+		file := lintFile(path, config, types, defaultType)
+		for _, f := range file.Findings {
+			switch domain.LintSeverity(f.Severity) {
+			case domain.LintSeverityError:
+				report.Errors++
+			case domain.LintSeverityWarning:
+				report.Warnings++
+			}
+		}
+		if file.Error != "" {
+			report.Errors++
+		}
+		report.Files = append(report.Files, file)
+	}
+	return report, nil
+}
+
+// lintFile lints one markdown file. Line numbers are those of the file, counting the
+// frontmatter.
+func lintFile(path string, config *domain.LintConfig, types map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition, defaultType string) LintFileReport {
+	file := LintFileReport{Path: filepath.ToSlash(path), Findings: []LintFinding{}}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to read file: %v", err)
+		return file
+	}
+
+	meta, body, err := frontmatter.Parse(content)
+	if err != nil {
+		file.Error = err.Error()
+		return file
+	}
+
+	k := &domain.Knowledge{
+		Type:    domain.KnowledgeType(meta.Type),
+		Title:   meta.Title,
+		Summary: meta.Summary,
+		BodyMD:  body,
+	}
+	if k.Type == "" {
+		k.Type = domain.KnowledgeType(defaultType)
+	}
+
+	offset := frontmatterLines(content, body)
+	for _, f := range domain.LintKnowledge(config, types[k.Type], k) {
+		finding := LintFinding{Rule: f.Rule, Severity: string(f.Severity), Message: f.Message}
+		if f.Line > 0 {
+			finding.Line = f.Line + offset
+		}
+		file.Findings = append(file.Findings, finding)
+	}
+	return file
+}
+
+// frontmatterLines returns how many lines of content come before body, which
+// frontmatter.Parse returns as the end of content with line endings normalized
+func frontmatterLines(content []byte, body string) int {
+	text := strings.ReplaceAll(strings.TrimPrefix(string(content), "\xef\xbb\xbf"), "\r\n", "\n")
+	if len(body) > len(text) {
+		return 0
+	}
+	return strings.Count(text[:len(text)-len(body)], "\n")
+}
+
+func printLintReport(report *LintReport) {
+	for _, file := range report.Files {
+		if file.Error != "" {
+			fmt.Printf("%s: error: %s\n", file.Path, file.Error)
+		}
+		for _, f := range file.Findings {
+			location := file.Path
+			if f.Line > 0 {
+				location = fmt.Sprintf("%s:%d", file.Path, f.Line)
+			}
+			fmt.Printf("%s: %s: %s [%s]\n", location, f.Severity, f.Message, f.Rule)
+		}
+	}
+
+	fmt.Printf("\n%d files checked: %d errors, %d warnings\n", len(report.Files), report.Errors, report.Warnings)
+}
+
+// printLintFindings lists the findings the server reported for a stored item
+func printLintFindings(findings []LintFinding) {
+	for _, f := range findings {
+		if f.Line > 0 {
+			fmt.Printf("Lint %s: line %d: %s [%s]\n", f.Severity, f.Line, f.Message, f.Rule)
+		} else {
+			fmt.Printf("Lint %s: %s [%s]\n", f.Severity, f.Message, f.Rule)
+		}
+	}
+}
+
+// reportLintFailure explains a write the server refused because the item breaks lint
+// rules in reject mode. It returns nil when apiErr is not such a refusal.
+func reportLintFailure(apiErr *APIError, outputJSON bool) error {
+	if apiErr.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+	var failure struct {
+		Findings []LintFinding `json:"findings"`
+	}
+	if json.Unmarshal(apiErr.Data, &failure) != nil || len(failure.Findings) == 0 {
+		return nil
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]interface{}{
+			"error":    "lint",
+			"findings": failure.Findings,
+		}, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		printLintFindings(failure.Findings)
+	}
+	return fmt.Errorf("knowledge breaks the lint rules of this organization")
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLintFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"decisions/postgres.md": "---\ntype: decision\ntitle: Use Postgres\nsummary: Why Postgres.\n---\n\n## Context\n\nWe need a database. TODO\n",
+		"deploys.md":            "---\ntype: guideline\nsummary: When we deploy.\n---\nDeploy on Tuesdays.\n",
+		"crlf.md":               "\xef\xbb\xbf---\r\ntype: guideline\r\n---\r\nSee asset://not-an-id.\r\n",
+		"notes.txt":             "TODO",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func findingsByFile(report *LintReport) map[string][]LintFinding {
+	byFile := make(map[string][]LintFinding)
+	for _, file := range report.Files {
+		byFile[filepath.Base(file.Path)] = file.Findings
+	}
+	return byFile
+}
+
+func lintFixtureTypes() map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition {
+	return lintTypeDefinitions([]KnowledgeTypeInfo{
+		{Name: "decision", RequiredSections: []string{"Context", "Decision", "Consequences"}},
+		{Name: "guideline"},
+	})
+}
+
+func TestLintPaths(t *testing.T) {
+	dir := writeLintFixture(t)
+
+	report, err := lintPaths([]string{dir}, domain.DefaultLintConfig(), lintFixtureTypes(), "learning")
+	require.NoError(t, err)
+
+	byFile := findingsByFile(report)
+	require.Len(t, byFile, 3)
+	assert.Empty(t, byFile["deploys.md"])
+	assert.Equal(t, []LintFinding{
+		{Rule: "required-sections", Severity: "error", Message: "decision is missing sections: Decision, Consequences"},
+		{Rule: "no-placeholders", Severity: "warning", Message: "placeholder TODO", Line: 9},
+	}, byFile["postgres.md"])
+
+	require.Len(t, byFile["crlf.md"], 2)
+	assert.Equal(t, "summary-required", byFile["crlf.md"][0].Rule)
+	assert.Equal(t, "broken-references", byFile["crlf.md"][1].Rule)
+	assert.Equal(t, 4, byFile["crlf.md"][1].Line)
+
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, 2, report.Warnings)
+}
+
+func TestLintPaths_File(t *testing.T) {
+	dir := writeLintFixture(t)
+	config := &domain.LintConfig{Mode: domain.LintModeWarn, Rules: map[string]domain.LintRule{
+		domain.LintRuleNoPlaceholders: {Severity: domain.LintSeverityOff},
+	}}
+
+	report, err := lintPaths([]string{filepath.Join(dir, "decisions", "postgres.md")}, config, lintFixtureTypes(), "learning")
+	require.NoError(t, err)
+
+	require.Len(t, report.Files, 1)
+	require.Len(t, report.Files[0].Findings, 1)
+	assert.Equal(t, "required-sections", report.Files[0].Findings[0].Rule)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, 0, report.Warnings)
+
+	_, err = lintPaths([]string{filepath.Join(dir, "missing.md")}, config, lintFixtureTypes(), "learning")
+	assert.Error(t, err)
+
+	// Without the org's types there are no required sections to check
+	report, err = lintPaths([]string{filepath.Join(dir, "decisions", "postgres.md")}, config, nil, "learning")
+	require.NoError(t, err)
+	assert.Empty(t, report.Files[0].Findings)
+}
+
+func TestLintPaths_InvalidFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\ntype: [\n---\nBody\n"), 0644))
+
+	report, err := lintPaths([]string{path}, domain.DefaultLintConfig(), nil, "learning")
+	require.NoError(t, err)
+
+	require.Len(t, report.Files, 1)
+	assert.NotEmpty(t, report.Files[0].Error)
+	assert.Equal(t, 1, report.Errors)
+}
+
+func TestReadLintConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "lint.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":{"max-body-size":{"max_bytes":1024}}}`), 0644))
+	config, err := readLintConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LintModeWarn, config.Mode)
+	assert.Equal(t, 1024, config.Rule(domain.LintRuleMaxBodySize).MaxBytes)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"mode":"warn","rules":{"no-swearing":{}}}`), 0644))
+	_, err = readLintConfig(invalid)
+	assert.ErrorContains(t, err, "no-swearing")
+}
+
+func TestReportLintFailure(t *testing.T) {
+	data, err := json.Marshal(map[string]interface{}{"findings": []LintFinding{
+		{Rule: "summary-required", Severity: "error", Message: "summary is empty"},
+	}})
+	require.NoError(t, err)
+
+	err = reportLintFailure(&APIError{StatusCode: http.StatusBadRequest, Message: "lint", Data: data}, true)
+	assert.ErrorContains(t, err, "lint rules")
+
+	assert.NoError(t, reportLintFailure(&APIError{StatusCode: http.StatusBadRequest, Message: "title is required"}, true))
+	assert.NoError(t, reportLintFailure(&APIError{StatusCode: http.StatusConflict, Data: data}, true))
+}
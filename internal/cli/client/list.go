@@ -15,6 +15,9 @@ type ListAPIRequest struct {
 	Type         string `json:"type,omitempty"`
 	Status       string `json:"status,omitempty"`
 	SourceType   string `json:"source_type,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	TagMatch     string `json:"tag_match,omitempty"`
+	Author       string `json:"author,omitempty"`
 	UpdatedSince string `json:"updated_since,omitempty"`
 	Limit        int    `json:"limit,omitempty"`
 	Cursor       string `json:"cursor,omitempty"`
@@ -27,7 +30,10 @@ type ListItemResponse struct {
 	Scope      string `json:"scope,omitempty"`
 	Type       string `json:"type,omitempty"`
 	Status     string `json:"status,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
 	SourceType string `json:"source_type"`
+	CreatedBy  string `json:"created_by,omitempty"`
+	UpdatedBy  string `json:"updated_by,omitempty"`
 	UpdatedAt  string `json:"updated_at,omitempty"`
 	ChunkCount int    `json:"chunk_count,omitempty"`
 	Filename   string `json:"filename,omitempty"`
@@ -50,6 +56,9 @@ func ListCmd() *cobra.Command {
 		sourceType   string
 		since        string
 		projectID    string
+		author       string
+		tags         []string
+		allTags      bool
 		limit        int
 		cursor       string
 	)
@@ -60,7 +69,7 @@ func ListCmd() *cobra.Command {
 		Long:  "Lists metadata for knowledge items and/or assets with filtering.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outputJSON, _ := cmd.Flags().GetBool("output")
-			return runList(pathPrefix, knowledgeType, status, sourceType, since, projectID, limit, cursor, outputJSON)
+			return runList(pathPrefix, knowledgeType, status, sourceType, since, projectID, author, tags, allTags, limit, cursor, outputJSON)
 		},
 	}
 
@@ -68,6 +77,9 @@ func ListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Filter by knowledge type")
 	cmd.Flags().StringVar(&status, "status", "", "Filter by knowledge status")
 	cmd.Flags().StringVar(&sourceType, "source", "", "Filter by source type (knowledge|asset|all)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Filter by tag (repeatable or comma-separated; assets match on keywords)")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Require every tag instead of any of them")
+	cmd.Flags().StringVar(&author, "author", "", "Filter knowledge created or last updated by this author (leaves out assets)")
 	cmd.Flags().StringVar(&since, "since", "", "Filter by updated_since (RFC3339 format)")
 	cmd.Flags().StringVar(&projectID, "project", "", "Override project ID from config")
 	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Maximum number of results")
@@ -76,7 +88,7 @@ func ListCmd() *cobra.Command {
 	return cmd
 }
 
-func runList(pathPrefix, knowledgeType, status, sourceType, since, projectID string, limit int, cursor string, outputJSON bool) error {
+func runList(pathPrefix, knowledgeType, status, sourceType, since, projectID, author string, tags []string, allTags bool, limit int, cursor string, outputJSON bool) error {
 	// Load config to get project ID
 	config, err := LoadConfig()
 	if err != nil {
@@ -99,11 +111,17 @@ func runList(pathPrefix, knowledgeType, status, sourceType, since, projectID str
 		Type:         knowledgeType,
 		Status:       status,
 		SourceType:   sourceType,
+		Tags:         tags,
+		Author:       author,
 		UpdatedSince: since,
 		Limit:        limit,
 		Cursor:       cursor,
 	}
 
+	if allTags {
+		req.TagMatch = "all"
+	}
+
 	resp, err := api.Post("/context/list", req)
 	if err != nil {
 		return fmt.Errorf("list failed: %w", err)
@@ -135,6 +153,12 @@ func runList(pathPrefix, knowledgeType, status, sourceType, since, projectID str
 		if item.Type != "" {
 			fmt.Printf("   Type: %s, Status: %s\n", item.Type, item.Status)
 		}
+		if len(item.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(item.Tags, ", "))
+		}
+		if item.UpdatedBy != "" {
+			fmt.Printf("   Updated by: %s\n", item.UpdatedBy)
+		}
 		if item.ChunkCount > 0 {
 			fmt.Printf("   Chunks: %d\n", item.ChunkCount)
 		}
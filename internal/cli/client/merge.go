@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// MergeRequest represents the merge knowledge API request.
+type MergeRequest struct {
+	SourceID string `json:"source_id"`
+	Mode     string `json:"mode,omitempty"`
+}
+
+// MovedReferences counts the references moved from the merged item.
+type MovedReferences struct {
+	AssetLinks int `json:"asset_links"`
+	Relations  int `json:"relations"`
+	SearchLogs int `json:"search_logs"`
+}
+
+// MergeResponse represents the merge knowledge API response.
+type MergeResponse struct {
+	Knowledge Knowledge       `json:"knowledge"`
+	Source    Knowledge       `json:"source"`
+	Moved     MovedReferences `json:"moved"`
+}
+
+// MergeCmd creates the merge command.
+func MergeCmd() *cobra.Command {
+	var replace bool
+
+	cmd := &cobra.Command{
+		Use:   "merge <target_id> <source_id>",
+		Short: "Merge one knowledge item into another",
+		Long: `Folds the source item into the target item.
+
+The source body is appended to the target body, or replaces it with --replace,
+and the target gets a new version. Asset links, relations and search-log
+references move to the target, and the source is deprecated as superseded by
+the target. The version history of both items is kept.`,
+		Example: `  # Fold a duplicate into the item that should remain
+  neotex merge <target_id> <duplicate_id>
+
+  # Keep only the duplicate's body
+  neotex merge <target_id> <duplicate_id> --replace`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runMerge(args[0], args[1], replace, outputJSON)
+		},
+	}
+
+	cmd.Flags().BoolVar(&replace, "replace", false, "Replace the target body with the source body instead of appending")
+
+	return cmd
+}
+
+func runMerge(targetID, sourceID string, replace, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	req := MergeRequest{SourceID: sourceID}
+	if replace {
+		req.Mode = "replace"
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/merge", targetID), req)
+	if err != nil {
+		return fmt.Errorf("failed to merge knowledge: %w", err)
+	}
+
+	var result MergeResponse
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Merged %s into %s (version %d)\n", result.Source.ID, result.Knowledge.ID, result.Knowledge.Version)
+	fmt.Printf("Title: %s\n", result.Knowledge.Title)
+	fmt.Printf("Moved %d asset links, %d relations and %d search log references\n",
+		result.Moved.AssetLinks, result.Moved.Relations, result.Moved.SearchLogs)
+	fmt.Printf("Deprecated: %s\n", result.Source.ID)
+	return nil
+}
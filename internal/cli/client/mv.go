@@ -0,0 +1,114 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// MoveRequest represents the move knowledge API request.
+type MoveRequest struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	ProjectID string `json:"project_id,omitempty"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+// ScopeMove is one item whose scope was, or would be, moved.
+type ScopeMove struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	OldScope string `json:"old_scope"`
+	NewScope string `json:"new_scope"`
+	Version  int64  `json:"version,omitempty"`
+}
+
+// MoveResponse represents the move knowledge API response.
+type MoveResponse struct {
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	DryRun bool        `json:"dry_run"`
+	Total  int         `json:"total"`
+	Items  []ScopeMove `json:"items"`
+}
+
+// MvCmd creates the mv command.
+func MvCmd() *cobra.Command {
+	var dryRun, allProjects bool
+
+	cmd := &cobra.Command{
+		Use:   "mv <old_scope> <new_scope>",
+		Short: "Move knowledge to a new scope prefix",
+		Long: `Rewrites the scope of every knowledge item at or below a path prefix.
+
+Prefixes match whole path segments: moving services/auth also moves
+services/auth/handlers/*.go but not services/authz. All items are moved in
+one transaction and each gets a new version recording the move; the content
+is unchanged, so nothing is re-embedded. Deprecated items keep their scope.
+
+Only items of the current project are moved unless --all-projects is set.`,
+		Example: `  # Preview what would move
+  neotex mv services/auth platform/identity --dry-run
+
+  # Move across every project of the organization
+  neotex mv apps/web apps/site --all-projects`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runMv(args[0], args[1], dryRun, allProjects, outputJSON)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the items that would move without changing them")
+	cmd.Flags().BoolVar(&allProjects, "all-projects", false, "Move items of every project, not only the current one")
+
+	return cmd
+}
+
+func runMv(from, to string, dryRun, allProjects, outputJSON bool) error {
+	req := MoveRequest{From: from, To: to, DryRun: dryRun}
+	if !allProjects {
+		config, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		req.ProjectID = config.ProjectID
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post("/knowledge/move", req)
+	if err != nil {
+		return fmt.Errorf("failed to move knowledge: %w", err)
+	}
+
+	var result MoveResponse
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if result.Total == 0 {
+		fmt.Printf("No knowledge scoped to %s\n", result.From)
+		return nil
+	}
+
+	for _, item := range result.Items {
+		fmt.Printf("%s  %s -> %s  %s\n", item.ID, item.OldScope, item.NewScope, item.Title)
+	}
+	if result.DryRun {
+		fmt.Printf("\n%d items would move (dry run)\n", result.Total)
+	} else {
+		fmt.Printf("\nMoved %d items from %s to %s\n", result.Total, result.From, result.To)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// RenderTemplateRequest represents the render template API request.
+type RenderTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Version   int64             `json:"version,omitempty"`
+}
+
+// InstantiateTemplateRequest represents the instantiate template API request.
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Version   int64             `json:"version,omitempty"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary,omitempty"`
+	ProjectID string            `json:"project_id,omitempty"`
+	Scope     string            `json:"scope,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+}
+
+// TemplateVariable describes a placeholder of a template.
+type TemplateVariable struct {
+	Name     string `json:"name"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required"`
+}
+
+// RenderedTemplate represents the render template API response.
+type RenderedTemplate struct {
+	TemplateID      string             `json:"template_id"`
+	TemplateVersion int64              `json:"template_version"`
+	BodyMD          string             `json:"body_md"`
+	Variables       []TemplateVariable `json:"variables"`
+}
+
+// NewCmd creates the new command.
+func NewCmd() *cobra.Command {
+	var (
+		templateID    string
+		vars          []string
+		varsFile      string
+		version       int64
+		knowledgeType string
+		title         string
+		summary       string
+		scope         string
+		tags          []string
+		outFile       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "new",
+		Short: "Create knowledge from a template",
+		Long: `Renders a template-type knowledge item and creates the result as a new
+draft knowledge item, or writes it to a local file with --out.
+
+Templates use {{name}} placeholders for required variables and
+{{name|default}} for optional ones. Values come from --var flags and/or a
+JSON object given with --vars (a file path, or - for stdin); --var wins.
+The title and summary may use the same placeholders.
+
+The new item records the template and the version it was rendered from.`,
+		Example: `  neotex new --from-template <template_id> --type learning --title "{{service}} outage" --var service=checkout
+  echo '{"service":"checkout","severity":"SEV1"}' | neotex new --from-template <template_id> --vars - --type learning --title "Checkout outage"
+  neotex new --from-template <template_id> --var service=checkout --out postmortem.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+
+			values, err := parseTemplateVars(vars, varsFile)
+			if err != nil {
+				return err
+			}
+
+			if outFile != "" {
+				return runNewToFile(templateID, values, version, outFile, outputJSON)
+			}
+			return runNew(templateID, values, version, knowledgeType, title, summary, scope, tags, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&templateID, "from-template", "", "ID of the template to render (required)")
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Template variable as name=value (repeatable)")
+	cmd.Flags().StringVar(&varsFile, "vars", "", "JSON object of template variables (file path, or - for stdin)")
+	cmd.Flags().Int64Var(&version, "version", 0, "Template version to render (default: latest)")
+	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Type of the new knowledge item")
+	cmd.Flags().StringVar(&title, "title", "", "Title of the new knowledge item")
+	cmd.Flags().StringVar(&summary, "summary", "", "Summary (optional)")
+	cmd.Flags().StringVar(&scope, "scope", "", "Scope (file path pattern)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&outFile, "out", "", "Write the rendered markdown to a file instead of creating knowledge")
+	_ = cmd.MarkFlagRequired("from-template")
+
+	return cmd
+}
+
+// parseTemplateVars merges the JSON variables file with name=value flags; flags take precedence
+func parseTemplateVars(vars []string, varsFile string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	if varsFile != "" {
+		var data []byte
+		var err error
+		if varsFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(varsFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variables: %w", err)
+		}
+
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse variables: expected a JSON object: %w", err)
+		}
+		for name, value := range raw {
+			if value == nil {
+				continue
+			}
+			if s, ok := value.(string); ok {
+				values[name] = s
+			} else {
+				values[name] = fmt.Sprint(value)
+			}
+		}
+	}
+
+	for _, v := range vars {
+		name, value, ok := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected name=value", v)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+func runNew(templateID string, values map[string]string, version int64, knowledgeType, title, summary, scope string, tags []string, outputJSON bool) error {
+	if knowledgeType == "" {
+		return fmt.Errorf("--type is required (or use --out to write a file)")
+	}
+	if title == "" {
+		return fmt.Errorf("--title is required (or use --out to write a file)")
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/instantiate", templateID), InstantiateTemplateRequest{
+		Variables: values,
+		Version:   version,
+		Type:      knowledgeType,
+		Title:     title,
+		Summary:   summary,
+		ProjectID: config.ProjectID,
+		Scope:     scope,
+		Tags:      tags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create knowledge from template: %w", err)
+	}
+
+	var knowledge Knowledge
+	if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(knowledge, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Created knowledge: %s\n", knowledge.ID)
+		fmt.Printf("Title: %s\n", knowledge.Title)
+		fmt.Printf("Type: %s\n", knowledge.Type)
+		fmt.Printf("Template: %s (v%d)\n", knowledge.TemplateID, knowledge.TemplateVersion)
+	}
+
+	return nil
+}
+
+func runNewToFile(templateID string, values map[string]string, version int64, outFile string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/render", templateID), RenderTemplateRequest{
+		Variables: values,
+		Version:   version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	var rendered RenderedTemplate
+	if err := json.Unmarshal(resp.Data, &rendered); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	content := templateProvenanceComment(rendered.TemplateID, rendered.TemplateVersion) + rendered.BodyMD
+	if err := os.WriteFile(outFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]interface{}{
+			"file":             outFile,
+			"template_id":      rendered.TemplateID,
+			"template_version": rendered.TemplateVersion,
+		}, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Wrote %s from template %s (v%d)\n", outFile, rendered.TemplateID, rendered.TemplateVersion)
+	}
+
+	return nil
+}
+
+// templateProvenanceComment is prepended to rendered files so they can be traced back to the template
+func templateProvenanceComment(templateID string, version int64) string {
+	return fmt.Sprintf("<!-- neotex template: %s version: %d -->\n", templateID, version)
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplateVars(t *testing.T) {
+	dir := t.TempDir()
+	varsFile := filepath.Join(dir, "vars.json")
+	require.NoError(t, os.WriteFile(varsFile, []byte(`{"service":"checkout","severity":1,"region":null}`), 0644))
+
+	values, err := parseTemplateVars([]string{"service=payments", "note=a=b"}, varsFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"service":  "payments",
+		"severity": "1",
+		"note":     "a=b",
+	}, values)
+
+	values, err = parseTemplateVars(nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = parseTemplateVars([]string{"service"}, "")
+	assert.Error(t, err)
+
+	_, err = parseTemplateVars(nil, filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestTemplateProvenanceComment(t *testing.T) {
+	assert.Equal(t, "<!-- neotex template: tpl-1 version: 3 -->\n", templateProvenanceComment("tpl-1", 3))
+}
@@ -10,11 +10,12 @@ import (
 
 // OpenRequest represents the open API request.
 type OpenRequest struct {
-	ID         string        `json:"id"`
-	SourceType string        `json:"source_type,omitempty"`
-	ChunkID    string        `json:"chunk_id,omitempty"`
-	Range      *ContentRange `json:"range,omitempty"`
-	IncludeURL bool          `json:"include_url,omitempty"`
+	ID              string        `json:"id"`
+	SourceType      string        `json:"source_type,omitempty"`
+	ChunkID         string        `json:"chunk_id,omitempty"`
+	Range           *ContentRange `json:"range,omitempty"`
+	IncludeURL      bool          `json:"include_url,omitempty"`
+	IncludeComments bool          `json:"include_comments,omitempty"`
 }
 
 // ContentRange specifies a portion of content to retrieve.
@@ -26,22 +27,27 @@ type ContentRange struct {
 
 // OpenResponse represents the open API response.
 type OpenResponse struct {
-	ID          string   `json:"id"`
-	SourceType  string   `json:"source_type"`
-	Title       string   `json:"title"`
-	Content     string   `json:"content,omitempty"`
-	TotalLines  int      `json:"total_lines,omitempty"`
-	TotalChars  int      `json:"total_chars,omitempty"`
-	ChunkID     string   `json:"chunk_id,omitempty"`
-	ChunkIndex  int      `json:"chunk_index,omitempty"`
-	ChunkCount  int      `json:"chunk_count,omitempty"`
-	UpdatedAt   string   `json:"updated_at,omitempty"`
-	Filename    string   `json:"filename,omitempty"`
-	MimeType    string   `json:"mime_type,omitempty"`
-	SizeBytes   int64    `json:"size_bytes,omitempty"`
-	Description string   `json:"description,omitempty"`
-	Keywords    []string `json:"keywords,omitempty"`
-	DownloadURL string   `json:"download_url,omitempty"`
+	ID           string           `json:"id"`
+	SourceType   string           `json:"source_type"`
+	Title        string           `json:"title"`
+	Content      string           `json:"content,omitempty"`
+	TotalLines   int              `json:"total_lines,omitempty"`
+	TotalChars   int              `json:"total_chars,omitempty"`
+	ChunkID      string           `json:"chunk_id,omitempty"`
+	ChunkIndex   int              `json:"chunk_index,omitempty"`
+	ChunkCount   int              `json:"chunk_count,omitempty"`
+	UpdatedAt    string           `json:"updated_at,omitempty"`
+	Filename     string           `json:"filename,omitempty"`
+	MimeType     string           `json:"mime_type,omitempty"`
+	SizeBytes    int64            `json:"size_bytes,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	Keywords     []string         `json:"keywords,omitempty"`
+	DownloadURL  string           `json:"download_url,omitempty"`
+	SupersededBy *SupersessionRef `json:"superseded_by,omitempty"`
+	Stale        bool             `json:"stale,omitempty"`
+	StaleSince   string           `json:"stale_since,omitempty"`
+	Owner        string           `json:"owner,omitempty"`
+	Comments     []CommentThread  `json:"comments,omitempty"`
 }
 
 // OpenCmd creates the context open command.
@@ -52,6 +58,7 @@ func OpenCmd() *cobra.Command {
 		lines      string
 		maxChars   int
 		includeURL bool
+		comments   bool
 	)
 
 	cmd := &cobra.Command{
@@ -61,7 +68,7 @@ func OpenCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outputJSON, _ := cmd.Flags().GetBool("output")
-			return runOpen(args[0], sourceType, chunkID, lines, maxChars, includeURL, outputJSON)
+			return runOpen(args[0], sourceType, chunkID, lines, maxChars, includeURL, comments, outputJSON)
 		},
 	}
 
@@ -70,21 +77,23 @@ func OpenCmd() *cobra.Command {
 	cmd.Flags().StringVar(&lines, "lines", "", "Line range (e.g., 0:100)")
 	cmd.Flags().IntVar(&maxChars, "max-chars", 4000, "Maximum characters to return")
 	cmd.Flags().BoolVar(&includeURL, "include-url", false, "Include presigned download URL for assets")
+	cmd.Flags().BoolVar(&comments, "comments", false, "Include unresolved comment threads on the item")
 
 	return cmd
 }
 
-func runOpen(id, sourceType, chunkID, lines string, maxChars int, includeURL, outputJSON bool) error {
+func runOpen(id, sourceType, chunkID, lines string, maxChars int, includeURL, comments, outputJSON bool) error {
 	api, err := NewAPIClient()
 	if err != nil {
 		return err
 	}
 
 	req := OpenRequest{
-		ID:         id,
-		SourceType: sourceType,
-		ChunkID:    chunkID,
-		IncludeURL: includeURL,
+		ID:              id,
+		SourceType:      sourceType,
+		ChunkID:         chunkID,
+		IncludeURL:      includeURL,
+		IncludeComments: comments,
 	}
 
 	// Parse line range
@@ -124,6 +133,15 @@ func runOpen(id, sourceType, chunkID, lines string, maxChars int, includeURL, ou
 	fmt.Printf("ID: %s\n", openResp.ID)
 	fmt.Printf("Title: %s\n", openResp.Title)
 	fmt.Printf("Type: %s\n", openResp.SourceType)
+	if openResp.SupersededBy != nil {
+		fmt.Printf("Superseded by: %s (%s)\n", openResp.SupersededBy.Title, openResp.SupersededBy.ID)
+	}
+	if openResp.Stale {
+		fmt.Printf("Stale: overdue for review since %s\n", openResp.StaleSince)
+	}
+	if openResp.Owner != "" {
+		fmt.Printf("Owner: %s\n", openResp.Owner)
+	}
 
 	if openResp.SourceType == "asset" {
 		if openResp.Filename != "" {
@@ -158,6 +176,13 @@ func runOpen(id, sourceType, chunkID, lines string, maxChars int, includeURL, ou
 			fmt.Println(strings.Repeat("-", 40))
 			fmt.Println(openResp.Content)
 		}
+		if len(openResp.Comments) > 0 {
+			fmt.Println(strings.Repeat("-", 40))
+			fmt.Printf("Open comments (%d):\n", len(openResp.Comments))
+			for _, thread := range openResp.Comments {
+				printCommentThread(thread)
+			}
+		}
 	}
 
 	return nil
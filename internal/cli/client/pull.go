@@ -11,11 +11,12 @@ import (
 
 // ManifestItem represents a knowledge item in the manifest.
 type ManifestItem struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Summary string `json:"summary"`
-	Type    string `json:"type"`
-	Scope   string `json:"scope,omitempty"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Summary  string `json:"summary"`
+	Type     string `json:"type"`
+	TypeName string `json:"type_name,omitempty"`
+	Scope    string `json:"scope,omitempty"`
 }
 
 // Manifest represents the full manifest response.
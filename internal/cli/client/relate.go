@@ -0,0 +1,246 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateRelationRequest represents the create relation API request.
+type CreateRelationRequest struct {
+	TargetID string `json:"target_id"`
+	Type     string `json:"type"`
+}
+
+// Relation represents a relation between two knowledge items.
+type Relation struct {
+	ID        string `json:"id"`
+	SourceID  string `json:"source_id"`
+	TargetID  string `json:"target_id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+}
+
+// RelatedKnowledge represents a knowledge item linked to another one.
+// Direction is "outgoing" for links the item declares and "incoming" for backlinks.
+type RelatedKnowledge struct {
+	RelationID    string `json:"relation_id"`
+	Type          string `json:"type"`
+	Direction     string `json:"direction"`
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Summary       string `json:"summary,omitempty"`
+	Scope         string `json:"scope,omitempty"`
+	KnowledgeType string `json:"knowledge_type"`
+	Status        string `json:"status"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// RelationListResponse represents the list relations API response.
+type RelationListResponse struct {
+	Items []RelatedKnowledge `json:"items"`
+}
+
+// RelateCmd creates the relate command.
+func RelateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relate",
+		Short: "Manage relations between knowledge items",
+		Long: `Commands for linking knowledge items to each other.
+
+Relation types:
+  relates_to   General association
+  depends_on   The source item relies on the target
+  refines      The source item narrows or elaborates the target
+  contradicts  The items give conflicting guidance
+
+Relations are directed; the target sees them as backlinks.`,
+	}
+
+	cmd.AddCommand(RelateAddCmd())
+	cmd.AddCommand(RelateRemoveCmd())
+	cmd.AddCommand(RelateListCmd())
+
+	return cmd
+}
+
+// RelateAddCmd creates the relate add command.
+func RelateAddCmd() *cobra.Command {
+	var relationType string
+
+	cmd := &cobra.Command{
+		Use:   "add <source_id> <target_id>",
+		Short: "Link a knowledge item to another",
+		Example: `  neotex relate add <guideline_id> <decision_id> --type depends_on
+  neotex relate add <new_id> <old_id> --type contradicts`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runRelateAdd(args[0], args[1], relationType, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVarP(&relationType, "type", "t", "relates_to", "Relation type (relates_to|depends_on|refines|contradicts)")
+
+	return cmd
+}
+
+// RelateRemoveCmd creates the relate remove command.
+func RelateRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <knowledge_id> <relation_id>",
+		Short:   "Remove a relation from a knowledge item",
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runRelateRemove(args[0], args[1], outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+// RelateListCmd creates the relate list command.
+func RelateListCmd() *cobra.Command {
+	var direction string
+
+	cmd := &cobra.Command{
+		Use:   "list <knowledge_id>",
+		Short: "List relations and backlinks of a knowledge item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runRelateList(args[0], direction, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&direction, "direction", "", "Only show outgoing relations or incoming backlinks (outgoing|incoming)")
+
+	return cmd
+}
+
+func runRelateAdd(sourceID, targetID, relationType string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/relations", sourceID), CreateRelationRequest{
+		TargetID: targetID,
+		Type:     relationType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create relation: %w", err)
+	}
+
+	var relation Relation
+	if err := json.Unmarshal(resp.Data, &relation); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(relation, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Related: %s %s %s\n", relation.SourceID, relation.Type, relation.TargetID)
+		fmt.Printf("Relation ID: %s\n", relation.ID)
+	}
+
+	return nil
+}
+
+func runRelateRemove(knowledgeID, relationID string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := api.Delete(fmt.Sprintf("/knowledge/%s/relations/%s", knowledgeID, relationID)); err != nil {
+		return fmt.Errorf("failed to remove relation: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]string{
+			"id":     relationID,
+			"status": "deleted",
+		}, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Removed relation: %s\n", relationID)
+	}
+
+	return nil
+}
+
+func runRelateList(knowledgeID, direction string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	related, err := fetchRelations(api, knowledgeID, direction)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(RelationListResponse{Items: related}, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(related) == 0 {
+		fmt.Println("No relations.")
+		return nil
+	}
+
+	printRelations(related)
+	return nil
+}
+
+func fetchRelations(api *APIClient, knowledgeID, direction string) ([]RelatedKnowledge, error) {
+	path := fmt.Sprintf("/knowledge/%s/relations", knowledgeID)
+	if direction != "" {
+		path += "?direction=" + url.QueryEscape(direction)
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relations: %w", err)
+	}
+
+	var listResp RelationListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to parse relations: %w", err)
+	}
+
+	return listResp.Items, nil
+}
+
+// printRelations prints outgoing relations followed by backlinks
+func printRelations(related []RelatedKnowledge) {
+	var outgoing, incoming []RelatedKnowledge
+	for _, r := range related {
+		if r.Direction == "incoming" {
+			incoming = append(incoming, r)
+		} else {
+			outgoing = append(outgoing, r)
+		}
+	}
+
+	if len(outgoing) > 0 {
+		fmt.Println("Relations:")
+		for _, r := range outgoing {
+			fmt.Printf("  %s -> %s [%s] (id %s, relation %s)\n", r.Type, r.Title, r.KnowledgeType, r.ID, r.RelationID)
+		}
+	}
+	if len(incoming) > 0 {
+		fmt.Println("Backlinks:")
+		for _, r := range incoming {
+			fmt.Printf("  %s <- %s [%s] (id %s, relation %s)\n", r.Type, r.Title, r.KnowledgeType, r.ID, r.RelationID)
+		}
+	}
+}
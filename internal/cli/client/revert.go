@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// RevertRequest represents the revert API request.
+type RevertRequest struct {
+	Version int64 `json:"version"`
+}
+
+// RevertResponse represents the revert API response.
+type RevertResponse struct {
+	Knowledge Knowledge        `json:"knowledge"`
+	Version   KnowledgeVersion `json:"version"`
+}
+
+// RevertCmd creates the revert command.
+func RevertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revert <knowledge_id> <version>",
+		Short: "Revert a knowledge item to an earlier version",
+		Long: `Restores the content of an earlier version as a new version.
+
+History is never rewritten: the revert is recorded as the next version
+and the item is re-embedded.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			version, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || version <= 0 {
+				return fmt.Errorf("invalid version %q", args[1])
+			}
+			return runRevert(args[0], version, outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+func runRevert(knowledgeID string, version int64, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/revert", knowledgeID), RevertRequest{Version: version})
+	if err != nil {
+		return fmt.Errorf("failed to revert knowledge: %w", err)
+	}
+
+	var result RevertResponse
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Reverted %s to v%d (now v%d)\n", result.Knowledge.ID, version, result.Version.VersionNumber)
+		fmt.Printf("Title: %s\n", result.Knowledge.Title)
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ReviewRequest represents the approve/reject API request. The server records the API
+// key of the request as the reviewer.
+type ReviewRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// KnowledgeListResponse represents a paginated list of knowledge items.
+type KnowledgeListResponse struct {
+	Items   []Knowledge `json:"items"`
+	Cursor  string      `json:"cursor,omitempty"`
+	HasMore bool        `json:"has_more"`
+}
+
+// ReviewCmd creates the review command.
+func ReviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Review workflow commands",
+		Long: `Commands for reviewing knowledge items awaiting sign-off.
+
+New knowledge starts as a draft. A reviewer approves it to make it team
+guidance, or rejects it with a note. Editing a rejected item puts it back
+in the review queue.
+
+The reviewer is the API key the command runs with, named by NEOTEX_AGENT or
+the key's name. A draft cannot be approved with the key that wrote it, and
+drafts only show up in search and context when asked for by status.`,
+	}
+
+	cmd.AddCommand(ReviewListCmd())
+	cmd.AddCommand(ReviewApproveCmd())
+	cmd.AddCommand(ReviewRejectCmd())
+
+	return cmd
+}
+
+// ReviewListCmd creates the review list command.
+func ReviewListCmd() *cobra.Command {
+	var (
+		projectID string
+		limit     int
+		cursor    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List knowledge items pending review",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runReviewList(projectID, limit, cursor, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "Override project ID from config")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor from previous response")
+
+	return cmd
+}
+
+// ReviewApproveCmd creates the review approve command.
+func ReviewApproveCmd() *cobra.Command {
+	var note string
+
+	cmd := &cobra.Command{
+		Use:   "approve <knowledge_id>",
+		Short: "Approve a pending knowledge item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runReview(args[0], "approve", note, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&note, "note", "", "Optional review note")
+
+	return cmd
+}
+
+// ReviewRejectCmd creates the review reject command.
+func ReviewRejectCmd() *cobra.Command {
+	var note string
+
+	cmd := &cobra.Command{
+		Use:   "reject <knowledge_id>",
+		Short: "Reject a pending knowledge item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			if note == "" {
+				return fmt.Errorf("--note is required when rejecting")
+			}
+			return runReview(args[0], "reject", note, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&note, "note", "", "Reason for rejection (required)")
+
+	return cmd
+}
+
+func defaultReviewer() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	return u.Username
+}
+
+func runReviewList(projectID string, limit int, cursor string, outputJSON bool) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	effectiveProjectID := config.ProjectID
+	if projectID != "" {
+		effectiveProjectID = projectID
+	}
+
+	params := url.Values{}
+	if effectiveProjectID != "" {
+		params.Set("project_id", effectiveProjectID)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	path := "/knowledge/pending"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to list pending review: %w", err)
+	}
+
+	var listResp KnowledgeListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(listResp, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(listResp.Items) == 0 {
+		fmt.Println("No items pending review.")
+		return nil
+	}
+
+	fmt.Printf("%d items pending review:\n\n", len(listResp.Items))
+	for i, k := range listResp.Items {
+		fmt.Printf("%d. %s [%s]\n", i+1, k.Title, k.Type)
+		if k.Scope != "" {
+			fmt.Printf("   Scope: %s\n", k.Scope)
+		}
+		if k.ReviewNote != "" {
+			fmt.Printf("   Previous review: %s (%s)\n", k.ReviewNote, k.ReviewedBy)
+		}
+		fmt.Printf("   Updated: %s\n", k.UpdatedAt)
+		fmt.Printf("   ID: %s\n", k.ID)
+		if i < len(listResp.Items)-1 {
+			fmt.Println(strings.Repeat("-", 40))
+		}
+	}
+
+	if listResp.HasMore && listResp.Cursor != "" {
+		fmt.Printf("\n%s\n", strings.Repeat("-", 40))
+		fmt.Printf("More results available. Use --cursor %s\n", listResp.Cursor)
+	}
+
+	return nil
+}
+
+func runReview(knowledgeID, action, note string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post(fmt.Sprintf("/knowledge/%s/%s", knowledgeID, action), ReviewRequest{Note: note})
+	if err != nil {
+		return fmt.Errorf("failed to %s knowledge: %w", action, err)
+	}
+
+	var knowledge Knowledge
+	if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(knowledge, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Knowledge %s: %s\n", knowledge.Status, knowledge.ID)
+		fmt.Printf("Title: %s\n", knowledge.Title)
+		fmt.Printf("Reviewed by: %s\n", knowledge.ReviewedBy)
+	}
+
+	return nil
+}
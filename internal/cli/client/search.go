@@ -11,30 +11,47 @@ import (
 
 // SearchRequest represents the search API request.
 type SearchRequest struct {
-	Query      string `json:"query"`
-	ProjectID  string `json:"project_id,omitempty"`
-	Type       string `json:"type,omitempty"`
-	Status     string `json:"status,omitempty"`
-	PathPrefix string `json:"path_prefix,omitempty"`
-	SourceType string `json:"source_type,omitempty"`
-	Mode       string `json:"mode,omitempty"`
-	Exact      bool   `json:"exact,omitempty"`
-	Limit      int    `json:"limit,omitempty"`
-	Cursor     string `json:"cursor,omitempty"`
+	Query                string   `json:"query"`
+	ProjectID            string   `json:"project_id,omitempty"`
+	Type                 string   `json:"type,omitempty"`
+	Status               string   `json:"status,omitempty"`
+	PathPrefix           string   `json:"path_prefix,omitempty"`
+	SourceType           string   `json:"source_type,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	TagMatch             string   `json:"tag_match,omitempty"`
+	Language             string   `json:"language,omitempty"`
+	Author               string   `json:"author,omitempty"`
+	Mode                 string   `json:"mode,omitempty"`
+	Exact                bool     `json:"exact,omitempty"`
+	Limit                int      `json:"limit,omitempty"`
+	Cursor               string   `json:"cursor,omitempty"`
+	SubstituteSuperseded bool     `json:"substitute_superseded,omitempty"`
+	ExpandRelations      bool     `json:"expand_relations,omitempty"`
+	DemoteStale          bool     `json:"demote_stale,omitempty"`
 }
 
 // SearchResult represents a search result.
 type SearchResult struct {
-	ID         string  `json:"id"`
-	Title      string  `json:"title"`
-	Summary    string  `json:"summary,omitempty"`
-	Scope      string  `json:"scope,omitempty"`
-	Snippet    string  `json:"snippet,omitempty"`
-	UpdatedAt  string  `json:"updated_at,omitempty"`
-	Score      float32 `json:"score"`
-	SourceType string  `json:"source_type"`
-	ChunkID    string  `json:"chunk_id,omitempty"`
-	ChunkIndex int     `json:"chunk_index,omitempty"`
+	ID           string             `json:"id"`
+	Title        string             `json:"title"`
+	Summary      string             `json:"summary,omitempty"`
+	Scope        string             `json:"scope,omitempty"`
+	Snippet      string             `json:"snippet,omitempty"`
+	UpdatedAt    string             `json:"updated_at,omitempty"`
+	Score        float32            `json:"score"`
+	SourceType   string             `json:"source_type"`
+	ChunkID      string             `json:"chunk_id,omitempty"`
+	ChunkIndex   int                `json:"chunk_index,omitempty"`
+	SupersededBy *SupersessionRef   `json:"superseded_by,omitempty"`
+	Replaces     string             `json:"replaces,omitempty"`
+	Related      []RelatedKnowledge `json:"related,omitempty"`
+	Stale        bool               `json:"stale,omitempty"`
+}
+
+// SupersessionRef points at the knowledge item that replaces a deprecated one.
+type SupersessionRef struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
 }
 
 // SearchResponse represents the search API response.
@@ -54,19 +71,33 @@ func SearchCmd() *cobra.Command {
 		sourceType    string
 		mode          string
 		projectID     string
+		language      string
+		author        string
+		tags          []string
 		limit         int
 		cursor        string
+		allTags       bool
 		exact         bool
+		substitute    bool
+		expand        bool
+		demoteStale   bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
 		Short: "Search knowledge and assets",
-		Long:  "Searches the knowledge base and assets using hybrid semantic + lexical search.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Searches the knowledge base and assets using hybrid semantic + lexical search.
+
+Filters can also be written inline in the query:
+  type:<type> status:<status> path:<prefix> source:<knowledge|asset> tag:<a,b> lang:<language> author:<name>`,
+		Example: `  neotex search "deploy rollback tag:ci,release"
+  neotex search "error handling" --tag go --tag api --all-tags
+  neotex search "retryWithBackoff lang:go"
+  neotex search "deploy author:release-bot"`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outputJSON, _ := cmd.Flags().GetBool("output")
-			return runSearch(args[0], knowledgeType, status, pathPrefix, sourceType, mode, projectID, exact, limit, cursor, outputJSON)
+			return runSearch(args[0], knowledgeType, status, pathPrefix, sourceType, mode, projectID, language, author, tags, exact, substitute, expand, allTags, demoteStale, limit, cursor, outputJSON)
 		},
 	}
 
@@ -74,16 +105,23 @@ func SearchCmd() *cobra.Command {
 	cmd.Flags().StringVar(&status, "status", "", "Filter by knowledge status")
 	cmd.Flags().StringVar(&pathPrefix, "path", "", "Filter by scope path prefix")
 	cmd.Flags().StringVar(&sourceType, "source", "", "Filter by source type (knowledge|asset)")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Filter by tag (repeatable or comma-separated; assets match on keywords)")
+	cmd.Flags().BoolVar(&allTags, "all-tags", false, "Require every tag instead of any of them")
+	cmd.Flags().StringVar(&language, "lang", "", "Filter snippets by code language (leaves out assets)")
+	cmd.Flags().StringVar(&author, "author", "", "Filter knowledge created or last updated by this author (leaves out assets)")
 	cmd.Flags().StringVar(&mode, "mode", "", "Search mode (hybrid|semantic|lexical)")
 	cmd.Flags().StringVar(&projectID, "project", "", "Override project ID from config")
 	cmd.Flags().BoolVar(&exact, "exact", false, "Disable query expansion")
+	cmd.Flags().BoolVar(&substitute, "follow-superseded", false, "Return the replacement in place of superseded knowledge")
+	cmd.Flags().BoolVar(&expand, "expand", false, "Include items directly related to each knowledge result")
+	cmd.Flags().BoolVar(&demoteStale, "demote-stale", false, "Rank knowledge that is overdue for review lower")
 	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of results")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor from previous response")
 
 	return cmd
 }
 
-func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, projectID string, exact bool, limit int, cursor string, outputJSON bool) error {
+func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, projectID, language, author string, tags []string, exact, substitute, expand, allTags, demoteStale bool, limit int, cursor string, outputJSON bool) error {
 	// Load config to get project ID
 	config, err := LoadConfig()
 	if err != nil {
@@ -116,6 +154,18 @@ func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, proje
 	if mode == "" {
 		mode = inline.Mode
 	}
+	if language == "" {
+		language = inline.Language
+	}
+	if author == "" {
+		author = inline.Author
+	}
+	tags = append(tags, inline.Tags...)
+
+	tagMatch := ""
+	if allTags {
+		tagMatch = "all"
+	}
 
 	effectiveProjectID := config.ProjectID
 	if inline.ProjectID != "" {
@@ -127,16 +177,23 @@ func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, proje
 
 	// Build search request
 	req := SearchRequest{
-		Query:      cleanQuery,
-		ProjectID:  effectiveProjectID,
-		Type:       knowledgeType,
-		Status:     status,
-		PathPrefix: pathPrefix,
-		SourceType: sourceType,
-		Mode:       mode,
-		Exact:      exact,
-		Limit:      limit,
-		Cursor:     cursor,
+		Query:                cleanQuery,
+		ProjectID:            effectiveProjectID,
+		Type:                 knowledgeType,
+		Status:               status,
+		PathPrefix:           pathPrefix,
+		SourceType:           sourceType,
+		Tags:                 tags,
+		TagMatch:             tagMatch,
+		Language:             language,
+		Author:               author,
+		Mode:                 mode,
+		Exact:                exact,
+		Limit:                limit,
+		Cursor:               cursor,
+		SubstituteSuperseded: substitute,
+		ExpandRelations:      expand,
+		DemoteStale:          demoteStale,
 	}
 
 	// Perform search
@@ -166,7 +223,11 @@ func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, proje
 			if sourceType == "" {
 				sourceType = "knowledge"
 			}
-			fmt.Printf("%d. %s [%s] (%.2f)\n", i+1, result.Title, sourceType, result.Score)
+			staleMarker := ""
+			if result.Stale {
+				staleMarker = " [stale]"
+			}
+			fmt.Printf("%d. %s [%s]%s (%.2f)\n", i+1, result.Title, sourceType, staleMarker, result.Score)
 			if result.Snippet != "" {
 				fmt.Printf("   %s\n", highlightSnippet(result.Snippet, cleanQuery))
 			} else if result.Summary != "" {
@@ -183,6 +244,19 @@ func runSearch(query, knowledgeType, status, pathPrefix, sourceType, mode, proje
 			if result.ChunkID != "" {
 				fmt.Printf("   Chunk: %s (index %d)\n", result.ChunkID, result.ChunkIndex)
 			}
+			if result.SupersededBy != nil {
+				fmt.Printf("   Superseded by: %s (%s)\n", result.SupersededBy.Title, result.SupersededBy.ID)
+			}
+			if result.Replaces != "" {
+				fmt.Printf("   Replaces: %s\n", result.Replaces)
+			}
+			for _, rel := range result.Related {
+				if rel.Direction == "incoming" {
+					fmt.Printf("   %s <- %s (%s)\n", rel.Type, rel.Title, rel.ID)
+				} else {
+					fmt.Printf("   %s -> %s (%s)\n", rel.Type, rel.Title, rel.ID)
+				}
+			}
 			if result.UpdatedAt != "" {
 				fmt.Printf("   Updated: %s\n", result.UpdatedAt)
 			}
@@ -210,6 +284,9 @@ type inlineSearchFilters struct {
 	SourceType string
 	Mode       string
 	ProjectID  string
+	Language   string
+	Author     string
+	Tags       []string
 }
 
 func parseInlineFilters(query string) (string, inlineSearchFilters) {
@@ -244,6 +321,12 @@ func parseInlineFilters(query string) (string, inlineSearchFilters) {
 			filters.Mode = value
 		case "project":
 			filters.ProjectID = value
+		case "lang", "language":
+			filters.Language = value
+		case "author":
+			filters.Author = value
+		case "tag", "tags":
+			filters.Tags = append(filters.Tags, splitTags(value)...)
 		default:
 			remaining = append(remaining, part)
 		}
@@ -251,6 +334,17 @@ func parseInlineFilters(query string) (string, inlineSearchFilters) {
 	return strings.Join(remaining, " "), filters
 }
 
+// splitTags splits a comma-separated tag list, dropping empty entries
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 func highlightSnippet(snippet, query string) string {
 	if snippet == "" || query == "" {
 		return snippet
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInlineFilters(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedQuery string
+		expected      inlineSearchFilters
+	}{
+		{"no filters", "deploy rollback", "deploy rollback", inlineSearchFilters{}},
+		{"type and status", "deploy type:guideline status:approved", "deploy", inlineSearchFilters{Type: "guideline", Status: "approved"}},
+		{"path alias", "scope:/src/api handlers", "handlers", inlineSearchFilters{PathPrefix: "/src/api"}},
+		{"single tag", "deploy tag:ci", "deploy", inlineSearchFilters{Tags: []string{"ci"}}},
+		{"comma separated tags", "deploy tag:ci,release", "deploy", inlineSearchFilters{Tags: []string{"ci", "release"}}},
+		{"repeated tags", "tag:ci deploy tags:release", "deploy", inlineSearchFilters{Tags: []string{"ci", "release"}}},
+		{"empty value kept in query", "deploy tag:", "deploy tag:", inlineSearchFilters{}},
+		{"language", "retryWithBackoff lang:go", "retryWithBackoff", inlineSearchFilters{Language: "go"}},
+		{"language long form", "language:python parse args", "parse args", inlineSearchFilters{Language: "python"}},
+		{"author", "deploy author:release-bot", "deploy", inlineSearchFilters{Author: "release-bot"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, filters := parseInlineFilters(tt.query)
+			assert.Equal(t, tt.expectedQuery, query)
+			assert.Equal(t, tt.expected, filters)
+		})
+	}
+}
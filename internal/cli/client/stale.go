@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// StaleCmd creates the stale command.
+func StaleCmd() *cobra.Command {
+	var (
+		projectID string
+		owner     string
+		limit     int
+		cursor    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List knowledge overdue for review",
+		Long: `Lists knowledge items whose review-by date has passed.
+
+Items are flagged periodically by the server. Updating an item with a later
+--review-after date (or removing it) clears the flag.`,
+		Example: `  neotex stale
+  neotex stale --owner platform-team`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runStale(projectID, owner, limit, cursor, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "Override project ID from config")
+	cmd.Flags().StringVar(&owner, "owner", "", "Only list items owned by this person or team")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Pagination cursor from previous response")
+
+	return cmd
+}
+
+func runStale(projectID, owner string, limit int, cursor string, outputJSON bool) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	effectiveProjectID := config.ProjectID
+	if projectID != "" {
+		effectiveProjectID = projectID
+	}
+
+	params := url.Values{}
+	if effectiveProjectID != "" {
+		params.Set("project_id", effectiveProjectID)
+	}
+	if owner != "" {
+		params.Set("owner", owner)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	path := "/knowledge/stale"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := api.Get(path)
+	if err != nil {
+		return fmt.Errorf("failed to list stale knowledge: %w", err)
+	}
+
+	var listResp KnowledgeListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(listResp, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(listResp.Items) == 0 {
+		fmt.Println("No stale knowledge.")
+		return nil
+	}
+
+	fmt.Printf("%d items overdue for review:\n\n", len(listResp.Items))
+	for i, k := range listResp.Items {
+		fmt.Printf("%d. %s [%s]\n", i+1, k.Title, k.Type)
+		fmt.Printf("   Review after: %s\n", k.ReviewAfter)
+		if k.Owner != "" {
+			fmt.Printf("   Owner: %s\n", k.Owner)
+		}
+		fmt.Printf("   Stale since: %s\n", k.StaleSince)
+		fmt.Printf("   ID: %s\n", k.ID)
+		if i < len(listResp.Items)-1 {
+			fmt.Println(strings.Repeat("-", 40))
+		}
+	}
+
+	if listResp.HasMore && listResp.Cursor != "" {
+		fmt.Printf("\n%s\n", strings.Repeat("-", 40))
+		fmt.Printf("More results available. Use --cursor %s\n", listResp.Cursor)
+	}
+
+	return nil
+}
@@ -0,0 +1,1013 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	syncStateFile  = "sync.json"
+	defaultSyncDir = "knowledge"
+	syncPageSize   = 100
+)
+
+// Actions reported for a synced file.
+const (
+	syncActionPull      = "pull"
+	syncActionPush      = "push"
+	syncActionCreate    = "create"
+	syncActionRemove    = "remove"
+	syncActionDeprecate = "deprecate"
+	syncActionConflict  = "conflict"
+	syncActionMoved     = "moved"
+)
+
+// SyncEntry is what the last sync saw of one mirrored item. Version is the base of
+// local edits and Hash the SHA-256 of the file as it was written.
+type SyncEntry struct {
+	Path      string `json:"path"`
+	Version   int64  `json:"version"`
+	Hash      string `json:"hash"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// SyncState is kept in .neotex/sync.json, with the items keyed by knowledge ID.
+type SyncState struct {
+	Dir   string                `json:"dir"`
+	Items map[string]*SyncEntry `json:"items"`
+}
+
+// SyncItem is the outcome for one file. Error is set when its action failed.
+type SyncItem struct {
+	Path    string `json:"path"`
+	ID      string `json:"id,omitempty"`
+	Action  string `json:"action"`
+	Version int64  `json:"version,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SyncReport summarizes a sync. Unchanged files are counted but not listed.
+type SyncReport struct {
+	Dir        string     `json:"dir"`
+	DryRun     bool       `json:"dry_run"`
+	Items      []SyncItem `json:"items"`
+	Pulled     int        `json:"pulled"`
+	Pushed     int        `json:"pushed"`
+	Created    int        `json:"created"`
+	Removed    int        `json:"removed"`
+	Deprecated int        `json:"deprecated"`
+	Conflicts  int        `json:"conflicts"`
+	Moved      int        `json:"moved"`
+	Unchanged  int        `json:"unchanged"`
+	Failed     int        `json:"failed"`
+}
+
+// syncOptions are the settings of one sync run.
+type syncOptions struct {
+	ProjectID   string
+	DefaultType string
+	DryRun      bool
+}
+
+// syncRun holds what one sync knows about both sides while it reconciles them
+type syncRun struct {
+	api    *APIClient
+	dir    string
+	opts   syncOptions
+	state  *SyncState
+	report *SyncReport
+	files  map[string][]byte
+	remote map[string]*Knowledge
+	// synced holds the IDs handled by this run, which are not pulled as new items
+	synced map[string]bool
+}
+
+// SyncCmd creates the sync command.
+func SyncCmd() *cobra.Command {
+	var (
+		knowledgeType string
+		dryRun        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [dir]",
+		Short: "Mirror knowledge into a folder and push local edits",
+		Long: `Keeps a folder of markdown files and the knowledge of the project in step.
+
+Every item that is not deprecated is written to <dir>/<type>/<title>.md, with
+its id, type, title, summary, scope, tags, status and version in the
+frontmatter. The folder defaults to "knowledge" and is remembered, together
+with the version each file is based on, in .neotex/sync.json.
+
+On every run:
+  - files edited locally are pushed as a new version, based on the version
+    they were pulled at
+  - items changed remotely are pulled again
+  - new files without an id are created and get their id written back
+  - deleted files deprecate their item, and items deprecated or deleted
+    remotely are removed locally
+  - items moved to another project are reported and their files kept
+
+When a file was edited on both sides, it is rewritten with conflict markers
+around the lines that differ (<<<<<<< local, =======, >>>>>>> remote). Resolve
+them, remove the markers and sync again to push the result. Type, status and
+version in the frontmatter are not pushed.`,
+		Example: `  # Mirror the project into ./knowledge
+  neotex sync
+
+  # Preview what would be pushed and pulled
+  neotex sync --dry-run
+
+  # Create new files without a type in their frontmatter as guidelines
+  neotex sync docs/knowledge --type guideline`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			dir := ""
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			return runSync(dir, syncOptions{DefaultType: knowledgeType, DryRun: dryRun}, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVarP(&knowledgeType, "type", "t", "", "Knowledge type of new files whose frontmatter has none")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be pushed and pulled without changing anything")
+
+	return cmd
+}
+
+func runSync(dir string, opts syncOptions, outputJSON bool) error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	opts.ProjectID = config.ProjectID
+
+	state, err := LoadSyncState()
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = state.Dir
+	}
+	if dir == "" {
+		dir = defaultSyncDir
+	}
+	dir = filepath.Clean(dir)
+	if state.Dir != "" && filepath.Clean(state.Dir) != dir {
+		return fmt.Errorf("knowledge is already synced to %s (remove %s to sync to another folder)",
+			state.Dir, filepath.Join(neotexDir, syncStateFile))
+	}
+	state.Dir = dir
+
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	report, syncErr := syncDir(api, dir, state, opts)
+	if !opts.DryRun && report != nil {
+		if err := SaveSyncState(state); err != nil {
+			return err
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		printSyncReport(report)
+	}
+
+	if report.Failed > 0 && !outputJSON {
+		return fmt.Errorf("sync completed with %d failures", report.Failed)
+	}
+	return nil
+}
+
+// LoadSyncState reads .neotex/sync.json, which does not exist before the first sync.
+func LoadSyncState() (*SyncState, error) {
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+	data, err := os.ReadFile(filepath.Join(neotexDir, syncStateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state: %w", err)
+	}
+	if state.Items == nil {
+		state.Items = map[string]*SyncEntry{}
+	}
+	return state, nil
+}
+
+// SaveSyncState writes .neotex/sync.json.
+func SaveSyncState(state *SyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(neotexDir, syncStateFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
+
+// syncDir reconciles the markdown files below dir with the knowledge of the project and
+// records the result in state. Items are first matched to the files they were synced
+// to, then files without state are created or adopted and the remaining remote items
+// are pulled.
+func syncDir(api *APIClient, dir string, state *SyncState, opts syncOptions) (*SyncReport, error) {
+	files, err := readSyncFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := fetchRemoteKnowledge(api, opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &syncRun{
+		api:    api,
+		dir:    dir,
+		opts:   opts,
+		state:  state,
+		report: &SyncReport{Dir: dir, DryRun: opts.DryRun, Items: []SyncItem{}},
+		files:  files,
+		remote: remote,
+		synced: map[string]bool{},
+	}
+
+	untracked := run.followRenames()
+	for _, id := range run.trackedIDs() {
+		run.syncTracked(id)
+	}
+	for _, path := range untracked {
+		run.syncUntracked(path)
+	}
+	run.pullNew()
+
+	for _, item := range run.report.Items {
+		switch {
+		case item.Error != "":
+			run.report.Failed++
+		case item.Action == syncActionPull:
+			run.report.Pulled++
+		case item.Action == syncActionPush:
+			run.report.Pushed++
+		case item.Action == syncActionCreate:
+			run.report.Created++
+		case item.Action == syncActionRemove:
+			run.report.Removed++
+		case item.Action == syncActionDeprecate:
+			run.report.Deprecated++
+		case item.Action == syncActionConflict:
+			run.report.Conflicts++
+		case item.Action == syncActionMoved:
+			run.report.Moved++
+		}
+	}
+
+	return run.report, nil
+}
+
+// readSyncFiles reads the markdown files below dir by their slash-separated path within
+// it. A missing dir has no files.
+func readSyncFiles(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files, nil
+	}
+
+	paths, err := collectMarkdownFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = content
+	}
+	return files, nil
+}
+
+// fetchRemoteKnowledge lists the knowledge of the project by ID, following the cursor
+// through every page
+func fetchRemoteKnowledge(api *APIClient, projectID string) (map[string]*Knowledge, error) {
+	remote := map[string]*Knowledge{}
+	cursor := ""
+	for {
+		query := url.Values{}
+		query.Set("project_id", projectID)
+		query.Set("limit", strconv.Itoa(syncPageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		resp, err := api.Get("/knowledge?" + query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list knowledge: %w", err)
+		}
+
+		var list KnowledgeListResponse
+		if err := json.Unmarshal(resp.Data, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		for i := range list.Items {
+			remote[list.Items[i].ID] = &list.Items[i]
+		}
+
+		if !list.HasMore || list.Cursor == "" {
+			return remote, nil
+		}
+		cursor = list.Cursor
+	}
+}
+
+// followRenames moves the state of items whose file was renamed to the new path and
+// returns the paths of the files that have no state, in order
+func (s *syncRun) followRenames() []string {
+	tracked := make(map[string]string, len(s.state.Items))
+	for id, entry := range s.state.Items {
+		tracked[entry.Path] = id
+	}
+
+	var untracked []string
+	for path := range s.files {
+		if _, ok := tracked[path]; ok {
+			continue
+		}
+		meta, _, err := frontmatter.Parse(s.files[path])
+		if err == nil && meta.ID != "" {
+			if entry, ok := s.state.Items[meta.ID]; ok {
+				if _, exists := s.files[entry.Path]; !exists {
+					delete(tracked, entry.Path)
+					entry.Path = path
+					tracked[path] = meta.ID
+					continue
+				}
+			}
+		}
+		untracked = append(untracked, path)
+	}
+
+	sort.Strings(untracked)
+	return untracked
+}
+
+// trackedIDs returns the IDs in the state ordered by their path
+func (s *syncRun) trackedIDs() []string {
+	ids := make([]string, 0, len(s.state.Items))
+	for id := range s.state.Items {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return s.state.Items[ids[i]].Path < s.state.Items[ids[j]].Path
+	})
+	return ids
+}
+
+// syncTracked reconciles an item with the file it was last synced to
+func (s *syncRun) syncTracked(id string) {
+	s.synced[id] = true
+	entry := s.state.Items[id]
+	content, exists := s.files[entry.Path]
+	item := SyncItem{Path: entry.Path, ID: id}
+
+	remote := s.remote[id]
+	if remote == nil {
+		missing, err := s.lookupMissing(id)
+		if err != nil {
+			item.Error = err.Error()
+			s.report.Items = append(s.report.Items, item)
+			return
+		}
+		if missing != nil && missing.Status != "deprecated" {
+			if !exists {
+				delete(s.state.Items, id)
+				return
+			}
+			item.Action = syncActionMoved
+			item.Reason = fmt.Sprintf("moved to project %s, file kept", missing.ProjectID)
+			if !s.opts.DryRun {
+				delete(s.state.Items, id)
+			}
+			s.report.Items = append(s.report.Items, item)
+			return
+		}
+		remote = missing
+	}
+
+	remoteGone := remote == nil || remote.Status == "deprecated"
+	goneReason := "deprecated remotely"
+	if remote == nil {
+		goneReason = "deleted remotely"
+	}
+	localChanged := exists && syncHash(content) != entry.Hash
+	remoteChanged := !remoteGone && remote.UpdatedAt != entry.UpdatedAt
+
+	switch {
+	case remoteGone && !exists:
+		delete(s.state.Items, id)
+		return
+	case remoteGone && localChanged:
+		item.Action = syncActionConflict
+		item.Reason = goneReason + ", local edits kept"
+		if !s.opts.DryRun {
+			delete(s.state.Items, id)
+		}
+	case remoteGone:
+		item.Action = syncActionRemove
+		item.Reason = goneReason
+		if !s.opts.DryRun {
+			if err := os.Remove(s.localPath(entry.Path)); err != nil {
+				item.Error = fmt.Sprintf("failed to remove file: %v", err)
+				break
+			}
+			delete(s.state.Items, id)
+		}
+	case !exists && remoteChanged:
+		item.Action = syncActionPull
+		item.Reason = "deleted locally but changed remotely"
+		if !s.opts.DryRun {
+			s.pull(&item, entry)
+		}
+	case !exists:
+		item.Action = syncActionDeprecate
+		item.Reason = "deleted locally"
+		if !s.opts.DryRun {
+			if _, err := s.api.Delete(fmt.Sprintf("/knowledge/%s", id)); err != nil {
+				item.Error = fmt.Sprintf("failed to deprecate knowledge: %v", err)
+				break
+			}
+			delete(s.state.Items, id)
+		}
+	case localChanged && hasConflictMarkers(content):
+		item.Action = syncActionConflict
+		item.Reason = "unresolved conflict markers"
+	case localChanged && remoteChanged:
+		item.Action = syncActionConflict
+		item.Reason = "changed locally and remotely"
+		if !s.opts.DryRun {
+			s.merge(&item, entry, content)
+		}
+	case localChanged:
+		item.Action = syncActionPush
+		if !s.opts.DryRun {
+			s.push(&item, entry, content)
+		}
+	case remoteChanged:
+		item.Action = syncActionPull
+		if s.opts.DryRun {
+			break
+		}
+		s.pull(&item, entry)
+		if item.Error == "" && item.Action == "" {
+			return
+		}
+	default:
+		s.report.Unchanged++
+		return
+	}
+
+	s.report.Items = append(s.report.Items, item)
+}
+
+// syncUntracked creates the item of a new file, or adopts a file whose frontmatter names
+// an item that has no state, such as after a fresh clone
+func (s *syncRun) syncUntracked(path string) {
+	content := s.files[path]
+	item := SyncItem{Path: path}
+
+	meta, body, err := frontmatter.Parse(content)
+	if err != nil {
+		item.Error = err.Error()
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+
+	if meta.ID == "" {
+		s.create(&item, meta, body)
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+
+	item.ID = meta.ID
+	if other, ok := s.state.Items[meta.ID]; ok {
+		item.Error = fmt.Sprintf("id is already synced to %s", other.Path)
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+	remote := s.remote[meta.ID]
+	if remote == nil {
+		missing, err := s.lookupMissing(meta.ID)
+		if err != nil {
+			item.Error = err.Error()
+			s.report.Items = append(s.report.Items, item)
+			return
+		}
+		if missing != nil && missing.Status != "deprecated" {
+			item.Action = syncActionMoved
+			item.Reason = fmt.Sprintf("item is in project %s", missing.ProjectID)
+			s.report.Items = append(s.report.Items, item)
+			return
+		}
+		remote = missing
+	}
+	if remote == nil || remote.Status == "deprecated" {
+		item.Error = "item does not exist or is deprecated (remove the id from the frontmatter to create it again)"
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+
+	entry := &SyncEntry{Path: path}
+	if s.opts.DryRun {
+		s.state.Items[meta.ID] = entry
+		item.Action = syncActionPull
+		item.Reason = "adopted existing file"
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+
+	current, err := getKnowledge(s.api, meta.ID)
+	if err != nil {
+		item.Error = err.Error()
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+	document, err := syncDocument(current)
+	if err != nil {
+		item.Error = err.Error()
+		s.report.Items = append(s.report.Items, item)
+		return
+	}
+
+	s.state.Items[meta.ID] = entry
+	if bytes.Equal(document, content) {
+		entry.Version, entry.Hash, entry.UpdatedAt = current.Version, syncHash(document), current.UpdatedAt
+		s.report.Unchanged++
+		return
+	}
+
+	item.Action = syncActionConflict
+	item.Reason = "file differs from the item and has no sync state"
+	s.writeConflict(&item, entry, content, current)
+	s.report.Items = append(s.report.Items, item)
+}
+
+// lookupMissing fetches an item that is not listed in the project, to tell an item that
+// was deleted or purged, for which it returns nil, from one that is deprecated or was
+// moved to another project
+func (s *syncRun) lookupMissing(id string) (*Knowledge, error) {
+	k, err := getKnowledge(s.api, id)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return k, err
+}
+
+// pullNew writes the remote items that have no file yet
+func (s *syncRun) pullNew() {
+	taken := make(map[string]bool, len(s.files))
+	for path := range s.files {
+		taken[strings.ToLower(path)] = true
+	}
+	for _, entry := range s.state.Items {
+		taken[strings.ToLower(entry.Path)] = true
+	}
+
+	ids := make([]string, 0, len(s.remote))
+	for id, k := range s.remote {
+		if _, ok := s.state.Items[id]; !ok && !s.synced[id] && k.Status != "deprecated" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := s.remote[ids[i]], s.remote[ids[j]]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID < b.ID
+	})
+
+	for _, id := range ids {
+		path := syncPath(s.remote[id], taken)
+		taken[strings.ToLower(path)] = true
+
+		item := SyncItem{Path: path, ID: id, Action: syncActionPull}
+		if !s.opts.DryRun {
+			entry := &SyncEntry{Path: path}
+			s.pull(&item, entry)
+			if item.Error == "" {
+				s.state.Items[id] = entry
+			}
+		}
+		s.report.Items = append(s.report.Items, item)
+	}
+}
+
+// pull writes the current version of an item to its file. A pull that finds the file
+// already up to date clears the action.
+func (s *syncRun) pull(item *SyncItem, entry *SyncEntry) {
+	current, err := getKnowledge(s.api, item.ID)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	document, err := syncDocument(current)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+
+	hash := syncHash(document)
+	if hash == entry.Hash {
+		if _, exists := s.files[entry.Path]; exists {
+			entry.UpdatedAt = current.UpdatedAt
+			item.Action = ""
+			s.report.Unchanged++
+			return
+		}
+	}
+
+	if err := s.writeFile(entry.Path, document); err != nil {
+		item.Error = err.Error()
+		return
+	}
+	entry.Version, entry.Hash, entry.UpdatedAt = current.Version, hash, current.UpdatedAt
+	item.Version = current.Version
+}
+
+// push sends a locally edited file as a new version based on the synced one. If the item
+// changed in the meantime the file is merged with the current version instead.
+func (s *syncRun) push(item *SyncItem, entry *SyncEntry, content []byte) {
+	meta, body, err := frontmatter.Parse(content)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	if strings.TrimSpace(body) == "" {
+		item.Error = "file has no content"
+		return
+	}
+
+	req := UpdateKnowledgeRequest{
+		Title:   meta.Title,
+		Summary: meta.Summary,
+		BodyMD:  body,
+		Scope:   meta.Scope,
+		Tags:    meta.Tags,
+	}
+	if req.Title == "" {
+		req.Title = markdownTitle(body, entry.Path)
+	}
+	if req.Tags == nil {
+		req.Tags = []string{}
+	}
+
+	opts := RequestOptions{IfMatch: strconv.Quote(strconv.FormatInt(entry.Version, 10))}
+	resp, err := s.api.PutWithOptions(fmt.Sprintf("/knowledge/%s", item.ID), req, opts)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+			item.Action = syncActionConflict
+			item.Reason = "changed remotely while pushing"
+			s.merge(item, entry, content)
+			return
+		}
+		item.Error = fmt.Sprintf("failed to update knowledge: %v", err)
+		return
+	}
+
+	var updated Knowledge
+	if err := json.Unmarshal(resp.Data, &updated); err != nil {
+		item.Error = fmt.Sprintf("failed to parse response: %v", err)
+		return
+	}
+
+	document, err := syncDocument(&updated)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	if err := s.writeFile(entry.Path, document); err != nil {
+		item.Error = err.Error()
+		return
+	}
+	entry.Version, entry.Hash, entry.UpdatedAt = updated.Version, syncHash(document), updated.UpdatedAt
+	item.Version = updated.Version
+}
+
+// merge handles a file edited on both sides. When the edit matches the current version
+// the file is simply brought up to date, otherwise it gets conflict markers.
+func (s *syncRun) merge(item *SyncItem, entry *SyncEntry, content []byte) {
+	current, err := getKnowledge(s.api, item.ID)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+
+	if meta, body, err := frontmatter.Parse(content); err == nil && sameContent(meta, body, current) {
+		item.Action = syncActionPull
+		item.Reason = "same edit made remotely"
+		s.pull(item, entry)
+		return
+	}
+
+	s.writeConflict(item, entry, content, current)
+}
+
+// writeConflict rewrites a file with conflict markers between its content and the
+// current version, which becomes the base of the resolved file
+func (s *syncRun) writeConflict(item *SyncItem, entry *SyncEntry, content []byte, current *Knowledge) {
+	document, err := syncDocument(current)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	merged, err := conflictDocument(content, current)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	if err := s.writeFile(entry.Path, merged); err != nil {
+		item.Error = err.Error()
+		return
+	}
+	entry.Version, entry.Hash, entry.UpdatedAt = current.Version, syncHash(document), current.UpdatedAt
+	item.Version = current.Version
+}
+
+// create creates the item of a new file and writes its id and version back into the file
+func (s *syncRun) create(item *SyncItem, meta *frontmatter.Metadata, body string) {
+	item.Action = syncActionCreate
+	if strings.TrimSpace(body) == "" {
+		item.Error = "file has no content"
+		return
+	}
+
+	req := CreateKnowledgeRequest{
+		Type:      meta.Type,
+		Title:     meta.Title,
+		Summary:   meta.Summary,
+		BodyMD:    body,
+		ProjectID: s.opts.ProjectID,
+		Scope:     meta.Scope,
+		Tags:      meta.Tags,
+	}
+	if req.Type == "" {
+		req.Type = s.opts.DefaultType
+	}
+	if req.Type == "" {
+		item.Error = "type is required (set it in the frontmatter or pass --type)"
+		return
+	}
+	if req.Title == "" {
+		req.Title = markdownTitle(body, item.Path)
+	}
+	if s.opts.DryRun {
+		return
+	}
+
+	resp, err := s.api.Post("/knowledge", req)
+	if err != nil {
+		item.Error = fmt.Sprintf("failed to create knowledge: %v", err)
+		return
+	}
+	var created Knowledge
+	if err := json.Unmarshal(resp.Data, &created); err != nil {
+		item.Error = fmt.Sprintf("failed to parse response: %v", err)
+		return
+	}
+	item.ID = created.ID
+	item.Version = created.Version
+
+	document, err := syncDocument(&created)
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+	if err := s.writeFile(item.Path, document); err != nil {
+		item.Error = err.Error()
+		return
+	}
+	s.state.Items[created.ID] = &SyncEntry{
+		Path:      item.Path,
+		Version:   created.Version,
+		Hash:      syncHash(document),
+		UpdatedAt: created.UpdatedAt,
+	}
+}
+
+func (s *syncRun) localPath(path string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path))
+}
+
+func (s *syncRun) writeFile(path string, content []byte) error {
+	localPath := s.localPath(path)
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(localPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+func getKnowledge(api *APIClient, id string) (*Knowledge, error) {
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get knowledge: %w", err)
+	}
+	var k Knowledge
+	if err := json.Unmarshal(resp.Data, &k); err != nil {
+		return nil, fmt.Errorf("failed to parse knowledge: %w", err)
+	}
+	return &k, nil
+}
+
+// syncDocument renders an item as the markdown file it is synced to
+func syncDocument(k *Knowledge) ([]byte, error) {
+	return frontmatter.Format(&frontmatter.Metadata{
+		ID:      k.ID,
+		Type:    k.Type,
+		Title:   k.Title,
+		Summary: k.Summary,
+		Scope:   k.Scope,
+		Tags:    k.Tags,
+		Status:  k.Status,
+		Version: k.Version,
+	}, k.BodyMD)
+}
+
+// sameContent reports whether a file holds the pushable fields of an item
+func sameContent(meta *frontmatter.Metadata, body string, k *Knowledge) bool {
+	return meta.Title == k.Title && meta.Summary == k.Summary && meta.Scope == k.Scope &&
+		strings.Join(meta.Tags, ",") == strings.Join(k.Tags, ",") && body == k.BodyMD
+}
+
+// conflictDocument marks the lines where a local file and the current version of its
+// item differ, git style. The local file is rendered with the id, type, status and
+// version of the item first, so only the fields that are pushed can conflict.
+func conflictDocument(local []byte, current *Knowledge) ([]byte, error) {
+	localDoc := local
+	if meta, body, err := frontmatter.Parse(local); err == nil {
+		meta.ID, meta.Type, meta.Status, meta.Version = current.ID, current.Type, current.Status, current.Version
+		if localDoc, err = frontmatter.Format(meta, body); err != nil {
+			return nil, err
+		}
+	}
+	remoteDoc, err := syncDocument(current)
+	if err != nil {
+		return nil, err
+	}
+
+	localLines := strings.SplitAfter(string(localDoc), "\n")
+	remoteLines := strings.SplitAfter(string(remoteDoc), "\n")
+
+	prefix := 0
+	for prefix < len(localLines) && prefix < len(remoteLines) && localLines[prefix] == remoteLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(localLines)-prefix && suffix < len(remoteLines)-prefix &&
+		localLines[len(localLines)-1-suffix] == remoteLines[len(remoteLines)-1-suffix] {
+		suffix++
+	}
+
+	var b strings.Builder
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			b.WriteString(line)
+		}
+		if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(strings.Join(localLines[:prefix], ""))
+	b.WriteString("<<<<<<< local\n")
+	writeLines(localLines[prefix : len(localLines)-suffix])
+	b.WriteString("=======\n")
+	writeLines(remoteLines[prefix : len(remoteLines)-suffix])
+	fmt.Fprintf(&b, ">>>>>>> remote v%d\n", current.Version)
+	b.WriteString(strings.Join(remoteLines[len(remoteLines)-suffix:], ""))
+	return []byte(b.String()), nil
+}
+
+// hasConflictMarkers reports whether a file still holds the markers of a conflict
+func hasConflictMarkers(content []byte) bool {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+			return true
+		}
+	}
+	return false
+}
+
+// syncPath picks the file of a newly pulled item: <type>/<title>.md, with the start of
+// the ID added when that path is taken
+func syncPath(k *Knowledge, taken map[string]bool) string {
+	name := slugify(k.Title)
+	if name == "" {
+		name = k.ID
+	}
+	dir := slugify(k.Type)
+	if dir == "" {
+		dir = "knowledge"
+	}
+
+	path := dir + "/" + name + ".md"
+	if taken[strings.ToLower(path)] {
+		short := k.ID
+		if len(short) > 8 {
+			short = short[:8]
+		}
+		path = dir + "/" + name + "-" + short + ".md"
+	}
+	return path
+}
+
+// slugify lowercases s and joins its letters and digits with dashes
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			if b.Len() >= 60 {
+				break
+			}
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+func syncHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func printSyncReport(report *SyncReport) {
+	for _, item := range report.Items {
+		switch {
+		case item.Error != "":
+			fmt.Printf("failed     %s: %s\n", item.Path, item.Error)
+		case item.Reason != "":
+			fmt.Printf("%-10s %s (%s)\n", item.Action, item.Path, item.Reason)
+		case item.Version > 0:
+			fmt.Printf("%-10s %s  v%d\n", item.Action, item.Path, item.Version)
+		default:
+			fmt.Printf("%-10s %s\n", item.Action, item.Path)
+		}
+	}
+
+	summary := fmt.Sprintf("%d pulled, %d pushed, %d created, %d removed, %d deprecated, %d conflicts, %d moved, %d unchanged, %d failed",
+		report.Pulled, report.Pushed, report.Created, report.Removed, report.Deprecated, report.Conflicts, report.Moved, report.Unchanged, report.Failed)
+	if report.DryRun {
+		fmt.Printf("\nDry run: %s\n", summary)
+	} else {
+		fmt.Printf("\nSynced %s: %s\n", report.Dir, summary)
+	}
+	if report.Conflicts > 0 {
+		fmt.Println("Resolve the conflict markers and run 'neotex sync' again.")
+	}
+}
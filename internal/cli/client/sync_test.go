@@ -0,0 +1,383 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncTestServer keeps knowledge in memory and serves the endpoints used by sync
+type syncTestServer struct {
+	mu    sync.Mutex
+	items map[string]*Knowledge
+	// elsewhere holds items of other projects, which are not listed but can be fetched
+	elsewhere map[string]*Knowledge
+	nextID    int
+	clock     int
+	puts      []UpdateKnowledgeRequest
+	ifMatch   []string
+}
+
+func newSyncTestServer(t *testing.T, items ...Knowledge) (*syncTestServer, *APIClient) {
+	t.Helper()
+	s := &syncTestServer{items: map[string]*Knowledge{}, elsewhere: map[string]*Knowledge{}}
+	for i := range items {
+		s.items[items[i].ID] = &items[i]
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(server.Close)
+
+	api, err := NewAPIClientWithConfig("ntx_test", server.URL)
+	require.NoError(t, err)
+	return s, api
+}
+
+func (s *syncTestServer) tick() string {
+	s.clock++
+	return fmt.Sprintf("2026-01-01T00:00:%02dZ", s.clock)
+}
+
+// edit changes an item as another client would
+func (s *syncTestServer) edit(id, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := s.items[id]
+	k.BodyMD = body
+	k.Version++
+	k.UpdatedAt = s.tick()
+}
+
+func (s *syncTestServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	id := strings.TrimPrefix(r.URL.Path, "/knowledge/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/knowledge":
+		list := KnowledgeListResponse{Items: []Knowledge{}}
+		for _, k := range s.items {
+			item := *k
+			item.Version = 0
+			list.Items = append(list.Items, item)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": list})
+	case r.Method == http.MethodGet:
+		k, ok := s.items[id]
+		if !ok {
+			k, ok = s.elsewhere[id]
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "knowledge not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": k})
+	case r.Method == http.MethodPost && r.URL.Path == "/knowledge":
+		var req CreateKnowledgeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.nextID++
+		k := &Knowledge{
+			ID: fmt.Sprintf("new-%d", s.nextID), Type: req.Type, Status: "draft", Title: req.Title,
+			Summary: req.Summary, BodyMD: req.BodyMD, Scope: req.Scope, Tags: req.Tags, Version: 1, UpdatedAt: s.tick(),
+		}
+		s.items[k.ID] = k
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": k})
+	case r.Method == http.MethodPut:
+		var req UpdateKnowledgeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.puts = append(s.puts, req)
+		s.ifMatch = append(s.ifMatch, r.Header.Get("If-Match"))
+		k := s.items[id]
+		if r.Header.Get("If-Match") != strconv.Quote(strconv.FormatInt(k.Version, 10)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "version conflict", "data": k})
+			return
+		}
+		k.Title, k.Summary, k.BodyMD, k.Scope, k.Tags = req.Title, req.Summary, req.BodyMD, req.Scope, req.Tags
+		k.Version++
+		k.UpdatedAt = s.tick()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": k})
+	case r.Method == http.MethodDelete:
+		s.items[id].Status = "deprecated"
+		s.items[id].UpdatedAt = s.tick()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": s.items[id]})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func readSyncFile(t *testing.T, dir, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func writeSyncFile(t *testing.T, dir, path, content string) {
+	t.Helper()
+	full := filepath.Join(dir, filepath.FromSlash(path))
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+}
+
+func deploysItem() Knowledge {
+	return Knowledge{
+		ID: "k-1", Type: "guideline", Status: "approved", Title: "Deploys", Summary: "How we ship",
+		BodyMD: "Ship on Tuesdays.\nTag the release.\n", Tags: []string{"ci"}, Version: 2, UpdatedAt: "2025-12-01T00:00:00Z",
+	}
+}
+
+func TestSyncDir_PullThenPush(t *testing.T) {
+	server, api := newSyncTestServer(t, deploysItem())
+	dir := filepath.Join(t.TempDir(), "knowledge")
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Pulled)
+	require.Contains(t, state.Items, "k-1")
+	assert.Equal(t, "guideline/deploys.md", state.Items["k-1"].Path)
+	assert.Equal(t, int64(2), state.Items["k-1"].Version)
+
+	content := readSyncFile(t, dir, "guideline/deploys.md")
+	assert.Contains(t, content, "id: k-1\n")
+	assert.Contains(t, content, "version: 2\n")
+	assert.True(t, strings.HasSuffix(content, "Ship on Tuesdays.\nTag the release.\n"))
+
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Empty(t, report.Items)
+
+	writeSyncFile(t, dir, "guideline/deploys.md", strings.Replace(content, "Tuesdays", "Wednesdays", 1))
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Pushed)
+	require.Len(t, server.puts, 1)
+	assert.Equal(t, `"2"`, server.ifMatch[0])
+	assert.Equal(t, "Ship on Wednesdays.\nTag the release.\n", server.puts[0].BodyMD)
+	assert.Equal(t, []string{"ci"}, server.puts[0].Tags)
+	assert.Equal(t, int64(3), state.Items["k-1"].Version)
+	assert.Contains(t, readSyncFile(t, dir, "guideline/deploys.md"), "version: 3\n")
+
+	server.edit("k-1", "Ship on Thursdays.\n")
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Pulled)
+	assert.True(t, strings.HasSuffix(readSyncFile(t, dir, "guideline/deploys.md"), "Ship on Thursdays.\n"))
+	assert.Equal(t, int64(4), state.Items["k-1"].Version)
+}
+
+func TestSyncDir_Conflict(t *testing.T) {
+	server, api := newSyncTestServer(t, deploysItem())
+	dir := t.TempDir()
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+
+	content := readSyncFile(t, dir, "guideline/deploys.md")
+	writeSyncFile(t, dir, "guideline/deploys.md", strings.Replace(content, "Tuesdays", "Mondays", 1))
+	server.edit("k-1", "Ship on Fridays.\nTag the release.\n")
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Empty(t, server.puts)
+
+	merged := readSyncFile(t, dir, "guideline/deploys.md")
+	assert.Contains(t, merged, "version: 3\n")
+	assert.True(t, strings.HasSuffix(merged,
+		"<<<<<<< local\nShip on Mondays.\n=======\nShip on Fridays.\n>>>>>>> remote v3\nTag the release.\n"), merged)
+	assert.Equal(t, int64(3), state.Items["k-1"].Version)
+
+	// Still conflicted until the markers are removed
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Empty(t, server.puts)
+
+	resolved := strings.Replace(merged, "<<<<<<< local\nShip on Mondays.\n=======\nShip on Fridays.\n>>>>>>> remote v3\n", "Ship on Mondays and Fridays.\n", 1)
+	writeSyncFile(t, dir, "guideline/deploys.md", resolved)
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Pushed)
+	require.Len(t, server.puts, 1)
+	assert.Equal(t, `"3"`, server.ifMatch[0])
+	assert.Equal(t, "Ship on Mondays and Fridays.\nTag the release.\n", server.items["k-1"].BodyMD)
+}
+
+func TestSyncDir_ConflictWhilePushing(t *testing.T) {
+	server, api := newSyncTestServer(t, deploysItem())
+	dir := t.TempDir()
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+
+	// The listing still shows the old item, but the update is rejected
+	server.items["k-1"].Version = 5
+	content := readSyncFile(t, dir, "guideline/deploys.md")
+	writeSyncFile(t, dir, "guideline/deploys.md", strings.Replace(content, "Tuesdays", "Mondays", 1))
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Equal(t, "changed remotely while pushing", report.Items[0].Reason)
+	assert.Contains(t, readSyncFile(t, dir, "guideline/deploys.md"), ">>>>>>> remote v5\n")
+	assert.Equal(t, int64(5), state.Items["k-1"].Version)
+}
+
+func TestSyncDir_CreateAndDeprecate(t *testing.T) {
+	server, api := newSyncTestServer(t, deploysItem())
+	dir := t.TempDir()
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+
+	writeSyncFile(t, dir, "notes/retries.md", "---\ntype: learning\n---\n# Retry flaky tests\n\nRetry once.\n")
+	writeSyncFile(t, dir, "notes/untyped.md", "No type here.\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "guideline", "deploys.md")))
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1", DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Deprecated)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, "approved", server.items["k-1"].Status)
+
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1", DefaultType: "learning"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Deprecated)
+	assert.Equal(t, 0, report.Failed)
+
+	assert.Equal(t, "deprecated", server.items["k-1"].Status)
+	assert.NotContains(t, state.Items, "k-1")
+	assert.Equal(t, "Retry flaky tests", server.items["new-1"].Title)
+	assert.Equal(t, "learning", server.items["new-1"].Type)
+	assert.Equal(t, "notes/retries.md", state.Items["new-1"].Path)
+
+	content := readSyncFile(t, dir, "notes/retries.md")
+	assert.Contains(t, content, "id: new-1\n")
+	assert.Contains(t, content, "version: 1\n")
+
+	// The created items are now tracked and nothing comes back for the deprecated one
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+	assert.Equal(t, 2, report.Unchanged)
+}
+
+func TestSyncDir_RemoteDeprecationAndRename(t *testing.T) {
+	second := deploysItem()
+	second.ID, second.Title, second.Type = "k-2", "Retries", "learning"
+	server, api := newSyncTestServer(t, deploysItem(), second)
+	dir := t.TempDir()
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	require.Len(t, state.Items, 2)
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "learning", "retries.md"), filepath.Join(dir, "retries.md")))
+	server.items["k-1"].Status = "deprecated"
+	server.items["k-1"].UpdatedAt = server.tick()
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Removed)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.NoFileExists(t, filepath.Join(dir, "guideline", "deploys.md"))
+	assert.Equal(t, "retries.md", state.Items["k-2"].Path)
+	assert.Equal(t, "approved", server.items["k-2"].Status)
+}
+
+func TestSyncDir_RemoteMoveAndDelete(t *testing.T) {
+	second := deploysItem()
+	second.ID, second.Title, second.Type = "k-2", "Retries", "learning"
+	server, api := newSyncTestServer(t, deploysItem(), second)
+	dir := t.TempDir()
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+
+	delete(server.items, "k-1")
+	moved := server.items["k-2"]
+	moved.ProjectID = "proj-2"
+	server.elsewhere["k-2"] = moved
+	delete(server.items, "k-2")
+
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Removed)
+	assert.Equal(t, 1, report.Moved)
+	assert.Zero(t, report.Failed)
+	assert.NoFileExists(t, filepath.Join(dir, "guideline", "deploys.md"))
+	assert.FileExists(t, filepath.Join(dir, "learning", "retries.md"), "moved items are not removed")
+	assert.Empty(t, state.Items)
+
+	// The file of the moved item is reported again, not adopted or failed
+	report, err = syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Moved)
+	assert.Zero(t, report.Failed)
+	assert.Empty(t, state.Items)
+}
+
+func TestSyncDir_AdoptsFilesWithoutState(t *testing.T) {
+	_, api := newSyncTestServer(t, deploysItem())
+	dir := t.TempDir()
+
+	state := &SyncState{Items: map[string]*SyncEntry{}}
+	_, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+
+	// A fresh clone has the files but not .neotex/sync.json
+	state = &SyncState{Items: map[string]*SyncEntry{}}
+	report, err := syncDir(api, dir, state, syncOptions{ProjectID: "proj-1"})
+	require.NoError(t, err)
+	assert.Empty(t, report.Items)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, "guideline/deploys.md", state.Items["k-1"].Path)
+}
+
+func TestConflictDocument_MetadataOnly(t *testing.T) {
+	current := deploysItem()
+	local, err := syncDocument(&Knowledge{
+		ID: "k-1", Type: "guideline", Status: "approved", Title: "Deploying", Summary: "How we ship",
+		BodyMD: current.BodyMD, Tags: []string{"ci"}, Version: 1,
+	})
+	require.NoError(t, err)
+
+	merged, err := conflictDocument(local, &current)
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "<<<<<<< local\ntitle: Deploying\n=======\ntitle: Deploys\n>>>>>>> remote v2\n")
+	assert.True(t, hasConflictMarkers(merged))
+}
+
+func TestSyncPath(t *testing.T) {
+	taken := map[string]bool{}
+	k := &Knowledge{ID: "0123456789", Type: "guideline", Title: "Deploys: staging & prod!"}
+	assert.Equal(t, "guideline/deploys-staging-prod.md", syncPath(k, taken))
+
+	taken["guideline/deploys-staging-prod.md"] = true
+	assert.Equal(t, "guideline/deploys-staging-prod-01234567.md", syncPath(k, taken))
+
+	assert.Equal(t, "guideline/0123456789.md", syncPath(&Knowledge{ID: "0123456789", Type: "guideline", Title: "!!"}, taken))
+}
@@ -0,0 +1,304 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateKnowledgeTypeRequest represents the create knowledge type API request.
+type CreateKnowledgeTypeRequest struct {
+	Name             string   `json:"name"`
+	DisplayName      string   `json:"display_name,omitempty"`
+	RequiredSections []string `json:"required_sections,omitempty"`
+	SearchBoost      float32  `json:"search_boost,omitempty"`
+}
+
+// UpdateKnowledgeTypeRequest represents the update knowledge type API request.
+// Nil fields are left unchanged; an empty section list removes the schema.
+type UpdateKnowledgeTypeRequest struct {
+	DisplayName      *string   `json:"display_name,omitempty"`
+	RequiredSections *[]string `json:"required_sections,omitempty"`
+	SearchBoost      *float32  `json:"search_boost,omitempty"`
+}
+
+// KnowledgeTypeInfo represents a knowledge type available to the org.
+type KnowledgeTypeInfo struct {
+	Name             string   `json:"name"`
+	DisplayName      string   `json:"display_name"`
+	RequiredSections []string `json:"required_sections"`
+	SearchBoost      float32  `json:"search_boost"`
+	Builtin          bool     `json:"builtin"`
+	Registered       bool     `json:"registered"`
+	CreatedAt        string   `json:"created_at,omitempty"`
+	UpdatedAt        string   `json:"updated_at,omitempty"`
+}
+
+// KnowledgeTypeListResponse represents the list knowledge types API response.
+type KnowledgeTypeListResponse struct {
+	Items []KnowledgeTypeInfo `json:"items"`
+}
+
+// TypesCmd creates the types command.
+func TypesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "types",
+		Short: "Manage the organization's knowledge types",
+		Long: `Commands for the per-organization knowledge type registry.
+
+Every org can use the built-in types (guideline, learning, decision, template,
+checklist, snippet). Registering a new name adds a custom type; registering a
+built-in name customizes it for the org.
+
+A type can require markdown sections: knowledge of that type is rejected
+unless its body has a heading for each one. The search boost multiplies the
+search score of items of the type (default 1).`,
+	}
+
+	cmd.AddCommand(TypesListCmd())
+	cmd.AddCommand(TypesAddCmd())
+	cmd.AddCommand(TypesUpdateCmd())
+	cmd.AddCommand(TypesRemoveCmd())
+
+	return cmd
+}
+
+// TypesListCmd creates the types list command.
+func TypesListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the knowledge types available to the org",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runTypesList(outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+// TypesAddCmd creates the types add command.
+func TypesAddCmd() *cobra.Command {
+	var (
+		displayName string
+		sections    []string
+		boost       float32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Register a knowledge type",
+		Example: `  neotex types add runbook --display-name "Runbook" --section Steps --section Rollback
+  neotex types add decision --boost 1.5`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runTypesAdd(args[0], displayName, sections, boost, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Human-readable name (defaults to the capitalized name)")
+	cmd.Flags().StringArrayVar(&sections, "section", nil, "Required markdown section (repeatable)")
+	cmd.Flags().Float32Var(&boost, "boost", 0, "Search score multiplier (default 1)")
+
+	return cmd
+}
+
+// TypesUpdateCmd creates the types update command.
+func TypesUpdateCmd() *cobra.Command {
+	var (
+		displayName   string
+		sections      []string
+		clearSections bool
+		boost         float32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <name>",
+		Short: "Change a registered knowledge type",
+		Example: `  neotex types update runbook --boost 2
+  neotex types update runbook --section Steps --section Rollback --section Verification
+  neotex types update runbook --clear-sections`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+
+			var req UpdateKnowledgeTypeRequest
+			if cmd.Flags().Changed("display-name") {
+				req.DisplayName = &displayName
+			}
+			if clearSections {
+				empty := []string{}
+				req.RequiredSections = &empty
+			} else if cmd.Flags().Changed("section") {
+				req.RequiredSections = &sections
+			}
+			if cmd.Flags().Changed("boost") {
+				req.SearchBoost = &boost
+			}
+			if req.DisplayName == nil && req.RequiredSections == nil && req.SearchBoost == nil {
+				return fmt.Errorf("nothing to update: use --display-name, --section, --clear-sections or --boost")
+			}
+
+			return runTypesUpdate(args[0], req, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(&displayName, "display-name", "", "New human-readable name")
+	cmd.Flags().StringArrayVar(&sections, "section", nil, "Required markdown section (repeatable, replaces the current list)")
+	cmd.Flags().BoolVar(&clearSections, "clear-sections", false, "Remove all required sections")
+	cmd.Flags().Float32Var(&boost, "boost", 0, "Search score multiplier")
+
+	return cmd
+}
+
+// TypesRemoveCmd creates the types remove command.
+func TypesRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a registered knowledge type",
+		Long: `Removes a type from the registry.
+
+Custom types still used by knowledge items cannot be removed. Removing a
+customized built-in type restores its defaults.`,
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			return runTypesRemove(args[0], outputJSON)
+		},
+	}
+
+	return cmd
+}
+
+func runTypesList(outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	types, err := fetchKnowledgeTypes(api)
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(KnowledgeTypeListResponse{Items: types}, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	for _, t := range types {
+		printKnowledgeType(t)
+	}
+
+	return nil
+}
+
+// fetchKnowledgeTypes lists the knowledge types of the org
+func fetchKnowledgeTypes(api *APIClient) ([]KnowledgeTypeInfo, error) {
+	resp, err := api.Get("/knowledge-types")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge types: %w", err)
+	}
+	var listResp KnowledgeTypeListResponse
+	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return listResp.Items, nil
+}
+
+func runTypesAdd(name, displayName string, sections []string, boost float32, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Post("/knowledge-types", CreateKnowledgeTypeRequest{
+		Name:             name,
+		DisplayName:      displayName,
+		RequiredSections: sections,
+		SearchBoost:      boost,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register knowledge type: %w", err)
+	}
+
+	return printKnowledgeTypeResponse(resp.Data, "Registered", outputJSON)
+}
+
+func runTypesUpdate(name string, req UpdateKnowledgeTypeRequest, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Put("/knowledge-types/"+url.PathEscape(name), req)
+	if err != nil {
+		return fmt.Errorf("failed to update knowledge type: %w", err)
+	}
+
+	return printKnowledgeTypeResponse(resp.Data, "Updated", outputJSON)
+}
+
+func runTypesRemove(name string, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := api.Delete("/knowledge-types/" + url.PathEscape(name)); err != nil {
+		return fmt.Errorf("failed to remove knowledge type: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]string{
+			"name":   name,
+			"status": "deleted",
+		}, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Removed knowledge type: %s\n", name)
+	}
+
+	return nil
+}
+
+func printKnowledgeTypeResponse(data json.RawMessage, verb string, outputJSON bool) error {
+	var t KnowledgeTypeInfo
+	if err := json.Unmarshal(data, &t); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(t, "", "  ")
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("%s knowledge type: %s\n", verb, t.Name)
+	printKnowledgeType(t)
+	return nil
+}
+
+func printKnowledgeType(t KnowledgeTypeInfo) {
+	origin := "custom"
+	if t.Builtin && t.Registered {
+		origin = "built-in, customized"
+	} else if t.Builtin {
+		origin = "built-in"
+	}
+
+	fmt.Printf("%s (%s) [%s]\n", t.Name, t.DisplayName, origin)
+	if len(t.RequiredSections) > 0 {
+		fmt.Printf("   Required sections: %s\n", strings.Join(t.RequiredSections, ", "))
+	}
+	if t.SearchBoost != 1 {
+		fmt.Printf("   Search boost: %g\n", t.SearchBoost)
+	}
+}
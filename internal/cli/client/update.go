@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// UpdateKnowledgeRequest represents the update knowledge API request.
+type UpdateKnowledgeRequest struct {
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	BodyMD      string   `json:"body_md"`
+	Scope       string   `json:"scope"`
+	Tags        []string `json:"tags"`
+	ReviewAfter *string  `json:"review_after,omitempty"`
+	Owner       *string  `json:"owner,omitempty"`
+	Language    *string  `json:"language,omitempty"`
+	SourcePath  string   `json:"source_path,omitempty"`
+	SourceHash  string   `json:"source_hash,omitempty"`
+}
+
+// UpdateCmd creates the update command.
+func UpdateCmd() *cobra.Command {
+	var (
+		file        string
+		title       string
+		summary     string
+		scope       string
+		tags        []string
+		reviewAfter string
+		owner       string
+		language    string
+		ifMatch     int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <knowledge_id>",
+		Short: "Update a knowledge item",
+		Long: `Updates a knowledge item, creating a new version.
+
+Fields that are not given keep their current value. The update only succeeds
+if nobody else changed the item in the meantime: by default the version read
+at the start of the command is sent as If-Match, or pass --if-match with the
+version your edit is based on. On conflict the current version is reported
+and a diff can be shown.
+
+Examples:
+  # Replace the body from a markdown file
+  neotex update <knowledge_id> --file guideline.md
+
+  # Change the title only
+  neotex update <knowledge_id> --title "Deploying to staging"
+
+  # Replace the tags (pass --tags "" to clear them)
+  neotex update <knowledge_id> --tags ci,release
+
+  # Push the review-by date out (pass --review-after "" to remove it)
+  neotex update <knowledge_id> --review-after 2027-01-01 --owner platform-team
+
+  # Correct the language of a snippet
+  neotex update <knowledge_id> --lang typescript
+
+  # Body from stdin, based on version 3
+  cat guideline.md | neotex update <knowledge_id> --file - --if-match 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputJSON, _ := cmd.Flags().GetBool("output")
+			if !cmd.Flags().Changed("tags") {
+				tags = nil
+			} else if tags == nil {
+				tags = []string{}
+			}
+			var reviewAfterPtr, ownerPtr, languagePtr *string
+			if cmd.Flags().Changed("review-after") {
+				reviewAfterPtr = &reviewAfter
+			}
+			if cmd.Flags().Changed("owner") {
+				ownerPtr = &owner
+			}
+			if cmd.Flags().Changed("lang") {
+				languagePtr = &language
+			}
+			return runUpdate(args[0], file, title, summary, scope, tags, reviewAfterPtr, ownerPtr, languagePtr, ifMatch, outputJSON)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Markdown file with the new body (- for stdin)")
+	cmd.Flags().StringVar(&title, "title", "", "New title")
+	cmd.Flags().StringVar(&summary, "summary", "", "New summary")
+	cmd.Flags().StringVar(&scope, "scope", "", "New scope (file path pattern)")
+	cmd.Flags().StringSliceVar(&tags, "tags", nil, "Replace the tags (comma-separated)")
+	cmd.Flags().StringVar(&reviewAfter, "review-after", "", "New review-by date (YYYY-MM-DD, empty to remove)")
+	cmd.Flags().StringVar(&owner, "owner", "", "New owner")
+	cmd.Flags().StringVar(&language, "lang", "", "New code language of a snippet")
+	cmd.Flags().Int64Var(&ifMatch, "if-match", 0, "Only update if the current version matches")
+
+	return cmd
+}
+
+// runUpdate sends tags, review date, owner and language only when they are non-nil; nil keeps the current value
+func runUpdate(knowledgeID, file, title, summary, scope string, tags []string, reviewAfter, owner, language *string, ifMatch int64, outputJSON bool) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.Get(fmt.Sprintf("/knowledge/%s", knowledgeID))
+	if err != nil {
+		return fmt.Errorf("failed to get knowledge: %w", err)
+	}
+
+	var current Knowledge
+	if err := json.Unmarshal(resp.Data, &current); err != nil {
+		return fmt.Errorf("failed to parse knowledge: %w", err)
+	}
+
+	req := UpdateKnowledgeRequest{
+		Title:       current.Title,
+		Summary:     current.Summary,
+		BodyMD:      current.BodyMD,
+		Scope:       current.Scope,
+		Tags:        tags,
+		ReviewAfter: reviewAfter,
+		Owner:       owner,
+		Language:    language,
+	}
+
+	if file != "" {
+		var body []byte
+		if file == "-" {
+			body, err = io.ReadAll(os.Stdin)
+		} else {
+			body, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read body: %w", err)
+		}
+		req.BodyMD = string(body)
+	}
+	if title != "" {
+		req.Title = title
+	}
+	if summary != "" {
+		req.Summary = summary
+	}
+	if scope != "" {
+		req.Scope = scope
+	}
+
+	if req.BodyMD == "" {
+		return fmt.Errorf("body is required")
+	}
+
+	baseVersion := current.Version
+	if ifMatch > 0 {
+		baseVersion = ifMatch
+	}
+
+	opts := RequestOptions{}
+	if baseVersion > 0 {
+		opts.IfMatch = strconv.Quote(strconv.FormatInt(baseVersion, 10))
+	}
+
+	resp, err = api.PutWithOptions(fmt.Sprintf("/knowledge/%s", knowledgeID), req, opts)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+			return reportVersionConflict(knowledgeID, baseVersion, apiErr, outputJSON)
+		}
+		if errors.As(err, &apiErr) {
+			if lintErr := reportLintFailure(apiErr, outputJSON); lintErr != nil {
+				return lintErr
+			}
+		}
+		return fmt.Errorf("failed to update knowledge: %w", err)
+	}
+
+	var knowledge Knowledge
+	if err := json.Unmarshal(resp.Data, &knowledge); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(knowledge, "", "  ")
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("Updated knowledge: %s (v%d)\n", knowledge.ID, knowledge.Version)
+		fmt.Printf("Title: %s\n", knowledge.Title)
+		printLintFindings(knowledge.LintFindings)
+	}
+
+	return nil
+}
+
+// reportVersionConflict explains a 412 response and, when interactive, offers a diff
+// between the version the edit was based on and the current one.
+func reportVersionConflict(knowledgeID string, baseVersion int64, apiErr *APIError, outputJSON bool) error {
+	var current Knowledge
+	_ = json.Unmarshal(apiErr.Data, &current)
+
+	if outputJSON {
+		output, _ := json.MarshalIndent(map[string]interface{}{
+			"error":           "version_conflict",
+			"id":              knowledgeID,
+			"base_version":    baseVersion,
+			"current_version": current.Version,
+			"current":         current,
+		}, "", "  ")
+		fmt.Println(string(output))
+		return fmt.Errorf("update rejected: knowledge was modified (now v%d)", current.Version)
+	}
+
+	fmt.Printf("Conflict: %s was modified by someone else.\n", knowledgeID)
+	fmt.Printf("Your edit is based on v%d, the current version is v%d.\n", baseVersion, current.Version)
+
+	if current.Version > baseVersion && isInteractive() {
+		fmt.Print("Show diff between the two versions? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		if strings.EqualFold(strings.TrimSpace(answer), "y") {
+			fmt.Println()
+			if err := runDiff(knowledgeID, baseVersion, current.Version, false); err != nil {
+				return err
+			}
+		}
+	} else if current.Version > baseVersion {
+		fmt.Printf("Run 'neotex diff %s %d %d' to see what changed.\n", knowledgeID, baseVersion, current.Version)
+	}
+
+	return fmt.Errorf("update rejected: re-apply your changes on top of v%d", current.Version)
+}
+
+// isInteractive reports whether stdin is a terminal
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -23,6 +24,29 @@ type Config struct {
 	OpenAIAPIKey string `envconfig:"OPENAI_API_KEY"`
 	// EmbeddingWorkers controls how many embedding workers to start
 	EmbeddingWorkers int `envconfig:"EMBEDDING_WORKERS" default:"1"`
+	// TextProvider selects the text generation provider used to fill in missing summaries
+	// and suggest titles: "openai" for any OpenAI-compatible API or "stub" for a
+	// deterministic local stand-in. Generation is disabled when empty.
+	TextProvider string `envconfig:"TEXT_PROVIDER"`
+	// TextAPIURL is the base URL of the OpenAI-compatible API; empty means the OpenAI API
+	TextAPIURL string `envconfig:"TEXT_API_URL"`
+	// TextAPIKey authenticates with the text API; OPENAI_API_KEY is used when empty
+	TextAPIKey string `envconfig:"TEXT_API_KEY"`
+	TextModel  string `envconfig:"TEXT_MODEL" default:"gpt-4o-mini"`
+	// SummaryInterval controls how often knowledge without a summary is looked for
+	SummaryInterval time.Duration `envconfig:"SUMMARY_INTERVAL" default:"5m"`
+	// DuplicateThreshold is the similarity (0-1) above which new knowledge is rejected as a
+	// likely duplicate; 0 disables the check
+	DuplicateThreshold float64 `envconfig:"DUPLICATE_THRESHOLD" default:"0.9"`
+	// StaleCheckInterval controls how often knowledge past its review-by date is flagged
+	StaleCheckInterval time.Duration `envconfig:"STALE_CHECK_INTERVAL" default:"1h"`
+	// IdempotencyTTL controls how long responses to requests with an Idempotency-Key are replayed
+	IdempotencyTTL time.Duration `envconfig:"IDEMPOTENCY_TTL" default:"24h"`
+	// MaxArchiveBytes caps the size of an archive uploaded to POST /admin/orgs/{orgID}/restore
+	MaxArchiveBytes int64 `envconfig:"MAX_ARCHIVE_BYTES" default:"268435456"`
+
+	// AdminToken authorizes the operator endpoints under /admin; they are disabled when empty
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
 
 	// Bootstrap: create initial organization and API key on startup
 	InitOrgName string `envconfig:"INIT_ORG_NAME"`
@@ -55,3 +79,11 @@ func (c *Config) HasS3() bool {
 func (c *Config) HasOpenAI() bool {
 	return c.OpenAIAPIKey != ""
 }
+
+// TextGenerationKey returns the API key for text generation, falling back to the OpenAI key
+func (c *Config) TextGenerationKey() string {
+	if c.TextAPIKey != "" {
+		return c.TextAPIKey
+	}
+	return c.OpenAIAPIKey
+}
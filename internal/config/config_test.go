@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,11 @@ func TestLoad_Defaults(t *testing.T) {
 	assert.Equal(t, "neotex-assets", cfg.S3Bucket)
 	assert.Equal(t, "us-east-1", cfg.S3Region)
 	assert.Equal(t, 1, cfg.EmbeddingWorkers)
+	assert.Equal(t, time.Hour, cfg.StaleCheckInterval)
+	assert.Equal(t, 0.9, cfg.DuplicateThreshold)
+	assert.Empty(t, cfg.TextProvider)
+	assert.Equal(t, "gpt-4o-mini", cfg.TextModel)
+	assert.Equal(t, 5*time.Minute, cfg.SummaryInterval)
 }
 
 func TestLoad_RequiredDatabaseURL(t *testing.T) {
@@ -79,3 +85,11 @@ func TestHasOpenAI(t *testing.T) {
 	cfg.OpenAIAPIKey = ""
 	assert.False(t, cfg.HasOpenAI())
 }
+
+func TestTextGenerationKey(t *testing.T) {
+	cfg := &Config{OpenAIAPIKey: "sk-embeddings"}
+	assert.Equal(t, "sk-embeddings", cfg.TextGenerationKey())
+
+	cfg.TextAPIKey = "sk-text"
+	assert.Equal(t, "sk-text", cfg.TextGenerationKey())
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "strings"
+
+// maxAuthorNameLength caps client-supplied agent or user names
+const maxAuthorNameLength = 100
+
+// Author identifies who wrote a knowledge item or version: the API key the request was
+// authenticated with and a display name. The name is the agent or user name the client
+// sent, or the API key's name when it sent none; only the key ID is verified.
+type Author struct {
+	APIKeyID string
+	Name     string
+}
+
+// IsZero returns true if nothing is known about the author
+func (a Author) IsZero() bool {
+	return a.APIKeyID == "" && a.Name == ""
+}
+
+// NewAuthor creates an Author, preferring the client-supplied name over the key name
+func NewAuthor(apiKeyID, apiKeyName, clientName string) Author {
+	name := NormalizeAuthorName(clientName)
+	if name == "" {
+		name = NormalizeAuthorName(apiKeyName)
+	}
+	return Author{APIKeyID: apiKeyID, Name: name}
+}
+
+// NormalizeAuthorName trims an author name, drops control characters and caps its length
+func NormalizeAuthorName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > maxAuthorNameLength {
+		name = strings.TrimSpace(string(runes[:maxAuthorNameLength]))
+	}
+	return name
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthor(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyName    string
+		clientName string
+		expected   Author
+	}{
+		{name: "client name wins", keyName: "ci-key", clientName: "release-bot", expected: Author{APIKeyID: "key-1", Name: "release-bot"}},
+		{name: "falls back to key name", keyName: "ci-key", clientName: "  ", expected: Author{APIKeyID: "key-1", Name: "ci-key"}},
+		{name: "control characters dropped", keyName: "ci-key", clientName: "bot\r\nX-Injected: 1", expected: Author{APIKeyID: "key-1", Name: "botX-Injected: 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewAuthor("key-1", tt.keyName, tt.clientName))
+		})
+	}
+}
+
+func TestNormalizeAuthorName_CapsLength(t *testing.T) {
+	assert.Len(t, NormalizeAuthorName(strings.Repeat("a", 500)), maxAuthorNameLength)
+}
+
+func TestAuthor_IsZero(t *testing.T) {
+	assert.True(t, Author{}.IsZero())
+	assert.False(t, Author{Name: "bot"}.IsZero())
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checklistItemPattern matches markdown task list items such as "- [ ] Tag the release"
+var checklistItemPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+
+// ChecklistItem is a task list item parsed from a checklist body
+type ChecklistItem struct {
+	// Number is the 1-based position of the item in the body
+	Number int
+	Text   string
+	// Section is the nearest markdown heading above the item
+	Section string
+	// Checked is true if the item is pre-ticked in the markdown ("- [x]")
+	Checked bool
+}
+
+// Checklist is the structured form of one version of a checklist-type knowledge item
+type Checklist struct {
+	KnowledgeID   string
+	VersionNumber int64
+	Title         string
+	Items         []ChecklistItem
+}
+
+// ParseChecklistItems extracts the task list items of a markdown body.
+// Items inside fenced code blocks are ignored.
+func ParseChecklistItems(body string) []ChecklistItem {
+	var items []ChecklistItem
+	section := ""
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			heading := strings.TrimLeft(trimmed, "#")
+			if heading != "" && (heading[0] == ' ' || heading[0] == '\t') {
+				section = strings.TrimSpace(strings.TrimRight(heading, "# \t"))
+			}
+			continue
+		}
+		m := checklistItemPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		items = append(items, ChecklistItem{
+			Number:  len(items) + 1,
+			Text:    m[2],
+			Section: section,
+			Checked: m[1] != " ",
+		})
+	}
+	return items
+}
+
+// ChecklistRunStatus represents the state of a checklist run
+type ChecklistRunStatus string
+
+const (
+	ChecklistRunStatusInProgress ChecklistRunStatus = "in_progress"
+	ChecklistRunStatusCompleted  ChecklistRunStatus = "completed"
+)
+
+// ChecklistRun records one pass through a checklist. The items are copied from the
+// checklist version the run was started from, so the run stays auditable after edits.
+type ChecklistRun struct {
+	ID            string
+	OrgID         string
+	KnowledgeID   string
+	VersionNumber int64
+	Status        ChecklistRunStatus
+	StartedBy     string
+	StartedAt     time.Time
+	CompletedBy   string
+	CompletedAt   *time.Time
+	Note          string
+	Items         []*ChecklistRunItem
+}
+
+// ChecklistRunItem is the state of one checklist item within a run
+type ChecklistRunItem struct {
+	Number    int
+	Text      string
+	Section   string
+	CheckedBy string
+	CheckedAt *time.Time
+	Note      string
+}
+
+// IsChecked returns true if the item has been ticked off in the run
+func (i *ChecklistRunItem) IsChecked() bool {
+	return i.CheckedAt != nil
+}
+
+// IsCompleted returns true if the run has been completed
+func (r *ChecklistRun) IsCompleted() bool {
+	return r.Status == ChecklistRunStatusCompleted
+}
+
+// Item returns the run item with the given number, or nil
+func (r *ChecklistRun) Item(number int) *ChecklistRunItem {
+	for _, item := range r.Items {
+		if item.Number == number {
+			return item
+		}
+	}
+	return nil
+}
+
+// UncheckedItems returns the numbers of the items not ticked off yet
+func (r *ChecklistRun) UncheckedItems() []int {
+	var unchecked []int
+	for _, item := range r.Items {
+		if !item.IsChecked() {
+			unchecked = append(unchecked, item.Number)
+		}
+	}
+	return unchecked
+}
+
+// NewChecklistRun starts a run over the items of a checklist version
+func NewChecklistRun(id, orgID string, checklist *Checklist, startedBy, note string, startedAt time.Time) *ChecklistRun {
+	items := make([]*ChecklistRunItem, len(checklist.Items))
+	for i, item := range checklist.Items {
+		items[i] = &ChecklistRunItem{
+			Number:  item.Number,
+			Text:    item.Text,
+			Section: item.Section,
+		}
+	}
+	return &ChecklistRun{
+		ID:            id,
+		OrgID:         orgID,
+		KnowledgeID:   checklist.KnowledgeID,
+		VersionNumber: checklist.VersionNumber,
+		Status:        ChecklistRunStatusInProgress,
+		StartedBy:     startedBy,
+		StartedAt:     startedAt,
+		Note:          note,
+		Items:         items,
+	}
+}
+
+// ValidateChecklistRun validates a ChecklistRun instance
+func ValidateChecklistRun(r *ChecklistRun) error {
+	if r == nil {
+		return fmt.Errorf("checklist run cannot be nil")
+	}
+
+	if r.ID == "" {
+		return fmt.Errorf("checklist run ID is required")
+	}
+
+	if r.OrgID == "" {
+		return fmt.Errorf("checklist run OrgID is required")
+	}
+
+	if r.KnowledgeID == "" {
+		return fmt.Errorf("checklist run KnowledgeID is required")
+	}
+
+	if r.VersionNumber <= 0 {
+		return fmt.Errorf("checklist run VersionNumber must be positive")
+	}
+
+	if strings.TrimSpace(r.StartedBy) == "" {
+		return fmt.Errorf("checklist run StartedBy is required")
+	}
+
+	if len(r.Items) == 0 {
+		return fmt.Errorf("checklist run has no items")
+	}
+
+	return nil
+}
+
+// NewChecklistRunIncompleteError reports the items that still need to be checked before completing a run
+func NewChecklistRunIncompleteError(unchecked []int) *DomainError {
+	numbers := make([]string, len(unchecked))
+	for i, n := range unchecked {
+		numbers[i] = strconv.Itoa(n)
+	}
+	return NewDomainError(ErrCodeInvalidOperation,
+		fmt.Sprintf("checklist items not checked: %s", strings.Join(numbers, ", ")))
+}
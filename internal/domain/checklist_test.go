@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChecklistItems(t *testing.T) {
+	body := `# Release checklist
+
+Intro text, not an item.
+
+## Before
+- [ ] Freeze main
+* [x] Bump version
+  - [ ] Update CHANGELOG
+
+` + "```md\n- [ ] example inside a code block\n```" + `
+
+## After ##
++ [X] Tag the release
+- [] not an item
+- [ ]
+1. [ ] numbered lists are not items
+`
+
+	items := ParseChecklistItems(body)
+
+	assert.Equal(t, []ChecklistItem{
+		{Number: 1, Text: "Freeze main", Section: "Before"},
+		{Number: 2, Text: "Bump version", Section: "Before", Checked: true},
+		{Number: 3, Text: "Update CHANGELOG", Section: "Before"},
+		{Number: 4, Text: "Tag the release", Section: "After", Checked: true},
+	}, items)
+
+	assert.Empty(t, ParseChecklistItems("no items here"))
+}
+
+func TestNewChecklistRun(t *testing.T) {
+	now := time.Now()
+	checklist := &Checklist{
+		KnowledgeID:   "k1",
+		VersionNumber: 3,
+		Items: []ChecklistItem{
+			{Number: 1, Text: "Freeze main", Section: "Before", Checked: true},
+			{Number: 2, Text: "Tag the release"},
+		},
+	}
+
+	run := NewChecklistRun("run1", "org1", checklist, "alice", "v1.2.0", now)
+
+	assert.Equal(t, "run1", run.ID)
+	assert.Equal(t, "org1", run.OrgID)
+	assert.Equal(t, "k1", run.KnowledgeID)
+	assert.Equal(t, int64(3), run.VersionNumber)
+	assert.Equal(t, ChecklistRunStatusInProgress, run.Status)
+	assert.Equal(t, "alice", run.StartedBy)
+	assert.Equal(t, "v1.2.0", run.Note)
+	assert.Equal(t, now, run.StartedAt)
+	require.Len(t, run.Items, 2)
+	assert.Equal(t, "Before", run.Items[0].Section)
+	assert.False(t, run.Items[0].IsChecked(), "pre-ticked markdown items still need to be checked in a run")
+	assert.Equal(t, []int{1, 2}, run.UncheckedItems())
+
+	run.Item(2).CheckedAt = &now
+	assert.Equal(t, []int{1}, run.UncheckedItems())
+	assert.Nil(t, run.Item(3))
+}
+
+func TestValidateChecklistRun(t *testing.T) {
+	valid := func() *ChecklistRun {
+		return NewChecklistRun("run1", "org1", &Checklist{
+			KnowledgeID:   "k1",
+			VersionNumber: 1,
+			Items:         []ChecklistItem{{Number: 1, Text: "Freeze main"}},
+		}, "alice", "", time.Now())
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *ChecklistRun)
+		wantErr string
+	}{
+		{"valid", func(r *ChecklistRun) {}, ""},
+		{"missing ID", func(r *ChecklistRun) { r.ID = "" }, "ID is required"},
+		{"missing OrgID", func(r *ChecklistRun) { r.OrgID = "" }, "OrgID is required"},
+		{"missing KnowledgeID", func(r *ChecklistRun) { r.KnowledgeID = "" }, "KnowledgeID is required"},
+		{"invalid version", func(r *ChecklistRun) { r.VersionNumber = 0 }, "VersionNumber must be positive"},
+		{"missing StartedBy", func(r *ChecklistRun) { r.StartedBy = " " }, "StartedBy is required"},
+		{"no items", func(r *ChecklistRun) { r.Items = nil }, "has no items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.mutate(r)
+			err := ValidateChecklistRun(r)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+
+	assert.Error(t, ValidateChecklistRun(nil))
+}
+
+func TestNewChecklistRunIncompleteError(t *testing.T) {
+	err := NewChecklistRunIncompleteError([]int{2, 5})
+
+	assert.Equal(t, ErrCodeInvalidOperation, err.Code)
+	assert.Equal(t, "checklist items not checked: 2, 5", err.Message)
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxCommentBodyLength caps the size of a single comment
+const MaxCommentBodyLength = 10000
+
+// KnowledgeComment is a comment in a discussion thread on a knowledge item. A comment
+// without a parent opens a thread and carries its anchor and resolution; replies belong
+// to the thread of their parent.
+type KnowledgeComment struct {
+	ID          string
+	OrgID       string
+	KnowledgeID string
+	ParentID    string
+	// VersionNumber is the knowledge version the comment was written against
+	VersionNumber int64
+	Body          string
+	Author        Author
+	// LineStart and LineEnd anchor a thread to body lines [LineStart, LineEnd) of that
+	// version, the same form as context open ranges; LineEnd is zero without a line anchor
+	LineStart int
+	LineEnd   int
+	// ChunkID anchors a thread to a search chunk; re-embedding replaces chunks, so it
+	// points at the chunk as it was when the comment was written
+	ChunkID    string
+	ResolvedBy Author
+	ResolvedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsReply returns true if the comment answers another comment instead of opening a thread
+func (c *KnowledgeComment) IsReply() bool {
+	return c.ParentID != ""
+}
+
+// IsResolved returns true if the thread opened by the comment has been resolved
+func (c *KnowledgeComment) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
+
+// HasLineRange returns true if the comment is anchored to a line range
+func (c *KnowledgeComment) HasLineRange() bool {
+	return c.LineEnd > 0
+}
+
+// CommentThread is a comment that opened a thread with its replies, oldest first
+type CommentThread struct {
+	*KnowledgeComment
+	Replies []*KnowledgeComment
+}
+
+// NewKnowledgeComment creates a new KnowledgeComment instance
+func NewKnowledgeComment(
+	id, orgID, knowledgeID, parentID string,
+	versionNumber int64,
+	body string,
+	author Author,
+	createdAt time.Time,
+) *KnowledgeComment {
+	return &KnowledgeComment{
+		ID:            id,
+		OrgID:         orgID,
+		KnowledgeID:   knowledgeID,
+		ParentID:      parentID,
+		VersionNumber: versionNumber,
+		Body:          body,
+		Author:        author,
+		CreatedAt:     createdAt,
+	}
+}
+
+// ValidateKnowledgeComment validates a KnowledgeComment instance
+func ValidateKnowledgeComment(c *KnowledgeComment) error {
+	if c == nil {
+		return fmt.Errorf("knowledge comment cannot be nil")
+	}
+
+	if c.ID == "" {
+		return fmt.Errorf("knowledge comment ID is required")
+	}
+
+	if c.OrgID == "" {
+		return fmt.Errorf("knowledge comment OrgID is required")
+	}
+
+	if c.KnowledgeID == "" {
+		return fmt.Errorf("knowledge comment KnowledgeID is required")
+	}
+
+	if c.VersionNumber <= 0 {
+		return fmt.Errorf("knowledge comment VersionNumber must be positive")
+	}
+
+	if strings.TrimSpace(c.Body) == "" {
+		return fmt.Errorf("knowledge comment Body is required")
+	}
+
+	if len(c.Body) > MaxCommentBodyLength {
+		return fmt.Errorf("knowledge comment Body exceeds %d characters", MaxCommentBodyLength)
+	}
+
+	if c.LineStart < 0 || (c.LineEnd == 0 && c.LineStart > 0) || (c.LineEnd > 0 && c.LineEnd <= c.LineStart) {
+		return fmt.Errorf("knowledge comment line range is invalid: %d:%d", c.LineStart, c.LineEnd)
+	}
+
+	if c.IsReply() && (c.HasLineRange() || c.ChunkID != "") {
+		return fmt.Errorf("knowledge comment replies cannot be anchored")
+	}
+
+	return nil
+}
+
+// GroupCommentThreads groups comments ordered oldest first into threads. Replies whose
+// thread is not among the comments are dropped.
+func GroupCommentThreads(comments []*KnowledgeComment) []*CommentThread {
+	threads := make([]*CommentThread, 0)
+	byID := make(map[string]*CommentThread)
+	for _, c := range comments {
+		if c.IsReply() {
+			continue
+		}
+		thread := &CommentThread{KnowledgeComment: c, Replies: make([]*KnowledgeComment, 0)}
+		threads = append(threads, thread)
+		byID[c.ID] = thread
+	}
+	for _, c := range comments {
+		if !c.IsReply() {
+			continue
+		}
+		if thread, ok := byID[c.ParentID]; ok {
+			thread.Replies = append(thread.Replies, c)
+		}
+	}
+	return threads
+}
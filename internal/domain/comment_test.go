@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKnowledgeComment(t *testing.T) {
+	now := time.Now()
+	author := Author{APIKeyID: "key1", Name: "alice"}
+
+	c := NewKnowledgeComment("c1", "org1", "k1", "", 2, "Is this still true?", author, now)
+
+	assert.Equal(t, "c1", c.ID)
+	assert.Equal(t, "org1", c.OrgID)
+	assert.Equal(t, "k1", c.KnowledgeID)
+	assert.Equal(t, int64(2), c.VersionNumber)
+	assert.Equal(t, "Is this still true?", c.Body)
+	assert.Equal(t, author, c.Author)
+	assert.Equal(t, now, c.CreatedAt)
+	assert.False(t, c.IsReply())
+	assert.False(t, c.IsResolved())
+	assert.False(t, c.HasLineRange())
+}
+
+func TestValidateKnowledgeComment(t *testing.T) {
+	valid := func() *KnowledgeComment {
+		return NewKnowledgeComment("c1", "org1", "k1", "", 1, "Is this still true?", Author{}, time.Now())
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *KnowledgeComment)
+		wantErr string
+	}{
+		{name: "valid thread", modify: func(c *KnowledgeComment) {}},
+		{name: "line anchor", modify: func(c *KnowledgeComment) { c.LineStart, c.LineEnd = 0, 3 }},
+		{name: "chunk anchor", modify: func(c *KnowledgeComment) { c.ChunkID = "chunk1" }},
+		{name: "reply", modify: func(c *KnowledgeComment) { c.ParentID = "c0" }},
+		{name: "missing ID", modify: func(c *KnowledgeComment) { c.ID = "" }, wantErr: "ID is required"},
+		{name: "missing org", modify: func(c *KnowledgeComment) { c.OrgID = "" }, wantErr: "OrgID is required"},
+		{name: "missing knowledge", modify: func(c *KnowledgeComment) { c.KnowledgeID = "" }, wantErr: "KnowledgeID is required"},
+		{name: "missing version", modify: func(c *KnowledgeComment) { c.VersionNumber = 0 }, wantErr: "VersionNumber must be positive"},
+		{name: "blank body", modify: func(c *KnowledgeComment) { c.Body = "  \n" }, wantErr: "Body is required"},
+		{name: "long body", modify: func(c *KnowledgeComment) { c.Body = strings.Repeat("a", MaxCommentBodyLength+1) }, wantErr: "Body exceeds"},
+		{name: "empty range", modify: func(c *KnowledgeComment) { c.LineStart, c.LineEnd = 4, 4 }, wantErr: "line range is invalid"},
+		{name: "start without end", modify: func(c *KnowledgeComment) { c.LineStart = 4 }, wantErr: "line range is invalid"},
+		{name: "negative start", modify: func(c *KnowledgeComment) { c.LineStart, c.LineEnd = -1, 2 }, wantErr: "line range is invalid"},
+		{name: "anchored reply", modify: func(c *KnowledgeComment) { c.ParentID, c.ChunkID = "c0", "chunk1" }, wantErr: "replies cannot be anchored"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			err := ValidateKnowledgeComment(c)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.Error(t, ValidateKnowledgeComment(nil))
+}
+
+func TestGroupCommentThreads(t *testing.T) {
+	now := time.Now()
+	first := NewKnowledgeComment("c1", "org1", "k1", "", 1, "Why 30 seconds?", Author{}, now)
+	second := NewKnowledgeComment("c2", "org1", "k1", "", 1, "Typo in step 2", Author{}, now)
+	reply := NewKnowledgeComment("c3", "org1", "k1", "c1", 1, "Load balancer timeout", Author{}, now)
+	orphan := NewKnowledgeComment("c4", "org1", "k1", "c9", 1, "Thread not listed", Author{}, now)
+
+	threads := GroupCommentThreads([]*KnowledgeComment{first, second, reply, orphan})
+
+	require.Len(t, threads, 2)
+	assert.Equal(t, "c1", threads[0].ID)
+	assert.Equal(t, []*KnowledgeComment{reply}, threads[0].Replies)
+	assert.Equal(t, "c2", threads[1].ID)
+	assert.Empty(t, threads[1].Replies)
+
+	assert.Empty(t, GroupCommentThreads(nil))
+}
@@ -42,13 +42,14 @@ func NewDomainErrorWithCause(code, message string, err error) *DomainError {
 
 // Common domain error codes
 const (
-	ErrCodeValidation       = "VALIDATION_ERROR"
-	ErrCodeNotFound         = "NOT_FOUND"
-	ErrCodeAlreadyExists    = "ALREADY_EXISTS"
-	ErrCodeUnauthorized     = "UNAUTHORIZED"
-	ErrCodeForbidden        = "FORBIDDEN"
-	ErrCodeInternalError    = "INTERNAL_ERROR"
-	ErrCodeInvalidOperation = "INVALID_OPERATION"
+	ErrCodeValidation         = "VALIDATION_ERROR"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeAlreadyExists      = "ALREADY_EXISTS"
+	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeForbidden          = "FORBIDDEN"
+	ErrCodeInternalError      = "INTERNAL_ERROR"
+	ErrCodeInvalidOperation   = "INVALID_OPERATION"
+	ErrCodePreconditionFailed = "PRECONDITION_FAILED"
 )
 
 // Validation errors
@@ -57,24 +58,58 @@ var (
 	ErrInvalidKnowledgeStatus    = NewDomainError(ErrCodeValidation, "invalid knowledge status")
 	ErrInvalidEmbeddingJobStatus = NewDomainError(ErrCodeValidation, "invalid embedding job status")
 	ErrMissingRequiredField      = NewDomainError(ErrCodeValidation, "missing required field")
+	ErrReviewerRequired          = NewDomainError(ErrCodeValidation, "reviewer is required")
+	ErrReviewNoteRequired        = NewDomainError(ErrCodeValidation, "note is required when rejecting")
+	ErrSupersedeSelf             = NewDomainError(ErrCodeValidation, "knowledge cannot supersede itself")
+	ErrSuccessorNotFound         = NewDomainError(ErrCodeValidation, "superseded_by does not reference knowledge in this organization")
+	ErrSuccessorDeprecated       = NewDomainError(ErrCodeValidation, "superseded_by references deprecated knowledge")
+	ErrInvalidRelationType       = NewDomainError(ErrCodeValidation, "invalid relation type")
+	ErrRelateSelf                = NewDomainError(ErrCodeValidation, "knowledge cannot be related to itself")
+	ErrRelationTargetNotFound    = NewDomainError(ErrCodeValidation, "target_id does not reference knowledge in this organization")
+	ErrInvalidKnowledgeTypeName  = NewDomainError(ErrCodeValidation, "knowledge type name must be a lowercase slug (letters, digits and dashes)")
+	ErrChecklistEmpty            = NewDomainError(ErrCodeValidation, "checklist has no items")
+	ErrActorRequired             = NewDomainError(ErrCodeValidation, "by is required")
+	ErrInvalidLanguage           = NewDomainError(ErrCodeValidation, "language must be a lowercase name such as go, python or c++")
+	ErrCommentBodyRequired       = NewDomainError(ErrCodeValidation, "comment body is required")
+	ErrCommentTooLong            = NewDomainError(ErrCodeValidation, "comment body is too long")
+	ErrInvalidCommentAnchor      = NewDomainError(ErrCodeValidation, "comment anchor must be a line range within the body or a chunk of the item")
+	ErrInvalidCommentStatus      = NewDomainError(ErrCodeValidation, "comment status must be open, resolved or all")
+	ErrBatchEmpty                = NewDomainError(ErrCodeValidation, "batch has no items")
+	ErrBatchTooLarge             = NewDomainError(ErrCodeValidation, "batch has too many items")
+	ErrInvalidBatchMode          = NewDomainError(ErrCodeValidation, "batch mode must be atomic or best_effort")
+	ErrInvalidScopeMove          = NewDomainError(ErrCodeValidation, "from and to must be different, non-empty scope prefixes")
+	ErrInvalidMergeMode          = NewDomainError(ErrCodeValidation, "merge mode must be append or replace")
+	ErrMergeSelf                 = NewDomainError(ErrCodeValidation, "knowledge cannot be merged into itself")
+	ErrMergeSourceNotFound       = NewDomainError(ErrCodeValidation, "source_id does not reference knowledge in this organization")
+	ErrLintFailed                = NewDomainError(ErrCodeValidation, "knowledge breaks the lint rules of this organization")
 )
 
 // Not found errors
 var (
-	ErrKnowledgeNotFound    = NewDomainError(ErrCodeNotFound, "knowledge item not found")
-	ErrAssetNotFound        = NewDomainError(ErrCodeNotFound, "asset not found")
-	ErrOrganizationNotFound = NewDomainError(ErrCodeNotFound, "organization not found")
-	ErrProjectNotFound      = NewDomainError(ErrCodeNotFound, "project not found")
-	ErrAPIKeyNotFound       = NewDomainError(ErrCodeNotFound, "api key not found")
+	ErrKnowledgeNotFound     = NewDomainError(ErrCodeNotFound, "knowledge item not found")
+	ErrVersionNotFound       = NewDomainError(ErrCodeNotFound, "knowledge version not found")
+	ErrAssetNotFound         = NewDomainError(ErrCodeNotFound, "asset not found")
+	ErrOrganizationNotFound  = NewDomainError(ErrCodeNotFound, "organization not found")
+	ErrProjectNotFound       = NewDomainError(ErrCodeNotFound, "project not found")
+	ErrAPIKeyNotFound        = NewDomainError(ErrCodeNotFound, "api key not found")
+	ErrRelationNotFound      = NewDomainError(ErrCodeNotFound, "knowledge relation not found")
+	ErrKnowledgeTypeNotFound = NewDomainError(ErrCodeNotFound, "knowledge type not found")
+	ErrChecklistRunNotFound  = NewDomainError(ErrCodeNotFound, "checklist run not found")
+	ErrChecklistItemNotFound = NewDomainError(ErrCodeNotFound, "checklist item not found")
+	ErrCommentNotFound       = NewDomainError(ErrCodeNotFound, "comment not found")
 )
 
 // Already exists errors
 var (
-	ErrKnowledgeAlreadyExists    = NewDomainError(ErrCodeAlreadyExists, "knowledge item already exists")
-	ErrAssetAlreadyExists        = NewDomainError(ErrCodeAlreadyExists, "asset already exists")
-	ErrOrganizationAlreadyExists = NewDomainError(ErrCodeAlreadyExists, "organization already exists")
-	ErrProjectAlreadyExists      = NewDomainError(ErrCodeAlreadyExists, "project already exists")
-	ErrAPIKeyAlreadyExists       = NewDomainError(ErrCodeAlreadyExists, "api key already exists")
+	ErrKnowledgeAlreadyExists     = NewDomainError(ErrCodeAlreadyExists, "knowledge item already exists")
+	ErrAssetAlreadyExists         = NewDomainError(ErrCodeAlreadyExists, "asset already exists")
+	ErrOrganizationAlreadyExists  = NewDomainError(ErrCodeAlreadyExists, "organization already exists")
+	ErrProjectAlreadyExists       = NewDomainError(ErrCodeAlreadyExists, "project already exists")
+	ErrAPIKeyAlreadyExists        = NewDomainError(ErrCodeAlreadyExists, "api key already exists")
+	ErrRelationAlreadyExists      = NewDomainError(ErrCodeAlreadyExists, "knowledge relation already exists")
+	ErrKnowledgeTypeAlreadyExists = NewDomainError(ErrCodeAlreadyExists, "knowledge type already exists")
+	ErrDuplicateKnowledge         = NewDomainError(ErrCodeAlreadyExists, "similar knowledge already exists; update it or create with force")
+	ErrArchiveIDConflict          = NewDomainError(ErrCodeAlreadyExists, "archive contains IDs that already exist; restore with remapped IDs")
 )
 
 // Authorization errors
@@ -83,10 +118,26 @@ var (
 	ErrInvalidAPIKey = NewDomainError(ErrCodeUnauthorized, "invalid api key")
 )
 
+// Permission errors
+var (
+	ErrSelfApproval = NewDomainError(ErrCodeForbidden, "knowledge cannot be approved with the api key that wrote it")
+)
+
 // Operation errors
 var (
 	ErrCannotModifyDeprecated = NewDomainError(ErrCodeInvalidOperation, "cannot modify deprecated knowledge")
 	ErrCannotDeleteKnowledge  = NewDomainError(ErrCodeInvalidOperation, "cannot delete knowledge, use deprecation instead")
+	ErrKnowledgeNotPending    = NewDomainError(ErrCodeInvalidOperation, "knowledge is not pending review")
+	ErrKnowledgeTypeInUse     = NewDomainError(ErrCodeInvalidOperation, "knowledge type is still used by knowledge items")
+	ErrNotATemplate           = NewDomainError(ErrCodeInvalidOperation, "knowledge item is not a template")
+	ErrNotAChecklist          = NewDomainError(ErrCodeInvalidOperation, "knowledge item is not a checklist")
+	ErrChecklistRunCompleted  = NewDomainError(ErrCodeInvalidOperation, "checklist run is already completed")
+	ErrCommentIsReply         = NewDomainError(ErrCodeInvalidOperation, "comment is a reply; anchor and resolve the thread it belongs to")
+)
+
+// Concurrency errors
+var (
+	ErrVersionConflict = NewDomainError(ErrCodePreconditionFailed, "knowledge was modified since the expected version")
 )
 
 // Asset-specific errors
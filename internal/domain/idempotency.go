@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord is the stored outcome of a request made with an Idempotency-Key.
+// A record without a status code is reserved by a request that is still running.
+type IdempotencyRecord struct {
+	OrgID string
+	Key   string
+	// Route is the method and path the key was first used with
+	Route string
+	// RequestHash fingerprints the query string and body of that request
+	RequestHash string
+	StatusCode  int
+	// Headers holds the response headers that are replayed with the body
+	Headers   map[string]string
+	Body      []byte
+	CreatedAt time.Time
+	// ExpiresAt is when the key can be used again; for a reserved record it is when
+	// the reservation is considered abandoned
+	ExpiresAt time.Time
+}
+
+// IsCompleted returns true if the request has finished and its response is stored
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// Matches returns true if the record was stored for the same request
+func (r *IdempotencyRecord) Matches(route, requestHash string) bool {
+	return r.Route == route && r.RequestHash == requestHash
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRecord(t *testing.T) {
+	record := &IdempotencyRecord{Route: "POST /knowledge", RequestHash: "abc"}
+
+	assert.False(t, record.IsCompleted())
+	assert.True(t, record.Matches("POST /knowledge", "abc"))
+	assert.False(t, record.Matches("POST /knowledge", "def"))
+	assert.False(t, record.Matches("PUT /knowledge/k-1", "abc"))
+
+	record.StatusCode = 201
+	assert.True(t, record.IsCompleted())
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -23,6 +24,7 @@ type KnowledgeStatus string
 const (
 	KnowledgeStatusDraft      KnowledgeStatus = "draft"
 	KnowledgeStatusApproved   KnowledgeStatus = "approved"
+	KnowledgeStatusRejected   KnowledgeStatus = "rejected"
 	KnowledgeStatusDeprecated KnowledgeStatus = "deprecated"
 )
 
@@ -39,6 +41,102 @@ type Knowledge struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Scope     string // Optional scope (file or path)
+	// Review metadata, set when a reviewer approves or rejects the item; ReviewedBy carries
+	// the API key the review was made with
+	ReviewedBy Author
+	ReviewedAt *time.Time
+	ReviewNote string
+	// SupersededBy is the ID of the item that replaces a deprecated one
+	SupersededBy string
+	// Tags are free-form labels, normalized with NormalizeTags
+	Tags []string
+	// ReviewAfter is the date by which the item should be looked at again; Owner is who to ask
+	ReviewAfter *time.Time
+	Owner       string
+	// StaleSince is set by the stale knowledge job once ReviewAfter has passed
+	StaleSince *time.Time
+	// TemplateID and TemplateVersion record the template version an item was instantiated from
+	TemplateID      string
+	TemplateVersion int64
+	// Language is the code language of a snippet, normalized with NormalizeLanguage
+	Language string
+	// CreatedBy wrote the first version, UpdatedBy the latest one
+	CreatedBy Author
+	UpdatedBy Author
+	// SourcePath is the project-relative file an imported item was read from and SourceHash
+	// a hash of that file's contents at the last import
+	SourcePath string
+	SourceHash string
+	// SummaryGeneratedBy names the model that wrote the summary when it was generated
+	// rather than written by a person; SuggestedTitle is a generated title that is
+	// offered but never applied
+	SummaryGeneratedBy string
+	SuggestedTitle     string
+}
+
+// IsPendingReview returns true if the knowledge item is awaiting review
+func (k *Knowledge) IsPendingReview() bool {
+	return k.Status == KnowledgeStatusDraft
+}
+
+// IsSuperseded returns true if the knowledge item has been replaced by another item
+func (k *Knowledge) IsSuperseded() bool {
+	return k.SupersededBy != ""
+}
+
+// IsFromTemplate returns true if the item was instantiated from a template
+func (k *Knowledge) IsFromTemplate() bool {
+	return k.TemplateID != ""
+}
+
+// IsStale returns true if the item was flagged as overdue for review
+func (k *Knowledge) IsStale() bool {
+	return k.StaleSince != nil
+}
+
+// IsReviewOverdue returns true if the review-by date has passed at the given time
+func (k *Knowledge) IsReviewOverdue(now time.Time) bool {
+	return k.ReviewAfter != nil && !k.ReviewAfter.After(now)
+}
+
+// IsSummaryGenerated returns true if the summary was written by a model
+func (k *Knowledge) IsSummaryGenerated() bool {
+	return k.SummaryGeneratedBy != ""
+}
+
+// GeneratedMetadata is what the summary generation job produced for a knowledge item.
+// Summary is empty when the model had nothing to say and SuggestedTitle when it did not
+// come up with a better title.
+type GeneratedMetadata struct {
+	Summary        string
+	SuggestedTitle string
+	Model          string
+	GeneratedAt    time.Time
+}
+
+// Author is who a generated summary is recorded as in the version history
+func (g GeneratedMetadata) Author() Author {
+	return Author{Name: "summary-generator:" + g.Model}
+}
+
+// NormalizeTags trims and lowercases tags, dropping empty and duplicate entries.
+// The original order is kept so that callers control how tags are displayed;
+// a nil slice stays nil so that "not given" can be told apart from "no tags".
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
 }
 
 // KnowledgeVersion represents an immutable version of a knowledge item
@@ -50,6 +148,7 @@ type KnowledgeVersion struct {
 	Summary       string
 	BodyMD        string
 	CreatedAt     time.Time
+	CreatedBy     Author
 }
 
 // NewKnowledge creates a new Knowledge instance
@@ -115,7 +214,8 @@ func ValidateKnowledge(k *Knowledge) error {
 		return fmt.Errorf("knowledge BodyMD is required")
 	}
 
-	if !isValidKnowledgeType(k.Type) {
+	// Whether the type is registered for the org is checked by the service layer
+	if !IsValidKnowledgeTypeName(k.Type) {
 		return fmt.Errorf("knowledge Type is invalid: %s", k.Type)
 	}
 
@@ -147,20 +247,10 @@ func ValidateKnowledgeVersion(kv *KnowledgeVersion) error {
 	return nil
 }
 
-// isValidKnowledgeType checks if a KnowledgeType is valid
-func isValidKnowledgeType(t KnowledgeType) bool {
-	switch t {
-	case KnowledgeTypeGuideline, KnowledgeTypeLearning, KnowledgeTypeDecision,
-		KnowledgeTypeTemplate, KnowledgeTypeChecklist, KnowledgeTypeSnippet:
-		return true
-	}
-	return false
-}
-
 // isValidKnowledgeStatus checks if a KnowledgeStatus is valid
 func isValidKnowledgeStatus(s KnowledgeStatus) bool {
 	switch s {
-	case KnowledgeStatusDraft, KnowledgeStatusApproved, KnowledgeStatusDeprecated:
+	case KnowledgeStatusDraft, KnowledgeStatusApproved, KnowledgeStatusRejected, KnowledgeStatusDeprecated:
 		return true
 	}
 	return false
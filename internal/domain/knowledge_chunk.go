@@ -13,6 +13,10 @@ type KnowledgeChunk struct {
 	Title       string
 	Summary     string
 	Scope       string
+	Tags        []string
+	Language    string
+	CreatedBy   string
+	UpdatedBy   string
 	ChunkIndex  int
 	Content     string
 	Embedding   []float32
@@ -37,6 +37,7 @@ func TestKnowledgeStatusConstants(t *testing.T) {
 	}{
 		{"Draft", KnowledgeStatusDraft, "draft"},
 		{"Approved", KnowledgeStatusApproved, "approved"},
+		{"Rejected", KnowledgeStatusRejected, "rejected"},
 		{"Deprecated", KnowledgeStatusDeprecated, "deprecated"},
 	}
 
@@ -47,6 +48,42 @@ func TestKnowledgeStatusConstants(t *testing.T) {
 	}
 }
 
+func TestKnowledge_IsPendingReview(t *testing.T) {
+	tests := []struct {
+		status   KnowledgeStatus
+		expected bool
+	}{
+		{KnowledgeStatusDraft, true},
+		{KnowledgeStatusApproved, false},
+		{KnowledgeStatusRejected, false},
+		{KnowledgeStatusDeprecated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			k := &Knowledge{Status: tt.status}
+			assert.Equal(t, tt.expected, k.IsPendingReview())
+		})
+	}
+}
+
+func TestKnowledge_IsReviewOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.False(t, (&Knowledge{}).IsReviewOverdue(now))
+	assert.True(t, (&Knowledge{ReviewAfter: &past}).IsReviewOverdue(now))
+	assert.True(t, (&Knowledge{ReviewAfter: &now}).IsReviewOverdue(now))
+	assert.False(t, (&Knowledge{ReviewAfter: &future}).IsReviewOverdue(now))
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"backend", "go", "ci/cd"}, NormalizeTags([]string{" Backend", "go", "", "GO", "ci/cd "}))
+	assert.Nil(t, NormalizeTags(nil))
+	assert.Equal(t, []string{}, NormalizeTags([]string{" "}))
+}
+
 func TestNewKnowledge(t *testing.T) {
 	now := time.Now()
 	knowledge := NewKnowledge(
@@ -170,7 +207,7 @@ func TestValidateKnowledge(t *testing.T) {
 				ID:        "k1",
 				OrgID:     "org1",
 				ProjectID: "proj1",
-				Type:      KnowledgeType("invalid"),
+				Type:      KnowledgeType("Not A Type"),
 				Status:    KnowledgeStatusDraft,
 				Title:     "Test Title",
 				Summary:   "Test Summary",
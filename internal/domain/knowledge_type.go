@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultSearchBoost leaves search scores unchanged
+const DefaultSearchBoost float32 = 1.0
+
+// MaxSearchBoost caps how strongly a type can be promoted in search
+const MaxSearchBoost float32 = 10.0
+
+var knowledgeTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,39}$`)
+
+// KnowledgeTypeDefinition describes a knowledge type registered by an organization.
+// Registering a built-in name overrides its display name, sections and boost for that org.
+type KnowledgeTypeDefinition struct {
+	ID          string
+	OrgID       string
+	Name        KnowledgeType
+	DisplayName string
+	// RequiredSections are markdown headings the body must contain
+	RequiredSections []string
+	// SearchBoost multiplies the search score of items of this type
+	SearchBoost float32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BuiltinKnowledgeTypes returns the types every organization can use without registering them
+func BuiltinKnowledgeTypes() []KnowledgeType {
+	return []KnowledgeType{
+		KnowledgeTypeGuideline,
+		KnowledgeTypeLearning,
+		KnowledgeTypeDecision,
+		KnowledgeTypeTemplate,
+		KnowledgeTypeChecklist,
+		KnowledgeTypeSnippet,
+	}
+}
+
+// IsBuiltinKnowledgeType checks if a KnowledgeType is one of the built-in types
+func IsBuiltinKnowledgeType(t KnowledgeType) bool {
+	switch t {
+	case KnowledgeTypeGuideline, KnowledgeTypeLearning, KnowledgeTypeDecision,
+		KnowledgeTypeTemplate, KnowledgeTypeChecklist, KnowledgeTypeSnippet:
+		return true
+	}
+	return false
+}
+
+// IsValidKnowledgeTypeName checks that a type name is a lowercase slug such as "api-contract"
+func IsValidKnowledgeTypeName(t KnowledgeType) bool {
+	return knowledgeTypeNamePattern.MatchString(string(t))
+}
+
+// defaultRequiredSections are the sections a built-in type requires until the org customizes it
+var defaultRequiredSections = map[KnowledgeType][]string{
+	KnowledgeTypeDecision: {"Context", "Decision", "Consequences"},
+}
+
+// DefaultKnowledgeTypeDefinition returns the definition used for a built-in type the org has not customized
+func DefaultKnowledgeTypeDefinition(t KnowledgeType) *KnowledgeTypeDefinition {
+	name := string(t)
+	if name != "" {
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	var sections []string
+	if required := defaultRequiredSections[t]; required != nil {
+		sections = append([]string(nil), required...)
+	}
+	return &KnowledgeTypeDefinition{
+		Name:             t,
+		DisplayName:      name,
+		RequiredSections: sections,
+		SearchBoost:      DefaultSearchBoost,
+	}
+}
+
+// NormalizeSections trims required section names and drops blanks and case-insensitive duplicates
+func NormalizeSections(sections []string) []string {
+	if sections == nil {
+		return nil
+	}
+	result := make([]string, 0, len(sections))
+	seen := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		section = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(section), "#"))
+		key := strings.ToLower(section)
+		if section == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, section)
+	}
+	return result
+}
+
+// MissingSections returns the required sections that do not appear as a markdown heading in body.
+// Headings match case-insensitively at any level.
+func (d *KnowledgeTypeDefinition) MissingSections(body string) []string {
+	if d == nil || len(d.RequiredSections) == 0 {
+		return nil
+	}
+
+	headings := make(map[string]bool)
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+		heading := strings.TrimLeft(line, "#")
+		if heading == "" || (heading[0] != ' ' && heading[0] != '\t') {
+			continue
+		}
+		headings[strings.ToLower(strings.TrimSpace(strings.TrimRight(heading, "# \t")))] = true
+	}
+
+	var missing []string
+	for _, section := range d.RequiredSections {
+		if !headings[strings.ToLower(section)] {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
+
+// ValidateKnowledgeTypeDefinition validates a KnowledgeTypeDefinition instance
+func ValidateKnowledgeTypeDefinition(d *KnowledgeTypeDefinition) error {
+	if d == nil {
+		return fmt.Errorf("knowledge type cannot be nil")
+	}
+
+	if d.ID == "" {
+		return fmt.Errorf("knowledge type ID is required")
+	}
+
+	if d.OrgID == "" {
+		return fmt.Errorf("knowledge type OrgID is required")
+	}
+
+	if !IsValidKnowledgeTypeName(d.Name) {
+		return fmt.Errorf("knowledge type Name is invalid: %s", d.Name)
+	}
+
+	if strings.TrimSpace(d.DisplayName) == "" {
+		return fmt.Errorf("knowledge type DisplayName is required")
+	}
+
+	if d.SearchBoost <= 0 || d.SearchBoost > MaxSearchBoost {
+		return fmt.Errorf("knowledge type SearchBoost must be greater than 0 and at most %g", MaxSearchBoost)
+	}
+
+	return nil
+}
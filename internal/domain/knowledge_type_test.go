@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKnowledgeTypeDefinition(t *testing.T) {
+	valid := func() *KnowledgeTypeDefinition {
+		return &KnowledgeTypeDefinition{
+			ID:               "t1",
+			OrgID:            "org1",
+			Name:             "api-contract",
+			DisplayName:      "API contract",
+			RequiredSections: []string{"Endpoints"},
+			SearchBoost:      1.5,
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(d *KnowledgeTypeDefinition)
+		wantErr string
+	}{
+		{name: "valid type", mutate: func(d *KnowledgeTypeDefinition) {}},
+		{name: "built-in override", mutate: func(d *KnowledgeTypeDefinition) { d.Name = KnowledgeTypeGuideline }},
+		{name: "missing ID", mutate: func(d *KnowledgeTypeDefinition) { d.ID = "" }, wantErr: "ID is required"},
+		{name: "missing OrgID", mutate: func(d *KnowledgeTypeDefinition) { d.OrgID = "" }, wantErr: "OrgID is required"},
+		{name: "uppercase name", mutate: func(d *KnowledgeTypeDefinition) { d.Name = "Runbook" }, wantErr: "Name is invalid"},
+		{name: "name with spaces", mutate: func(d *KnowledgeTypeDefinition) { d.Name = "post mortem" }, wantErr: "Name is invalid"},
+		{name: "missing display name", mutate: func(d *KnowledgeTypeDefinition) { d.DisplayName = " " }, wantErr: "DisplayName is required"},
+		{name: "zero boost", mutate: func(d *KnowledgeTypeDefinition) { d.SearchBoost = 0 }, wantErr: "SearchBoost"},
+		{name: "boost too high", mutate: func(d *KnowledgeTypeDefinition) { d.SearchBoost = 11 }, wantErr: "SearchBoost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid()
+			tt.mutate(d)
+			err := ValidateKnowledgeTypeDefinition(d)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.Error(t, ValidateKnowledgeTypeDefinition(nil))
+}
+
+func TestKnowledgeTypeDefinition_MissingSections(t *testing.T) {
+	def := &KnowledgeTypeDefinition{
+		Name:             "postmortem",
+		RequiredSections: []string{"Impact", "Root cause", "Action items"},
+	}
+
+	body := "# Outage 2026-03-01\n\n## Impact\nCheckout down.\n\n### ROOT CAUSE ###\nExpired cert.\n\n#Action items\n"
+	assert.Equal(t, []string{"Action items"}, def.MissingSections(body))
+
+	assert.Empty(t, def.MissingSections(body+"## Action items\n- Renew certs\n"))
+	assert.Empty(t, (&KnowledgeTypeDefinition{}).MissingSections("no headings"))
+
+	var nilDef *KnowledgeTypeDefinition
+	assert.Empty(t, nilDef.MissingSections("body"))
+}
+
+func TestNormalizeSections(t *testing.T) {
+	assert.Nil(t, NormalizeSections(nil))
+	assert.Equal(t, []string{"Impact", "Root cause"}, NormalizeSections([]string{" Impact ", "## Root cause", "impact", ""}))
+}
+
+func TestIsBuiltinKnowledgeType(t *testing.T) {
+	for _, kt := range BuiltinKnowledgeTypes() {
+		assert.True(t, IsBuiltinKnowledgeType(kt), kt)
+	}
+	assert.False(t, IsBuiltinKnowledgeType("runbook"))
+}
+
+func TestDefaultKnowledgeTypeDefinition(t *testing.T) {
+	def := DefaultKnowledgeTypeDefinition(KnowledgeTypeGuideline)
+
+	assert.Equal(t, KnowledgeTypeGuideline, def.Name)
+	assert.Equal(t, "Guideline", def.DisplayName)
+	assert.Equal(t, DefaultSearchBoost, def.SearchBoost)
+	assert.Empty(t, def.RequiredSections)
+
+	decision := DefaultKnowledgeTypeDefinition(KnowledgeTypeDecision)
+	assert.Equal(t, []string{"Context", "Decision", "Consequences"}, decision.RequiredSections)
+	decision.RequiredSections[0] = "Background"
+	assert.Equal(t, "Context", DefaultKnowledgeTypeDefinition(KnowledgeTypeDecision).RequiredSections[0])
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// languagePattern accepts code language names such as "go", "c++", "c#" or "objective-c"
+var languagePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]{0,31}$`)
+
+// fenceInfoPattern matches the opening line of a fenced code block and captures its info string
+var fenceInfoPattern = regexp.MustCompile("(?m)^[ \\t]*(?:```|~~~)[ \\t]*([A-Za-z0-9+#._-]+)")
+
+// languageAliases maps common short names to the name stored on knowledge items
+var languageAliases = map[string]string{
+	"golang": "go",
+	"js":     "javascript",
+	"ts":     "typescript",
+	"py":     "python",
+	"rb":     "ruby",
+	"sh":     "bash",
+	"shell":  "bash",
+	"yml":    "yaml",
+	"rs":     "rust",
+	"kt":     "kotlin",
+	"cs":     "csharp",
+	"c#":     "csharp",
+	"cpp":    "c++",
+	"psql":   "sql",
+}
+
+// NormalizeLanguage trims and lowercases a code language name and resolves common aliases
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[language]; ok {
+		return alias
+	}
+	return language
+}
+
+// IsValidLanguage checks a normalized language name; empty means no language
+func IsValidLanguage(language string) bool {
+	return language == "" || languagePattern.MatchString(language)
+}
+
+// DetectCodeLanguage returns the normalized language of the first fenced code block
+// that declares one, or an empty string
+func DetectCodeLanguage(body string) string {
+	m := fenceInfoPattern.FindStringSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	language := NormalizeLanguage(m[1])
+	if !IsValidLanguage(language) {
+		return ""
+	}
+	return language
+}
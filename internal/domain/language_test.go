@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	assert.Equal(t, "go", NormalizeLanguage(" Golang "))
+	assert.Equal(t, "typescript", NormalizeLanguage("TS"))
+	assert.Equal(t, "c++", NormalizeLanguage("C++"))
+	assert.Equal(t, "elixir", NormalizeLanguage("elixir"))
+	assert.Equal(t, "", NormalizeLanguage("  "))
+}
+
+func TestIsValidLanguage(t *testing.T) {
+	for _, language := range []string{"", "go", "c++", "objective-c", "f#", "vue.js"} {
+		assert.True(t, IsValidLanguage(language), language)
+	}
+	for _, language := range []string{"Go", "two words", "-go", "go;drop", "averyveryveryveryverylonglanguagename"} {
+		assert.False(t, IsValidLanguage(language), language)
+	}
+}
+
+func TestDetectCodeLanguage(t *testing.T) {
+	body := "Cancel work when the context is done.\n\n```\nplain block\n```\n\n```golang\nselect {\ncase <-ctx.Done():\n}\n```\n"
+	assert.Equal(t, "go", DetectCodeLanguage(body))
+	assert.Equal(t, "python", DetectCodeLanguage("~~~py\nprint(1)\n~~~"))
+	assert.Equal(t, "", DetectCodeLanguage("no code here"))
+	assert.Equal(t, "", DetectCodeLanguage("```\nuntagged\n```"))
+}
@@ -0,0 +1,334 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintMode decides what happens to knowledge that breaks an organization's lint rules
+type LintMode string
+
+const (
+	// LintModeOff skips linting
+	LintModeOff LintMode = "off"
+	// LintModeWarn stores knowledge as is and reports the findings with the response
+	LintModeWarn LintMode = "warn"
+	// LintModeReject refuses to store knowledge with findings of severity error
+	LintModeReject LintMode = "reject"
+)
+
+// LintSeverity is how serious a finding of a rule is
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+	// LintSeverityOff disables a rule
+	LintSeverityOff LintSeverity = "off"
+)
+
+// Lint rule names
+const (
+	// LintRuleRequiredSections checks that items have the headings their type requires in
+	// the organization's type registry
+	LintRuleRequiredSections = "required-sections"
+	// LintRuleSummaryRequired checks that the summary is not empty
+	LintRuleSummaryRequired = "summary-required"
+	// LintRuleMaxBodySize checks that the body is no larger than MaxBytes
+	LintRuleMaxBodySize = "max-body-size"
+	// LintRuleBrokenReferences checks asset:// and knowledge:// references
+	LintRuleBrokenReferences = "broken-references"
+	// LintRuleNoPlaceholders checks for placeholder words such as TODO outside code blocks
+	LintRuleNoPlaceholders = "no-placeholders"
+)
+
+// LintRuleNames lists the known rules in the order they are reported
+var LintRuleNames = []string{
+	LintRuleRequiredSections,
+	LintRuleSummaryRequired,
+	LintRuleMaxBodySize,
+	LintRuleBrokenReferences,
+	LintRuleNoPlaceholders,
+}
+
+// LintRule configures one rule. Options that do not apply to the rule are ignored and
+// options left empty take the rule's default.
+type LintRule struct {
+	Severity LintSeverity `json:"severity,omitempty"`
+	// MaxBytes is the largest body allowed (max-body-size)
+	MaxBytes int `json:"max_bytes,omitempty"`
+	// Placeholders are the words that mark unfinished text (no-placeholders)
+	Placeholders []string `json:"placeholders,omitempty"`
+}
+
+// LintConfig is an organization's lint configuration. Rules not listed keep their defaults.
+type LintConfig struct {
+	Mode  LintMode            `json:"mode"`
+	Rules map[string]LintRule `json:"rules,omitempty"`
+}
+
+// LintFinding is a rule a knowledge item breaks. Line is 1-based and zero when the finding
+// is about the item as a whole.
+type LintFinding struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+	Line     int          `json:"line,omitempty"`
+}
+
+// LintReference is an asset:// or knowledge:// reference in a body
+type LintReference struct {
+	// Kind is "asset" or "knowledge"
+	Kind string
+	ID   string
+	Line int
+}
+
+// defaultLintRules are the rules used when an organization has not configured them
+var defaultLintRules = map[string]LintRule{
+	LintRuleRequiredSections: {Severity: LintSeverityError},
+	LintRuleSummaryRequired:  {Severity: LintSeverityWarning},
+	LintRuleMaxBodySize:      {Severity: LintSeverityError, MaxBytes: 64 * 1024},
+	LintRuleBrokenReferences: {Severity: LintSeverityError},
+	LintRuleNoPlaceholders:   {Severity: LintSeverityWarning, Placeholders: []string{"TODO", "TBD", "FIXME", "XXX"}},
+}
+
+var (
+	lintReferencePattern = regexp.MustCompile(`\b(asset|knowledge)://([\w-]*)`)
+	lintUUIDPattern      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// DefaultLintConfig returns the configuration of an organization that has not set one:
+// every rule with its default options, in warn mode
+func DefaultLintConfig() *LintConfig {
+	return &LintConfig{Mode: LintModeWarn}
+}
+
+// Rule returns the effective configuration of a rule, filling in defaults
+func (c *LintConfig) Rule(name string) LintRule {
+	rule := defaultLintRules[name]
+	if c == nil {
+		return rule
+	}
+	configured, ok := c.Rules[name]
+	if !ok {
+		return rule
+	}
+	if configured.Severity != "" {
+		rule.Severity = configured.Severity
+	}
+	if configured.MaxBytes > 0 {
+		rule.MaxBytes = configured.MaxBytes
+	}
+	if configured.Placeholders != nil {
+		rule.Placeholders = configured.Placeholders
+	}
+	return rule
+}
+
+// Enabled returns true if the rule reports findings
+func (c *LintConfig) Enabled(name string) bool {
+	return c.Rule(name).Severity != LintSeverityOff
+}
+
+// ValidateLintConfig checks the mode, rule names, severities and options of a configuration
+func ValidateLintConfig(c *LintConfig) error {
+	if c == nil {
+		return NewDomainError(ErrCodeValidation, "lint config cannot be nil")
+	}
+
+	switch c.Mode {
+	case LintModeOff, LintModeWarn, LintModeReject:
+	default:
+		return NewDomainError(ErrCodeValidation, "lint mode must be off, warn or reject")
+	}
+
+	for name, rule := range c.Rules {
+		if _, ok := defaultLintRules[name]; !ok {
+			return NewDomainError(ErrCodeValidation,
+				fmt.Sprintf("unknown lint rule %q; known rules: %s", name, strings.Join(LintRuleNames, ", ")))
+		}
+		switch rule.Severity {
+		case "", LintSeverityError, LintSeverityWarning, LintSeverityOff:
+		default:
+			return NewDomainError(ErrCodeValidation,
+				fmt.Sprintf("lint rule %s: severity must be error, warning or off", name))
+		}
+		if rule.MaxBytes < 0 {
+			return NewDomainError(ErrCodeValidation,
+				fmt.Sprintf("lint rule %s: max_bytes cannot be negative", name))
+		}
+		for _, word := range rule.Placeholders {
+			if strings.TrimSpace(word) == "" {
+				return NewDomainError(ErrCodeValidation,
+					fmt.Sprintf("lint rule %s: placeholders cannot be empty", name))
+			}
+		}
+	}
+	return nil
+}
+
+// LintKnowledge checks k against every enabled rule of c and returns the findings ordered by
+// line. def is the definition of k's type in the type registry, whose required sections are
+// checked; it may be nil. References are only checked for their form here; whether they
+// point at something that exists is up to the caller, see LintReferences.
+func LintKnowledge(c *LintConfig, def *KnowledgeTypeDefinition, k *Knowledge) []LintFinding {
+	var findings []LintFinding
+
+	if rule := c.Rule(LintRuleRequiredSections); rule.Severity != LintSeverityOff {
+		if missing := def.MissingSections(k.BodyMD); len(missing) > 0 {
+			findings = append(findings, LintFinding{
+				Rule:     LintRuleRequiredSections,
+				Severity: rule.Severity,
+				Message:  fmt.Sprintf("%s is missing sections: %s", k.Type, strings.Join(missing, ", ")),
+			})
+		}
+	}
+
+	if rule := c.Rule(LintRuleSummaryRequired); rule.Severity != LintSeverityOff && strings.TrimSpace(k.Summary) == "" {
+		findings = append(findings, LintFinding{
+			Rule:     LintRuleSummaryRequired,
+			Severity: rule.Severity,
+			Message:  "summary is empty",
+		})
+	}
+
+	if rule := c.Rule(LintRuleMaxBodySize); rule.Severity != LintSeverityOff && rule.MaxBytes > 0 && len(k.BodyMD) > rule.MaxBytes {
+		findings = append(findings, LintFinding{
+			Rule:     LintRuleMaxBodySize,
+			Severity: rule.Severity,
+			Message:  fmt.Sprintf("body is %d bytes, more than the limit of %d", len(k.BodyMD), rule.MaxBytes),
+		})
+	}
+
+	lines := lintProseLines(k.BodyMD)
+
+	if rule := c.Rule(LintRuleBrokenReferences); rule.Severity != LintSeverityOff {
+		for _, ref := range scanLintReferences(lines) {
+			if !lintUUIDPattern.MatchString(ref.ID) {
+				findings = append(findings, NewBrokenReferenceFinding(c, ref, "is not a valid ID"))
+			}
+		}
+	}
+
+	if rule := c.Rule(LintRuleNoPlaceholders); rule.Severity != LintSeverityOff && len(rule.Placeholders) > 0 {
+		pattern := placeholderPattern(rule.Placeholders)
+		for _, line := range lines {
+			if match := pattern.FindStringSubmatch(line.text); match != nil {
+				findings = append(findings, LintFinding{
+					Rule:     LintRuleNoPlaceholders,
+					Severity: rule.Severity,
+					Message:  fmt.Sprintf("placeholder %s", match[1]),
+					Line:     line.number,
+				})
+			}
+		}
+	}
+
+	SortLintFindings(findings)
+	return findings
+}
+
+// LintReferences returns the well-formed asset:// and knowledge:// references of a body,
+// outside code blocks, so the caller can check that they exist
+func LintReferences(body string) []LintReference {
+	var refs []LintReference
+	for _, ref := range scanLintReferences(lintProseLines(body)) {
+		if lintUUIDPattern.MatchString(ref.ID) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// NewBrokenReferenceFinding reports a reference that is malformed or points at nothing
+func NewBrokenReferenceFinding(c *LintConfig, ref LintReference, problem string) LintFinding {
+	return LintFinding{
+		Rule:     LintRuleBrokenReferences,
+		Severity: c.Rule(LintRuleBrokenReferences).Severity,
+		Message:  fmt.Sprintf("%s://%s %s", ref.Kind, ref.ID, problem),
+		Line:     ref.Line,
+	}
+}
+
+// SortLintFindings orders findings by line, with findings about the whole item first
+func SortLintFindings(findings []LintFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+}
+
+// HasLintErrors returns true if any finding has severity error
+func HasLintErrors(findings []LintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+type lintLine struct {
+	number int
+	text   string
+}
+
+// lintProseLines returns the lines of a markdown body outside fenced code blocks, with
+// inline code spans blanked out
+func lintProseLines(body string) []lintLine {
+	var lines []lintLine
+	var fence string
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		lines = append(lines, lintLine{number: i + 1, text: stripCodeSpans(line)})
+	}
+	return lines
+}
+
+// stripCodeSpans removes `inline code` from a line
+func stripCodeSpans(line string) string {
+	var b strings.Builder
+	inCode := false
+	for _, r := range line {
+		if r == '`' {
+			inCode = !inCode
+			continue
+		}
+		if !inCode {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func scanLintReferences(lines []lintLine) []LintReference {
+	var refs []LintReference
+	for _, line := range lines {
+		for _, match := range lintReferencePattern.FindAllStringSubmatch(line.text, -1) {
+			refs = append(refs, LintReference{Kind: match[1], ID: match[2], Line: line.number})
+		}
+	}
+	return refs
+}
+
+// placeholderPattern matches any of the words on its own, case-sensitively, since a word
+// like "todo" is usually prose while "TODO" marks unfinished text
+func placeholderPattern(words []string) *regexp.Regexp {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(strings.TrimSpace(word))
+	}
+	return regexp.MustCompile(`(?:^|[^\w])(` + strings.Join(quoted, "|") + `)(?:[^\w]|$)`)
+}
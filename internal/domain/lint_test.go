@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintKnowledge_Defaults(t *testing.T) {
+	k := &Knowledge{
+		Type:  KnowledgeTypeDecision,
+		Title: "Use Postgres",
+		BodyMD: "# Context\n\nWe need a database. TODO: add numbers\n\n" +
+			"See asset://not-an-id and knowledge://0b0e5f4e-8a1e-4c5b-9b1a-2f0c6d1e7a3b.\n\n" +
+			"```go\n// TODO in code is fine\n```\n\nRun `TODO` in a span too.\n",
+	}
+
+	def := &KnowledgeTypeDefinition{Name: KnowledgeTypeDecision, RequiredSections: []string{"Context", "Decision", "Consequences"}}
+
+	findings := LintKnowledge(DefaultLintConfig(), def, k)
+
+	assert.Equal(t, []LintFinding{
+		{Rule: LintRuleRequiredSections, Severity: LintSeverityError, Message: "decision is missing sections: Decision, Consequences"},
+		{Rule: LintRuleSummaryRequired, Severity: LintSeverityWarning, Message: "summary is empty"},
+		{Rule: LintRuleNoPlaceholders, Severity: LintSeverityWarning, Message: "placeholder TODO", Line: 3},
+		{Rule: LintRuleBrokenReferences, Severity: LintSeverityError, Message: "asset://not-an-id is not a valid ID", Line: 5},
+	}, findings)
+	assert.True(t, HasLintErrors(findings))
+}
+
+func TestLintKnowledge_Clean(t *testing.T) {
+	k := &Knowledge{
+		Type:    KnowledgeTypeDecision,
+		Title:   "Use Postgres",
+		Summary: "We use Postgres for everything.",
+		BodyMD:  "## Context\n\nWe need a database.\n\n## Decision\n\nPostgres.\n\n## Consequences\n\nOne thing to run.\n",
+	}
+
+	def := &KnowledgeTypeDefinition{Name: KnowledgeTypeDecision, RequiredSections: []string{"Context", "Decision", "Consequences"}}
+
+	assert.Empty(t, LintKnowledge(DefaultLintConfig(), def, k))
+	assert.Empty(t, LintKnowledge(DefaultLintConfig(), nil, k))
+}
+
+func TestLintKnowledge_Configured(t *testing.T) {
+	config := &LintConfig{
+		Mode: LintModeReject,
+		Rules: map[string]LintRule{
+			LintRuleRequiredSections: {Severity: LintSeverityWarning},
+			LintRuleSummaryRequired:  {Severity: LintSeverityOff},
+			LintRuleMaxBodySize:      {Severity: LintSeverityWarning, MaxBytes: 10},
+			LintRuleNoPlaceholders:   {Severity: LintSeverityError, Placeholders: []string{"WIP"}},
+		},
+	}
+	k := &Knowledge{
+		Type:   KnowledgeTypeGuideline,
+		Title:  "Deploys",
+		BodyMD: "Deploy on Tuesdays. TODO\nWIP: Fridays\n",
+	}
+
+	def := &KnowledgeTypeDefinition{Name: KnowledgeTypeGuideline, RequiredSections: []string{"Why"}}
+
+	findings := LintKnowledge(config, def, k)
+
+	assert.Equal(t, []LintFinding{
+		{Rule: LintRuleRequiredSections, Severity: LintSeverityWarning, Message: "guideline is missing sections: Why"},
+		{Rule: LintRuleMaxBodySize, Severity: LintSeverityWarning, Message: "body is 38 bytes, more than the limit of 10"},
+		{Rule: LintRuleNoPlaceholders, Severity: LintSeverityError, Message: "placeholder WIP", Line: 2},
+	}, findings)
+}
+
+func TestLintReferences(t *testing.T) {
+	body := "![diagram](asset://0b0e5f4e-8a1e-4c5b-9b1a-2f0c6d1e7a3b)\n" +
+		"```\nknowledge://11111111-1111-1111-1111-111111111111\n```\n" +
+		"Builds on knowledge://22222222-2222-2222-2222-222222222222 and asset://broken.\n"
+
+	assert.Equal(t, []LintReference{
+		{Kind: "asset", ID: "0b0e5f4e-8a1e-4c5b-9b1a-2f0c6d1e7a3b", Line: 1},
+		{Kind: "knowledge", ID: "22222222-2222-2222-2222-222222222222", Line: 5},
+	}, LintReferences(body))
+}
+
+func TestValidateLintConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *LintConfig
+		wantErr string
+	}{
+		{name: "default", config: DefaultLintConfig()},
+		{name: "nil", config: nil, wantErr: "cannot be nil"},
+		{name: "bad mode", config: &LintConfig{Mode: "strict"}, wantErr: "lint mode must be off, warn or reject"},
+		{name: "unknown rule", config: &LintConfig{Mode: LintModeWarn, Rules: map[string]LintRule{"no-swearing": {}}}, wantErr: "unknown lint rule"},
+		{name: "bad severity", config: &LintConfig{Mode: LintModeWarn, Rules: map[string]LintRule{LintRuleSummaryRequired: {Severity: "fatal"}}}, wantErr: "severity must be"},
+		{name: "negative size", config: &LintConfig{Mode: LintModeWarn, Rules: map[string]LintRule{LintRuleMaxBodySize: {MaxBytes: -1}}}, wantErr: "max_bytes"},
+		{name: "empty placeholder", config: &LintConfig{Mode: LintModeWarn, Rules: map[string]LintRule{LintRuleNoPlaceholders: {Placeholders: []string{" "}}}}, wantErr: "placeholders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLintConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLintConfig_Rule(t *testing.T) {
+	config := &LintConfig{Mode: LintModeWarn, Rules: map[string]LintRule{LintRuleMaxBodySize: {Severity: LintSeverityWarning}}}
+
+	rule := config.Rule(LintRuleMaxBodySize)
+	assert.Equal(t, LintSeverityWarning, rule.Severity)
+	assert.Equal(t, 64*1024, rule.MaxBytes)
+	assert.True(t, config.Enabled(LintRuleMaxBodySize))
+
+	config.Rules[LintRuleMaxBodySize] = LintRule{Severity: LintSeverityOff}
+	assert.False(t, config.Enabled(LintRuleMaxBodySize))
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelationType represents the kind of link between two knowledge items
+type RelationType string
+
+const (
+	RelationTypeRelatesTo   RelationType = "relates_to"
+	RelationTypeDependsOn   RelationType = "depends_on"
+	RelationTypeRefines     RelationType = "refines"
+	RelationTypeContradicts RelationType = "contradicts"
+)
+
+// KnowledgeRelation is a directed, typed edge from one knowledge item to another
+type KnowledgeRelation struct {
+	ID        string
+	OrgID     string
+	SourceID  string
+	TargetID  string
+	Type      RelationType
+	CreatedAt time.Time
+}
+
+// NewKnowledgeRelation creates a new KnowledgeRelation instance
+func NewKnowledgeRelation(
+	id, orgID, sourceID, targetID string,
+	relationType RelationType,
+	createdAt time.Time,
+) *KnowledgeRelation {
+	return &KnowledgeRelation{
+		ID:        id,
+		OrgID:     orgID,
+		SourceID:  sourceID,
+		TargetID:  targetID,
+		Type:      relationType,
+		CreatedAt: createdAt,
+	}
+}
+
+// ValidateKnowledgeRelation validates a KnowledgeRelation instance
+func ValidateKnowledgeRelation(r *KnowledgeRelation) error {
+	if r == nil {
+		return fmt.Errorf("knowledge relation cannot be nil")
+	}
+
+	if r.ID == "" {
+		return fmt.Errorf("knowledge relation ID is required")
+	}
+
+	if r.OrgID == "" {
+		return fmt.Errorf("knowledge relation OrgID is required")
+	}
+
+	if r.SourceID == "" {
+		return fmt.Errorf("knowledge relation SourceID is required")
+	}
+
+	if r.TargetID == "" {
+		return fmt.Errorf("knowledge relation TargetID is required")
+	}
+
+	if r.SourceID == r.TargetID {
+		return fmt.Errorf("knowledge relation cannot link an item to itself")
+	}
+
+	if !IsValidRelationType(r.Type) {
+		return fmt.Errorf("knowledge relation Type is invalid: %s", r.Type)
+	}
+
+	return nil
+}
+
+// IsValidRelationType checks if a RelationType is valid
+func IsValidRelationType(t RelationType) bool {
+	switch t {
+	case RelationTypeRelatesTo, RelationTypeDependsOn, RelationTypeRefines, RelationTypeContradicts:
+		return true
+	}
+	return false
+}
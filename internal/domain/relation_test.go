@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKnowledgeRelation(t *testing.T) {
+	now := time.Now()
+	relation := NewKnowledgeRelation("r1", "org1", "k1", "k2", RelationTypeDependsOn, now)
+
+	assert.Equal(t, "r1", relation.ID)
+	assert.Equal(t, "org1", relation.OrgID)
+	assert.Equal(t, "k1", relation.SourceID)
+	assert.Equal(t, "k2", relation.TargetID)
+	assert.Equal(t, RelationTypeDependsOn, relation.Type)
+	assert.Equal(t, now, relation.CreatedAt)
+}
+
+func TestValidateKnowledgeRelation(t *testing.T) {
+	valid := func() *KnowledgeRelation {
+		return NewKnowledgeRelation("r1", "org1", "k1", "k2", RelationTypeRelatesTo, time.Now())
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *KnowledgeRelation)
+		wantErr string
+	}{
+		{name: "valid relation", mutate: func(r *KnowledgeRelation) {}},
+		{name: "missing ID", mutate: func(r *KnowledgeRelation) { r.ID = "" }, wantErr: "ID is required"},
+		{name: "missing OrgID", mutate: func(r *KnowledgeRelation) { r.OrgID = "" }, wantErr: "OrgID is required"},
+		{name: "missing SourceID", mutate: func(r *KnowledgeRelation) { r.SourceID = "" }, wantErr: "SourceID is required"},
+		{name: "missing TargetID", mutate: func(r *KnowledgeRelation) { r.TargetID = "" }, wantErr: "TargetID is required"},
+		{name: "self link", mutate: func(r *KnowledgeRelation) { r.TargetID = r.SourceID }, wantErr: "itself"},
+		{name: "invalid type", mutate: func(r *KnowledgeRelation) { r.Type = "blocks" }, wantErr: "Type is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.mutate(r)
+			err := ValidateKnowledgeRelation(r)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.Error(t, ValidateKnowledgeRelation(nil))
+}
+
+func TestIsValidRelationType(t *testing.T) {
+	for _, rt := range []RelationType{RelationTypeRelatesTo, RelationTypeDependsOn, RelationTypeRefines, RelationTypeContradicts} {
+		assert.True(t, IsValidRelationType(rt), rt)
+	}
+	assert.False(t, IsValidRelationType(""))
+	assert.False(t, IsValidRelationType("supersedes"))
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+// NormalizeScopePrefix trims whitespace and trailing slashes from a scope prefix
+func NormalizeScopePrefix(prefix string) string {
+	return strings.TrimRight(strings.TrimSpace(prefix), "/")
+}
+
+// ScopeHasPrefix reports whether a scope is the prefix itself or lies below it. Prefixes
+// match whole path segments, so "services/auth" does not match "services/authz".
+func ScopeHasPrefix(scope, prefix string) bool {
+	if prefix == "" || !strings.HasPrefix(scope, prefix) {
+		return false
+	}
+	return len(scope) == len(prefix) || scope[len(prefix)] == '/'
+}
+
+// MoveScope replaces the prefix of a scope with a new one. Scopes outside the prefix
+// are returned unchanged with false.
+func MoveScope(scope, from, to string) (string, bool) {
+	if !ScopeHasPrefix(scope, from) {
+		return scope, false
+	}
+	return to + scope[len(from):], true
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeScopePrefix(t *testing.T) {
+	assert.Equal(t, "services/auth", NormalizeScopePrefix(" services/auth/ "))
+	assert.Equal(t, "", NormalizeScopePrefix("/"))
+}
+
+func TestScopeHasPrefix(t *testing.T) {
+	assert.True(t, ScopeHasPrefix("services/auth", "services/auth"))
+	assert.True(t, ScopeHasPrefix("services/auth/**/*.go", "services/auth"))
+	assert.False(t, ScopeHasPrefix("services/authz", "services/auth"))
+	assert.False(t, ScopeHasPrefix("services", "services/auth"))
+	assert.False(t, ScopeHasPrefix("services/auth", ""))
+}
+
+func TestMoveScope(t *testing.T) {
+	moved, ok := MoveScope("services/auth/handlers/*.go", "services/auth", "platform/identity")
+	assert.True(t, ok)
+	assert.Equal(t, "platform/identity/handlers/*.go", moved)
+
+	moved, ok = MoveScope("services/auth", "services/auth", "identity")
+	assert.True(t, ok)
+	assert.Equal(t, "identity", moved)
+
+	moved, ok = MoveScope("services/authz", "services/auth", "identity")
+	assert.False(t, ok)
+	assert.Equal(t, "services/authz", moved)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templateVariablePattern matches {{name}} and {{name|default}} placeholders
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*(?:\|([^{}]*))?\}\}`)
+
+// TemplateVariable is a placeholder found in template markdown.
+// A variable with a default ({{name|default}}) is optional.
+type TemplateVariable struct {
+	Name     string
+	Default  string
+	Required bool
+}
+
+// TemplateVariables returns the variables used in the given texts in order of first appearance.
+// A variable is optional if any of its placeholders declares a default.
+func TemplateVariables(texts ...string) []TemplateVariable {
+	var vars []TemplateVariable
+	index := make(map[string]int)
+	for _, text := range texts {
+		for _, m := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+			name := m[1]
+			hasDefault := strings.Contains(m[0], "|")
+			i, seen := index[name]
+			if !seen {
+				index[name] = len(vars)
+				vars = append(vars, TemplateVariable{Name: name, Required: true})
+				i = len(vars) - 1
+			}
+			if hasDefault && vars[i].Required {
+				vars[i].Required = false
+				vars[i].Default = strings.TrimSpace(m[2])
+			}
+		}
+	}
+	return vars
+}
+
+// MissingTemplateVariables returns the required variables without a non-blank value
+func MissingTemplateVariables(vars []TemplateVariable, values map[string]string) []string {
+	var missing []string
+	for _, v := range vars {
+		if v.Required && strings.TrimSpace(values[v.Name]) == "" {
+			missing = append(missing, v.Name)
+		}
+	}
+	return missing
+}
+
+// RenderTemplate replaces placeholders with their values, falling back to the placeholder's default.
+// Placeholders without a value or default render as empty text; check MissingTemplateVariables first.
+func RenderTemplate(text string, values map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		m := templateVariablePattern.FindStringSubmatch(placeholder)
+		if value, ok := values[m[1]]; ok && strings.TrimSpace(value) != "" {
+			return value
+		}
+		return strings.TrimSpace(m[2])
+	})
+}
+
+// NewMissingTemplateVariablesError reports the required template variables that were not given
+func NewMissingTemplateVariablesError(missing []string) *DomainError {
+	return NewDomainError(ErrCodeValidation,
+		fmt.Sprintf("missing template variables: %s", strings.Join(missing, ", ")))
+}
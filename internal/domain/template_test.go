@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateVariables(t *testing.T) {
+	vars := TemplateVariables(
+		"Postmortem: {{ service }}",
+		"# {{service}} outage\n\nSeverity: {{severity|SEV3}}\nOwner: {{owner}}\nAgain: {{severity}}",
+	)
+
+	assert.Equal(t, []TemplateVariable{
+		{Name: "service", Required: true},
+		{Name: "severity", Default: "SEV3"},
+		{Name: "owner", Required: true},
+	}, vars)
+
+	assert.Empty(t, TemplateVariables("no placeholders, {{ not a var }} or {single}"))
+}
+
+func TestMissingTemplateVariables(t *testing.T) {
+	vars := TemplateVariables("{{service}} {{owner}} {{severity|SEV3}}")
+
+	assert.Equal(t, []string{"service", "owner"}, MissingTemplateVariables(vars, nil))
+	assert.Equal(t, []string{"owner"}, MissingTemplateVariables(vars, map[string]string{"service": "api", "owner": "  "}))
+	assert.Empty(t, MissingTemplateVariables(vars, map[string]string{"service": "api", "owner": "sre"}))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	body := "# {{ service }} outage\nSeverity: {{severity|SEV3}}\nRegion: {{region|}}\nKeep {{ not a var }}"
+
+	rendered := RenderTemplate(body, map[string]string{"service": "checkout"})
+	assert.Equal(t, "# checkout outage\nSeverity: SEV3\nRegion: \nKeep {{ not a var }}", rendered)
+
+	rendered = RenderTemplate(body, map[string]string{"service": "checkout", "severity": "SEV1", "region": "eu"})
+	assert.Equal(t, "# checkout outage\nSeverity: SEV1\nRegion: eu\nKeep {{ not a var }}", rendered)
+}
+
+func TestNewMissingTemplateVariablesError(t *testing.T) {
+	err := NewMissingTemplateVariablesError([]string{"service", "owner"})
+
+	assert.Equal(t, ErrCodeValidation, err.Code)
+	assert.Equal(t, "missing template variables: service, owner", err.Message)
+}
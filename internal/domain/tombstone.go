@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// KnowledgeTombstone is the audit record left behind when a knowledge item is purged.
+// It keeps identifiers and counts of what was removed, never the purged content.
+type KnowledgeTombstone struct {
+	ID          string
+	KnowledgeID string
+	OrgID       string
+	ProjectID   string
+	Type        KnowledgeType
+	PurgedBy    string
+	// Reason is a reference for the erasure request (a ticket, for example) and must
+	// not repeat the purged content
+	Reason   string
+	PurgedAt time.Time
+
+	// Counts of removed rows, filled in by the purge
+	Versions      int
+	Chunks        int
+	AssetLinks    int
+	EmbeddingJobs int
+	SearchLogs    int
+}
+
+// NewKnowledgeTombstone creates the tombstone for a purge that is about to run
+func NewKnowledgeTombstone(id, knowledgeID, purgedBy, reason string, purgedAt time.Time) *KnowledgeTombstone {
+	return &KnowledgeTombstone{
+		ID:          id,
+		KnowledgeID: knowledgeID,
+		PurgedBy:    purgedBy,
+		Reason:      reason,
+		PurgedAt:    purgedAt,
+	}
+}
+
+// ValidateKnowledgeTombstone validates a KnowledgeTombstone before the purge runs
+func ValidateKnowledgeTombstone(t *KnowledgeTombstone) error {
+	if t == nil {
+		return fmt.Errorf("knowledge tombstone cannot be nil")
+	}
+
+	if t.ID == "" {
+		return fmt.Errorf("knowledge tombstone ID is required")
+	}
+
+	if t.KnowledgeID == "" {
+		return fmt.Errorf("knowledge tombstone KnowledgeID is required")
+	}
+
+	if t.PurgedBy == "" {
+		return ErrActorRequired
+	}
+
+	return nil
+}
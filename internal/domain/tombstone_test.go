@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKnowledgeTombstone(t *testing.T) {
+	now := time.Now()
+	tombstone := NewKnowledgeTombstone("t1", "k1", "dpo@example.com", "ERASURE-42", now)
+
+	assert.Equal(t, "t1", tombstone.ID)
+	assert.Equal(t, "k1", tombstone.KnowledgeID)
+	assert.Equal(t, "dpo@example.com", tombstone.PurgedBy)
+	assert.Equal(t, "ERASURE-42", tombstone.Reason)
+	assert.Equal(t, now, tombstone.PurgedAt)
+	assert.Empty(t, tombstone.OrgID)
+}
+
+func TestValidateKnowledgeTombstone(t *testing.T) {
+	valid := func() *KnowledgeTombstone {
+		return NewKnowledgeTombstone("t1", "k1", "dpo@example.com", "", time.Now())
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(t *KnowledgeTombstone)
+		wantErr string
+	}{
+		{name: "valid tombstone", mutate: func(t *KnowledgeTombstone) {}},
+		{name: "missing ID", mutate: func(t *KnowledgeTombstone) { t.ID = "" }, wantErr: "ID is required"},
+		{name: "missing KnowledgeID", mutate: func(t *KnowledgeTombstone) { t.KnowledgeID = "" }, wantErr: "KnowledgeID is required"},
+		{name: "missing PurgedBy", mutate: func(t *KnowledgeTombstone) { t.PurgedBy = "" }, wantErr: "by is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tombstone := valid()
+			tt.mutate(tombstone)
+			err := ValidateKnowledgeTombstone(tombstone)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.Error(t, ValidateKnowledgeTombstone(nil))
+}
@@ -0,0 +1,195 @@
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	delimiter     = "---"
+	tomlDelimiter = "+++"
+)
+
+var (
+	ErrUnterminated = errors.New("frontmatter is not terminated by --- or +++")
+)
+
+// Metadata holds the knowledge fields a markdown file can declare in its frontmatter
+type Metadata struct {
+	ID      string `yaml:"id,omitempty"`
+	Type    string `yaml:"type,omitempty"`
+	Title   string `yaml:"title,omitempty"`
+	Summary string `yaml:"summary,omitempty"`
+	Scope   string `yaml:"scope,omitempty"`
+	Tags    Tags   `yaml:"tags,omitempty"`
+	Status  string `yaml:"status,omitempty"`
+	// Version is the knowledge version the file holds
+	Version int64 `yaml:"version,omitempty"`
+}
+
+// Tags accepts either a YAML list or a comma-separated string
+type Tags []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (t *Tags) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = splitTags(node.Value)
+		return nil
+	}
+
+	var tags []string
+	if err := node.Decode(&tags); err != nil {
+		return err
+	}
+	*t = tags
+	return nil
+}
+
+func splitTags(value string) Tags {
+	var tags Tags
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Parse splits a markdown document into its frontmatter and body. The frontmatter is
+// YAML between --- lines or TOML between +++ lines.
+// A document without frontmatter yields empty metadata and the content unchanged.
+func Parse(content []byte) (*Metadata, string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	var closing []string
+	var unmarshal func(string) (*Metadata, error)
+	switch {
+	case strings.HasPrefix(text, delimiter+"\n"):
+		closing = []string{delimiter, "..."}
+		unmarshal = parseYAML
+	case strings.HasPrefix(text, tomlDelimiter+"\n"):
+		closing = []string{tomlDelimiter}
+		unmarshal = parseTOML
+	default:
+		return &Metadata{}, text, nil
+	}
+
+	lines := strings.SplitAfter(text[len(delimiter)+1:], "\n")
+	for i, line := range lines {
+		if !slices.Contains(closing, strings.TrimRight(line, " \t\n")) {
+			continue
+		}
+
+		meta, err := unmarshal(strings.Join(lines[:i], ""))
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid frontmatter: %w", err)
+		}
+		body := strings.Join(lines[i+1:], "")
+		return meta, strings.TrimLeft(body, "\n"), nil
+	}
+
+	return nil, "", ErrUnterminated
+}
+
+func parseYAML(text string) (*Metadata, error) {
+	var meta Metadata
+	if err := yaml.Unmarshal([]byte(text), &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Format renders metadata as YAML frontmatter followed by the body.
+// Empty metadata renders the body alone.
+func Format(meta *Metadata, body string) ([]byte, error) {
+	if meta == nil || isEmpty(meta) {
+		return []byte(body), nil
+	}
+
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(delimiter + "\n")
+	buf.Write(data)
+	buf.WriteString(delimiter + "\n\n")
+	buf.WriteString(body)
+	return buf.Bytes(), nil
+}
+
+func isEmpty(meta *Metadata) bool {
+	return meta.ID == "" && meta.Type == "" && meta.Title == "" && meta.Summary == "" &&
+		meta.Scope == "" && len(meta.Tags) == 0 && meta.Status == "" && meta.Version == 0
+}
+
+// SetID records a knowledge ID in the frontmatter of a markdown document and leaves the
+// rest of it untouched. An existing root-level id is replaced, and a document without
+// frontmatter gets a YAML one.
+func SetID(content []byte, id string) ([]byte, error) {
+	bom := ""
+	if bytes.HasPrefix(content, []byte("\xef\xbb\xbf")) {
+		bom = "\xef\xbb\xbf"
+		content = content[len(bom):]
+	}
+	text := string(content)
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+	}
+
+	var idLine string
+	var isIDLine func(line string) bool
+	switch {
+	case strings.HasPrefix(text, delimiter+newline):
+		idLine = "id: " + yamlScalar(id) + newline
+		isIDLine = func(line string) bool { return strings.HasPrefix(line, "id:") }
+	case strings.HasPrefix(text, tomlDelimiter+newline):
+		idLine = "id = " + strconv.Quote(id) + newline
+		isIDLine = func(line string) bool {
+			return strings.HasPrefix(line, "id") && strings.HasPrefix(strings.TrimLeft(line[2:], " \t"), "=")
+		}
+	default:
+		header := delimiter + newline + "id: " + yamlScalar(id) + newline + delimiter + newline + newline
+		return []byte(bom + header + text), nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	opening := strings.TrimRight(lines[0], "\r\n")
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimRight(lines[i], " \t\r\n")
+		if trimmed == opening || (opening == delimiter && trimmed == "...") {
+			break
+		}
+		if opening == tomlDelimiter && strings.HasPrefix(trimmed, "[") {
+			break
+		}
+		if isIDLine(lines[i]) {
+			lines[i] = idLine
+			return []byte(bom + strings.Join(lines, "")), nil
+		}
+		if i == len(lines)-1 {
+			return nil, ErrUnterminated
+		}
+	}
+
+	return []byte(bom + lines[0] + idLine + strings.Join(lines[1:], "")), nil
+}
+
+// yamlScalar quotes a value unless it can be written as a plain YAML scalar
+func yamlScalar(value string) string {
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
@@ -0,0 +1,202 @@
+package frontmatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("reads the metadata and body", func(t *testing.T) {
+		content := "---\ntype: guideline\ntitle: Deploys\nsummary: How we ship\nscope: services/api/\ntags: [ci, release]\nstatus: approved\n---\n\n# Deploys\n\nShip on Tuesdays.\n"
+
+		meta, body, err := Parse([]byte(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, &Metadata{
+			Type:    "guideline",
+			Title:   "Deploys",
+			Summary: "How we ship",
+			Scope:   "services/api/",
+			Tags:    Tags{"ci", "release"},
+			Status:  "approved",
+		}, meta)
+		assert.Equal(t, "# Deploys\n\nShip on Tuesdays.\n", body)
+	})
+
+	t.Run("accepts comma-separated tags and CRLF line endings", func(t *testing.T) {
+		meta, body, err := Parse([]byte("---\r\ntags: ci, release\r\n---\r\nBody\r\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, Tags{"ci", "release"}, meta.Tags)
+		assert.Equal(t, "Body\n", body)
+	})
+
+	t.Run("returns content without frontmatter unchanged", func(t *testing.T) {
+		meta, body, err := Parse([]byte("# Title\n---\nnot frontmatter\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, &Metadata{}, meta)
+		assert.Equal(t, "# Title\n---\nnot frontmatter\n", body)
+	})
+
+	t.Run("ignores unknown keys", func(t *testing.T) {
+		meta, _, err := Parse([]byte("---\nlayout: post\ntitle: Deploys\n---\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Deploys", meta.Title)
+	})
+
+	t.Run("rejects unterminated frontmatter", func(t *testing.T) {
+		_, _, err := Parse([]byte("---\ntitle: Deploys\n# Deploys\n"))
+		assert.ErrorIs(t, err, ErrUnterminated)
+	})
+
+	t.Run("rejects invalid YAML", func(t *testing.T) {
+		_, _, err := Parse([]byte("---\ntitle: [unclosed\n---\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_TOML(t *testing.T) {
+	t.Run("reads the metadata and body", func(t *testing.T) {
+		content := `+++
+# Written by hand
+id = "k-1"
+type = 'guideline'
+title = "Deploys \"Tuesday\""
+summary = """
+How we ship \
+to production"""
+scope = "services/api/"
+tags = [
+  "ci",  # pipelines
+  'release',
+]
+version = 3
+draft = false
+date = 2026-01-02T10:00:00Z
+extra = { weight = 10, layout = "post" }
+
+[params]
+title = "ignored"
++++
+
+# Deploys
+`
+
+		meta, body, err := Parse([]byte(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, &Metadata{
+			ID:      "k-1",
+			Type:    "guideline",
+			Title:   `Deploys "Tuesday"`,
+			Summary: "How we ship to production",
+			Scope:   "services/api/",
+			Tags:    Tags{"ci", "release"},
+			Version: 3,
+		}, meta)
+		assert.Equal(t, "# Deploys\n", body)
+	})
+
+	t.Run("accepts comma-separated tags and unicode escapes", func(t *testing.T) {
+		meta, _, err := Parse([]byte("+++\r\ntitle = \"Caf\\u00e9\"\r\ntags = \"ci, release\"\r\n+++\r\nBody\r\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Café", meta.Title)
+		assert.Equal(t, Tags{"ci", "release"}, meta.Tags)
+	})
+
+	t.Run("rejects values of the wrong kind", func(t *testing.T) {
+		_, _, err := Parse([]byte("+++\ntitle = 42\n+++\n"))
+		assert.ErrorContains(t, err, "title must be a string")
+
+		_, _, err = Parse([]byte("+++\ntags = [1, 2]\n+++\n"))
+		assert.ErrorContains(t, err, "tags must be strings")
+	})
+
+	t.Run("rejects invalid TOML", func(t *testing.T) {
+		for _, content := range []string{
+			"+++\ntitle = \"unclosed\n+++\n",
+			"+++\ntitle \"Deploys\"\n+++\n",
+			"+++\ntags = [\"ci\"\n+++\n",
+			"+++\ntitle = \"Deploys\" trailing\n+++\n",
+		} {
+			_, _, err := Parse([]byte(content))
+			assert.Error(t, err, content)
+		}
+	})
+
+	t.Run("rejects unterminated frontmatter", func(t *testing.T) {
+		_, _, err := Parse([]byte("+++\ntitle = \"Deploys\"\n"))
+		assert.ErrorIs(t, err, ErrUnterminated)
+	})
+}
+
+func TestSetID(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "inserts into YAML",
+			content: "---\n# comment kept\ntitle: Deploys\n---\n\nBody\n",
+			want:    "---\nid: k-1\n# comment kept\ntitle: Deploys\n---\n\nBody\n",
+		},
+		{
+			name:    "replaces an existing YAML id",
+			content: "---\ntitle: Deploys\nid: old\n---\nBody\n",
+			want:    "---\ntitle: Deploys\nid: k-1\n---\nBody\n",
+		},
+		{
+			name:    "inserts into TOML before its tables",
+			content: "+++\ntitle = \"Deploys\"\n[params]\nid = \"other\"\n+++\nBody\n",
+			want:    "+++\nid = \"k-1\"\ntitle = \"Deploys\"\n[params]\nid = \"other\"\n+++\nBody\n",
+		},
+		{
+			name:    "replaces an existing TOML id",
+			content: "+++\nid   = \"old\"\n+++\nBody\n",
+			want:    "+++\nid = \"k-1\"\n+++\nBody\n",
+		},
+		{
+			name:    "adds frontmatter and keeps CRLF line endings",
+			content: "# Deploys\r\n",
+			want:    "---\r\nid: k-1\r\n---\r\n\r\n# Deploys\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetID([]byte(tt.content), "k-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+
+			meta, _, err := Parse(got)
+			require.NoError(t, err)
+			assert.Equal(t, "k-1", meta.ID)
+		})
+	}
+
+	_, err := SetID([]byte("---\ntitle: Deploys\n"), "k-1")
+	assert.ErrorIs(t, err, ErrUnterminated)
+}
+
+func TestFormat(t *testing.T) {
+	meta := &Metadata{ID: "k-1", Type: "guideline", Title: "Deploys", Tags: Tags{"ci"}}
+
+	data, err := Format(meta, "# Deploys\n")
+	require.NoError(t, err)
+	assert.Equal(t, "---\nid: k-1\ntype: guideline\ntitle: Deploys\ntags:\n    - ci\n---\n\n# Deploys\n", string(data))
+
+	parsed, body, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, meta, parsed)
+	assert.Equal(t, "# Deploys\n", body)
+
+	data, err = Format(&Metadata{}, "# Deploys\n")
+	require.NoError(t, err)
+	assert.Equal(t, "# Deploys\n", string(data))
+}
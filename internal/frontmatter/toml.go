@@ -0,0 +1,86 @@
+package frontmatter
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+func parseTOML(text string) (*Metadata, error) {
+	var values map[string]any
+	md, err := toml.Decode(text, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are visited in document order so the first bad field is the one reported.
+	// Keys below a [table] header and dotted keys are skipped, like unknown keys.
+	meta := &Metadata{}
+	for _, key := range md.Keys() {
+		if len(key) != 1 {
+			continue
+		}
+		if err := setTOMLField(meta, key[0], values[key[0]]); err != nil {
+			return nil, err
+		}
+	}
+	return meta, nil
+}
+
+// setTOMLField stores a root-level value on the metadata field of the same name
+func setTOMLField(meta *Metadata, key string, value any) error {
+	var field *string
+	switch key {
+	case "id":
+		field = &meta.ID
+	case "type":
+		field = &meta.Type
+	case "title":
+		field = &meta.Title
+	case "summary":
+		field = &meta.Summary
+	case "scope":
+		field = &meta.Scope
+	case "status":
+		field = &meta.Status
+	case "tags":
+		return setTOMLTags(meta, value)
+	case "version":
+		version, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("version must be an integer")
+		}
+		meta.Version = version
+		return nil
+	default:
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string", key)
+	}
+	*field = s
+	return nil
+}
+
+// setTOMLTags accepts an array of strings or a comma-separated string
+func setTOMLTags(meta *Metadata, value any) error {
+	switch v := value.(type) {
+	case string:
+		meta.Tags = splitTags(v)
+		return nil
+	case []any:
+		tags := make(Tags, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("tags must be strings")
+			}
+			tags = append(tags, s)
+		}
+		meta.Tags = tags
+		return nil
+	}
+	return fmt.Errorf("tags must be an array of strings")
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// IdempotencyKeyRepository defines the persistence needed to expire idempotency keys
+type IdempotencyKeyRepository interface {
+	// DeleteExpired removes the keys that expired before now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// IdempotencyCleanupJob periodically removes expired idempotency keys
+type IdempotencyCleanupJob struct {
+	repo IdempotencyKeyRepository
+	now  func() time.Time
+}
+
+// NewIdempotencyCleanupJob creates a new IdempotencyCleanupJob instance
+func NewIdempotencyCleanupJob(repo IdempotencyKeyRepository) *IdempotencyCleanupJob {
+	return &IdempotencyCleanupJob{
+		repo: repo,
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ProcessJobs implements the JobProcessor interface
+func (j *IdempotencyCleanupJob) ProcessJobs(ctx context.Context) error {
+	deleted, err := j.repo.DeleteExpired(ctx, j.now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	if deleted > 0 {
+		log.Printf("Idempotency cleanup: %d expired keys removed", deleted)
+	}
+
+	return nil
+}
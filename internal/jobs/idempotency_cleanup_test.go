@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyKeyRepository is a mock implementation of IdempotencyKeyRepository
+type MockIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyKeyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// TestIdempotencyCleanupJob_ProcessJobs tests that keys are expired against the current time
+func TestIdempotencyCleanupJob_ProcessJobs(t *testing.T) {
+	mockRepo := new(MockIdempotencyKeyRepository)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	mockRepo.On("DeleteExpired", mock.Anything, now).Return(int64(3), nil)
+
+	job := NewIdempotencyCleanupJob(mockRepo)
+	job.now = func() time.Time { return now }
+
+	err := job.ProcessJobs(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestIdempotencyCleanupJob_ProcessJobs_RepositoryError tests repository error handling
+func TestIdempotencyCleanupJob_ProcessJobs_RepositoryError(t *testing.T) {
+	mockRepo := new(MockIdempotencyKeyRepository)
+
+	mockRepo.On("DeleteExpired", mock.Anything, mock.Anything).Return(int64(0), errors.New("database error"))
+
+	job := NewIdempotencyCleanupJob(mockRepo)
+	err := job.ProcessJobs(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete expired idempotency keys")
+	mockRepo.AssertExpectations(t)
+}
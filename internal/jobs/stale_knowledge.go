@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// StaleKnowledgeRepository defines the persistence needed to flag overdue knowledge
+type StaleKnowledgeRepository interface {
+	// FlagStale marks items whose review-by date has passed and returns how many were flagged
+	FlagStale(ctx context.Context, now time.Time) (int64, error)
+
+	// ClearStale unflags items that are no longer overdue and returns how many were cleared
+	ClearStale(ctx context.Context, now time.Time) (int64, error)
+}
+
+// StaleKnowledgeJob periodically flags knowledge whose review-by date has passed
+type StaleKnowledgeJob struct {
+	repo StaleKnowledgeRepository
+	now  func() time.Time
+}
+
+// NewStaleKnowledgeJob creates a new StaleKnowledgeJob instance
+func NewStaleKnowledgeJob(repo StaleKnowledgeRepository) *StaleKnowledgeJob {
+	return &StaleKnowledgeJob{
+		repo: repo,
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ProcessJobs implements the JobProcessor interface
+func (j *StaleKnowledgeJob) ProcessJobs(ctx context.Context) error {
+	now := j.now()
+
+	cleared, err := j.repo.ClearStale(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to clear stale knowledge: %w", err)
+	}
+
+	flagged, err := j.repo.FlagStale(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to flag stale knowledge: %w", err)
+	}
+
+	if flagged > 0 || cleared > 0 {
+		log.Printf("Stale knowledge check: %d flagged, %d cleared", flagged, cleared)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStaleKnowledgeRepository is a mock implementation of StaleKnowledgeRepository
+type MockStaleKnowledgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockStaleKnowledgeRepository) FlagStale(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStaleKnowledgeRepository) ClearStale(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// TestStaleKnowledgeJob_ProcessJobs tests that both passes use the same reference time
+func TestStaleKnowledgeJob_ProcessJobs(t *testing.T) {
+	mockRepo := new(MockStaleKnowledgeRepository)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	mockRepo.On("ClearStale", mock.Anything, now).Return(int64(1), nil)
+	mockRepo.On("FlagStale", mock.Anything, now).Return(int64(2), nil)
+
+	job := NewStaleKnowledgeJob(mockRepo)
+	job.now = func() time.Time { return now }
+
+	err := job.ProcessJobs(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestStaleKnowledgeJob_ProcessJobs_RepositoryError tests repository error handling
+func TestStaleKnowledgeJob_ProcessJobs_RepositoryError(t *testing.T) {
+	mockRepo := new(MockStaleKnowledgeRepository)
+
+	mockRepo.On("ClearStale", mock.Anything, mock.Anything).Return(int64(0), nil)
+	mockRepo.On("FlagStale", mock.Anything, mock.Anything).Return(int64(0), errors.New("database error"))
+
+	job := NewStaleKnowledgeJob(mockRepo)
+	err := job.ProcessJobs(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to flag stale knowledge")
+	mockRepo.AssertExpectations(t)
+}
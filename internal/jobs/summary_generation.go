@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/openai"
+)
+
+const (
+	// summaryBatchSize is how many items one run of the job looks at
+	summaryBatchSize = 20
+	// maxGenerationInput caps how much of a body is sent to the model, in characters
+	maxGenerationInput = 12000
+
+	summaryMaxTokens  = 120
+	maxSummaryLength  = 500
+	titleMaxTokens    = 20
+	maxSuggestedTitle = 120
+)
+
+// SummaryGenerationRepository defines the persistence needed to fill in missing summaries
+type SummaryGenerationRepository interface {
+	// ListMissingSummaries returns up to limit items without a summary that were not
+	// looked at since they last changed
+	ListMissingSummaries(ctx context.Context, limit int) ([]*domain.Knowledge, error)
+
+	// SaveGenerated stores generated metadata unless the item changed since it was read
+	// and reports whether it was stored
+	SaveGenerated(ctx context.Context, k *domain.Knowledge, generated domain.GeneratedMetadata) (bool, error)
+}
+
+// SummaryGenerationJob periodically writes summaries for knowledge that arrived without
+// one, and suggests a title for it. Generated summaries are recorded with the model that
+// wrote them; suggested titles are stored next to the title and never replace it.
+type SummaryGenerationJob struct {
+	repo      SummaryGenerationRepository
+	generator openai.TextGenerator
+	now       func() time.Time
+}
+
+// NewSummaryGenerationJob creates a new SummaryGenerationJob instance
+func NewSummaryGenerationJob(repo SummaryGenerationRepository, generator openai.TextGenerator) *SummaryGenerationJob {
+	return &SummaryGenerationJob{
+		repo:      repo,
+		generator: generator,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ProcessJobs implements the JobProcessor interface. An item that cannot be generated
+// for is logged and tried again on the next run; the run only fails when every item did,
+// which usually means the provider is down.
+func (j *SummaryGenerationJob) ProcessJobs(ctx context.Context) error {
+	items, err := j.repo.ListMissingSummaries(ctx, summaryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list knowledge without summary: %w", err)
+	}
+
+	var generated, failed int
+	var lastErr error
+	for _, k := range items {
+		saved, err := j.generate(ctx, k)
+		if err != nil {
+			log.Printf("Summary generation failed for knowledge %s: %v", k.ID, err)
+			failed++
+			lastErr = err
+			continue
+		}
+		if saved {
+			generated++
+		}
+	}
+
+	if failed > 0 && failed == len(items) {
+		return fmt.Errorf("failed to generate summaries: %w", lastErr)
+	}
+
+	if generated > 0 || failed > 0 {
+		log.Printf("Summary generation: %d generated, %d failed", generated, failed)
+	}
+
+	return nil
+}
+
+// generate asks for a summary and a title for one item and saves them
+func (j *SummaryGenerationJob) generate(ctx context.Context, k *domain.Knowledge) (bool, error) {
+	body := k.BodyMD
+	if len(body) > maxGenerationInput {
+		body = strings.ToValidUTF8(body[:maxGenerationInput], "")
+	}
+	if strings.TrimSpace(body) == "" {
+		// Nothing to summarize; record that the item was looked at
+		return j.repo.SaveGenerated(ctx, k, domain.GeneratedMetadata{Model: j.generator.Model(), GeneratedAt: j.now()})
+	}
+
+	summary, err := j.generator.GenerateText(ctx, openai.TextRequest{
+		Instruction: summaryInstruction(k),
+		Input:       body,
+		MaxTokens:   summaryMaxTokens,
+	})
+	if err != nil {
+		return false, fmt.Errorf("summary: %w", err)
+	}
+
+	title, err := j.generator.GenerateText(ctx, openai.TextRequest{
+		Instruction: titleInstruction(k),
+		Input:       body,
+		MaxTokens:   titleMaxTokens,
+	})
+	if err != nil {
+		return false, fmt.Errorf("title: %w", err)
+	}
+
+	title = strings.TrimRight(cleanGenerated(title, maxSuggestedTitle), ".")
+	if strings.EqualFold(title, k.Title) {
+		title = ""
+	}
+
+	saved, err := j.repo.SaveGenerated(ctx, k, domain.GeneratedMetadata{
+		Summary:        cleanGenerated(summary, maxSummaryLength),
+		SuggestedTitle: title,
+		Model:          j.generator.Model(),
+		GeneratedAt:    j.now(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("save: %w", err)
+	}
+	return saved, nil
+}
+
+func summaryInstruction(k *domain.Knowledge) string {
+	return fmt.Sprintf(`You write summaries for a team's knowledge base. The user message is the markdown body of a %s titled %q.
+Reply with one or two plain sentences of at most 300 characters that tell the reader what it says.
+Do not use markdown and do not start with phrases like "This %s".`, k.Type, k.Title, k.Type)
+}
+
+func titleInstruction(k *domain.Knowledge) string {
+	return fmt.Sprintf(`You suggest titles for a team's knowledge base. The user message is the markdown body of a %s currently titled %q.
+Reply with a short, specific title of at most 80 characters and nothing else, without quotes or a trailing period.`, k.Type, k.Title)
+}
+
+// cleanGenerated puts generated text on one line, strips quotes around it and cuts it to
+// limit characters
+func cleanGenerated(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.Trim(text, "\"'`")
+	if runes := []rune(text); len(runes) > limit {
+		text = strings.TrimSpace(string(runes[:limit]))
+	}
+	return text
+}
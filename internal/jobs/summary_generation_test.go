@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSummaryGenerationRepository is a mock implementation of SummaryGenerationRepository
+type MockSummaryGenerationRepository struct {
+	mock.Mock
+}
+
+func (m *MockSummaryGenerationRepository) ListMissingSummaries(ctx context.Context, limit int) ([]*domain.Knowledge, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockSummaryGenerationRepository) SaveGenerated(ctx context.Context, k *domain.Knowledge, generated domain.GeneratedMetadata) (bool, error) {
+	args := m.Called(ctx, k, generated)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockTextGenerator is a mock implementation of openai.TextGenerator
+type MockTextGenerator struct {
+	mock.Mock
+}
+
+func (m *MockTextGenerator) GenerateText(ctx context.Context, req openai.TextRequest) (string, error) {
+	args := m.Called(ctx, req)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTextGenerator) Model() string {
+	return "test-model"
+}
+
+// TestSummaryGenerationJob_ProcessJobs tests that generated metadata is saved with the model
+func TestSummaryGenerationJob_ProcessJobs(t *testing.T) {
+	mockRepo := new(MockSummaryGenerationRepository)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	item := &domain.Knowledge{
+		ID:     "knowledge-1",
+		Type:   domain.KnowledgeTypeGuideline,
+		Title:  "Deploys",
+		BodyMD: "# Deploys\n\nDeploys go out on Tuesdays. Never on Fridays.\n",
+	}
+
+	mockRepo.On("ListMissingSummaries", mock.Anything, summaryBatchSize).Return([]*domain.Knowledge{item}, nil)
+	mockRepo.On("SaveGenerated", mock.Anything, item, domain.GeneratedMetadata{
+		Summary:        "Deploys go out on Tuesdays.",
+		SuggestedTitle: "Deploys go out on Tuesdays",
+		Model:          openai.StubTextModel,
+		GeneratedAt:    now,
+	}).Return(true, nil)
+
+	job := NewSummaryGenerationJob(mockRepo, openai.StubTextGenerator{})
+	job.now = func() time.Time { return now }
+
+	err := job.ProcessJobs(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestSummaryGenerationJob_ProcessJobs_CleansOutput tests that answers are put on one line and
+// that a suggestion equal to the current title is dropped
+func TestSummaryGenerationJob_ProcessJobs_CleansOutput(t *testing.T) {
+	mockRepo := new(MockSummaryGenerationRepository)
+	mockGenerator := new(MockTextGenerator)
+
+	item := &domain.Knowledge{ID: "knowledge-1", Type: domain.KnowledgeTypeDecision, Title: "Use Postgres", BodyMD: "We use Postgres."}
+
+	mockRepo.On("ListMissingSummaries", mock.Anything, summaryBatchSize).Return([]*domain.Knowledge{item}, nil)
+	mockGenerator.On("GenerateText", mock.Anything, mock.MatchedBy(func(req openai.TextRequest) bool {
+		return req.MaxTokens == summaryMaxTokens
+	})).Return("\"We store everything\n in Postgres.\"", nil)
+	mockGenerator.On("GenerateText", mock.Anything, mock.MatchedBy(func(req openai.TextRequest) bool {
+		return req.MaxTokens == titleMaxTokens
+	})).Return("use postgres.", nil)
+	mockRepo.On("SaveGenerated", mock.Anything, item, mock.MatchedBy(func(generated domain.GeneratedMetadata) bool {
+		return generated.Summary == "We store everything in Postgres." && generated.SuggestedTitle == "" && generated.Model == "test-model"
+	})).Return(true, nil)
+
+	job := NewSummaryGenerationJob(mockRepo, mockGenerator)
+	err := job.ProcessJobs(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockGenerator.AssertExpectations(t)
+}
+
+// TestSummaryGenerationJob_ProcessJobs_ProviderError tests that a run fails when no item could be generated
+func TestSummaryGenerationJob_ProcessJobs_ProviderError(t *testing.T) {
+	mockRepo := new(MockSummaryGenerationRepository)
+	mockGenerator := new(MockTextGenerator)
+
+	items := []*domain.Knowledge{
+		{ID: "knowledge-1", Title: "One", BodyMD: "First."},
+		{ID: "knowledge-2", Title: "Two", BodyMD: "Second."},
+	}
+
+	mockRepo.On("ListMissingSummaries", mock.Anything, summaryBatchSize).Return(items, nil)
+	mockGenerator.On("GenerateText", mock.Anything, mock.Anything).Return("", errors.New("connection refused"))
+
+	job := NewSummaryGenerationJob(mockRepo, mockGenerator)
+	err := job.ProcessJobs(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to generate summaries")
+	mockRepo.AssertNotCalled(t, "SaveGenerated", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSummaryGenerationJob_ProcessJobs_BlankBody tests that an item without text is only marked as looked at
+func TestSummaryGenerationJob_ProcessJobs_BlankBody(t *testing.T) {
+	mockRepo := new(MockSummaryGenerationRepository)
+	mockGenerator := new(MockTextGenerator)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	item := &domain.Knowledge{ID: "knowledge-1", Title: "Empty", BodyMD: " \n"}
+
+	mockRepo.On("ListMissingSummaries", mock.Anything, summaryBatchSize).Return([]*domain.Knowledge{item}, nil)
+	mockRepo.On("SaveGenerated", mock.Anything, item, domain.GeneratedMetadata{Model: "test-model", GeneratedAt: now}).Return(true, nil)
+
+	job := NewSummaryGenerationJob(mockRepo, mockGenerator)
+	job.now = func() time.Time { return now }
+	err := job.ProcessJobs(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockGenerator.AssertNotCalled(t, "GenerateText", mock.Anything, mock.Anything)
+}
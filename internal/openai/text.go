@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	// DefaultTextModel is the chat model used for generating summaries and titles
+	DefaultTextModel = openai.GPT4oMini
+	// StubTextModel is the model name reported by StubTextGenerator
+	StubTextModel = "stub"
+)
+
+// TextRequest asks a TextGenerator to follow an instruction on an input text
+type TextRequest struct {
+	Instruction string
+	Input       string
+	// MaxTokens caps the length of the answer; zero leaves it to the provider
+	MaxTokens int
+}
+
+// TextGenerator defines the interface for text generation. It is kept apart from
+// EmbeddingAPI so that summaries can come from a different provider than embeddings,
+// or be generated while embeddings are disabled.
+type TextGenerator interface {
+	GenerateText(ctx context.Context, req TextRequest) (string, error)
+	// Model names the model behind the generator, which is recorded on generated text
+	Model() string
+}
+
+// TextConfig configures a ChatGenerator
+type TextConfig struct {
+	// BaseURL is the API root of an OpenAI-compatible server; empty means the OpenAI API
+	BaseURL string
+	APIKey  string
+	Model   string
+}
+
+// ChatGenerator generates text with the chat completions endpoint of the OpenAI API or
+// of any server that implements it, such as Ollama, vLLM or LiteLLM
+type ChatGenerator struct {
+	client *openai.Client
+	model  string
+}
+
+// NewChatGenerator creates a ChatGenerator for the given configuration
+func NewChatGenerator(cfg TextConfig) *ChatGenerator {
+	config := openai.DefaultConfig(cfg.APIKey)
+	if cfg.BaseURL != "" {
+		config.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	model := cfg.Model
+	if model == "" {
+		model = DefaultTextModel
+	}
+	return &ChatGenerator{
+		client: openai.NewClientWithConfig(config),
+		model:  model,
+	}
+}
+
+// Model implements TextGenerator
+func (g *ChatGenerator) Model() string {
+	return g.model
+}
+
+// GenerateText sends the instruction as the system message and the input as the user
+// message and returns the trimmed answer
+func (g *ChatGenerator) GenerateText(ctx context.Context, req TextRequest) (string, error) {
+	if strings.TrimSpace(req.Input) == "" {
+		return "", ErrEmptyText
+	}
+
+	var messages []openai.ChatCompletionMessage
+	if req.Instruction != "" {
+		messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleSystem, Content: req.Instruction})
+	}
+	messages = append(messages, openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: req.Input})
+
+	resp, err := g.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:    g.model,
+		Messages: messages,
+		// max_tokens rather than max_completion_tokens, which most compatible servers do not know yet
+		MaxTokens: req.MaxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", errors.New("no completion choices returned")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
+
+// StubTextGenerator is a deterministic TextGenerator for tests and local development. It
+// answers every request with the first sentence of the input's first paragraph of prose,
+// cut to about four characters per token of MaxTokens, without calling any API.
+type StubTextGenerator struct{}
+
+// Model implements TextGenerator
+func (StubTextGenerator) Model() string {
+	return StubTextModel
+}
+
+// GenerateText implements TextGenerator
+func (StubTextGenerator) GenerateText(ctx context.Context, req TextRequest) (string, error) {
+	if strings.TrimSpace(req.Input) == "" {
+		return "", ErrEmptyText
+	}
+
+	text := firstSentence(req.Input)
+	if req.MaxTokens > 0 {
+		text = truncateWords(text, req.MaxTokens*4)
+	}
+	return text, nil
+}
+
+// firstSentence returns the first sentence of the first line of markdown that is prose,
+// skipping headings, rules, code blocks and list or quote markers
+func firstSentence(markdown string) string {
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || line == "" || strings.HasPrefix(line, "#") || strings.Trim(line, "-*_ ") == "" {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*+> "))
+		if line == "" {
+			continue
+		}
+		if end := strings.Index(line, ". "); end >= 0 {
+			return line[:end+1]
+		}
+		return line
+	}
+	return ""
+}
+
+// truncateWords cuts text to at most limit characters, at a word boundary when there is one
+func truncateWords(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	cut := string([]rune(text)[:limit])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimSpace(cut)
+}
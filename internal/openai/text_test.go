@@ -0,0 +1,121 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatGenerator_GenerateText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+		var body struct {
+			Model     string `json:"model"`
+			MaxTokens int    `json:"max_tokens"`
+			Messages  []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "llama3", body.Model)
+		assert.Equal(t, 60, body.MaxTokens)
+		require.Len(t, body.Messages, 2)
+		assert.Equal(t, "system", body.Messages[0].Role)
+		assert.Equal(t, "Summarize this.", body.Messages[0].Content)
+		assert.Equal(t, "user", body.Messages[1].Role)
+		assert.Equal(t, "Deploys go out on Tuesdays.", body.Messages[1].Content)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"index":0,"message":{"role":"assistant","content":"  Deploy on Tuesdays.\n"}}]}`))
+	}))
+	defer server.Close()
+
+	generator := NewChatGenerator(TextConfig{BaseURL: server.URL + "/v1/", APIKey: "sk-test", Model: "llama3"})
+
+	text, err := generator.GenerateText(context.Background(), TextRequest{
+		Instruction: "Summarize this.",
+		Input:       "Deploys go out on Tuesdays.",
+		MaxTokens:   60,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Deploy on Tuesdays.", text)
+	assert.Equal(t, "llama3", generator.Model())
+}
+
+func TestChatGenerator_GenerateText_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"rate limit exceeded","type":"rate_limit"}}`))
+	}))
+	defer server.Close()
+
+	generator := NewChatGenerator(TextConfig{BaseURL: server.URL})
+
+	_, err := generator.GenerateText(context.Background(), TextRequest{Input: "Text"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit exceeded")
+}
+
+func TestChatGenerator_GenerateText_NoChoices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[]}`))
+	}))
+	defer server.Close()
+
+	generator := NewChatGenerator(TextConfig{BaseURL: server.URL})
+
+	_, err := generator.GenerateText(context.Background(), TextRequest{Input: "Text"})
+
+	assert.Error(t, err)
+	assert.Equal(t, DefaultTextModel, generator.Model())
+}
+
+func TestChatGenerator_GenerateText_EmptyInput(t *testing.T) {
+	generator := NewChatGenerator(TextConfig{})
+
+	_, err := generator.GenerateText(context.Background(), TextRequest{Input: "  \n"})
+
+	assert.Equal(t, ErrEmptyText, err)
+}
+
+func TestStubTextGenerator(t *testing.T) {
+	input := "# Deploys\n\n```sh\nmake deploy\n```\n\n- Deploys go out on Tuesdays. Never on Fridays.\n"
+
+	for _, tc := range []struct {
+		name      string
+		maxTokens int
+		expected  string
+	}{
+		{name: "first sentence", expected: "Deploys go out on Tuesdays."},
+		{name: "cut at a word", maxTokens: 4, expected: "Deploys go out"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			generator := StubTextGenerator{}
+
+			first, err := generator.GenerateText(context.Background(), TextRequest{Input: input, MaxTokens: tc.maxTokens})
+			require.NoError(t, err)
+			second, err := generator.GenerateText(context.Background(), TextRequest{Input: input, MaxTokens: tc.maxTokens})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, first)
+			assert.Equal(t, first, second)
+		})
+	}
+
+	_, err := StubTextGenerator{}.GenerateText(context.Background(), TextRequest{})
+	assert.Equal(t, ErrEmptyText, err)
+	assert.Equal(t, StubTextModel, StubTextGenerator{}.Model())
+}
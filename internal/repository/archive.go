@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrgArchiveRepository reads and writes the whole content of an organization for
+// export archives.
+type OrgArchiveRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrgArchiveRepository(pool *pgxpool.Pool) *OrgArchiveRepository {
+	return &OrgArchiveRepository{pool: pool}
+}
+
+// Snapshot reads projects, types, the lint configuration, knowledge with its versions,
+// assets, asset links and relations of an org in a single read-only repeatable-read
+// transaction, so the archive is consistent even while agents keep writing.
+func (r *OrgArchiveRepository) Snapshot(ctx context.Context, orgID string) (*service.OrgSnapshot, error) {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	snapshot := &service.OrgSnapshot{OrgID: orgID}
+
+	if snapshot.Projects, err = listProjects(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+	if snapshot.Types, err = NewKnowledgeTypeRepositoryWithTx(tx).ListByOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+	if snapshot.LintConfig, err = NewLintConfigRepositoryWithTx(tx).Get(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+knowledgeColumns+` FROM knowledge WHERE org_id = $1 ORDER BY created_at, id`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		k, err := scanKnowledge(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		snapshot.Knowledge = append(snapshot.Knowledge, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT `+versionColumns+` FROM knowledge_versions
+		 WHERE knowledge_id IN (SELECT id FROM knowledge WHERE org_id = $1)
+		 ORDER BY knowledge_id, version_number`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		v, err := scanVersion(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		snapshot.Versions = append(snapshot.Versions, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT id, org_id, project_id, filename, mime_type, sha256, storage_key, keywords, description, created_at
+		 FROM assets WHERE org_id = $1 ORDER BY created_at, id`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var a domain.Asset
+		var projectID *string
+		if err := rows.Scan(&a.ID, &a.OrgID, &projectID, &a.Filename, &a.MimeType, &a.SHA256, &a.StorageKey, &a.Keywords, &a.Description, &a.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if projectID != nil {
+			a.ProjectID = *projectID
+		}
+		snapshot.Assets = append(snapshot.Assets, &a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT ka.knowledge_id, ka.asset_id
+		 FROM knowledge_assets ka
+		 INNER JOIN knowledge k ON k.id = ka.knowledge_id
+		 WHERE k.org_id = $1
+		 ORDER BY ka.knowledge_id, ka.asset_id`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var link service.KnowledgeAssetLink
+		if err := rows.Scan(&link.KnowledgeID, &link.AssetID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		snapshot.Links = append(snapshot.Links, link)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx,
+		`SELECT id, org_id, source_id, target_id, relation_type, created_at
+		 FROM knowledge_relations WHERE org_id = $1 ORDER BY created_at, id`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rel domain.KnowledgeRelation
+		if err := rows.Scan(&rel.ID, &rel.OrgID, &rel.SourceID, &rel.TargetID, &rel.Type, &rel.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		snapshot.Relations = append(snapshot.Relations, &rel)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snapshot, tx.Commit(ctx)
+}
+
+// ListProjects returns the projects of an org
+func (r *OrgArchiveRepository) ListProjects(ctx context.Context, orgID string) ([]*domain.Project, error) {
+	return listProjects(ctx, r.pool, orgID)
+}
+
+func listProjects(ctx context.Context, db dbtx, orgID string) ([]*domain.Project, error) {
+	rows, err := db.Query(ctx,
+		`SELECT id, org_id, name, created_at FROM projects WHERE org_id = $1 ORDER BY created_at, id`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*domain.Project
+	for rows.Next() {
+		var p domain.Project
+		if err := rows.Scan(&p.ID, &p.OrgID, &p.Name, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, &p)
+	}
+	return projects, rows.Err()
+}
+
+// Restore writes a snapshot and its embedding jobs in one transaction. Knowledge is
+// inserted before its supersession and template references are set, so items can point
+// at each other in any order. Types the org already has are left untouched, and so is a
+// lint configuration it already has. A row whose ID is already taken fails the restore
+// with ErrArchiveIDConflict.
+func (r *OrgArchiveRepository) Restore(ctx context.Context, snapshot *service.OrgSnapshot, jobs []*domain.EmbeddingJob) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := restoreRows(ctx, tx, snapshot, jobs); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrArchiveIDConflict
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func restoreRows(ctx context.Context, tx pgx.Tx, snapshot *service.OrgSnapshot, jobs []*domain.EmbeddingJob) error {
+	for _, p := range snapshot.Projects {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO projects (id, org_id, name, created_at) VALUES ($1, $2, $3, $4)`,
+			p.ID, p.OrgID, p.Name, p.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range snapshot.Types {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO knowledge_types (id, org_id, name, display_name, required_sections, search_boost, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 ON CONFLICT (org_id, name) DO NOTHING`,
+			d.ID, d.OrgID, d.Name, d.DisplayName, nonNilSections(d.RequiredSections), d.SearchBoost, d.CreatedAt, d.UpdatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	if snapshot.LintConfig != nil {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO lint_configs (org_id, config) VALUES ($1, $2) ON CONFLICT (org_id) DO NOTHING`,
+			snapshot.OrgID, snapshot.LintConfig,
+		); err != nil {
+			return err
+		}
+	}
+
+	knowledgeRepo := NewKnowledgeRepositoryWithTx(tx)
+	for _, k := range snapshot.Knowledge {
+		item := *k
+		item.SupersededBy = ""
+		item.TemplateID = ""
+		item.TemplateVersion = 0
+		if err := knowledgeRepo.Create(ctx, &item); err != nil {
+			return err
+		}
+	}
+	for _, k := range snapshot.Knowledge {
+		if k.SupersededBy == "" && k.TemplateID == "" {
+			continue
+		}
+		if _, err := tx.Exec(ctx,
+			`UPDATE knowledge SET superseded_by = $1, template_id = $2, template_version = $3 WHERE id = $4`,
+			nullableString(k.SupersededBy), nullableString(k.TemplateID), nullableVersion(k.TemplateVersion), k.ID,
+		); err != nil {
+			return err
+		}
+	}
+	for _, v := range snapshot.Versions {
+		if err := knowledgeRepo.CreateVersion(ctx, v); err != nil {
+			return err
+		}
+	}
+
+	assetRepo := NewAssetRepositoryWithTx(tx)
+	for _, a := range snapshot.Assets {
+		if err := assetRepo.Create(ctx, a); err != nil {
+			return err
+		}
+	}
+	for _, link := range snapshot.Links {
+		if err := assetRepo.LinkToKnowledge(ctx, link.KnowledgeID, link.AssetID); err != nil {
+			return err
+		}
+	}
+
+	relationRepo := NewKnowledgeRelationRepositoryWithTx(tx)
+	for _, rel := range snapshot.Relations {
+		if err := relationRepo.Create(ctx, rel); err != nil {
+			return err
+		}
+	}
+
+	jobRepo := NewEmbeddingJobRepositoryWithTx(tx)
+	for _, job := range jobs {
+		if err := jobRepo.Create(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgArchiveRepository_SnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	assetRepo := NewAssetRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+	typeRepo := NewKnowledgeTypeRepository(pool)
+	archiveRepo := NewOrgArchiveRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	require.NoError(t, typeRepo.Create(ctx, &domain.KnowledgeTypeDefinition{
+		ID: uuid.NewString(), OrgID: org.ID, Name: "runbook", DisplayName: "Runbook", SearchBoost: 1, CreatedAt: now, UpdatedAt: now,
+	}))
+
+	create := func(title string, knowledgeType domain.KnowledgeType) *domain.Knowledge {
+		k := domain.NewKnowledge(uuid.NewString(), org.ID, project.ID, knowledgeType, domain.KnowledgeStatusApproved,
+			title, "", "Body of "+title, now, now)
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		require.NoError(t, knowledgeRepo.CreateVersion(ctx, &domain.KnowledgeVersion{
+			ID: uuid.NewString(), KnowledgeID: k.ID, VersionNumber: 1, Title: k.Title, BodyMD: k.BodyMD, CreatedAt: now,
+		}))
+		return k
+	}
+
+	oldItem := create("Deploy on Fridays", domain.KnowledgeTypeGuideline)
+	newItem := create("Deploy on Tuesdays", "runbook")
+	oldItem.Status = domain.KnowledgeStatusDeprecated
+	oldItem.SupersededBy = newItem.ID
+	require.NoError(t, knowledgeRepo.Update(ctx, oldItem))
+
+	asset := domain.NewAsset(uuid.NewString(), org.ID, project.ID, "diagram.pdf", "application/pdf", "abc",
+		org.ID+"/diagram.pdf", []string{"architecture"}, "System diagram", now)
+	require.NoError(t, assetRepo.Create(ctx, asset))
+	require.NoError(t, assetRepo.LinkToKnowledge(ctx, newItem.ID, asset.ID))
+	require.NoError(t, relationRepo.Create(ctx, domain.NewKnowledgeRelation(uuid.NewString(), org.ID, newItem.ID, oldItem.ID, domain.RelationTypeRefines, now)))
+
+	otherOrg, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	snapshot, err := archiveRepo.Snapshot(ctx, org.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshot.Projects, 1)
+	require.Len(t, snapshot.Types, 1)
+	require.Len(t, snapshot.Knowledge, 2)
+	assert.Equal(t, newItem.ID, supersededBy(snapshot.Knowledge, oldItem.ID))
+	assert.Len(t, snapshot.Versions, 2)
+	require.Len(t, snapshot.Assets, 1)
+	assert.Equal(t, []service.KnowledgeAssetLink{{KnowledgeID: newItem.ID, AssetID: asset.ID}}, snapshot.Links)
+	require.Len(t, snapshot.Relations, 1)
+
+	t.Run("restoring the same IDs again conflicts", func(t *testing.T) {
+		err := archiveRepo.Restore(ctx, snapshot, nil)
+		assert.ErrorIs(t, err, domain.ErrArchiveIDConflict)
+	})
+
+	t.Run("restores into another org", func(t *testing.T) {
+		restored := &service.OrgSnapshot{OrgID: otherOrg.ID}
+		projectID := uuid.NewString()
+		restored.Projects = []*domain.Project{domain.NewProject(projectID, otherOrg.ID, "platform", now)}
+		for _, d := range snapshot.Types {
+			def := *d
+			def.ID = uuid.NewString()
+			def.OrgID = otherOrg.ID
+			restored.Types = append(restored.Types, &def)
+		}
+
+		ids := map[string]string{}
+		for _, k := range snapshot.Knowledge {
+			ids[k.ID] = uuid.NewString()
+		}
+		for _, k := range snapshot.Knowledge {
+			item := *k
+			item.ID = ids[k.ID]
+			item.OrgID = otherOrg.ID
+			item.ProjectID = projectID
+			item.SupersededBy = ids[k.SupersededBy]
+			restored.Knowledge = append(restored.Knowledge, &item)
+		}
+		for _, v := range snapshot.Versions {
+			version := *v
+			version.ID = uuid.NewString()
+			version.KnowledgeID = ids[v.KnowledgeID]
+			restored.Versions = append(restored.Versions, &version)
+		}
+		restoredAsset := *asset
+		restoredAsset.ID = uuid.NewString()
+		restoredAsset.OrgID = otherOrg.ID
+		restoredAsset.ProjectID = projectID
+		restored.Assets = []*domain.Asset{&restoredAsset}
+		restored.Links = []service.KnowledgeAssetLink{{KnowledgeID: ids[newItem.ID], AssetID: restoredAsset.ID}}
+		restored.Relations = []*domain.KnowledgeRelation{
+			domain.NewKnowledgeRelation(uuid.NewString(), otherOrg.ID, ids[newItem.ID], ids[oldItem.ID], domain.RelationTypeRefines, now),
+		}
+		jobs := []*domain.EmbeddingJob{{ID: uuid.NewString(), KnowledgeID: ids[newItem.ID], Status: domain.EmbeddingJobStatusPending, CreatedAt: now}}
+
+		require.NoError(t, archiveRepo.Restore(ctx, restored, jobs))
+
+		roundTrip, err := archiveRepo.Snapshot(ctx, otherOrg.ID)
+		require.NoError(t, err)
+		assert.Len(t, roundTrip.Projects, 2, "the test project and the restored one")
+		assert.Len(t, roundTrip.Types, 1)
+		require.Len(t, roundTrip.Knowledge, 2)
+		assert.Equal(t, ids[newItem.ID], supersededBy(roundTrip.Knowledge, ids[oldItem.ID]))
+		assert.Len(t, roundTrip.Versions, 2)
+		assert.Len(t, roundTrip.Assets, 1)
+		assert.Len(t, roundTrip.Links, 1)
+		assert.Len(t, roundTrip.Relations, 1)
+
+		var pending int
+		require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM embedding_jobs WHERE knowledge_id = $1`, ids[newItem.ID]).Scan(&pending))
+		assert.Equal(t, 1, pending)
+	})
+}
+
+func supersededBy(items []*domain.Knowledge, id string) string {
+	for _, k := range items {
+		if k.ID == id {
+			return k.SupersededBy
+		}
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChecklistRunRepository struct {
+	db dbtx
+}
+
+func NewChecklistRunRepository(pool *pgxpool.Pool) *ChecklistRunRepository {
+	return &ChecklistRunRepository{db: pool}
+}
+
+func NewChecklistRunRepositoryWithTx(tx pgx.Tx) *ChecklistRunRepository {
+	return &ChecklistRunRepository{db: tx}
+}
+
+const checklistRunColumns = `id, org_id, knowledge_id, version_number, status, started_by, started_at, completed_by, completed_at, note`
+
+func scanChecklistRun(row pgx.Row) (*domain.ChecklistRun, error) {
+	var run domain.ChecklistRun
+	var completedBy *string
+	if err := row.Scan(&run.ID, &run.OrgID, &run.KnowledgeID, &run.VersionNumber, &run.Status,
+		&run.StartedBy, &run.StartedAt, &completedBy, &run.CompletedAt, &run.Note); err != nil {
+		return nil, err
+	}
+	if completedBy != nil {
+		run.CompletedBy = *completedBy
+	}
+	return &run, nil
+}
+
+// Create stores a run together with its items in a single statement
+func (r *ChecklistRunRepository) Create(ctx context.Context, run *domain.ChecklistRun) error {
+	numbers := make([]int32, len(run.Items))
+	texts := make([]string, len(run.Items))
+	sections := make([]string, len(run.Items))
+	for i, item := range run.Items {
+		numbers[i] = int32(item.Number)
+		texts[i] = item.Text
+		sections[i] = item.Section
+	}
+
+	_, err := r.db.Exec(ctx,
+		`WITH run AS (
+		     INSERT INTO checklist_runs (id, org_id, knowledge_id, version_number, status, started_by, started_at, note)
+		     VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		     RETURNING id
+		 )
+		 INSERT INTO checklist_run_items (run_id, number, text, section)
+		 SELECT run.id, item.number, item.text, item.section
+		 FROM run, unnest($9::int[], $10::text[], $11::text[]) AS item(number, text, section)`,
+		run.ID, run.OrgID, run.KnowledgeID, run.VersionNumber, run.Status, run.StartedBy, run.StartedAt, run.Note,
+		numbers, texts, sections,
+	)
+	return err
+}
+
+// GetByID returns a run with its items
+func (r *ChecklistRunRepository) GetByID(ctx context.Context, id string) (*domain.ChecklistRun, error) {
+	run, err := scanChecklistRun(r.db.QueryRow(ctx,
+		`SELECT `+checklistRunColumns+` FROM checklist_runs WHERE id = $1`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrChecklistRunNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.attachItems(ctx, []*domain.ChecklistRun{run}); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// ListByKnowledge returns the runs of a checklist with their items, newest first
+func (r *ChecklistRunRepository) ListByKnowledge(ctx context.Context, knowledgeID string) ([]*domain.ChecklistRun, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+checklistRunColumns+` FROM checklist_runs
+		 WHERE knowledge_id = $1
+		 ORDER BY started_at DESC, id`,
+		knowledgeID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make([]*domain.ChecklistRun, 0)
+	for rows.Next() {
+		run, err := scanChecklistRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachItems(ctx, runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// UpdateItem stores the checked state of one item of a run that is still in progress
+func (r *ChecklistRunRepository) UpdateItem(ctx context.Context, runID string, item *domain.ChecklistRunItem) error {
+	var checkedBy *string
+	if item.CheckedBy != "" {
+		checkedBy = &item.CheckedBy
+	}
+
+	result, err := r.db.Exec(ctx,
+		`UPDATE checklist_run_items i
+		 SET checked_by = $3, checked_at = $4, note = $5
+		 FROM checklist_runs r
+		 WHERE r.id = i.run_id AND i.run_id = $1 AND i.number = $2 AND r.status = $6`,
+		runID, item.Number, checkedBy, item.CheckedAt, item.Note, domain.ChecklistRunStatusInProgress,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+	return nil
+}
+
+// Complete marks a run as completed; runs that are already completed are left untouched
+func (r *ChecklistRunRepository) Complete(ctx context.Context, run *domain.ChecklistRun) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE checklist_runs
+		 SET status = $2, completed_by = $3, completed_at = $4
+		 WHERE id = $1 AND status = $5`,
+		run.ID, domain.ChecklistRunStatusCompleted, run.CompletedBy, run.CompletedAt, domain.ChecklistRunStatusInProgress,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrChecklistRunCompleted
+	}
+	return nil
+}
+
+// attachItems loads the items of the given runs with a single query
+func (r *ChecklistRunRepository) attachItems(ctx context.Context, runs []*domain.ChecklistRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(runs))
+	byID := make(map[string]*domain.ChecklistRun, len(runs))
+	for i, run := range runs {
+		ids[i] = run.ID
+		byID[run.ID] = run
+		run.Items = make([]*domain.ChecklistRunItem, 0)
+	}
+
+	rows, err := r.db.Query(ctx,
+		`SELECT run_id, number, text, section, checked_by, checked_at, note
+		 FROM checklist_run_items
+		 WHERE run_id = ANY($1)
+		 ORDER BY run_id, number`,
+		ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var runID string
+		var item domain.ChecklistRunItem
+		var checkedBy *string
+		if err := rows.Scan(&runID, &item.Number, &item.Text, &item.Section, &checkedBy, &item.CheckedAt, &item.Note); err != nil {
+			return err
+		}
+		if checkedBy != nil {
+			item.CheckedBy = *checkedBy
+		}
+		if run, ok := byID[runID]; ok {
+			run.Items = append(run.Items, &item)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecklistRunRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	runRepo := NewChecklistRunRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	checklist := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "Release checklist")
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	run := domain.NewChecklistRun(uuid.NewString(), org.ID, &domain.Checklist{
+		KnowledgeID:   checklist.ID,
+		VersionNumber: 2,
+		Items: []domain.ChecklistItem{
+			{Number: 1, Text: "Freeze main", Section: "Before"},
+			{Number: 2, Text: "Tag the release", Section: "After"},
+		},
+	}, "alice", "v1.2.0", now)
+	require.NoError(t, runRepo.Create(ctx, run))
+
+	retrieved, err := runRepo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), retrieved.VersionNumber)
+	assert.Equal(t, domain.ChecklistRunStatusInProgress, retrieved.Status)
+	assert.Equal(t, "alice", retrieved.StartedBy)
+	assert.Equal(t, "v1.2.0", retrieved.Note)
+	require.Len(t, retrieved.Items, 2)
+	assert.Equal(t, "Freeze main", retrieved.Items[0].Text)
+	assert.Equal(t, "After", retrieved.Items[1].Section)
+	assert.False(t, retrieved.Items[0].IsChecked())
+
+	item := retrieved.Items[0]
+	item.CheckedBy = "bob"
+	item.CheckedAt = &now
+	item.Note = "done by CI"
+	require.NoError(t, runRepo.UpdateItem(ctx, run.ID, item))
+
+	err = runRepo.UpdateItem(ctx, run.ID, &domain.ChecklistRunItem{Number: 9})
+	assert.ErrorIs(t, err, domain.ErrChecklistItemNotFound)
+
+	retrieved.CompletedBy = "bob"
+	retrieved.CompletedAt = &now
+	require.NoError(t, runRepo.Complete(ctx, retrieved))
+	assert.ErrorIs(t, runRepo.Complete(ctx, retrieved), domain.ErrChecklistRunCompleted)
+
+	runs, err := runRepo.ListByKnowledge(ctx, checklist.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, domain.ChecklistRunStatusCompleted, runs[0].Status)
+	assert.Equal(t, "bob", runs[0].CompletedBy)
+	require.Len(t, runs[0].Items, 2)
+	assert.Equal(t, "bob", runs[0].Items[0].CheckedBy)
+	assert.Equal(t, "done by CI", runs[0].Items[0].Note)
+	assert.Equal(t, []int{2}, runs[0].UncheckedItems())
+
+	_, err = runRepo.GetByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, domain.ErrChecklistRunNotFound)
+}
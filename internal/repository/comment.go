@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type KnowledgeCommentRepository struct {
+	db dbtx
+}
+
+func NewKnowledgeCommentRepository(pool *pgxpool.Pool) *KnowledgeCommentRepository {
+	return &KnowledgeCommentRepository{db: pool}
+}
+
+func NewKnowledgeCommentRepositoryWithTx(tx pgx.Tx) *KnowledgeCommentRepository {
+	return &KnowledgeCommentRepository{db: tx}
+}
+
+const commentColumns = `c.id, c.org_id, c.knowledge_id, c.parent_id, c.version_number, c.body, c.author, c.author_key_id,
+		 c.line_start, c.line_end, c.chunk_id, c.resolved_by, c.resolved_by_key_id, c.resolved_at, c.created_at`
+
+func scanComment(row rowScanner) (*domain.KnowledgeComment, error) {
+	var c domain.KnowledgeComment
+	var parentID, author, authorKeyID, chunkID, resolvedBy, resolvedByKeyID *string
+	var lineStart, lineEnd *int
+	if err := row.Scan(&c.ID, &c.OrgID, &c.KnowledgeID, &parentID, &c.VersionNumber, &c.Body, &author, &authorKeyID,
+		&lineStart, &lineEnd, &chunkID, &resolvedBy, &resolvedByKeyID, &c.ResolvedAt, &c.CreatedAt); err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		c.ParentID = *parentID
+	}
+	if lineStart != nil && lineEnd != nil {
+		c.LineStart = *lineStart
+		c.LineEnd = *lineEnd
+	}
+	if chunkID != nil {
+		c.ChunkID = *chunkID
+	}
+	c.Author = scanAuthor(author, authorKeyID)
+	c.ResolvedBy = scanAuthor(resolvedBy, resolvedByKeyID)
+	return &c, nil
+}
+
+func (r *KnowledgeCommentRepository) Create(ctx context.Context, c *domain.KnowledgeComment) error {
+	var lineStart, lineEnd *int
+	if c.HasLineRange() {
+		lineStart, lineEnd = &c.LineStart, &c.LineEnd
+	}
+
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO knowledge_comments (id, org_id, knowledge_id, parent_id, version_number, body, author, author_key_id,
+		                                 line_start, line_end, chunk_id, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		c.ID, c.OrgID, c.KnowledgeID, nullableString(c.ParentID), c.VersionNumber, c.Body,
+		nullableString(c.Author.Name), nullableString(c.Author.APIKeyID),
+		lineStart, lineEnd, nullableString(c.ChunkID), c.CreatedAt,
+	)
+	return err
+}
+
+func (r *KnowledgeCommentRepository) GetByID(ctx context.Context, id string) (*domain.KnowledgeComment, error) {
+	c, err := scanComment(r.db.QueryRow(ctx,
+		`SELECT `+commentColumns+` FROM knowledge_comments c WHERE c.id = $1`,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// ListByKnowledge returns the comments on an item, oldest first. A non-nil resolved
+// keeps only the threads (with their replies) that are resolved or still open.
+func (r *KnowledgeCommentRepository) ListByKnowledge(ctx context.Context, knowledgeID string, resolved *bool) ([]*domain.KnowledgeComment, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+commentColumns+`
+		 FROM knowledge_comments c
+		 INNER JOIN knowledge_comments thread ON thread.id = COALESCE(c.parent_id, c.id)
+		 WHERE c.knowledge_id = $1
+		   AND ($2::boolean IS NULL OR (thread.resolved_at IS NOT NULL) = $2)
+		 ORDER BY c.created_at, c.id`,
+		knowledgeID, resolved,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.KnowledgeComment, 0)
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// UpdateResolution stores the resolution of a thread, or clears it to reopen the thread
+func (r *KnowledgeCommentRepository) UpdateResolution(ctx context.Context, c *domain.KnowledgeComment) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE knowledge_comments
+		 SET resolved_by = $2, resolved_by_key_id = $3, resolved_at = $4
+		 WHERE id = $1 AND parent_id IS NULL`,
+		c.ID, nullableString(c.ResolvedBy.Name), nullableString(c.ResolvedBy.APIKeyID), c.ResolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// Delete removes a comment; deleting the comment that opened a thread removes its replies
+func (r *KnowledgeCommentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM knowledge_comments WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
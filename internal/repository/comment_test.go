@@ -0,0 +1,97 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeCommentRepository_Threads(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	commentRepo := NewKnowledgeCommentRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	k := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "Retry policy")
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	alice := domain.Author{APIKeyID: uuid.NewString(), Name: "alice"}
+
+	anchored := domain.NewKnowledgeComment(uuid.NewString(), org.ID, k.ID, "", 1, "Why 30 seconds?", alice, now)
+	anchored.LineStart, anchored.LineEnd = 2, 4
+	require.NoError(t, commentRepo.Create(ctx, anchored))
+
+	reply := domain.NewKnowledgeComment(uuid.NewString(), org.ID, k.ID, anchored.ID, 1, "Load balancer timeout", domain.Author{Name: "bob"}, now.Add(time.Second))
+	require.NoError(t, commentRepo.Create(ctx, reply))
+
+	chunkID := uuid.NewString()
+	other := domain.NewKnowledgeComment(uuid.NewString(), org.ID, k.ID, "", 1, "Typo in the example", alice, now.Add(2*time.Second))
+	other.ChunkID = chunkID
+	require.NoError(t, commentRepo.Create(ctx, other))
+
+	retrieved, err := commentRepo.GetByID(ctx, anchored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Why 30 seconds?", retrieved.Body)
+	assert.Equal(t, alice, retrieved.Author)
+	assert.Equal(t, 2, retrieved.LineStart)
+	assert.Equal(t, 4, retrieved.LineEnd)
+	assert.Empty(t, retrieved.ParentID)
+	assert.True(t, retrieved.ResolvedBy.IsZero())
+	assert.False(t, retrieved.IsResolved())
+
+	retrieved, err = commentRepo.GetByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, chunkID, retrieved.ChunkID)
+	assert.False(t, retrieved.HasLineRange())
+
+	// Resolving the first thread moves it and its reply out of the open list
+	anchored.ResolvedBy = domain.Author{Name: "carol"}
+	anchored.ResolvedAt = &now
+	require.NoError(t, commentRepo.UpdateResolution(ctx, anchored))
+	assert.ErrorIs(t, commentRepo.UpdateResolution(ctx, reply), domain.ErrCommentNotFound)
+
+	open, resolved := false, true
+	comments, err := commentRepo.ListByKnowledge(ctx, k.ID, &open)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, other.ID, comments[0].ID)
+
+	comments, err = commentRepo.ListByKnowledge(ctx, k.ID, &resolved)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, anchored.ID, comments[0].ID)
+	assert.Equal(t, "carol", comments[0].ResolvedBy.Name)
+	assert.Equal(t, reply.ID, comments[1].ID)
+	assert.Equal(t, anchored.ID, comments[1].ParentID)
+
+	comments, err = commentRepo.ListByKnowledge(ctx, k.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, comments, 3)
+
+	// Deleting a thread removes its replies
+	require.NoError(t, commentRepo.Delete(ctx, anchored.ID))
+	_, err = commentRepo.GetByID(ctx, reply.ID)
+	assert.ErrorIs(t, err, domain.ErrCommentNotFound)
+	assert.ErrorIs(t, commentRepo.Delete(ctx, anchored.ID), domain.ErrCommentNotFound)
+
+	comments, err = commentRepo.ListByKnowledge(ctx, k.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, other.ID, comments[0].ID)
+}
@@ -22,17 +22,18 @@ func NewContextRepository(pool *pgxpool.Pool) *ContextRepository {
 
 func (r *ContextRepository) GetManifest(ctx context.Context, orgID, projectID string) ([]*service.KnowledgeManifestItem, error) {
 	query := `
-		SELECT id, title, summary, type, scope_path
-		FROM knowledge
-		WHERE org_id = $1`
+		SELECT k.id, k.title, k.summary, k.type, k.scope_path, kt.display_name
+		FROM knowledge k
+		LEFT JOIN knowledge_types kt ON kt.org_id = k.org_id AND kt.name = k.type
+		WHERE k.org_id = $1 AND ` + reviewedCondition("k.status")
 	args := []interface{}{orgID}
 
 	if projectID != "" {
-		query += " AND project_id = $2"
+		query += " AND k.project_id = $2"
 		args = append(args, projectID)
 	}
 
-	query += " ORDER BY updated_at DESC"
+	query += " ORDER BY k.updated_at DESC"
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -43,21 +44,33 @@ func (r *ContextRepository) GetManifest(ctx context.Context, orgID, projectID st
 	var results []*service.KnowledgeManifestItem
 	for rows.Next() {
 		var item service.KnowledgeManifestItem
-		var scope *string
+		var scope, typeName *string
 		var knowledgeType string
-		if err := rows.Scan(&item.ID, &item.Title, &item.Summary, &knowledgeType, &scope); err != nil {
+		if err := rows.Scan(&item.ID, &item.Title, &item.Summary, &knowledgeType, &scope, &typeName); err != nil {
 			return nil, err
 		}
 		item.Type = domain.KnowledgeType(knowledgeType)
 		if scope != nil {
 			item.Scope = *scope
 		}
+		if typeName != nil {
+			item.TypeName = *typeName
+		} else {
+			item.TypeName = domain.DefaultKnowledgeTypeDefinition(item.Type).DisplayName
+		}
 		results = append(results, &item)
 	}
 
 	return results, rows.Err()
 }
 
+// Knowledge and chunks are matched twice: prose through the stemmed 'english' search_tsv and
+// code through the 'simple' code_tsv, which keeps identifiers such as ctx.Done() intact.
+const (
+	knowledgeLexicalMatch = `(search_tsv @@ websearch_to_tsquery('english', $1) OR code_tsv @@ websearch_to_tsquery('simple', $1))`
+	knowledgeLexicalScore = `(ts_rank_cd(search_tsv, websearch_to_tsquery('english', $1)) + ts_rank_cd(code_tsv, websearch_to_tsquery('simple', $1)))`
+)
+
 func (r *ContextRepository) SearchKnowledgeChunksSemantic(ctx context.Context, embedding []float32, filters service.SearchFilters, limit int) ([]*service.ChunkSearchResult, error) {
 	if limit <= 0 {
 		limit = 20
@@ -72,6 +85,8 @@ func (r *ContextRepository) SearchKnowledgeChunksSemantic(ctx context.Context, e
 
 	query := fmt.Sprintf(`
 		SELECT id, knowledge_id, chunk_index, title, summary, scope_path, content, updated_at,
+		       COALESCE((SELECT k.stale_since IS NOT NULL FROM knowledge k WHERE k.id = knowledge_chunks.knowledge_id), false) AS stale,
+		       COALESCE((SELECT kt.search_boost FROM knowledge_types kt WHERE kt.org_id = knowledge_chunks.org_id AND kt.name = knowledge_chunks.type), 1.0) AS type_boost,
 		       1.0 / (1.0 + (embedding <=> $1)) AS score
 		FROM knowledge_chunks
 		WHERE %s
@@ -90,7 +105,7 @@ func (r *ContextRepository) SearchKnowledgeChunksSemantic(ctx context.Context, e
 	for rows.Next() {
 		var result service.ChunkSearchResult
 		var scope *string
-		if err := rows.Scan(&result.ChunkID, &result.KnowledgeID, &result.ChunkIndex, &result.Title, &result.Summary, &scope, &result.Content, &result.UpdatedAt, &result.Score); err != nil {
+		if err := rows.Scan(&result.ChunkID, &result.KnowledgeID, &result.ChunkIndex, &result.Title, &result.Summary, &scope, &result.Content, &result.UpdatedAt, &result.Stale, &result.TypeBoost, &result.Score); err != nil {
 			return nil, err
 		}
 		if scope != nil {
@@ -110,12 +125,14 @@ func (r *ContextRepository) SearchKnowledgeChunksLexical(ctx context.Context, qu
 	args := []interface{}{queryText}
 	argIdx := 2
 
-	where := []string{"search_tsv @@ websearch_to_tsquery('english', $1)"}
+	where := []string{knowledgeLexicalMatch}
 	where = append(where, buildKnowledgeFilters(filters, &args, &argIdx, "")...)
 
 	query := fmt.Sprintf(`
 		SELECT id, knowledge_id, chunk_index, title, summary, scope_path, content, updated_at,
-		       ts_rank_cd(search_tsv, websearch_to_tsquery('english', $1)) AS score
+		       COALESCE((SELECT k.stale_since IS NOT NULL FROM knowledge k WHERE k.id = knowledge_chunks.knowledge_id), false) AS stale,
+		       COALESCE((SELECT kt.search_boost FROM knowledge_types kt WHERE kt.org_id = knowledge_chunks.org_id AND kt.name = knowledge_chunks.type), 1.0) AS type_boost,
+		       `+knowledgeLexicalScore+` AS score
 		FROM knowledge_chunks
 		WHERE %s
 		ORDER BY score DESC
@@ -133,7 +150,7 @@ func (r *ContextRepository) SearchKnowledgeChunksLexical(ctx context.Context, qu
 	for rows.Next() {
 		var result service.ChunkSearchResult
 		var scope *string
-		if err := rows.Scan(&result.ChunkID, &result.KnowledgeID, &result.ChunkIndex, &result.Title, &result.Summary, &scope, &result.Content, &result.UpdatedAt, &result.Score); err != nil {
+		if err := rows.Scan(&result.ChunkID, &result.KnowledgeID, &result.ChunkIndex, &result.Title, &result.Summary, &scope, &result.Content, &result.UpdatedAt, &result.Stale, &result.TypeBoost, &result.Score); err != nil {
 			return nil, err
 		}
 		if scope != nil {
@@ -158,7 +175,8 @@ func (r *ContextRepository) SearchKnowledgeSemantic(ctx context.Context, embeddi
 	where = append(where, buildKnowledgeFilters(filters, &args, &argIdx, "")...)
 
 	query := fmt.Sprintf(`
-		SELECT id, title, summary, scope_path, updated_at,
+		SELECT id, title, summary, scope_path, updated_at, stale_since IS NOT NULL AS stale,
+		       COALESCE((SELECT kt.search_boost FROM knowledge_types kt WHERE kt.org_id = knowledge.org_id AND kt.name = knowledge.type), 1.0) AS type_boost,
 		       1.0 / (1.0 + (embedding <=> $1)) AS score
 		FROM knowledge
 		WHERE %s
@@ -177,7 +195,7 @@ func (r *ContextRepository) SearchKnowledgeSemantic(ctx context.Context, embeddi
 	for rows.Next() {
 		var result service.SearchResult
 		var scope *string
-		if err := rows.Scan(&result.ID, &result.Title, &result.Summary, &scope, &result.UpdatedAt, &result.Score); err != nil {
+		if err := rows.Scan(&result.ID, &result.Title, &result.Summary, &scope, &result.UpdatedAt, &result.Stale, &result.TypeBoost, &result.Score); err != nil {
 			return nil, err
 		}
 		if scope != nil {
@@ -198,12 +216,13 @@ func (r *ContextRepository) SearchKnowledgeLexical(ctx context.Context, queryTex
 	args := []interface{}{queryText}
 	argIdx := 2
 
-	where := []string{"search_tsv @@ websearch_to_tsquery('english', $1)"}
+	where := []string{knowledgeLexicalMatch}
 	where = append(where, buildKnowledgeFilters(filters, &args, &argIdx, "")...)
 
 	query := fmt.Sprintf(`
-		SELECT id, title, summary, scope_path, updated_at,
-		       ts_rank_cd(search_tsv, websearch_to_tsquery('english', $1)) AS score
+		SELECT id, title, summary, scope_path, updated_at, stale_since IS NOT NULL AS stale,
+		       COALESCE((SELECT kt.search_boost FROM knowledge_types kt WHERE kt.org_id = knowledge.org_id AND kt.name = knowledge.type), 1.0) AS type_boost,
+		       `+knowledgeLexicalScore+` AS score
 		FROM knowledge
 		WHERE %s
 		ORDER BY score DESC
@@ -221,7 +240,7 @@ func (r *ContextRepository) SearchKnowledgeLexical(ctx context.Context, queryTex
 	for rows.Next() {
 		var result service.SearchResult
 		var scope *string
-		if err := rows.Scan(&result.ID, &result.Title, &result.Summary, &scope, &result.UpdatedAt, &result.Score); err != nil {
+		if err := rows.Scan(&result.ID, &result.Title, &result.Summary, &scope, &result.UpdatedAt, &result.Stale, &result.TypeBoost, &result.Score); err != nil {
 			return nil, err
 		}
 		if scope != nil {
@@ -321,7 +340,7 @@ func (r *ContextRepository) GetByIDs(ctx context.Context, ids []string) ([]*doma
 	}
 
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+		`SELECT `+knowledgeColumns+`
 		 FROM knowledge WHERE id = ANY($1)`,
 		ids,
 	)
@@ -391,15 +410,55 @@ func buildKnowledgeFilters(filters service.SearchFilters, args *[]interface{}, a
 		where = append(where, fmt.Sprintf("%s = $%d", column("status"), *argIdx))
 		*args = append(*args, filters.Status)
 		*argIdx++
+	} else {
+		where = append(where, reviewedCondition(column("status")))
 	}
 	if filters.PathPrefix != "" {
 		where = append(where, fmt.Sprintf("%s LIKE $%d", column("scope_path"), *argIdx))
 		*args = append(*args, filters.PathPrefix+"%")
 		*argIdx++
 	}
+	if len(filters.Tags) > 0 {
+		where = append(where, tagCondition(column("tags"), filters.TagMatch, *argIdx))
+		*args = append(*args, filters.Tags)
+		*argIdx++
+	}
+	if filters.Language != "" {
+		where = append(where, fmt.Sprintf("%s = $%d", column("language"), *argIdx))
+		*args = append(*args, filters.Language)
+		*argIdx++
+	}
+	if filters.Author != "" {
+		where = append(where, authorCondition(column("created_by"), column("updated_by"), *argIdx))
+		*args = append(*args, filters.Author)
+		*argIdx++
+	}
 	return where
 }
 
+// tagCondition matches a normalized tag array column against the tag parameter at argIdx
+func tagCondition(column string, match service.TagMatch, argIdx int) string {
+	if match == service.TagMatchAll {
+		return fmt.Sprintf("%s @> $%d", column, argIdx)
+	}
+	return fmt.Sprintf("%s && $%d", column, argIdx)
+}
+
+// reviewedCondition leaves out drafts and rejected items unless a status filter asks for them
+func reviewedCondition(column string) string {
+	return fmt.Sprintf("coalesce(%s, '') NOT IN ('draft', 'rejected')", column)
+}
+
+// authorCondition matches items created or last updated by the author name at argIdx, case-insensitively
+func authorCondition(createdByColumn, updatedByColumn string, argIdx int) string {
+	return fmt.Sprintf("(lower(%s) = lower($%d) OR lower(%s) = lower($%d))", createdByColumn, argIdx, updatedByColumn, argIdx)
+}
+
+// keywordCondition matches asset keywords case-insensitively, since they are stored as entered
+func keywordCondition(column string, match service.TagMatch, argIdx int) string {
+	return tagCondition(fmt.Sprintf("ARRAY(SELECT lower(kw) FROM unnest(%s) AS kw)", column), match, argIdx)
+}
+
 func buildAssetFilters(filters service.SearchFilters, args *[]interface{}, argIdx *int, tableAlias string) []string {
 	where := []string{}
 	column := func(name string) string {
@@ -418,6 +477,11 @@ func buildAssetFilters(filters service.SearchFilters, args *[]interface{}, argId
 		*args = append(*args, filters.ProjectID)
 		*argIdx++
 	}
+	if len(filters.Tags) > 0 {
+		where = append(where, keywordCondition(column("keywords"), filters.TagMatch, *argIdx))
+		*args = append(*args, filters.Tags)
+		*argIdx++
+	}
 	return where
 }
 
@@ -442,12 +506,24 @@ func (r *ContextRepository) ListKnowledge(ctx context.Context, input service.Lis
 		where = append(where, fmt.Sprintf("k.status = $%d", argIdx))
 		args = append(args, input.Status)
 		argIdx++
+	} else {
+		where = append(where, reviewedCondition("k.status"))
 	}
 	if input.PathPrefix != "" {
 		where = append(where, fmt.Sprintf("k.scope_path LIKE $%d", argIdx))
 		args = append(args, input.PathPrefix+"%")
 		argIdx++
 	}
+	if len(input.Tags) > 0 {
+		where = append(where, tagCondition("k.tags", input.TagMatch, argIdx))
+		args = append(args, input.Tags)
+		argIdx++
+	}
+	if input.Author != "" {
+		where = append(where, authorCondition("k.created_by", "k.updated_by", argIdx))
+		args = append(args, input.Author)
+		argIdx++
+	}
 	if input.UpdatedSince != nil {
 		where = append(where, fmt.Sprintf("k.updated_at >= $%d", argIdx))
 		args = append(args, *input.UpdatedSince)
@@ -460,7 +536,7 @@ func (r *ContextRepository) ListKnowledge(ctx context.Context, input service.Lis
 	}
 
 	query := fmt.Sprintf(`
-		SELECT k.id, k.title, k.scope_path, k.type, k.status, k.updated_at,
+		SELECT k.id, k.title, k.scope_path, k.type, k.status, k.tags, k.created_by, k.updated_by, k.updated_at,
 		       COALESCE((SELECT COUNT(*) FROM knowledge_chunks WHERE knowledge_id = k.id), 0) AS chunk_count
 		FROM knowledge k
 		WHERE %s
@@ -478,13 +554,19 @@ func (r *ContextRepository) ListKnowledge(ctx context.Context, input service.Lis
 	var items []*service.ListItem
 	for rows.Next() {
 		var item service.ListItem
-		var scope *string
-		if err := rows.Scan(&item.ID, &item.Title, &scope, &item.Type, &item.Status, &item.UpdatedAt, &item.ChunkCount); err != nil {
+		var scope, createdBy, updatedBy *string
+		if err := rows.Scan(&item.ID, &item.Title, &scope, &item.Type, &item.Status, &item.Tags, &createdBy, &updatedBy, &item.UpdatedAt, &item.ChunkCount); err != nil {
 			return nil, err
 		}
 		if scope != nil {
 			item.Scope = *scope
 		}
+		if createdBy != nil {
+			item.CreatedBy = *createdBy
+		}
+		if updatedBy != nil {
+			item.UpdatedBy = *updatedBy
+		}
 		item.SourceType = "knowledge"
 		items = append(items, &item)
 	}
@@ -504,6 +586,11 @@ func (r *ContextRepository) ListAssets(ctx context.Context, input service.ListIn
 		args = append(args, input.ProjectID)
 		argIdx++
 	}
+	if len(input.Tags) > 0 {
+		where = append(where, keywordCondition("keywords", input.TagMatch, argIdx))
+		args = append(args, input.Tags)
+		argIdx++
+	}
 	if input.UpdatedSince != nil {
 		where = append(where, fmt.Sprintf("created_at >= $%d", argIdx))
 		args = append(args, *input.UpdatedSince)
@@ -516,7 +603,7 @@ func (r *ContextRepository) ListAssets(ctx context.Context, input service.ListIn
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, filename, mime_type, created_at
+		SELECT id, filename, mime_type, keywords, created_at
 		FROM assets
 		WHERE %s
 		ORDER BY created_at DESC
@@ -533,7 +620,7 @@ func (r *ContextRepository) ListAssets(ctx context.Context, input service.ListIn
 	var items []*service.ListItem
 	for rows.Next() {
 		var item service.ListItem
-		if err := rows.Scan(&item.ID, &item.Filename, &item.MimeType, &item.UpdatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.Filename, &item.MimeType, &item.Tags, &item.UpdatedAt); err != nil {
 			return nil, err
 		}
 		item.Title = item.Filename
@@ -544,3 +631,7 @@ func (r *ContextRepository) ListAssets(ctx context.Context, input service.ListIn
 
 	return items, rows.Err()
 }
+
+func (r *ContextRepository) ListRelated(ctx context.Context, knowledgeIDs []string) ([]*service.RelatedKnowledge, error) {
+	return listRelatedKnowledge(ctx, r.pool, knowledgeIDs)
+}
@@ -0,0 +1,260 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextRepository_ListKnowledge_Tags(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	contextRepo := NewContextRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	create := func(title string, tags []string) *domain.Knowledge {
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			Type:      domain.KnowledgeTypeGuideline,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    "# " + title,
+			Tags:      tags,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	both := create("Release pipeline", []string{"ci", "release"})
+	ciOnly := create("Lint in CI", []string{"ci"})
+	create("Untagged", nil)
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, both.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci", "release"}, retrieved.Tags)
+
+	ids := func(items []*service.ListItem) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.ID
+		}
+		return result
+	}
+
+	anyItems, err := contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Tags: []string{"ci", "release"}, Limit: 10})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{both.ID, ciOnly.ID}, ids(anyItems))
+
+	allItems, err := contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Tags: []string{"ci", "release"}, TagMatch: service.TagMatchAll, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{both.ID}, ids(allItems))
+	assert.Equal(t, []string{"ci", "release"}, allItems[0].Tags)
+}
+
+func TestContextRepository_SearchKnowledgeLexical_Code(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	contextRepo := NewContextRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	create := func(title, language, body string) *domain.Knowledge {
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			Type:      domain.KnowledgeTypeSnippet,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    body,
+			Language:  language,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	goSnippet := create("Retry helper", "go", "func retryWithBackoff(ctx context.Context) error {\n\treturn nil\n}")
+	pySnippet := create("Retry helper", "python", "def retry_with_backoff(fn):\n    return fn()")
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, goSnippet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "go", retrieved.Language)
+
+	ids := func(results []*service.SearchResult) []string {
+		result := make([]string, len(results))
+		for i, r := range results {
+			result[i] = r.ID
+		}
+		return result
+	}
+
+	// Identifiers are matched whole and by their parts
+	results, err := contextRepo.SearchKnowledgeLexical(ctx, "retryWithBackoff", service.SearchFilters{OrgID: org.ID}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{goSnippet.ID}, ids(results))
+
+	results, err = contextRepo.SearchKnowledgeLexical(ctx, "backoff", service.SearchFilters{OrgID: org.ID}, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{goSnippet.ID, pySnippet.ID}, ids(results))
+
+	results, err = contextRepo.SearchKnowledgeLexical(ctx, "backoff", service.SearchFilters{OrgID: org.ID, Language: "python"}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pySnippet.ID}, ids(results))
+}
+
+func TestContextRepository_AuthorFilter(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	contextRepo := NewContextRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	alice := domain.Author{APIKeyID: uuid.NewString(), Name: "alice"}
+	bot := domain.Author{APIKeyID: uuid.NewString(), Name: "Release-Bot"}
+
+	create := func(title string, author domain.Author) *domain.Knowledge {
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			Type:      domain.KnowledgeTypeLearning,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    title + " deploy notes",
+			CreatedAt: now,
+			UpdatedAt: now,
+			CreatedBy: author,
+			UpdatedBy: author,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		require.NoError(t, knowledgeRepo.CreateVersion(ctx, &domain.KnowledgeVersion{
+			ID: uuid.NewString(), KnowledgeID: k.ID, VersionNumber: 1, Title: k.Title, BodyMD: k.BodyMD, CreatedAt: now, CreatedBy: author,
+		}))
+		return k
+	}
+
+	byAlice := create("Canary rollout", alice)
+	editedByBot := create("Blue green rollout", alice)
+	editedByBot.UpdatedBy = bot
+	require.NoError(t, knowledgeRepo.Update(ctx, editedByBot))
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, editedByBot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice, retrieved.CreatedBy)
+	assert.Equal(t, bot, retrieved.UpdatedBy)
+
+	version, err := knowledgeRepo.GetLatestVersion(ctx, byAlice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice, version.CreatedBy)
+
+	items, err := contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Author: "release-bot"})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, editedByBot.ID, items[0].ID)
+	assert.Equal(t, "alice", items[0].CreatedBy)
+
+	items, err = contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Author: "ALICE"})
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	results, err := contextRepo.SearchKnowledgeLexical(ctx, "deploy", service.SearchFilters{OrgID: org.ID, Author: "release-bot"}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, editedByBot.ID, results[0].ID)
+}
+
+func TestContextRepository_ExcludesUnreviewed(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	contextRepo := NewContextRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	create := func(title string, status domain.KnowledgeStatus) *domain.Knowledge {
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			Type:      domain.KnowledgeTypeGuideline,
+			Status:    status,
+			Title:     title,
+			BodyMD:    "Deploy " + title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	approved := create("approved", domain.KnowledgeStatusApproved)
+	draft := create("draft", domain.KnowledgeStatusDraft)
+	create("rejected", domain.KnowledgeStatusRejected)
+
+	items, err := contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, approved.ID, items[0].ID)
+
+	items, err = contextRepo.ListKnowledge(ctx, service.ListInput{OrgID: org.ID, Status: domain.KnowledgeStatusDraft, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, draft.ID, items[0].ID)
+
+	results, err := contextRepo.SearchKnowledgeLexical(ctx, "deploy", service.SearchFilters{OrgID: org.ID}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, approved.ID, results[0].ID)
+
+	manifest, err := contextRepo.GetManifest(ctx, org.ID, "")
+	require.NoError(t, err)
+	require.Len(t, manifest, 1)
+	assert.Equal(t, approved.ID, manifest[0].ID)
+}
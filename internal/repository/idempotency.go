@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyRepository struct {
+	db dbtx
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: pool}
+}
+
+func NewIdempotencyRepositoryWithTx(tx pgx.Tx) *IdempotencyRepository {
+	return &IdempotencyRepository{db: tx}
+}
+
+// Reserve claims a key for a request that is about to run. A key whose previous record
+// has expired is claimed again. When the key is held by a live record, that record is
+// returned and nothing is stored.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	var orgID string
+	err := r.db.QueryRow(ctx,
+		`INSERT INTO idempotency_keys (org_id, key, route, request_hash, created_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (org_id, key) DO UPDATE
+		 SET route = EXCLUDED.route,
+		     request_hash = EXCLUDED.request_hash,
+		     status_code = NULL,
+		     response_headers = '{}',
+		     response_body = NULL,
+		     created_at = EXCLUDED.created_at,
+		     expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		 RETURNING org_id`,
+		rec.OrgID, rec.Key, rec.Route, rec.RequestHash, rec.CreatedAt, rec.ExpiresAt,
+	).Scan(&orgID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	existing := domain.IdempotencyRecord{OrgID: rec.OrgID, Key: rec.Key}
+	var statusCode *int
+	err = r.db.QueryRow(ctx,
+		`SELECT route, request_hash, status_code, response_headers, response_body, created_at, expires_at
+		 FROM idempotency_keys
+		 WHERE org_id = $1 AND key = $2`,
+		rec.OrgID, rec.Key,
+	).Scan(&existing.Route, &existing.RequestHash, &statusCode, &existing.Headers, &existing.Body,
+		&existing.CreatedAt, &existing.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != nil {
+		existing.StatusCode = *statusCode
+	}
+	return &existing, nil
+}
+
+// Save stores the response of a reserved request and keeps it until the record expires
+func (r *IdempotencyRepository) Save(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	headers := rec.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	_, err := r.db.Exec(ctx,
+		`UPDATE idempotency_keys
+		 SET status_code = $3, response_headers = $4, response_body = $5, expires_at = $6
+		 WHERE org_id = $1 AND key = $2 AND request_hash = $7`,
+		rec.OrgID, rec.Key, rec.StatusCode, headers, rec.Body, rec.ExpiresAt, rec.RequestHash,
+	)
+	return err
+}
+
+// Release frees a reserved key that has no stored response so the request can be retried
+func (r *IdempotencyRepository) Release(ctx context.Context, orgID, key string) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE org_id = $1 AND key = $2 AND status_code IS NULL`,
+		orgID, key,
+	)
+	return err
+}
+
+// DeleteExpired removes the records that expired before now and returns how many were removed
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE expires_at <= $1`,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
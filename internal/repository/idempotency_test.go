@@ -0,0 +1,93 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository_ReserveSaveReplay(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	repo := NewIdempotencyRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	rec := &domain.IdempotencyRecord{
+		OrgID:       org.ID,
+		Key:         "key-1",
+		Route:       "POST /knowledge",
+		RequestHash: "hash-1",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(5 * time.Minute),
+	}
+	existing, err := repo.Reserve(ctx, rec)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// A second reservation sees the pending record
+	existing, err = repo.Reserve(ctx, rec)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.IsCompleted())
+	assert.True(t, existing.Matches("POST /knowledge", "hash-1"))
+
+	rec.StatusCode = 201
+	rec.Headers = map[string]string{"Content-Type": "application/json"}
+	rec.Body = []byte(`{"data":{"id":"k-1"}}`)
+	rec.ExpiresAt = now.Add(24 * time.Hour)
+	require.NoError(t, repo.Save(ctx, rec))
+
+	existing, err = repo.Reserve(ctx, rec)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, rec.Headers, existing.Headers)
+	assert.Equal(t, rec.Body, existing.Body)
+
+	// Release only frees reservations without a stored response
+	require.NoError(t, repo.Release(ctx, org.ID, "key-1"))
+	existing, err = repo.Reserve(ctx, rec)
+	require.NoError(t, err)
+	assert.NotNil(t, existing)
+
+	// Expired records are claimed again and removed by DeleteExpired
+	later := now.Add(25 * time.Hour)
+	retry := &domain.IdempotencyRecord{
+		OrgID:       org.ID,
+		Key:         "key-1",
+		Route:       "POST /knowledge",
+		RequestHash: "hash-2",
+		CreatedAt:   later,
+		ExpiresAt:   later.Add(5 * time.Minute),
+	}
+	existing, err = repo.Reserve(ctx, retry)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	require.NoError(t, repo.Release(ctx, org.ID, "key-1"))
+	_, err = repo.Reserve(ctx, &domain.IdempotencyRecord{
+		OrgID: org.ID, Key: "key-2", Route: "DELETE /knowledge/k-1", RequestHash: "hash-3",
+		CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteExpired(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cloo-solutions/neotexai/internal/domain"
@@ -27,39 +29,40 @@ func NewKnowledgeRepositoryWithTx(tx pgx.Tx) *KnowledgeRepository {
 
 func (r *KnowledgeRepository) Create(ctx context.Context, k *domain.Knowledge) error {
 	_, err := r.db.Exec(ctx,
-		`INSERT INTO knowledge (id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		`INSERT INTO knowledge (id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at,
+		                        reviewed_by, reviewed_by_key_id, reviewed_at, review_note, superseded_by, tags, review_after, owner, stale_since,
+		                        template_id, template_version, language, created_by, created_by_key_id, updated_by, updated_by_key_id,
+		                        source_path, source_hash, summary_generated_by, suggested_title)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26,
+		         $27, $28, $29, $30, $31)`,
 		k.ID, k.OrgID, nullableString(k.ProjectID), k.Type, k.Status, k.Title, k.Summary, k.BodyMD, nullableString(k.Scope), k.CreatedAt, k.UpdatedAt,
+		nullableString(k.ReviewedBy.Name), nullableString(k.ReviewedBy.APIKeyID), k.ReviewedAt, nullableString(k.ReviewNote),
+		nullableString(k.SupersededBy), nonNilTags(k.Tags), k.ReviewAfter, nullableString(k.Owner), k.StaleSince,
+		nullableString(k.TemplateID), nullableVersion(k.TemplateVersion), nullableString(k.Language),
+		nullableString(k.CreatedBy.Name), nullableString(k.CreatedBy.APIKeyID), nullableString(k.UpdatedBy.Name), nullableString(k.UpdatedBy.APIKeyID),
+		nullableString(k.SourcePath), nullableString(k.SourceHash), nullableString(k.SummaryGeneratedBy), nullableString(k.SuggestedTitle),
 	)
 	return err
 }
 
 func (r *KnowledgeRepository) GetByID(ctx context.Context, id string) (*domain.Knowledge, error) {
-	var k domain.Knowledge
-	var projectID, scope *string
-	err := r.db.QueryRow(ctx,
-		`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+	k, err := scanKnowledge(r.db.QueryRow(ctx,
+		`SELECT `+knowledgeColumns+`
 		 FROM knowledge WHERE id = $1`,
 		id,
-	).Scan(&k.ID, &k.OrgID, &projectID, &k.Type, &k.Status, &k.Title, &k.Summary, &k.BodyMD, &scope, &k.CreatedAt, &k.UpdatedAt)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrKnowledgeNotFound
 		}
 		return nil, err
 	}
-	if projectID != nil {
-		k.ProjectID = *projectID
-	}
-	if scope != nil {
-		k.Scope = *scope
-	}
-	return &k, nil
+	return k, nil
 }
 
 func (r *KnowledgeRepository) ListByOrg(ctx context.Context, orgID string) ([]*domain.Knowledge, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+		`SELECT `+knowledgeColumns+`
 		 FROM knowledge WHERE org_id = $1 ORDER BY updated_at DESC`,
 		orgID,
 	)
@@ -72,7 +75,7 @@ func (r *KnowledgeRepository) ListByOrg(ctx context.Context, orgID string) ([]*d
 
 func (r *KnowledgeRepository) ListByProject(ctx context.Context, projectID string) ([]*domain.Knowledge, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+		`SELECT `+knowledgeColumns+`
 		 FROM knowledge WHERE project_id = $1 ORDER BY updated_at DESC`,
 		projectID,
 	)
@@ -93,7 +96,7 @@ func (r *KnowledgeRepository) ListByOrgWithCursor(ctx context.Context, orgID str
 
 	if cursor != nil {
 			rows, err = r.db.Query(ctx,
-			`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+			`SELECT `+knowledgeColumns+`
 			 FROM knowledge 
 			 WHERE org_id = $1 AND (updated_at, id) < ($2, $3)
 			 ORDER BY updated_at DESC, id DESC
@@ -102,7 +105,7 @@ func (r *KnowledgeRepository) ListByOrgWithCursor(ctx context.Context, orgID str
 		)
 	} else {
 		rows, err = r.db.Query(ctx,
-			`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+			`SELECT `+knowledgeColumns+`
 			 FROM knowledge 
 			 WHERE org_id = $1
 			 ORDER BY updated_at DESC, id DESC
@@ -149,7 +152,7 @@ func (r *KnowledgeRepository) ListByProjectWithCursor(ctx context.Context, proje
 
 	if cursor != nil {
 			rows, err = r.db.Query(ctx,
-			`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+			`SELECT `+knowledgeColumns+`
 			 FROM knowledge 
 			 WHERE project_id = $1 AND (updated_at, id) < ($2, $3)
 			 ORDER BY updated_at DESC, id DESC
@@ -158,7 +161,7 @@ func (r *KnowledgeRepository) ListByProjectWithCursor(ctx context.Context, proje
 		)
 	} else {
 		rows, err = r.db.Query(ctx,
-			`SELECT id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at
+			`SELECT `+knowledgeColumns+`
 			 FROM knowledge 
 			 WHERE project_id = $1
 			 ORDER BY updated_at DESC, id DESC
@@ -195,12 +198,91 @@ func (r *KnowledgeRepository) ListByProjectWithCursor(ctx context.Context, proje
 	}, nil
 }
 
+// ListWithCursor lists knowledge items matching the given filter, newest first.
+func (r *KnowledgeRepository) ListWithCursor(ctx context.Context, filter service.KnowledgeListFilter, cursor *pagination.Cursor, limit int) (*service.KnowledgePageResult, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	args := []interface{}{filter.OrgID}
+	argIdx := 2
+	where := []string{"org_id = $1"}
+
+	if filter.ProjectID != "" {
+		where = append(where, fmt.Sprintf("project_id = $%d", argIdx))
+		args = append(args, filter.ProjectID)
+		argIdx++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = $%d", argIdx))
+		args = append(args, string(filter.Status))
+		argIdx++
+	}
+	if filter.Owner != "" {
+		where = append(where, fmt.Sprintf("owner = $%d", argIdx))
+		args = append(args, filter.Owner)
+		argIdx++
+	}
+	if filter.Stale {
+		where = append(where, "stale_since IS NOT NULL")
+	}
+	if cursor != nil {
+		where = append(where, fmt.Sprintf("(updated_at, id) < ($%d, $%d)", argIdx, argIdx+1))
+		args = append(args, cursor.Timestamp, cursor.LastID)
+		argIdx += 2
+	}
+
+	query := fmt.Sprintf(`SELECT `+knowledgeColumns+`
+		 FROM knowledge
+		 WHERE %s
+		 ORDER BY updated_at DESC, id DESC
+		 LIMIT $%d`, strings.Join(where, " AND "), argIdx)
+	args = append(args, limit+1)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items, err := scanKnowledgeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		lastItem := items[len(items)-1]
+		nextCursor = pagination.EncodeCursor(lastItem.ID, lastItem.UpdatedAt)
+	}
+
+	return &service.KnowledgePageResult{
+		Items:      items,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
 func (r *KnowledgeRepository) Update(ctx context.Context, k *domain.Knowledge) error {
 	k.UpdatedAt = time.Now().UTC()
 	cmdTag, err := r.db.Exec(ctx,
-		`UPDATE knowledge SET type = $1, status = $2, title = $3, summary = $4, body_md = $5, scope_path = $6, updated_at = $7
-		 WHERE id = $8`,
-		k.Type, k.Status, k.Title, k.Summary, k.BodyMD, nullableString(k.Scope), k.UpdatedAt, k.ID,
+		`UPDATE knowledge SET type = $1, status = $2, title = $3, summary = $4, body_md = $5, scope_path = $6, updated_at = $7,
+		                      reviewed_by = $8, reviewed_by_key_id = $9, reviewed_at = $10, review_note = $11, superseded_by = $12,
+		                      tags = $13, review_after = $14, owner = $15, stale_since = $16, language = $17,
+		                      updated_by = $18, updated_by_key_id = $19, source_path = $20, source_hash = $21,
+		                      summary_generated_by = $22, suggested_title = $23
+		 WHERE id = $24`,
+		k.Type, k.Status, k.Title, k.Summary, k.BodyMD, nullableString(k.Scope), k.UpdatedAt,
+		nullableString(k.ReviewedBy.Name), nullableString(k.ReviewedBy.APIKeyID), k.ReviewedAt, nullableString(k.ReviewNote),
+		nullableString(k.SupersededBy), nonNilTags(k.Tags),
+		k.ReviewAfter, nullableString(k.Owner), k.StaleSince, nullableString(k.Language),
+		nullableString(k.UpdatedBy.Name), nullableString(k.UpdatedBy.APIKeyID), nullableString(k.SourcePath), nullableString(k.SourceHash),
+		nullableString(k.SummaryGeneratedBy), nullableString(k.SuggestedTitle), k.ID,
 	)
 	if err != nil {
 		return err
@@ -208,7 +290,183 @@ func (r *KnowledgeRepository) Update(ctx context.Context, k *domain.Knowledge) e
 	if cmdTag.RowsAffected() == 0 {
 		return domain.ErrKnowledgeNotFound
 	}
-	return nil
+
+	// Keep denormalized chunk metadata in sync so status/type/tag/language/author filters apply before re-embedding.
+	_, err = r.db.Exec(ctx,
+		`UPDATE knowledge_chunks SET type = $1, status = $2, title = $3, summary = $4, scope_path = $5, tags = $6, language = $7,
+		                             created_by = $8, updated_by = $9
+		 WHERE knowledge_id = $10`,
+		k.Type, k.Status, k.Title, k.Summary, nullableString(k.Scope), nonNilTags(k.Tags), nullableString(k.Language),
+		nullableString(k.CreatedBy.Name), nullableString(k.UpdatedBy.Name), k.ID,
+	)
+	return err
+}
+
+// FlagStale marks items whose review-by date has passed as stale. Deprecated items
+// are skipped since they are no longer guidance anyone acts on.
+func (r *KnowledgeRepository) FlagStale(ctx context.Context, now time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx,
+		`UPDATE knowledge SET stale_since = $1
+		 WHERE stale_since IS NULL AND review_after <= $1 AND status <> $2`,
+		now, domain.KnowledgeStatusDeprecated,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// ClearStale removes the stale flag from items whose review-by date was moved
+// into the future or removed, or that were deprecated since they were flagged.
+func (r *KnowledgeRepository) ClearStale(ctx context.Context, now time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx,
+		`UPDATE knowledge SET stale_since = NULL
+		 WHERE stale_since IS NOT NULL AND (review_after IS NULL OR review_after > $1 OR status = $2)`,
+		now, domain.KnowledgeStatusDeprecated,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// ListMissingSummaries returns up to limit items without a summary that the summary
+// generation job has not looked at since they last changed, least recently changed first.
+// Deprecated items are skipped.
+func (r *KnowledgeRepository) ListMissingSummaries(ctx context.Context, limit int) ([]*domain.Knowledge, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+knowledgeColumns+`
+		 FROM knowledge
+		 WHERE coalesce(summary, '') = '' AND status <> $1 AND (generated_at IS NULL OR generated_at < updated_at)
+		 ORDER BY updated_at, id
+		 LIMIT $2`,
+		domain.KnowledgeStatusDeprecated, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanKnowledgeRows(rows)
+}
+
+// SaveGenerated stores the output of the summary generation job on an item, unless the item
+// was changed or given a summary since k was read, and reports whether it was stored. A
+// generated summary counts as a change of the item: it adds a version written by the
+// generator, moves updated_at, updates the chunk metadata and queues an embedding job,
+// since the summary is part of the embedded text. Everything happens in one statement so
+// that it is atomic without a transaction; a version added concurrently by an edit makes
+// it store nothing.
+func (r *KnowledgeRepository) SaveGenerated(ctx context.Context, k *domain.Knowledge, generated domain.GeneratedMetadata) (bool, error) {
+	var saved int64
+	err := r.db.QueryRow(ctx,
+		`WITH updated AS (
+		     UPDATE knowledge
+		        SET summary = $3, summary_generated_by = $4, suggested_title = $5, generated_at = $6,
+		            updated_at = CASE WHEN $3 = '' THEN updated_at ELSE $6 END
+		      WHERE id = $1 AND updated_at = $2 AND coalesce(summary, '') = ''
+		     RETURNING id, title, summary, body_md
+		 ), versioned AS (
+		     INSERT INTO knowledge_versions (id, knowledge_id, version_number, title, summary, body_md, created_at, created_by)
+		     SELECT gen_random_uuid(), id,
+		            coalesce((SELECT max(version_number) FROM knowledge_versions WHERE knowledge_id = updated.id), 0) + 1,
+		            title, summary, body_md, $6, $8
+		       FROM updated WHERE summary <> ''
+		 ), chunks AS (
+		     UPDATE knowledge_chunks SET summary = updated.summary
+		       FROM updated
+		      WHERE knowledge_chunks.knowledge_id = updated.id
+		 ), queued AS (
+		     INSERT INTO embedding_jobs (id, knowledge_id, status, retries, error, created_at)
+		     SELECT gen_random_uuid(), id, $7, 0, '', $6 FROM updated WHERE summary <> ''
+		 )
+		 SELECT count(*) FROM updated`,
+		k.ID, k.UpdatedAt, generated.Summary, nullableString(generatedBy(generated)), nullableString(generated.SuggestedTitle),
+		generated.GeneratedAt, domain.EmbeddingJobStatusPending, generated.Author().Name,
+	).Scan(&saved)
+	if isUniqueViolation(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return saved > 0, nil
+}
+
+// generatedBy is the model recorded for a generated summary, none when there is no summary
+func generatedBy(generated domain.GeneratedMetadata) string {
+	if generated.Summary == "" {
+		return ""
+	}
+	return generated.Model
+}
+
+// ListByScopePrefix returns the items of an organization, optionally limited to a project,
+// whose scope is the prefix or lies below it. Deprecated items are left out.
+func (r *KnowledgeRepository) ListByScopePrefix(ctx context.Context, orgID, projectID, prefix string) ([]*domain.Knowledge, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+knowledgeColumns+`
+		 FROM knowledge
+		 WHERE org_id = $1 AND ($2 = '' OR project_id::text = $2) AND status <> $3
+		   AND (scope_path = $4 OR scope_path LIKE $5 ESCAPE '\')
+		 ORDER BY scope_path, id`,
+		orgID, projectID, domain.KnowledgeStatusDeprecated, prefix, escapeLike(prefix)+"/%",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanKnowledgeRows(rows)
+}
+
+// ListWithSource returns the items of an organization and project that were imported from
+// files, ordered by source path. An empty projectID matches items without a project.
+// Deprecated items are included so that a re-import does not recreate them.
+func (r *KnowledgeRepository) ListWithSource(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+knowledgeColumns+`
+		 FROM knowledge
+		 WHERE org_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND source_path IS NOT NULL
+		 ORDER BY source_path, created_at`,
+		orgID, nullableString(projectID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanKnowledgeRows(rows)
+}
+
+// FindSimilar returns the embedded items of an organization and project whose cosine
+// similarity to the embedding is at least minSimilarity, most similar first. An empty
+// projectID matches items without a project. Deprecated items are left out.
+func (r *KnowledgeRepository) FindSimilar(ctx context.Context, orgID, projectID string, embedding []float32, minSimilarity float64, limit int) ([]*service.DuplicateCandidate, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT id, type, title, summary, scope_path, 1 - (embedding <=> $1) AS similarity
+		 FROM knowledge
+		 WHERE org_id = $2 AND project_id IS NOT DISTINCT FROM $3 AND status <> $4
+		   AND embedding IS NOT NULL AND embedding <=> $1 <= $5
+		 ORDER BY embedding <=> $1
+		 LIMIT $6`,
+		pgvector.NewVector(embedding), orgID, nullableString(projectID), domain.KnowledgeStatusDeprecated, 1-minSimilarity, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]*service.DuplicateCandidate, 0)
+	for rows.Next() {
+		var c service.DuplicateCandidate
+		var scope *string
+		if err := rows.Scan(&c.ID, &c.Type, &c.Title, &c.Summary, &scope, &c.Similarity); err != nil {
+			return nil, err
+		}
+		if scope != nil {
+			c.Scope = *scope
+		}
+		candidates = append(candidates, &c)
+	}
+	return candidates, rows.Err()
 }
 
 func (r *KnowledgeRepository) Delete(ctx context.Context, id string) error {
@@ -241,16 +499,20 @@ func (r *KnowledgeRepository) UpdateEmbedding(ctx context.Context, id string, em
 
 func (r *KnowledgeRepository) CreateVersion(ctx context.Context, v *domain.KnowledgeVersion) error {
 	_, err := r.db.Exec(ctx,
-		`INSERT INTO knowledge_versions (id, knowledge_id, version_number, title, summary, body_md, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		`INSERT INTO knowledge_versions (id, knowledge_id, version_number, title, summary, body_md, created_at, created_by, created_by_key_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
 		v.ID, v.KnowledgeID, v.VersionNumber, v.Title, v.Summary, v.BodyMD, v.CreatedAt,
+		nullableString(v.CreatedBy.Name), nullableString(v.CreatedBy.APIKeyID),
 	)
+	if isUniqueViolation(err) {
+		return domain.ErrVersionConflict
+	}
 	return err
 }
 
 func (r *KnowledgeRepository) GetVersions(ctx context.Context, knowledgeID string) ([]*domain.KnowledgeVersion, error) {
 	rows, err := r.db.Query(ctx,
-		`SELECT id, knowledge_id, version_number, title, summary, body_md, created_at
+		`SELECT `+versionColumns+`
 		 FROM knowledge_versions WHERE knowledge_id = $1 ORDER BY version_number DESC`,
 		knowledgeID,
 	)
@@ -261,46 +523,141 @@ func (r *KnowledgeRepository) GetVersions(ctx context.Context, knowledgeID strin
 
 	var versions []*domain.KnowledgeVersion
 	for rows.Next() {
-		var v domain.KnowledgeVersion
-		if err := rows.Scan(&v.ID, &v.KnowledgeID, &v.VersionNumber, &v.Title, &v.Summary, &v.BodyMD, &v.CreatedAt); err != nil {
+		v, err := scanVersion(rows)
+		if err != nil {
 			return nil, err
 		}
-		versions = append(versions, &v)
+		versions = append(versions, v)
 	}
 	return versions, rows.Err()
 }
 
+func (r *KnowledgeRepository) GetVersion(ctx context.Context, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error) {
+	v, err := scanVersion(r.db.QueryRow(ctx,
+		`SELECT `+versionColumns+`
+		 FROM knowledge_versions WHERE knowledge_id = $1 AND version_number = $2`,
+		knowledgeID, versionNumber,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrVersionNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
 func (r *KnowledgeRepository) GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error) {
-	var v domain.KnowledgeVersion
-	err := r.db.QueryRow(ctx,
-		`SELECT id, knowledge_id, version_number, title, summary, body_md, created_at
+	v, err := scanVersion(r.db.QueryRow(ctx,
+		`SELECT `+versionColumns+`
 		 FROM knowledge_versions WHERE knowledge_id = $1 ORDER BY version_number DESC LIMIT 1`,
 		knowledgeID,
-	).Scan(&v.ID, &v.KnowledgeID, &v.VersionNumber, &v.Title, &v.Summary, &v.BodyMD, &v.CreatedAt)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrKnowledgeNotFound
 		}
 		return nil, err
 	}
+	return v, nil
+}
+
+// versionColumns is the column list read by scanVersion.
+const versionColumns = `id, knowledge_id, version_number, title, summary, body_md, created_at, created_by, created_by_key_id`
+
+func scanVersion(row rowScanner) (*domain.KnowledgeVersion, error) {
+	var v domain.KnowledgeVersion
+	var createdBy, createdByKeyID *string
+	if err := row.Scan(&v.ID, &v.KnowledgeID, &v.VersionNumber, &v.Title, &v.Summary, &v.BodyMD, &v.CreatedAt, &createdBy, &createdByKeyID); err != nil {
+		return nil, err
+	}
+	v.CreatedBy = scanAuthor(createdBy, createdByKeyID)
 	return &v, nil
 }
 
+func scanAuthor(name, apiKeyID *string) domain.Author {
+	var a domain.Author
+	if name != nil {
+		a.Name = *name
+	}
+	if apiKeyID != nil {
+		a.APIKeyID = *apiKeyID
+	}
+	return a
+}
+
+// knowledgeColumns is the column list read by scanKnowledge.
+const knowledgeColumns = `id, org_id, project_id, type, status, title, summary, body_md, scope_path, created_at, updated_at,
+		 reviewed_by, reviewed_by_key_id, reviewed_at, review_note, superseded_by, tags, review_after, owner, stale_since,
+		 template_id, template_version, language, created_by, created_by_key_id, updated_by, updated_by_key_id,
+		 source_path, source_hash, summary_generated_by, suggested_title`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanKnowledge(row rowScanner) (*domain.Knowledge, error) {
+	var k domain.Knowledge
+	var projectID, scope, reviewNote, supersededBy, owner, templateID, language *string
+	var reviewedBy, reviewedByKeyID, createdBy, createdByKeyID, updatedBy, updatedByKeyID *string
+	var sourcePath, sourceHash, summaryGeneratedBy, suggestedTitle *string
+	var templateVersion *int64
+	if err := row.Scan(&k.ID, &k.OrgID, &projectID, &k.Type, &k.Status, &k.Title, &k.Summary, &k.BodyMD, &scope, &k.CreatedAt, &k.UpdatedAt,
+		&reviewedBy, &reviewedByKeyID, &k.ReviewedAt, &reviewNote, &supersededBy, &k.Tags, &k.ReviewAfter, &owner, &k.StaleSince,
+		&templateID, &templateVersion, &language, &createdBy, &createdByKeyID, &updatedBy, &updatedByKeyID,
+		&sourcePath, &sourceHash, &summaryGeneratedBy, &suggestedTitle); err != nil {
+		return nil, err
+	}
+	if projectID != nil {
+		k.ProjectID = *projectID
+	}
+	if scope != nil {
+		k.Scope = *scope
+	}
+	if reviewNote != nil {
+		k.ReviewNote = *reviewNote
+	}
+	if supersededBy != nil {
+		k.SupersededBy = *supersededBy
+	}
+	if owner != nil {
+		k.Owner = *owner
+	}
+	if templateID != nil {
+		k.TemplateID = *templateID
+	}
+	if templateVersion != nil {
+		k.TemplateVersion = *templateVersion
+	}
+	if language != nil {
+		k.Language = *language
+	}
+	if sourcePath != nil {
+		k.SourcePath = *sourcePath
+	}
+	if sourceHash != nil {
+		k.SourceHash = *sourceHash
+	}
+	if summaryGeneratedBy != nil {
+		k.SummaryGeneratedBy = *summaryGeneratedBy
+	}
+	if suggestedTitle != nil {
+		k.SuggestedTitle = *suggestedTitle
+	}
+	k.ReviewedBy = scanAuthor(reviewedBy, reviewedByKeyID)
+	k.CreatedBy = scanAuthor(createdBy, createdByKeyID)
+	k.UpdatedBy = scanAuthor(updatedBy, updatedByKeyID)
+	return &k, nil
+}
+
 func scanKnowledgeRows(rows pgx.Rows) ([]*domain.Knowledge, error) {
 	var results []*domain.Knowledge
 	for rows.Next() {
-		var k domain.Knowledge
-		var projectID, scope *string
-		if err := rows.Scan(&k.ID, &k.OrgID, &projectID, &k.Type, &k.Status, &k.Title, &k.Summary, &k.BodyMD, &scope, &k.CreatedAt, &k.UpdatedAt); err != nil {
+		k, err := scanKnowledge(rows)
+		if err != nil {
 			return nil, err
 		}
-		if projectID != nil {
-			k.ProjectID = *projectID
-		}
-		if scope != nil {
-			k.Scope = *scope
-		}
-		results = append(results, &k)
+		results = append(results, k)
 	}
 	return results, rows.Err()
 }
@@ -311,3 +668,24 @@ func nullableString(s string) *string {
 	}
 	return &s
 }
+
+// nullableVersion stores an unset (zero) version number as NULL
+func nullableVersion(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// nonNilTags keeps NOT NULL tag columns from receiving NULL for untagged items
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -102,9 +102,10 @@ func (r *KnowledgeChunkRepository) ReplaceChunks(ctx context.Context, knowledgeI
 		}
 		_, err := r.db.Exec(ctx,
 			`INSERT INTO knowledge_chunks
-				(knowledge_id, org_id, project_id, type, status, title, summary, scope_path, chunk_index, content, embedding, created_at, updated_at)
+				(knowledge_id, org_id, project_id, type, status, title, summary, scope_path, tags, language, created_by, updated_by,
+				 chunk_index, content, embedding, created_at, updated_at)
 			 VALUES
-				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
 			c.KnowledgeID,
 			c.OrgID,
 			nullableString(c.ProjectID),
@@ -113,6 +114,10 @@ func (r *KnowledgeChunkRepository) ReplaceChunks(ctx context.Context, knowledgeI
 			c.Title,
 			c.Summary,
 			nullableString(c.Scope),
+			nonNilTags(c.Tags),
+			nullableString(c.Language),
+			nullableString(c.CreatedBy),
+			nullableString(c.UpdatedBy),
 			c.ChunkIndex,
 			c.Content,
 			pgvector.NewVector(c.Embedding),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
 	"github.com/cloo-solutions/neotexai/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -234,6 +235,7 @@ func TestKnowledgeRepository_Update(t *testing.T) {
 
 	k.Title = "Updated"
 	k.Status = domain.KnowledgeStatusApproved
+	k.ReviewedBy = domain.Author{APIKeyID: uuid.New().String(), Name: "alice"}
 	err := knowledgeRepo.Update(ctx, k)
 	require.NoError(t, err)
 
@@ -241,6 +243,7 @@ func TestKnowledgeRepository_Update(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Updated", retrieved.Title)
 	assert.Equal(t, domain.KnowledgeStatusApproved, retrieved.Status)
+	assert.Equal(t, k.ReviewedBy, retrieved.ReviewedBy)
 }
 
 func TestKnowledgeRepository_Update_NotFound(t *testing.T) {
@@ -459,3 +462,386 @@ func TestKnowledgeRepository_GetLatestVersion_NotFound(t *testing.T) {
 	_, err := knowledgeRepo.GetLatestVersion(ctx, uuid.NewString())
 	assert.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
 }
+
+func TestKnowledgeRepository_GetVersion(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org := &domain.Organization{ID: uuid.NewString(), Name: "Org", CreatedAt: time.Now().UTC().Truncate(time.Microsecond)}
+	require.NoError(t, orgRepo.Create(ctx, org))
+
+	k := &domain.Knowledge{
+		ID:        uuid.NewString(),
+		OrgID:     org.ID,
+		Type:      domain.KnowledgeTypeGuideline,
+		Status:    domain.KnowledgeStatusDraft,
+		Title:     "Versioned Knowledge",
+		BodyMD:    "Body",
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	require.NoError(t, knowledgeRepo.Create(ctx, k))
+
+	for i := int64(1); i <= 2; i++ {
+		v := &domain.KnowledgeVersion{
+			ID:            uuid.NewString(),
+			KnowledgeID:   k.ID,
+			VersionNumber: i,
+			Title:         "Title v" + string(rune('0'+i)),
+			BodyMD:        "Body v" + string(rune('0'+i)),
+			CreatedAt:     time.Now().UTC().Truncate(time.Microsecond),
+		}
+		require.NoError(t, knowledgeRepo.CreateVersion(ctx, v))
+	}
+
+	v1, err := knowledgeRepo.GetVersion(ctx, k.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Title v1", v1.Title)
+	assert.Equal(t, "Body v1", v1.BodyMD)
+
+	_, err = knowledgeRepo.GetVersion(ctx, k.ID, 3)
+	assert.ErrorIs(t, err, domain.ErrVersionNotFound)
+}
+
+func TestKnowledgeRepository_FlagStale(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	create := func(title string, reviewAfter *time.Time) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:          uuid.NewString(),
+			OrgID:       org.ID,
+			Type:        domain.KnowledgeTypeGuideline,
+			Status:      domain.KnowledgeStatusApproved,
+			Title:       title,
+			BodyMD:      "# " + title,
+			ReviewAfter: reviewAfter,
+			Owner:       "platform-team",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+	overdue := create("Overdue", &past)
+	current := create("Current", &future)
+	create("No review date", nil)
+
+	flagged, err := knowledgeRepo.FlagStale(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), flagged)
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, overdue.ID)
+	require.NoError(t, err)
+	assert.True(t, retrieved.IsStale())
+	assert.Equal(t, "platform-team", retrieved.Owner)
+
+	retrieved, err = knowledgeRepo.GetByID(ctx, current.ID)
+	require.NoError(t, err)
+	assert.False(t, retrieved.IsStale())
+
+	page, err := knowledgeRepo.ListWithCursor(ctx, service.KnowledgeListFilter{OrgID: org.ID, Owner: "platform-team", Stale: true}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, overdue.ID, page.Items[0].ID)
+
+	// Moving the date past the check time clears the flag on the next run
+	_, err = pool.Exec(ctx, "UPDATE knowledge SET review_after = $1 WHERE id = $2", future, overdue.ID)
+	require.NoError(t, err)
+
+	cleared, err := knowledgeRepo.ClearStale(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cleared)
+}
+
+func TestKnowledgeRepository_TemplateProvenance(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	template := &domain.Knowledge{
+		ID:        uuid.NewString(),
+		OrgID:     org.ID,
+		Type:      domain.KnowledgeTypeTemplate,
+		Status:    domain.KnowledgeStatusApproved,
+		Title:     "Postmortem template",
+		BodyMD:    "# {{service}} outage",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	require.NoError(t, knowledgeRepo.Create(ctx, template))
+
+	instance := &domain.Knowledge{
+		ID:              uuid.NewString(),
+		OrgID:           org.ID,
+		Type:            domain.KnowledgeTypeLearning,
+		Status:          domain.KnowledgeStatusDraft,
+		Title:           "Checkout outage",
+		BodyMD:          "# checkout outage",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		TemplateID:      template.ID,
+		TemplateVersion: 2,
+	}
+	require.NoError(t, knowledgeRepo.Create(ctx, instance))
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, instance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, template.ID, retrieved.TemplateID)
+	assert.Equal(t, int64(2), retrieved.TemplateVersion)
+
+	retrieved, err = knowledgeRepo.GetByID(ctx, template.ID)
+	require.NoError(t, err)
+	assert.False(t, retrieved.IsFromTemplate())
+	assert.Zero(t, retrieved.TemplateVersion)
+}
+
+func TestKnowledgeRepository_ListByScopePrefix(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	create := func(scope string, status domain.KnowledgeStatus, projectID string) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			ProjectID: projectID,
+			Type:      domain.KnowledgeTypeGuideline,
+			Status:    status,
+			Title:     scope,
+			BodyMD:    "Body",
+			Scope:     scope,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	exact := create("services/auth", domain.KnowledgeStatusApproved, project.ID)
+	nested := create("services/auth/handlers/*.go", domain.KnowledgeStatusDraft, "")
+	create("services/authz", domain.KnowledgeStatusApproved, project.ID)
+	create("services/auth/old", domain.KnowledgeStatusDeprecated, project.ID)
+	create("services_auth/x", domain.KnowledgeStatusApproved, project.ID)
+
+	items, err := knowledgeRepo.ListByScopePrefix(ctx, org.ID, "", "services/auth")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, exact.ID, items[0].ID)
+	assert.Equal(t, nested.ID, items[1].ID)
+
+	items, err = knowledgeRepo.ListByScopePrefix(ctx, org.ID, project.ID, "services/auth")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, exact.ID, items[0].ID)
+
+	// LIKE wildcards in the prefix match literally
+	items, err = knowledgeRepo.ListByScopePrefix(ctx, org.ID, "", "services_auth")
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestKnowledgeRepository_FindSimilar(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	// unitVector points mostly along the first axis, tilted toward the second by tilt
+	unitVector := func(tilt float32) []float32 {
+		v := make([]float32, 1536)
+		v[0] = 1
+		v[1] = tilt
+		return v
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	create := func(title string, status domain.KnowledgeStatus, projectID string, embedding []float32) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			ProjectID: projectID,
+			Type:      domain.KnowledgeTypeLearning,
+			Status:    status,
+			Title:     title,
+			BodyMD:    "Body",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		if embedding != nil {
+			require.NoError(t, knowledgeRepo.UpdateEmbedding(ctx, k.ID, embedding))
+		}
+		return k
+	}
+
+	similar := create("Similar", domain.KnowledgeStatusApproved, project.ID, unitVector(0.1))
+	create("Far", domain.KnowledgeStatusApproved, project.ID, unitVector(5))
+	create("Deprecated", domain.KnowledgeStatusDeprecated, project.ID, unitVector(0))
+	create("Other project", domain.KnowledgeStatusApproved, "", unitVector(0))
+	create("Not embedded", domain.KnowledgeStatusApproved, project.ID, nil)
+
+	candidates, err := knowledgeRepo.FindSimilar(ctx, org.ID, project.ID, unitVector(0), 0.9, 5)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, similar.ID, candidates[0].ID)
+	assert.Equal(t, domain.KnowledgeTypeLearning, candidates[0].Type)
+	assert.InDelta(t, 0.995, candidates[0].Similarity, 0.001)
+
+	candidates, err = knowledgeRepo.FindSimilar(ctx, org.ID, "", unitVector(0), 0.9, 5)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "Other project", candidates[0].Title)
+}
+
+func TestKnowledgeRepository_SaveGenerated(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	create := func(title, summary string) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			Type:      domain.KnowledgeTypeGuideline,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			Summary:   summary,
+			BodyMD:    "# " + title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	missing := create("Deploys", "")
+	empty := create("Empty", "")
+	create("Summarized", "Already has one")
+	require.NoError(t, knowledgeRepo.CreateVersion(ctx, &domain.KnowledgeVersion{
+		ID:            uuid.NewString(),
+		KnowledgeID:   missing.ID,
+		VersionNumber: 1,
+		Title:         missing.Title,
+		BodyMD:        missing.BodyMD,
+		CreatedAt:     now,
+		CreatedBy:     domain.Author{Name: "jane", APIKeyID: uuid.NewString()},
+	}))
+
+	items, err := knowledgeRepo.ListMissingSummaries(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.ElementsMatch(t, []string{missing.ID, empty.ID}, []string{items[0].ID, items[1].ID})
+
+	generatedAt := now.Add(time.Minute)
+	saved, err := knowledgeRepo.SaveGenerated(ctx, missing, domain.GeneratedMetadata{
+		Summary:        "Deploys go out on Tuesdays.",
+		SuggestedTitle: "Tuesday deploys",
+		Model:          "stub",
+		GeneratedAt:    generatedAt,
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	saved, err = knowledgeRepo.SaveGenerated(ctx, empty, domain.GeneratedMetadata{Model: "stub", GeneratedAt: generatedAt})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	retrieved, err := knowledgeRepo.GetByID(ctx, missing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Deploys go out on Tuesdays.", retrieved.Summary)
+	assert.Equal(t, "stub", retrieved.SummaryGeneratedBy)
+	assert.Equal(t, "Tuesday deploys", retrieved.SuggestedTitle)
+	assert.Equal(t, "Deploys", retrieved.Title)
+	assert.Equal(t, generatedAt, retrieved.UpdatedAt)
+
+	retrieved, err = knowledgeRepo.GetByID(ctx, empty.ID)
+	require.NoError(t, err)
+	assert.Empty(t, retrieved.Summary)
+	assert.Empty(t, retrieved.SummaryGeneratedBy)
+
+	var jobs int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM embedding_jobs WHERE knowledge_id = $1`, missing.ID).Scan(&jobs))
+	assert.Equal(t, 1, jobs)
+
+	// The generated summary is a new version, so If-Match and the history see it
+	latest, err := knowledgeRepo.GetLatestVersion(ctx, missing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), latest.VersionNumber)
+	assert.Equal(t, "Deploys go out on Tuesdays.", latest.Summary)
+	assert.Equal(t, "# Deploys", latest.BodyMD)
+	assert.Equal(t, domain.Author{Name: "summary-generator:stub"}, latest.CreatedBy)
+
+	versions, err := knowledgeRepo.GetVersions(ctx, empty.ID)
+	require.NoError(t, err)
+	assert.Empty(t, versions, "no summary, no version")
+
+	// Both items were looked at since they last changed
+	items, err = knowledgeRepo.ListMissingSummaries(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// A stale read does not overwrite the item
+	saved, err = knowledgeRepo.SaveGenerated(ctx, missing, domain.GeneratedMetadata{Summary: "Other", Model: "stub", GeneratedAt: generatedAt})
+	require.NoError(t, err)
+	assert.False(t, saved)
+}
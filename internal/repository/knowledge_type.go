@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type KnowledgeTypeRepository struct {
+	db dbtx
+}
+
+func NewKnowledgeTypeRepository(pool *pgxpool.Pool) *KnowledgeTypeRepository {
+	return &KnowledgeTypeRepository{db: pool}
+}
+
+func NewKnowledgeTypeRepositoryWithTx(tx pgx.Tx) *KnowledgeTypeRepository {
+	return &KnowledgeTypeRepository{db: tx}
+}
+
+const knowledgeTypeColumns = `id, org_id, name, display_name, required_sections, search_boost, created_at, updated_at`
+
+func scanKnowledgeType(row pgx.Row) (*domain.KnowledgeTypeDefinition, error) {
+	var d domain.KnowledgeTypeDefinition
+	if err := row.Scan(&d.ID, &d.OrgID, &d.Name, &d.DisplayName, &d.RequiredSections, &d.SearchBoost, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *KnowledgeTypeRepository) Create(ctx context.Context, d *domain.KnowledgeTypeDefinition) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO knowledge_types (id, org_id, name, display_name, required_sections, search_boost, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		d.ID, d.OrgID, d.Name, d.DisplayName, nonNilSections(d.RequiredSections), d.SearchBoost, d.CreatedAt, d.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrKnowledgeTypeAlreadyExists
+	}
+	return err
+}
+
+func (r *KnowledgeTypeRepository) GetByName(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error) {
+	d, err := scanKnowledgeType(r.db.QueryRow(ctx,
+		`SELECT `+knowledgeTypeColumns+` FROM knowledge_types WHERE org_id = $1 AND name = $2`,
+		orgID, name,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrKnowledgeTypeNotFound
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *KnowledgeTypeRepository) ListByOrg(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+knowledgeTypeColumns+` FROM knowledge_types WHERE org_id = $1 ORDER BY name`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]*domain.KnowledgeTypeDefinition, 0)
+	for rows.Next() {
+		d, err := scanKnowledgeType(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, d)
+	}
+	return results, rows.Err()
+}
+
+func (r *KnowledgeTypeRepository) Update(ctx context.Context, d *domain.KnowledgeTypeDefinition) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE knowledge_types
+		 SET display_name = $1, required_sections = $2, search_boost = $3, updated_at = $4
+		 WHERE org_id = $5 AND name = $6`,
+		d.DisplayName, nonNilSections(d.RequiredSections), d.SearchBoost, d.UpdatedAt, d.OrgID, d.Name,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrKnowledgeTypeNotFound
+	}
+	return nil
+}
+
+func (r *KnowledgeTypeRepository) Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM knowledge_types WHERE org_id = $1 AND name = $2`,
+		orgID, name,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrKnowledgeTypeNotFound
+	}
+	return nil
+}
+
+// CountKnowledge returns how many knowledge items of the org use the type
+func (r *KnowledgeTypeRepository) CountKnowledge(ctx context.Context, orgID string, name domain.KnowledgeType) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM knowledge WHERE org_id = $1 AND type = $2`,
+		orgID, name,
+	).Scan(&count)
+	return count, err
+}
+
+// nonNilSections keeps the NOT NULL required_sections column from receiving NULL
+func nonNilSections(sections []string) []string {
+	if sections == nil {
+		return []string{}
+	}
+	return sections
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKnowledgeTypeDefinition(orgID string, name domain.KnowledgeType) *domain.KnowledgeTypeDefinition {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return &domain.KnowledgeTypeDefinition{
+		ID:               uuid.NewString(),
+		OrgID:            orgID,
+		Name:             name,
+		DisplayName:      "Runbook",
+		RequiredSections: []string{"Steps", "Rollback"},
+		SearchBoost:      1.5,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+func TestKnowledgeTypeRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	typeRepo := NewKnowledgeTypeRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	def := newKnowledgeTypeDefinition(org.ID, "runbook")
+	require.NoError(t, typeRepo.Create(ctx, def))
+
+	err := typeRepo.Create(ctx, newKnowledgeTypeDefinition(org.ID, "runbook"))
+	assert.ErrorIs(t, err, domain.ErrKnowledgeTypeAlreadyExists)
+
+	retrieved, err := typeRepo.GetByName(ctx, org.ID, "runbook")
+	require.NoError(t, err)
+	assert.Equal(t, def.ID, retrieved.ID)
+	assert.Equal(t, []string{"Steps", "Rollback"}, retrieved.RequiredSections)
+	assert.Equal(t, float32(1.5), retrieved.SearchBoost)
+
+	retrieved.RequiredSections = nil
+	retrieved.SearchBoost = 2
+	require.NoError(t, typeRepo.Update(ctx, retrieved))
+
+	types, err := typeRepo.ListByOrg(ctx, org.ID)
+	require.NoError(t, err)
+	require.Len(t, types, 1)
+	assert.Empty(t, types[0].RequiredSections)
+	assert.Equal(t, float32(2), types[0].SearchBoost)
+
+	require.NoError(t, typeRepo.Delete(ctx, org.ID, "runbook"))
+	_, err = typeRepo.GetByName(ctx, org.ID, "runbook")
+	assert.ErrorIs(t, err, domain.ErrKnowledgeTypeNotFound)
+	assert.ErrorIs(t, typeRepo.Delete(ctx, org.ID, "runbook"), domain.ErrKnowledgeTypeNotFound)
+}
+
+func TestKnowledgeTypeRepository_CountKnowledge(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	typeRepo := NewKnowledgeTypeRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	require.NoError(t, typeRepo.Create(ctx, newKnowledgeTypeDefinition(org.ID, "runbook")))
+
+	count, err := typeRepo.CountKnowledge(ctx, org.ID, "runbook")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	k := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "Restart the API")
+	k.Type = "runbook"
+	require.NoError(t, knowledgeRepo.Update(ctx, k))
+
+	count, err = typeRepo.CountKnowledge(ctx, org.ID, "runbook")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LintConfigRepository struct {
+	db dbtx
+}
+
+func NewLintConfigRepository(pool *pgxpool.Pool) *LintConfigRepository {
+	return &LintConfigRepository{db: pool}
+}
+
+func NewLintConfigRepositoryWithTx(tx pgx.Tx) *LintConfigRepository {
+	return &LintConfigRepository{db: tx}
+}
+
+// Get returns the lint configuration of an org, or nil if it has not set one
+func (r *LintConfigRepository) Get(ctx context.Context, orgID string) (*domain.LintConfig, error) {
+	var config domain.LintConfig
+	err := r.db.QueryRow(ctx,
+		`SELECT config FROM lint_configs WHERE org_id = $1`,
+		orgID,
+	).Scan(&config)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Save stores the lint configuration of an org, replacing the one it had
+func (r *LintConfigRepository) Save(ctx context.Context, orgID string, config *domain.LintConfig, updatedAt time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO lint_configs (org_id, config, updated_at)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (org_id) DO UPDATE SET config = EXCLUDED.config, updated_at = EXCLUDED.updated_at`,
+		orgID, config, updatedAt,
+	)
+	return err
+}
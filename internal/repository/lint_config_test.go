@@ -0,0 +1,52 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfigRepository_GetSave(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	lintRepo := NewLintConfigRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+
+	config, err := lintRepo.Get(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	saved := &domain.LintConfig{
+		Mode: domain.LintModeReject,
+		Rules: map[string]domain.LintRule{
+			domain.LintRuleMaxBodySize:    {Severity: domain.LintSeverityWarning, MaxBytes: 1024},
+			domain.LintRuleNoPlaceholders: {Placeholders: []string{"WIP"}},
+		},
+	}
+	require.NoError(t, lintRepo.Save(ctx, org.ID, saved, time.Now().UTC()))
+
+	config, err = lintRepo.Get(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, config)
+
+	require.NoError(t, lintRepo.Save(ctx, org.ID, &domain.LintConfig{Mode: domain.LintModeOff}, time.Now().UTC()))
+
+	config, err = lintRepo.Get(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.LintConfig{Mode: domain.LintModeOff}, config)
+}
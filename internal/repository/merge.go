@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// KnowledgeMergeRepository moves the references of a merged knowledge item to its target.
+type KnowledgeMergeRepository struct {
+	db dbtx
+}
+
+func NewKnowledgeMergeRepository(pool *pgxpool.Pool) *KnowledgeMergeRepository {
+	return &KnowledgeMergeRepository{db: pool}
+}
+
+func NewKnowledgeMergeRepositoryWithTx(tx pgx.Tx) *KnowledgeMergeRepository {
+	return &KnowledgeMergeRepository{db: tx}
+}
+
+// MoveReferences re-points asset links, relations and search logs from source to target.
+// Links and relations the target already has are dropped instead of duplicated, as are
+// relations that would point the target at itself. It should run in the transaction that
+// deprecates the source.
+func (r *KnowledgeMergeRepository) MoveReferences(ctx context.Context, orgID, sourceID, targetID string) (*service.MovedReferences, error) {
+	moved := &service.MovedReferences{}
+
+	result, err := r.db.Exec(ctx,
+		`INSERT INTO knowledge_assets (knowledge_id, asset_id)
+		 SELECT $2::uuid, asset_id FROM knowledge_assets WHERE knowledge_id = $1
+		 ON CONFLICT DO NOTHING`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	moved.AssetLinks = int(result.RowsAffected())
+	if _, err := r.db.Exec(ctx, `DELETE FROM knowledge_assets WHERE knowledge_id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+
+	relationMoves := []string{
+		`UPDATE knowledge_relations rel SET source_id = $2
+		 WHERE rel.source_id = $1 AND rel.target_id <> $2
+		   AND NOT EXISTS (
+		       SELECT 1 FROM knowledge_relations o
+		       WHERE o.source_id = $2 AND o.target_id = rel.target_id AND o.relation_type = rel.relation_type)`,
+		`UPDATE knowledge_relations rel SET target_id = $2
+		 WHERE rel.target_id = $1 AND rel.source_id <> $2
+		   AND NOT EXISTS (
+		       SELECT 1 FROM knowledge_relations o
+		       WHERE o.target_id = $2 AND o.source_id = rel.source_id AND o.relation_type = rel.relation_type)`,
+	}
+	for _, query := range relationMoves {
+		result, err := r.db.Exec(ctx, query, sourceID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		moved.Relations += int(result.RowsAffected())
+	}
+	if _, err := r.db.Exec(ctx,
+		`DELETE FROM knowledge_relations WHERE source_id = $1 OR target_id = $1`,
+		sourceID,
+	); err != nil {
+		return nil, err
+	}
+
+	// Result entries of the source are renamed to the target, or dropped when the target is
+	// already listed. $1 and $2 are the source and target IDs as uuid, $3 and $4 as text.
+	result, err = r.db.Exec(ctx,
+		`UPDATE search_logs
+		 SET results = CASE WHEN jsonb_typeof(results) = 'array' THEN
+		                   (SELECT coalesce(jsonb_agg(
+		                               CASE WHEN e->>'id' = $3 THEN jsonb_set(e, '{id}', to_jsonb($4::text)) ELSE e END
+		                               ORDER BY n), '[]'::jsonb)
+		                    FROM jsonb_array_elements(results) WITH ORDINALITY AS r(e, n)
+		                    WHERE NOT (e->>'id' = $3 AND results @> jsonb_build_array(jsonb_build_object('id', $4::text))))
+		               ELSE results END,
+		     result_count = CASE WHEN jsonb_typeof(results) = 'array' THEN
+		                   (SELECT count(*) FROM jsonb_array_elements(results) e
+		                    WHERE NOT (e->>'id' = $3 AND results @> jsonb_build_array(jsonb_build_object('id', $4::text))))
+		               ELSE result_count END,
+		     chosen_id = CASE WHEN chosen_id = $1 THEN $2 ELSE chosen_id END
+		 WHERE org_id = $5
+		   AND (chosen_id = $1 OR results @> jsonb_build_array(jsonb_build_object('id', $3::text)))`,
+		sourceID, targetID, sourceID, targetID, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	moved.SearchLogs = int(result.RowsAffected())
+
+	return moved, nil
+}
@@ -0,0 +1,129 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeMergeRepository_MoveReferences(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	assetRepo := NewAssetRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+	searchLogRepo := NewSearchLogRepository(pool)
+	mergeRepo := NewKnowledgeMergeRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	create := func(title string) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			ProjectID: project.ID,
+			Type:      domain.KnowledgeTypeLearning,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	target := create("Retry flaky tests")
+	source := create("Retry flaky tests once")
+	other := create("CI timeouts")
+
+	for i, linkTarget := range []bool{true, false} {
+		asset := &domain.Asset{
+			ID:         uuid.NewString(),
+			OrgID:      org.ID,
+			Filename:   "log.txt",
+			MimeType:   "text/plain",
+			SHA256:     uuid.NewString(),
+			StorageKey: "bucket/log.txt" + uuid.NewString(),
+			CreatedAt:  now.Add(time.Duration(i) * time.Second),
+		}
+		require.NoError(t, assetRepo.Create(ctx, asset))
+		require.NoError(t, assetRepo.LinkToKnowledge(ctx, source.ID, asset.ID))
+		if linkTarget {
+			require.NoError(t, assetRepo.LinkToKnowledge(ctx, target.ID, asset.ID))
+		}
+	}
+
+	relate := func(from, to string) {
+		require.NoError(t, relationRepo.Create(ctx, domain.NewKnowledgeRelation(uuid.NewString(), org.ID, from, to, domain.RelationTypeRelatesTo, now)))
+	}
+	relate(source.ID, other.ID)  // moves to target -> other
+	relate(other.ID, source.ID)  // already exists as other -> target, dropped
+	relate(other.ID, target.ID)  // kept
+	relate(source.ID, target.ID) // would relate the target to itself, dropped
+
+	searchID, err := searchLogRepo.CreateSearchLog(ctx, service.SearchLogEntry{
+		OrgID: org.ID,
+		Query: "flaky",
+		Results: []service.SearchLogResult{
+			{ID: source.ID, SourceType: "knowledge", Score: 0.9},
+			{ID: target.ID, SourceType: "knowledge", Score: 0.8},
+			{ID: other.ID, SourceType: "knowledge", Score: 0.5},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, searchLogRepo.RecordSearchSelection(ctx, org.ID, searchID, source.ID, "knowledge"))
+
+	moved, err := mergeRepo.MoveReferences(ctx, org.ID, source.ID, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved.AssetLinks) // the other asset was already linked to the target
+	assert.Equal(t, 1, moved.Relations)
+	assert.Equal(t, 1, moved.SearchLogs)
+
+	assets, err := assetRepo.ListByKnowledge(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Len(t, assets, 2)
+
+	var sourceRefs int
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT (SELECT count(*) FROM knowledge_assets WHERE knowledge_id = $1) +
+		        (SELECT count(*) FROM knowledge_relations WHERE source_id = $1 OR target_id = $1)`,
+		source.ID,
+	).Scan(&sourceRefs))
+	assert.Zero(t, sourceRefs)
+
+	var targetRelations int
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT count(*) FROM knowledge_relations WHERE source_id = $1 OR target_id = $1`, target.ID,
+	).Scan(&targetRelations))
+	assert.Equal(t, 2, targetRelations)
+
+	var resultCount int
+	var chosenID string
+	var results string
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT result_count, chosen_id::text, results::text FROM search_logs WHERE id = $1`, searchID,
+	).Scan(&resultCount, &chosenID, &results))
+	assert.Equal(t, 2, resultCount)
+	assert.Equal(t, target.ID, chosenID)
+	assert.NotContains(t, results, source.ID)
+	assert.Contains(t, results, target.ID)
+	assert.Contains(t, results, other.ID)
+}
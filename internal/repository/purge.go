@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// KnowledgePurgeRepository hard-deletes knowledge items for erasure requests.
+type KnowledgePurgeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewKnowledgePurgeRepository(pool *pgxpool.Pool) *KnowledgePurgeRepository {
+	return &KnowledgePurgeRepository{pool: pool}
+}
+
+// Purge removes a knowledge item with everything derived from it and records the
+// tombstone in the same transaction. The tombstone's org, project, type and counts are
+// filled in from the purged rows. Relations, checklist runs and comments go with the item
+// through ON DELETE CASCADE; supersession and template links pointing at it are cleared,
+// and stored idempotent responses that mention it are deleted.
+func (r *KnowledgePurgeRepository) Purge(ctx context.Context, t *domain.KnowledgeTombstone) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var projectID *string
+	err = tx.QueryRow(ctx,
+		`SELECT org_id, project_id, type FROM knowledge WHERE id = $1 FOR UPDATE`,
+		t.KnowledgeID,
+	).Scan(&t.OrgID, &projectID, &t.Type)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrKnowledgeNotFound
+		}
+		return err
+	}
+	if projectID != nil {
+		t.ProjectID = *projectID
+	}
+
+	deletes := []struct {
+		query string
+		count *int
+	}{
+		{`DELETE FROM embedding_jobs WHERE knowledge_id = $1`, &t.EmbeddingJobs},
+		{`DELETE FROM knowledge_assets WHERE knowledge_id = $1`, &t.AssetLinks},
+		{`DELETE FROM knowledge_chunks WHERE knowledge_id = $1`, &t.Chunks},
+		{`DELETE FROM knowledge_versions WHERE knowledge_id = $1`, &t.Versions},
+	}
+	for _, d := range deletes {
+		result, err := tx.Exec(ctx, d.query, t.KnowledgeID)
+		if err != nil {
+			return err
+		}
+		*d.count = int(result.RowsAffected())
+	}
+
+	// Search logs keep the rest of their results; only entries for the item and a
+	// recorded selection of it are dropped. $1 is the ID as uuid, $2 as text.
+	result, err := tx.Exec(ctx,
+		`UPDATE search_logs
+		 SET results = CASE WHEN jsonb_typeof(results) = 'array' THEN
+		                   (SELECT coalesce(jsonb_agg(e), '[]'::jsonb) FROM jsonb_array_elements(results) e WHERE e->>'id' IS DISTINCT FROM $2)
+		               ELSE results END,
+		     result_count = CASE WHEN jsonb_typeof(results) = 'array' THEN
+		                   (SELECT count(*) FROM jsonb_array_elements(results) e WHERE e->>'id' IS DISTINCT FROM $2)
+		               ELSE result_count END,
+		     chosen_id = CASE WHEN chosen_id = $1 THEN NULL ELSE chosen_id END,
+		     chosen_source = CASE WHEN chosen_id = $1 THEN NULL ELSE chosen_source END,
+		     chosen_at = CASE WHEN chosen_id = $1 THEN NULL ELSE chosen_at END
+		 WHERE org_id = $3
+		   AND (chosen_id = $1 OR results @> jsonb_build_array(jsonb_build_object('id', $2::text)))`,
+		t.KnowledgeID, t.KnowledgeID, t.OrgID,
+	)
+	if err != nil {
+		return err
+	}
+	t.SearchLogs = int(result.RowsAffected())
+
+	// Stored idempotent responses can carry the item's body; drop every one that mentions
+	// the item, so a retry with the same key cannot replay the erased content
+	_, err = tx.Exec(ctx,
+		`DELETE FROM idempotency_keys
+		 WHERE org_id = $1
+		   AND (strpos(route, $2) > 0 OR position(convert_to($2, 'UTF8') IN response_body) > 0)`,
+		t.OrgID, t.KnowledgeID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM knowledge WHERE id = $1`, t.KnowledgeID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO knowledge_tombstones (id, knowledge_id, org_id, project_id, type, purged_by, reason, purged_at,
+		                                   version_count, chunk_count, asset_link_count, embedding_job_count, search_log_count)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		t.ID, t.KnowledgeID, t.OrgID, nullableString(t.ProjectID), t.Type, t.PurgedBy, t.Reason, t.PurgedAt,
+		t.Versions, t.Chunks, t.AssetLinks, t.EmbeddingJobs, t.SearchLogs,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListTombstones returns the tombstones of an org, newest first
+func (r *KnowledgePurgeRepository) ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, knowledge_id, org_id, project_id, type, purged_by, reason, purged_at,
+		        version_count, chunk_count, asset_link_count, embedding_job_count, search_log_count
+		 FROM knowledge_tombstones
+		 WHERE org_id = $1
+		 ORDER BY purged_at DESC`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstones := []*domain.KnowledgeTombstone{}
+	for rows.Next() {
+		var t domain.KnowledgeTombstone
+		var projectID *string
+		if err := rows.Scan(&t.ID, &t.KnowledgeID, &t.OrgID, &projectID, &t.Type, &t.PurgedBy, &t.Reason, &t.PurgedAt,
+			&t.Versions, &t.Chunks, &t.AssetLinks, &t.EmbeddingJobs, &t.SearchLogs); err != nil {
+			return nil, err
+		}
+		if projectID != nil {
+			t.ProjectID = *projectID
+		}
+		tombstones = append(tombstones, &t)
+	}
+	return tombstones, rows.Err()
+}
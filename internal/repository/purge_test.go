@@ -0,0 +1,202 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgePurgeRepository_Purge(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	chunkRepo := NewKnowledgeChunkRepository(pool)
+	jobRepo := NewEmbeddingJobRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+	searchLogRepo := NewSearchLogRepository(pool)
+	purgeRepo := NewKnowledgePurgeRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	create := func(title string) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			ProjectID: project.ID,
+			Type:      domain.KnowledgeTypeLearning,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    "Customer jane@example.com reported " + title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		for v := int64(1); v <= 2; v++ {
+			require.NoError(t, knowledgeRepo.CreateVersion(ctx, &domain.KnowledgeVersion{
+				ID: uuid.NewString(), KnowledgeID: k.ID, VersionNumber: v, Title: k.Title, BodyMD: k.BodyMD, CreatedAt: now,
+			}))
+		}
+		require.NoError(t, chunkRepo.ReplaceChunks(ctx, k.ID, []domain.KnowledgeChunk{
+			{KnowledgeID: k.ID, OrgID: org.ID, Type: k.Type, Status: k.Status, Title: k.Title, Content: k.BodyMD, Embedding: make([]float32, 1536)},
+		}))
+		require.NoError(t, jobRepo.Create(ctx, domain.NewEmbeddingJob(uuid.NewString(), k.ID, domain.EmbeddingJobStatusCompleted, 0, "", now, nil)))
+		return k
+	}
+
+	purged := create("a login outage")
+	kept := create("a billing outage")
+
+	require.NoError(t, relationRepo.Create(ctx, domain.NewKnowledgeRelation(uuid.NewString(), org.ID, kept.ID, purged.ID, domain.RelationTypeRelatesTo, now)))
+	kept.SupersededBy = purged.ID
+	kept.Status = domain.KnowledgeStatusDeprecated
+	require.NoError(t, knowledgeRepo.Update(ctx, kept))
+
+	searchID, err := searchLogRepo.CreateSearchLog(ctx, service.SearchLogEntry{
+		OrgID: org.ID,
+		Query: "outage",
+		Results: []service.SearchLogResult{
+			{ID: purged.ID, SourceType: "knowledge", Score: 0.9},
+			{ID: kept.ID, SourceType: "knowledge", Score: 0.8},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, searchLogRepo.RecordSearchSelection(ctx, org.ID, searchID, purged.ID, "knowledge"))
+
+	tombstone := domain.NewKnowledgeTombstone(uuid.NewString(), purged.ID, "dpo@example.com", "ERASURE-42", now)
+	require.NoError(t, purgeRepo.Purge(ctx, tombstone))
+
+	assert.Equal(t, org.ID, tombstone.OrgID)
+	assert.Equal(t, project.ID, tombstone.ProjectID)
+	assert.Equal(t, domain.KnowledgeTypeLearning, tombstone.Type)
+	assert.Equal(t, 2, tombstone.Versions)
+	assert.Equal(t, 1, tombstone.Chunks)
+	assert.Equal(t, 1, tombstone.EmbeddingJobs)
+	assert.Equal(t, 0, tombstone.AssetLinks)
+	assert.Equal(t, 1, tombstone.SearchLogs)
+
+	_, err = knowledgeRepo.GetByID(ctx, purged.ID)
+	assert.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+
+	var remaining int
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT (SELECT count(*) FROM knowledge_versions WHERE knowledge_id = $1) +
+		        (SELECT count(*) FROM knowledge_chunks WHERE knowledge_id = $1) +
+		        (SELECT count(*) FROM embedding_jobs WHERE knowledge_id = $1) +
+		        (SELECT count(*) FROM knowledge_relations WHERE source_id = $1 OR target_id = $1)`,
+		purged.ID,
+	).Scan(&remaining))
+	assert.Zero(t, remaining)
+
+	var resultCount int
+	var chosenID *string
+	var results string
+	require.NoError(t, pool.QueryRow(ctx,
+		`SELECT result_count, chosen_id::text, results::text FROM search_logs WHERE id = $1`, searchID,
+	).Scan(&resultCount, &chosenID, &results))
+	assert.Equal(t, 1, resultCount)
+	assert.Nil(t, chosenID)
+	assert.NotContains(t, results, purged.ID)
+	assert.Contains(t, results, kept.ID)
+
+	// The other item survives with its supersession link cleared
+	survivor, err := knowledgeRepo.GetByID(ctx, kept.ID)
+	require.NoError(t, err)
+	assert.Empty(t, survivor.SupersededBy)
+
+	tombstones, err := purgeRepo.ListTombstones(ctx, org.ID)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 1)
+	assert.Equal(t, purged.ID, tombstones[0].KnowledgeID)
+	assert.Equal(t, "ERASURE-42", tombstones[0].Reason)
+
+	err = purgeRepo.Purge(ctx, domain.NewKnowledgeTombstone(uuid.NewString(), purged.ID, "dpo@example.com", "", now))
+	assert.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+}
+
+func TestKnowledgePurgeRepository_Purge_IdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	idempotencyRepo := NewIdempotencyRepository(pool)
+	purgeRepo := NewKnowledgePurgeRepository(pool)
+
+	org, project := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	create := func(title string) *domain.Knowledge {
+		k := &domain.Knowledge{
+			ID:        uuid.NewString(),
+			OrgID:     org.ID,
+			ProjectID: project.ID,
+			Type:      domain.KnowledgeTypeLearning,
+			Status:    domain.KnowledgeStatusApproved,
+			Title:     title,
+			BodyMD:    "Customer jane@example.com reported " + title,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		require.NoError(t, knowledgeRepo.Create(ctx, k))
+		return k
+	}
+
+	purged := create("a login outage")
+	kept := create("a billing outage")
+
+	store := func(key, route string, k *domain.Knowledge) {
+		rec := &domain.IdempotencyRecord{
+			OrgID:       org.ID,
+			Key:         key,
+			Route:       route,
+			RequestHash: "hash-" + key,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Hour),
+		}
+		existing, err := idempotencyRepo.Reserve(ctx, rec)
+		require.NoError(t, err)
+		require.Nil(t, existing)
+		rec.StatusCode = 201
+		rec.Body = []byte(`{"data":{"id":"` + k.ID + `","body_md":"` + k.BodyMD + `"}}`)
+		require.NoError(t, idempotencyRepo.Save(ctx, rec))
+	}
+	store("create-purged", "POST /knowledge", purged)
+	store("update-purged", "PUT /knowledge/"+purged.ID, purged)
+	store("create-kept", "POST /knowledge", kept)
+
+	require.NoError(t, purgeRepo.Purge(ctx, domain.NewKnowledgeTombstone(uuid.NewString(), purged.ID, "dpo@example.com", "ERASURE-42", now)))
+
+	var keys []string
+	rows, err := pool.Query(ctx, `SELECT key FROM idempotency_keys WHERE org_id = $1`, org.ID)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		require.NoError(t, rows.Scan(&key))
+		keys = append(keys, key)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"create-kept"}, keys)
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/service"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type KnowledgeRelationRepository struct {
+	db dbtx
+}
+
+func NewKnowledgeRelationRepository(pool *pgxpool.Pool) *KnowledgeRelationRepository {
+	return &KnowledgeRelationRepository{db: pool}
+}
+
+func NewKnowledgeRelationRepositoryWithTx(tx pgx.Tx) *KnowledgeRelationRepository {
+	return &KnowledgeRelationRepository{db: tx}
+}
+
+func (r *KnowledgeRelationRepository) Create(ctx context.Context, rel *domain.KnowledgeRelation) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO knowledge_relations (id, org_id, source_id, target_id, relation_type, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		rel.ID, rel.OrgID, rel.SourceID, rel.TargetID, rel.Type, rel.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrRelationAlreadyExists
+	}
+	return err
+}
+
+func (r *KnowledgeRelationRepository) GetByID(ctx context.Context, id string) (*domain.KnowledgeRelation, error) {
+	var rel domain.KnowledgeRelation
+	err := r.db.QueryRow(ctx,
+		`SELECT id, org_id, source_id, target_id, relation_type, created_at
+		 FROM knowledge_relations WHERE id = $1`,
+		id,
+	).Scan(&rel.ID, &rel.OrgID, &rel.SourceID, &rel.TargetID, &rel.Type, &rel.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRelationNotFound
+		}
+		return nil, err
+	}
+	return &rel, nil
+}
+
+func (r *KnowledgeRelationRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM knowledge_relations WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrRelationNotFound
+	}
+	return nil
+}
+
+func (r *KnowledgeRelationRepository) ListRelated(ctx context.Context, knowledgeIDs []string) ([]*service.RelatedKnowledge, error) {
+	return listRelatedKnowledge(ctx, r.db, knowledgeIDs)
+}
+
+// listRelatedKnowledge returns both outgoing links and backlinks for the given items,
+// joined with the knowledge item on the other end of each edge.
+func listRelatedKnowledge(ctx context.Context, db dbtx, knowledgeIDs []string) ([]*service.RelatedKnowledge, error) {
+	if len(knowledgeIDs) == 0 {
+		return []*service.RelatedKnowledge{}, nil
+	}
+
+	rows, err := db.Query(ctx,
+		`SELECT rel.id, rel.relation_type, rel.direction, rel.from_id,
+		        k.id, k.title, k.summary, k.scope_path, k.type, k.status, k.updated_at
+		 FROM (
+		     SELECT id, relation_type, 'outgoing' AS direction, source_id AS from_id, target_id AS other_id, created_at
+		     FROM knowledge_relations WHERE source_id = ANY($1)
+		     UNION ALL
+		     SELECT id, relation_type, 'incoming' AS direction, target_id AS from_id, source_id AS other_id, created_at
+		     FROM knowledge_relations WHERE target_id = ANY($1)
+		 ) rel
+		 INNER JOIN knowledge k ON k.id = rel.other_id
+		 ORDER BY rel.from_id, rel.direction DESC, rel.relation_type, rel.created_at`,
+		knowledgeIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]*service.RelatedKnowledge, 0)
+	for rows.Next() {
+		var item service.RelatedKnowledge
+		var scope *string
+		if err := rows.Scan(&item.RelationID, &item.Type, &item.Direction, &item.FromID,
+			&item.ID, &item.Title, &item.Summary, &scope, &item.KnowledgeType, &item.Status, &item.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if scope != nil {
+			item.Scope = *scope
+		}
+		results = append(results, &item)
+	}
+	return results, rows.Err()
+}
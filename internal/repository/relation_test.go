@@ -0,0 +1,125 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createKnowledgeForRelation(ctx context.Context, t *testing.T, repo *KnowledgeRepository, orgID, title string) *domain.Knowledge {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	k := &domain.Knowledge{
+		ID:        uuid.NewString(),
+		OrgID:     orgID,
+		Type:      domain.KnowledgeTypeDecision,
+		Status:    domain.KnowledgeStatusApproved,
+		Title:     title,
+		Summary:   title + " summary",
+		BodyMD:    "# " + title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	require.NoError(t, repo.Create(ctx, k))
+	return k
+}
+
+func TestKnowledgeRelationRepository_CreateAndListRelated(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	decision := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "Use Postgres")
+	guideline := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "Migration guideline")
+
+	rel := domain.NewKnowledgeRelation(uuid.NewString(), org.ID, guideline.ID, decision.ID, domain.RelationTypeDependsOn,
+		time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, relationRepo.Create(ctx, rel))
+
+	retrieved, err := relationRepo.GetByID(ctx, rel.ID)
+	require.NoError(t, err)
+	assert.Equal(t, guideline.ID, retrieved.SourceID)
+	assert.Equal(t, decision.ID, retrieved.TargetID)
+	assert.Equal(t, domain.RelationTypeDependsOn, retrieved.Type)
+
+	related, err := relationRepo.ListRelated(ctx, []string{guideline.ID, decision.ID})
+	require.NoError(t, err)
+	require.Len(t, related, 2)
+
+	byFrom := map[string]*struct{ direction, other string }{}
+	for _, r := range related {
+		byFrom[r.FromID] = &struct{ direction, other string }{r.Direction, r.ID}
+	}
+	assert.Equal(t, "outgoing", byFrom[guideline.ID].direction)
+	assert.Equal(t, decision.ID, byFrom[guideline.ID].other)
+	assert.Equal(t, "incoming", byFrom[decision.ID].direction)
+	assert.Equal(t, guideline.ID, byFrom[decision.ID].other)
+}
+
+func TestKnowledgeRelationRepository_Create_Duplicate(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	a := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "A")
+	b := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "B")
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, relationRepo.Create(ctx, domain.NewKnowledgeRelation(uuid.NewString(), org.ID, a.ID, b.ID, domain.RelationTypeRefines, now)))
+
+	err := relationRepo.Create(ctx, domain.NewKnowledgeRelation(uuid.NewString(), org.ID, a.ID, b.ID, domain.RelationTypeRefines, now))
+	assert.ErrorIs(t, err, domain.ErrRelationAlreadyExists)
+}
+
+func TestKnowledgeRelationRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	pc := testutil.NewPostgresContainer(ctx, t)
+	defer pc.Terminate(ctx)
+
+	pool := testutil.NewTestPool(ctx, t, pc, "../../migrations")
+	defer pool.Close()
+
+	orgRepo := NewOrgRepository(pool)
+	projectRepo := NewProjectRepository(pool)
+	knowledgeRepo := NewKnowledgeRepository(pool)
+	relationRepo := NewKnowledgeRelationRepository(pool)
+
+	org, _ := setupOrgProjectForKnowledge(ctx, t, nil, orgRepo, projectRepo)
+	a := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "A")
+	b := createKnowledgeForRelation(ctx, t, knowledgeRepo, org.ID, "B")
+
+	rel := domain.NewKnowledgeRelation(uuid.NewString(), org.ID, a.ID, b.ID, domain.RelationTypeContradicts,
+		time.Now().UTC().Truncate(time.Microsecond))
+	require.NoError(t, relationRepo.Create(ctx, rel))
+
+	require.NoError(t, relationRepo.Delete(ctx, rel.ID))
+
+	_, err := relationRepo.GetByID(ctx, rel.ID)
+	assert.ErrorIs(t, err, domain.ErrRelationNotFound)
+	assert.ErrorIs(t, relationRepo.Delete(ctx, rel.ID), domain.ErrRelationNotFound)
+}
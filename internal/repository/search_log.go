@@ -36,6 +36,18 @@ func (r *SearchLogRepository) CreateSearchLog(ctx context.Context, entry service
 	if entry.Filters.SourceType != "" {
 		filters["source_type"] = entry.Filters.SourceType
 	}
+	if len(entry.Filters.Tags) > 0 {
+		filters["tags"] = entry.Filters.Tags
+		if entry.Filters.TagMatch != "" {
+			filters["tag_match"] = entry.Filters.TagMatch
+		}
+	}
+	if entry.Filters.Language != "" {
+		filters["language"] = entry.Filters.Language
+	}
+	if entry.Filters.Author != "" {
+		filters["author"] = entry.Filters.Author
+	}
 
 	filtersJSON, _ := json.Marshal(filters)
 	resultsJSON, _ := json.Marshal(entry.Results)
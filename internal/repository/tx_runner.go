@@ -47,3 +47,21 @@ func (r *txRepos) EmbeddingJobs() service.EmbeddingJobRepositoryInterface {
 func (r *txRepos) Assets() service.AssetRepositoryInterface {
 	return NewAssetRepositoryWithTx(r.tx)
 }
+
+func (r *txRepos) Merges() service.KnowledgeMergeRepositoryInterface {
+	return NewKnowledgeMergeRepositoryWithTx(r.tx)
+}
+
+func (r *txRepos) Savepoint(ctx context.Context, fn func(repos service.TxRepositories) error) error {
+	sp, err := r.tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&txRepos{tx: sp}); err != nil {
+		_ = sp.Rollback(ctx)
+		return err
+	}
+
+	return sp.Commit(ctx)
+}
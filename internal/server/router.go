@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/cloo-solutions/neotexai/internal/api"
 	"github.com/cloo-solutions/neotexai/internal/api/handlers"
@@ -10,12 +11,29 @@ import (
 )
 
 type RouterConfig struct {
-	AuthValidator    middleware.AuthValidator
-	KnowledgeHandler *handlers.KnowledgeHandler
-	AssetHandler     *handlers.AssetHandler
-	ContextHandler   *handlers.ContextHandler
-	AuthHandler      *handlers.AuthHandler
-	ProjectHandler   *handlers.ProjectHandler
+	AuthValidator        middleware.AuthValidator
+	KnowledgeHandler     *handlers.KnowledgeHandler
+	AssetHandler         *handlers.AssetHandler
+	ContextHandler       *handlers.ContextHandler
+	AuthHandler          *handlers.AuthHandler
+	ProjectHandler       *handlers.ProjectHandler
+	RelationHandler      *handlers.RelationHandler
+	KnowledgeTypeHandler *handlers.KnowledgeTypeHandler
+	LintHandler          *handlers.LintHandler
+	ChecklistHandler     *handlers.ChecklistHandler
+	CommentHandler       *handlers.CommentHandler
+	AdminHandler         *handlers.AdminHandler
+	ArchiveHandler       *handlers.ArchiveHandler
+	// AdminToken authorizes the /admin routes; they answer 403 when it is empty
+	AdminToken string
+	// IdempotencyStore enables replaying responses to retried requests that carry an
+	// Idempotency-Key; the header is ignored when it is nil
+	IdempotencyStore middleware.IdempotencyStore
+	// IdempotencyTTL is how long a stored response is replayed
+	IdempotencyTTL time.Duration
+	// MaxArchiveBytes caps the archive uploaded to /admin/orgs/{orgID}/restore; other bodies
+	// are capped at 5 MB
+	MaxArchiveBytes int64
 }
 
 func NewRouter(cfg RouterConfig) http.Handler {
@@ -26,23 +44,71 @@ func NewRouter(cfg RouterConfig) http.Handler {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.SentryMiddleware)
 	r.Use(middleware.AccessLog)
-	r.Use(middleware.MaxBodyBytes(maxBodyBytes))
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		api.Success(w, http.StatusOK, map[string]string{"status": "ok"})
 	})
 
 	r.Group(func(r chi.Router) {
+		r.Use(middleware.MaxBodyBytes(maxBodyBytes))
 		r.Use(middleware.APIKeyAuth(cfg.AuthValidator))
+		if cfg.IdempotencyStore != nil {
+			r.Use(middleware.Idempotency(cfg.IdempotencyStore, cfg.IdempotencyTTL))
+		}
 
 		r.Route("/knowledge", func(r chi.Router) {
 			r.Post("/", cfg.KnowledgeHandler.Create)
 			r.Get("/", cfg.KnowledgeHandler.List)
+			r.Get("/pending", cfg.KnowledgeHandler.ListPending)
+			r.Get("/stale", cfg.KnowledgeHandler.ListStale)
+			r.Get("/sources", cfg.KnowledgeHandler.ListSources)
+			r.Post("/batch", cfg.KnowledgeHandler.BatchCreate)
+			r.Post("/batch/deprecate", cfg.KnowledgeHandler.BatchDeprecate)
+			r.Post("/move", cfg.KnowledgeHandler.Move)
 			r.Get("/{id}", cfg.KnowledgeHandler.Get)
 			r.Put("/{id}", cfg.KnowledgeHandler.Update)
 			r.Delete("/{id}", cfg.KnowledgeHandler.Delete)
+			r.Post("/{id}/approve", cfg.KnowledgeHandler.Approve)
+			r.Post("/{id}/reject", cfg.KnowledgeHandler.Reject)
+			r.Get("/{id}/versions", cfg.KnowledgeHandler.ListVersions)
+			r.Get("/{id}/versions/{version}", cfg.KnowledgeHandler.GetVersion)
+			r.Get("/{id}/diff", cfg.KnowledgeHandler.Diff)
+			r.Post("/{id}/revert", cfg.KnowledgeHandler.Revert)
+			r.Post("/{id}/merge", cfg.KnowledgeHandler.Merge)
+			r.Post("/{id}/render", cfg.KnowledgeHandler.RenderTemplate)
+			r.Post("/{id}/instantiate", cfg.KnowledgeHandler.Instantiate)
+			r.Post("/{id}/relations", cfg.RelationHandler.Create)
+			r.Get("/{id}/relations", cfg.RelationHandler.List)
+			r.Delete("/{id}/relations/{relationID}", cfg.RelationHandler.Delete)
+			r.Get("/{id}/checklist", cfg.ChecklistHandler.Get)
+			r.Post("/{id}/checklist/runs", cfg.ChecklistHandler.StartRun)
+			r.Get("/{id}/checklist/runs", cfg.ChecklistHandler.ListRuns)
+			r.Post("/{id}/comments", cfg.CommentHandler.Create)
+			r.Get("/{id}/comments", cfg.CommentHandler.List)
 		})
 
+		r.Route("/comments", func(r chi.Router) {
+			r.Post("/{commentID}/resolve", cfg.CommentHandler.Resolve)
+			r.Delete("/{commentID}", cfg.CommentHandler.Delete)
+		})
+
+		r.Route("/checklist-runs", func(r chi.Router) {
+			r.Get("/{runID}", cfg.ChecklistHandler.GetRun)
+			r.Put("/{runID}/items/{number}", cfg.ChecklistHandler.CheckItem)
+			r.Post("/{runID}/complete", cfg.ChecklistHandler.CompleteRun)
+		})
+
+		r.Route("/knowledge-types", func(r chi.Router) {
+			r.Get("/", cfg.KnowledgeTypeHandler.List)
+			r.Post("/", cfg.KnowledgeTypeHandler.Create)
+			r.Get("/{name}", cfg.KnowledgeTypeHandler.Get)
+			r.Put("/{name}", cfg.KnowledgeTypeHandler.Update)
+			r.Delete("/{name}", cfg.KnowledgeTypeHandler.Delete)
+		})
+
+		r.Get("/lint/config", cfg.LintHandler.GetConfig)
+		r.Put("/lint/config", cfg.LintHandler.UpdateConfig)
+
 		r.Route("/assets", func(r chi.Router) {
 			r.Post("/init", cfg.AssetHandler.InitUpload)
 			r.Post("/complete", cfg.AssetHandler.CompleteUpload)
@@ -62,8 +128,25 @@ func NewRouter(cfg RouterConfig) http.Handler {
 		})
 	})
 
-	r.Post("/orgs", cfg.AuthHandler.CreateOrg)
-	r.Post("/apikeys", cfg.AuthHandler.CreateAPIKey)
+	// Archives may be far larger than other bodies, so they get their own cap and are not
+	// buffered for idempotent replay. A restore writes a whole org and, like the /admin
+	// routes, takes the admin token.
+	r.Group(func(r chi.Router) {
+		r.With(middleware.APIKeyAuth(cfg.AuthValidator)).Get("/export", cfg.ArchiveHandler.Export)
+		r.With(middleware.AdminTokenAuth(cfg.AdminToken), middleware.MaxBodyBytes(cfg.MaxArchiveBytes)).
+			Post("/admin/orgs/{orgID}/restore", cfg.ArchiveHandler.Restore)
+	})
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(middleware.MaxBodyBytes(maxBodyBytes))
+		r.Use(middleware.AdminTokenAuth(cfg.AdminToken))
+
+		r.Post("/knowledge/{id}/purge", cfg.AdminHandler.PurgeKnowledge)
+		r.Get("/tombstones", cfg.AdminHandler.ListTombstones)
+	})
+
+	r.With(middleware.MaxBodyBytes(maxBodyBytes)).Post("/orgs", cfg.AuthHandler.CreateOrg)
+	r.With(middleware.MaxBodyBytes(maxBodyBytes)).Post("/apikeys", cfg.AuthHandler.CreateAPIKey)
 
 	return r
 }
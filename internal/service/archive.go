@@ -0,0 +1,684 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxArchiveDocumentBytes caps the manifest and each markdown file read from an archive
+	maxArchiveDocumentBytes = 16 << 20
+	// maxArchiveAssetBytes caps each asset binary read from an archive
+	maxArchiveAssetBytes = 512 << 20
+)
+
+// KnowledgeAssetLink links a knowledge item to an asset
+type KnowledgeAssetLink struct {
+	KnowledgeID string `json:"knowledge_id"`
+	AssetID     string `json:"asset_id"`
+}
+
+// OrgSnapshot is everything an archive holds for an organization. Deprecated knowledge
+// is included so that supersession chains survive a round trip.
+type OrgSnapshot struct {
+	OrgID     string
+	Projects  []*domain.Project
+	Types     []*domain.KnowledgeTypeDefinition
+	Knowledge []*domain.Knowledge
+	Versions  []*domain.KnowledgeVersion
+	Assets    []*domain.Asset
+	Links     []KnowledgeAssetLink
+	Relations []*domain.KnowledgeRelation
+
+	// LintConfig is nil when the org lints with the defaults
+	LintConfig *domain.LintConfig
+}
+
+// OrgArchiveRepositoryInterface defines the repository interface for org archives
+type OrgArchiveRepositoryInterface interface {
+	// Snapshot reads an organization's content in a single consistent transaction
+	Snapshot(ctx context.Context, orgID string) (*OrgSnapshot, error)
+	ListProjects(ctx context.Context, orgID string) ([]*domain.Project, error)
+	// Restore writes a snapshot and its embedding jobs in a single transaction.
+	// Types and a lint configuration that already exist in the org are kept as they are.
+	Restore(ctx context.Context, snapshot *OrgSnapshot, jobs []*domain.EmbeddingJob) error
+}
+
+// ArchiveStorage reads and writes asset binaries
+type ArchiveStorage interface {
+	GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// ArchiveService exports an organization as a portable archive and restores archives
+// into an organization. Comments, checklist runs and search logs are not archived.
+type ArchiveService struct {
+	repo    OrgArchiveRepositoryInterface
+	storage ArchiveStorage
+	uuidGen UUIDGenerator
+}
+
+// NewArchiveService creates a new ArchiveService instance. storage may be nil, in which
+// case archives are written without asset binaries.
+func NewArchiveService(repo OrgArchiveRepositoryInterface, storage ArchiveStorage) *ArchiveService {
+	return NewArchiveServiceWithUUIDGen(repo, storage, &DefaultUUIDGenerator{})
+}
+
+// NewArchiveServiceWithUUIDGen creates a new ArchiveService with custom UUID generator (for testing)
+func NewArchiveServiceWithUUIDGen(repo OrgArchiveRepositoryInterface, storage ArchiveStorage, uuidGen UUIDGenerator) *ArchiveService {
+	return &ArchiveService{
+		repo:    repo,
+		storage: storage,
+		uuidGen: uuidGen,
+	}
+}
+
+// Snapshot reads the content of an organization
+func (s *ArchiveService) Snapshot(ctx context.Context, orgID string) (*OrgSnapshot, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveService.Snapshot", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "snapshot_org",
+	})
+	defer span.End()
+
+	if _, err := uuid.Parse(orgID); err != nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	return s.repo.Snapshot(ctx, orgID)
+}
+
+// Export writes an archive of an organization to w
+func (s *ArchiveService) Export(ctx context.Context, orgID string, w io.Writer) error {
+	snapshot, err := s.Snapshot(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	return s.WriteArchive(ctx, snapshot, w)
+}
+
+// WriteArchive writes a snapshot to w as a gzipped tarball, fetching asset binaries
+// through storage when it is configured
+func (s *ArchiveService) WriteArchive(ctx context.Context, snapshot *OrgSnapshot, w io.Writer) error {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveService.WriteArchive", telemetry.SpanAttributes{
+		OrgID:     snapshot.OrgID,
+		Operation: "write_archive",
+	})
+	defer span.End()
+
+	now := time.Now().UTC()
+	manifest := buildManifest(snapshot, now, s.storage != nil)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeArchiveJSON(tw, archiveManifestPath, manifest, now); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	latest := make(map[string]int64)
+	for _, v := range snapshot.Versions {
+		if v.VersionNumber > latest[v.KnowledgeID] {
+			latest[v.KnowledgeID] = v.VersionNumber
+		}
+	}
+
+	for _, k := range snapshot.Knowledge {
+		doc, err := knowledgeDocument(k, latest[k.ID])
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveFile(tw, knowledgeArchivePath(k.ID), doc, k.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to write knowledge %s: %w", k.ID, err)
+		}
+	}
+
+	for _, v := range snapshot.Versions {
+		doc, err := versionDocument(v)
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveFile(tw, versionArchivePath(v.KnowledgeID, v.VersionNumber), doc, v.CreatedAt); err != nil {
+			return fmt.Errorf("failed to write version %d of knowledge %s: %w", v.VersionNumber, v.KnowledgeID, err)
+		}
+	}
+
+	if s.storage != nil {
+		for _, a := range snapshot.Assets {
+			if err := s.writeAssetBinary(ctx, tw, a); err != nil {
+				return fmt.Errorf("failed to write asset %s: %w", a.ID, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func (s *ArchiveService) writeAssetBinary(ctx context.Context, tw *tar.Writer, a *domain.Asset) error {
+	body, size, err := s.storage.GetObject(ctx, a.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if size < 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		return writeArchiveFile(tw, assetArchivePath(a.ID, a.Filename), data, a.CreatedAt)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     assetArchivePath(a.ID, a.Filename),
+		Mode:     0644,
+		Size:     size,
+		ModTime:  a.CreatedAt,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, body)
+	return err
+}
+
+// RestoreInput represents the input for restoring an archive
+type RestoreInput struct {
+	OrgID   string
+	Archive io.Reader
+	// RemapIDs gives knowledge items, assets and created projects new IDs, which is
+	// needed to restore an archive next to the org it was exported from
+	RemapIDs bool
+	// QueueEmbeddings queues embedding jobs for the restored knowledge and assets
+	QueueEmbeddings bool
+}
+
+// RestoreResult counts what a restore wrote. IDs maps archived knowledge, asset and
+// project IDs to the IDs they were restored under, where the two differ.
+type RestoreResult struct {
+	Projects      int               `json:"projects"`
+	Types         int               `json:"types"`
+	Knowledge     int               `json:"knowledge"`
+	Versions      int               `json:"versions"`
+	Assets        int               `json:"assets"`
+	SkippedAssets int               `json:"skipped_assets"`
+	Links         int               `json:"links"`
+	Relations     int               `json:"relations"`
+	EmbeddingJobs int               `json:"embedding_jobs"`
+	IDs           map[string]string `json:"ids,omitempty"`
+}
+
+// archiveContents is a parsed archive. Asset binaries are spooled to temporary files
+// rather than held in memory; close removes them.
+type archiveContents struct {
+	manifest *ArchiveManifest
+	files    map[string][]byte
+	assets   map[string]*spooledAsset
+}
+
+// spooledAsset is an asset binary copied from an archive to a temporary file
+type spooledAsset struct {
+	path   string
+	size   int64
+	sha256 string
+}
+
+func (c *archiveContents) close() {
+	for _, a := range c.assets {
+		_ = os.Remove(a.path)
+	}
+}
+
+// Restore writes an archive into an organization. Projects are matched by ID, then by
+// name, and created when neither matches. Supersession, template, relation and link
+// references to items outside the archive are dropped. Assets whose binaries were not
+// exported are skipped together with their links.
+func (s *ArchiveService) Restore(ctx context.Context, input RestoreInput) (*RestoreResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveService.Restore", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "restore_archive",
+	})
+	defer span.End()
+
+	if _, err := uuid.Parse(input.OrgID); err != nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	contents, err := readArchive(input.Archive)
+	if err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+	}
+	defer contents.close()
+	manifest := contents.manifest
+
+	existing, err := s.repo.ListProjects(ctx, input.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	result := &RestoreResult{}
+	snapshot := &OrgSnapshot{OrgID: input.OrgID}
+	ids := make(map[string]string)
+	newID := func(id string) string {
+		if input.RemapIDs {
+			return s.uuidGen.NewString()
+		}
+		return id
+	}
+
+	projectIDs := s.restoreProjects(manifest.Projects, existing, input, now, snapshot)
+	for oldID, id := range projectIDs {
+		if oldID != id {
+			ids[oldID] = id
+		}
+	}
+	result.Projects = len(snapshot.Projects)
+
+	for _, t := range manifest.Types {
+		def := &domain.KnowledgeTypeDefinition{
+			ID:               s.uuidGen.NewString(),
+			OrgID:            input.OrgID,
+			Name:             domain.KnowledgeType(t.Name),
+			DisplayName:      t.DisplayName,
+			RequiredSections: t.RequiredSections,
+			SearchBoost:      t.SearchBoost,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		if err := domain.ValidateKnowledgeTypeDefinition(def); err != nil {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+		}
+		snapshot.Types = append(snapshot.Types, def)
+	}
+
+	if manifest.LintConfig != nil {
+		if err := domain.ValidateLintConfig(manifest.LintConfig); err != nil {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+		}
+		snapshot.LintConfig = manifest.LintConfig
+	}
+
+	knowledgeIDs := make(map[string]string, len(manifest.Knowledge))
+	for _, item := range manifest.Knowledge {
+		knowledgeIDs[item.ID] = newID(item.ID)
+	}
+
+	for _, item := range manifest.Knowledge {
+		k, versions, err := restoreKnowledge(item, contents.files, knowledgeIDs, projectIDs, input.OrgID, s.uuidGen)
+		if err != nil {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+		}
+		snapshot.Knowledge = append(snapshot.Knowledge, k)
+		snapshot.Versions = append(snapshot.Versions, versions...)
+		if k.ID != item.ID {
+			ids[item.ID] = k.ID
+		}
+	}
+
+	assetIDs := make(map[string]string, len(manifest.Assets))
+	for _, a := range manifest.Assets {
+		if a.Path == "" {
+			result.SkippedAssets++
+			continue
+		}
+		spooled, ok := contents.assets[a.Path]
+		if !ok {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive",
+				fmt.Errorf("binary of asset %s is missing", a.ID))
+		}
+		if a.SHA256 != "" {
+			if !strings.EqualFold(spooled.sha256, a.SHA256) {
+				return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive",
+					fmt.Errorf("binary of asset %s does not match its sha256", a.ID))
+			}
+		}
+
+		id := newID(a.ID)
+		asset := domain.NewAsset(id, input.OrgID, projectIDs[a.ProjectID], a.Filename, a.MimeType, a.SHA256,
+			buildStorageKey(input.OrgID, id, a.Filename), a.Keywords, a.Description, a.CreatedAt)
+		if err := domain.ValidateAsset(asset); err != nil {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+		}
+		snapshot.Assets = append(snapshot.Assets, asset)
+		assetIDs[a.ID] = id
+		if id != a.ID {
+			ids[a.ID] = id
+		}
+	}
+
+	if len(snapshot.Assets) > 0 && s.storage == nil {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "restoring asset binaries requires storage to be configured")
+	}
+
+	for _, link := range manifest.Links {
+		knowledgeID, okK := knowledgeIDs[link.KnowledgeID]
+		assetID, okA := assetIDs[link.AssetID]
+		if okK && okA {
+			snapshot.Links = append(snapshot.Links, KnowledgeAssetLink{KnowledgeID: knowledgeID, AssetID: assetID})
+		}
+	}
+
+	for _, r := range manifest.Relations {
+		sourceID, okS := knowledgeIDs[r.SourceID]
+		targetID, okT := knowledgeIDs[r.TargetID]
+		if !okS || !okT {
+			continue
+		}
+		relation := domain.NewKnowledgeRelation(s.uuidGen.NewString(), input.OrgID, sourceID, targetID,
+			domain.RelationType(r.Type), r.CreatedAt)
+		if err := domain.ValidateKnowledgeRelation(relation); err != nil {
+			return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid export archive", err)
+		}
+		snapshot.Relations = append(snapshot.Relations, relation)
+	}
+
+	var jobs []*domain.EmbeddingJob
+	if input.QueueEmbeddings {
+		for _, k := range snapshot.Knowledge {
+			jobs = append(jobs, &domain.EmbeddingJob{
+				ID:          s.uuidGen.NewString(),
+				KnowledgeID: k.ID,
+				Status:      domain.EmbeddingJobStatusPending,
+				CreatedAt:   now,
+			})
+		}
+		for _, a := range snapshot.Assets {
+			if a.Description == "" && len(a.Keywords) == 0 {
+				continue
+			}
+			jobs = append(jobs, &domain.EmbeddingJob{
+				ID:        s.uuidGen.NewString(),
+				AssetID:   a.ID,
+				Status:    domain.EmbeddingJobStatusPending,
+				CreatedAt: now,
+			})
+		}
+	}
+
+	uploaded, err := s.uploadAssets(ctx, snapshot.Assets, assetPaths(manifest.Assets, assetIDs), contents.assets)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, snapshot, jobs); err != nil {
+		s.deleteObjects(ctx, uploaded)
+		return nil, err
+	}
+
+	result.Types = len(snapshot.Types)
+	result.Knowledge = len(snapshot.Knowledge)
+	result.Versions = len(snapshot.Versions)
+	result.Assets = len(snapshot.Assets)
+	result.Links = len(snapshot.Links)
+	result.Relations = len(snapshot.Relations)
+	result.EmbeddingJobs = len(jobs)
+	if len(ids) > 0 {
+		result.IDs = ids
+	}
+	return result, nil
+}
+
+// restoreProjects maps archived project IDs to projects of the target org, adding the
+// projects that have to be created to the snapshot
+func (s *ArchiveService) restoreProjects(projects []ArchiveProject, existing []*domain.Project, input RestoreInput, now time.Time, snapshot *OrgSnapshot) map[string]string {
+	byID := make(map[string]string, len(existing))
+	byName := make(map[string]string, len(existing))
+	for _, p := range existing {
+		byID[p.ID] = p.ID
+		byName[p.Name] = p.ID
+	}
+
+	projectIDs := make(map[string]string, len(projects))
+	for _, p := range projects {
+		if id, ok := byID[p.ID]; ok {
+			projectIDs[p.ID] = id
+			continue
+		}
+		if id, ok := byName[p.Name]; ok {
+			projectIDs[p.ID] = id
+			continue
+		}
+
+		id := p.ID
+		if input.RemapIDs {
+			id = s.uuidGen.NewString()
+		}
+		createdAt := p.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = now
+		}
+		snapshot.Projects = append(snapshot.Projects, domain.NewProject(id, input.OrgID, p.Name, createdAt))
+		projectIDs[p.ID] = id
+		byName[p.Name] = id
+	}
+	return projectIDs
+}
+
+// restoreKnowledge rebuilds a knowledge item and its versions from their markdown files
+func restoreKnowledge(item ArchiveKnowledge, files map[string][]byte, knowledgeIDs, projectIDs map[string]string, orgID string, uuidGen UUIDGenerator) (*domain.Knowledge, []*domain.KnowledgeVersion, error) {
+	data, ok := files[item.Path]
+	if !ok {
+		return nil, nil, fmt.Errorf("file of knowledge %s is missing", item.ID)
+	}
+	meta, body, err := frontmatter.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("knowledge %s: %w", item.ID, err)
+	}
+
+	k := domain.NewKnowledge(knowledgeIDs[item.ID], orgID, projectIDs[item.ProjectID],
+		domain.KnowledgeType(meta.Type), domain.KnowledgeStatus(meta.Status),
+		meta.Title, meta.Summary, body, item.CreatedAt, item.UpdatedAt)
+	k.Scope = meta.Scope
+	k.Tags = domain.NormalizeTags(meta.Tags)
+	k.ReviewedBy = domain.Author{Name: item.ReviewedBy}
+	k.ReviewedAt = item.ReviewedAt
+	k.ReviewNote = item.ReviewNote
+	k.SupersededBy = knowledgeIDs[item.SupersededBy]
+	k.ReviewAfter = item.ReviewAfter
+	k.Owner = item.Owner
+	if templateID, ok := knowledgeIDs[item.TemplateID]; ok {
+		k.TemplateID = templateID
+		k.TemplateVersion = item.TemplateVersion
+	}
+	k.Language = item.Language
+	k.CreatedBy = domain.Author{Name: item.CreatedBy}
+	k.UpdatedBy = domain.Author{Name: item.UpdatedBy}
+	k.SourcePath = item.SourcePath
+	k.SourceHash = item.SourceHash
+	k.SummaryGeneratedBy = item.SummaryGeneratedBy
+	k.SuggestedTitle = item.SuggestedTitle
+	if err := domain.ValidateKnowledge(k); err != nil {
+		return nil, nil, fmt.Errorf("knowledge %s: %w", item.ID, err)
+	}
+
+	versions := make([]*domain.KnowledgeVersion, 0, len(item.Versions))
+	for _, v := range item.Versions {
+		data, ok := files[v.Path]
+		if !ok {
+			return nil, nil, fmt.Errorf("version %d of knowledge %s is missing", v.Number, item.ID)
+		}
+		meta, body, err := frontmatter.Parse(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("version %d of knowledge %s: %w", v.Number, item.ID, err)
+		}
+		version := &domain.KnowledgeVersion{
+			ID:            uuidGen.NewString(),
+			KnowledgeID:   k.ID,
+			VersionNumber: v.Number,
+			Title:         meta.Title,
+			Summary:       meta.Summary,
+			BodyMD:        body,
+			CreatedAt:     v.CreatedAt,
+			CreatedBy:     domain.Author{Name: v.CreatedBy},
+		}
+		if err := domain.ValidateKnowledgeVersion(version); err != nil {
+			return nil, nil, fmt.Errorf("version %d of knowledge %s: %w", v.Number, item.ID, err)
+		}
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 {
+		versions = append(versions, &domain.KnowledgeVersion{
+			ID:            uuidGen.NewString(),
+			KnowledgeID:   k.ID,
+			VersionNumber: 1,
+			Title:         k.Title,
+			Summary:       k.Summary,
+			BodyMD:        k.BodyMD,
+			CreatedAt:     k.UpdatedAt,
+			CreatedBy:     k.UpdatedBy,
+		})
+	}
+
+	return k, versions, nil
+}
+
+// assetPaths maps restored asset IDs to the archive paths of their binaries
+func assetPaths(assets []ArchiveAsset, assetIDs map[string]string) map[string]string {
+	paths := make(map[string]string, len(assetIDs))
+	for _, a := range assets {
+		if id, ok := assetIDs[a.ID]; ok {
+			paths[id] = a.Path
+		}
+	}
+	return paths
+}
+
+// uploadAssets stores the binaries of restored assets and returns the keys written.
+// On failure the keys already written are deleted again.
+func (s *ArchiveService) uploadAssets(ctx context.Context, assets []*domain.Asset, paths map[string]string, spooled map[string]*spooledAsset) ([]string, error) {
+	uploaded := make([]string, 0, len(assets))
+	for _, a := range assets {
+		if err := s.uploadAsset(ctx, a, spooled[paths[a.ID]]); err != nil {
+			s.deleteObjects(ctx, uploaded)
+			return nil, fmt.Errorf("failed to upload asset %s: %w", a.ID, err)
+		}
+		uploaded = append(uploaded, a.StorageKey)
+	}
+	return uploaded, nil
+}
+
+func (s *ArchiveService) uploadAsset(ctx context.Context, a *domain.Asset, spooled *spooledAsset) error {
+	f, err := os.Open(spooled.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.storage.PutObject(ctx, a.StorageKey, a.MimeType, f, spooled.size)
+}
+
+func (s *ArchiveService) deleteObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		_ = s.storage.DeleteObject(ctx, key)
+	}
+}
+
+// readArchive reads a gzipped tarball whose first entry is the manifest. Markdown files
+// are read into memory and asset binaries are spooled to temporary files, which are
+// removed again when reading fails.
+func readArchive(r io.Reader) (contents *archiveContents, err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("archive is not gzip compressed: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if header.Name != archiveManifestPath {
+		return nil, fmt.Errorf("archive does not start with %s", archiveManifestPath)
+	}
+	data, err := readArchiveFile(tr, header, maxArchiveDocumentBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.FormatVersion != ArchiveFormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+
+	contents = &archiveContents{manifest: &manifest, files: make(map[string][]byte), assets: make(map[string]*spooledAsset)}
+	defer func() {
+		if err != nil {
+			contents.close()
+		}
+	}()
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.HasPrefix(header.Name, "assets/") {
+			spooled, err := spoolArchiveAsset(tr, header)
+			if err != nil {
+				return nil, err
+			}
+			contents.assets[header.Name] = spooled
+			continue
+		}
+		data, err := readArchiveFile(tr, header, maxArchiveDocumentBytes)
+		if err != nil {
+			return nil, err
+		}
+		contents.files[header.Name] = data
+	}
+
+	return contents, nil
+}
+
+// spoolArchiveAsset copies an asset binary to a temporary file, hashing it on the way
+func spoolArchiveAsset(tr *tar.Reader, header *tar.Header) (*spooledAsset, error) {
+	if header.Size > maxArchiveAssetBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", header.Name, maxArchiveAssetBytes)
+	}
+
+	f, err := os.CreateTemp("", "neotex-restore-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool %s: %w", header.Name, err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(tr, maxArchiveAssetBytes))
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("failed to spool %s: %w", header.Name, err)
+	}
+
+	return &spooledAsset{path: f.Name(), size: size, sha256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
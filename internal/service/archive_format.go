@@ -0,0 +1,271 @@
+package service
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/frontmatter"
+)
+
+// ArchiveFormatVersion is the archive layout written by WriteArchive. An archive is a
+// gzipped tarball holding manifest.json first, then one markdown file per knowledge item
+// and per version, then the asset binaries.
+const ArchiveFormatVersion = 1
+
+const archiveManifestPath = "manifest.json"
+
+// ArchiveManifest describes the contents of an archive. Type, status, title, summary,
+// scope, tags and body of each item live in its markdown file; the manifest carries the
+// remaining fields, the files of its versions, and the links and relations between items.
+type ArchiveManifest struct {
+	FormatVersion int                    `json:"format_version"`
+	OrgID         string                 `json:"org_id"`
+	ExportedAt    time.Time              `json:"exported_at"`
+	Projects      []ArchiveProject       `json:"projects"`
+	Types         []ArchiveKnowledgeType `json:"types"`
+	Knowledge     []ArchiveKnowledge     `json:"knowledge"`
+	Assets        []ArchiveAsset         `json:"assets"`
+	Links         []KnowledgeAssetLink   `json:"links"`
+	Relations     []ArchiveRelation      `json:"relations"`
+
+	// LintConfig is the organization's lint configuration, if it set one
+	LintConfig *domain.LintConfig `json:"lint_config,omitempty"`
+}
+
+type ArchiveProject struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ArchiveKnowledgeType struct {
+	Name             string   `json:"name"`
+	DisplayName      string   `json:"display_name"`
+	RequiredSections []string `json:"required_sections,omitempty"`
+	SearchBoost      float32  `json:"search_boost"`
+}
+
+type ArchiveKnowledge struct {
+	ID              string           `json:"id"`
+	Path            string           `json:"path"`
+	ProjectID       string           `json:"project_id,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	CreatedBy       string           `json:"created_by,omitempty"`
+	UpdatedBy       string           `json:"updated_by,omitempty"`
+	ReviewedBy      string           `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time       `json:"reviewed_at,omitempty"`
+	ReviewNote      string           `json:"review_note,omitempty"`
+	SupersededBy    string           `json:"superseded_by,omitempty"`
+	ReviewAfter     *time.Time       `json:"review_after,omitempty"`
+	Owner           string           `json:"owner,omitempty"`
+	TemplateID      string           `json:"template_id,omitempty"`
+	TemplateVersion int64            `json:"template_version,omitempty"`
+	Language        string           `json:"language,omitempty"`
+	SourcePath      string           `json:"source_path,omitempty"`
+	SourceHash      string           `json:"source_hash,omitempty"`
+	Versions        []ArchiveVersion `json:"versions"`
+
+	// SummaryGeneratedBy and SuggestedTitle carry machine-generated metadata
+	SummaryGeneratedBy string `json:"summary_generated_by,omitempty"`
+	SuggestedTitle     string `json:"suggested_title,omitempty"`
+}
+
+type ArchiveVersion struct {
+	Number    int64     `json:"number"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// ArchiveAsset describes an asset. Path is empty when the binary was not exported
+// because no storage was configured.
+type ArchiveAsset struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	Filename    string    `json:"filename"`
+	MimeType    string    `json:"mime_type,omitempty"`
+	SHA256      string    `json:"sha256,omitempty"`
+	Keywords    []string  `json:"keywords,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Path        string    `json:"path,omitempty"`
+}
+
+type ArchiveRelation struct {
+	SourceID  string    `json:"source_id"`
+	TargetID  string    `json:"target_id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func knowledgeArchivePath(id string) string {
+	return path.Join("knowledge", id+".md")
+}
+
+func versionArchivePath(id string, number int64) string {
+	return path.Join("knowledge", id, fmt.Sprintf("v%d.md", number))
+}
+
+func assetArchivePath(id, filename string) string {
+	return path.Join("assets", id, path.Base(filename))
+}
+
+// buildManifest describes a snapshot; withBinaries sets the paths of the asset binaries
+func buildManifest(snapshot *OrgSnapshot, exportedAt time.Time, withBinaries bool) *ArchiveManifest {
+	manifest := &ArchiveManifest{
+		FormatVersion: ArchiveFormatVersion,
+		OrgID:         snapshot.OrgID,
+		ExportedAt:    exportedAt,
+		Projects:      make([]ArchiveProject, 0, len(snapshot.Projects)),
+		Types:         make([]ArchiveKnowledgeType, 0, len(snapshot.Types)),
+		Knowledge:     make([]ArchiveKnowledge, 0, len(snapshot.Knowledge)),
+		Assets:        make([]ArchiveAsset, 0, len(snapshot.Assets)),
+		Links:         snapshot.Links,
+		Relations:     make([]ArchiveRelation, 0, len(snapshot.Relations)),
+		LintConfig:    snapshot.LintConfig,
+	}
+	if manifest.Links == nil {
+		manifest.Links = []KnowledgeAssetLink{}
+	}
+
+	for _, p := range snapshot.Projects {
+		manifest.Projects = append(manifest.Projects, ArchiveProject{ID: p.ID, Name: p.Name, CreatedAt: p.CreatedAt})
+	}
+	for _, t := range snapshot.Types {
+		manifest.Types = append(manifest.Types, ArchiveKnowledgeType{
+			Name:             string(t.Name),
+			DisplayName:      t.DisplayName,
+			RequiredSections: t.RequiredSections,
+			SearchBoost:      t.SearchBoost,
+		})
+	}
+
+	versions := make(map[string][]ArchiveVersion)
+	for _, v := range snapshot.Versions {
+		versions[v.KnowledgeID] = append(versions[v.KnowledgeID], ArchiveVersion{
+			Number:    v.VersionNumber,
+			Path:      versionArchivePath(v.KnowledgeID, v.VersionNumber),
+			CreatedAt: v.CreatedAt,
+			CreatedBy: v.CreatedBy.Name,
+		})
+	}
+
+	for _, k := range snapshot.Knowledge {
+		item := ArchiveKnowledge{
+			ID:              k.ID,
+			Path:            knowledgeArchivePath(k.ID),
+			ProjectID:       k.ProjectID,
+			CreatedAt:       k.CreatedAt,
+			UpdatedAt:       k.UpdatedAt,
+			CreatedBy:       k.CreatedBy.Name,
+			UpdatedBy:       k.UpdatedBy.Name,
+			ReviewedBy:      k.ReviewedBy.Name,
+			ReviewedAt:      k.ReviewedAt,
+			ReviewNote:      k.ReviewNote,
+			SupersededBy:    k.SupersededBy,
+			ReviewAfter:     k.ReviewAfter,
+			Owner:           k.Owner,
+			TemplateID:      k.TemplateID,
+			TemplateVersion: k.TemplateVersion,
+			Language:        k.Language,
+			SourcePath:      k.SourcePath,
+			SourceHash:      k.SourceHash,
+			Versions:        versions[k.ID],
+
+			SummaryGeneratedBy: k.SummaryGeneratedBy,
+			SuggestedTitle:     k.SuggestedTitle,
+		}
+		if item.Versions == nil {
+			item.Versions = []ArchiveVersion{}
+		}
+		manifest.Knowledge = append(manifest.Knowledge, item)
+	}
+
+	for _, a := range snapshot.Assets {
+		asset := ArchiveAsset{
+			ID:          a.ID,
+			ProjectID:   a.ProjectID,
+			Filename:    a.Filename,
+			MimeType:    a.MimeType,
+			SHA256:      a.SHA256,
+			Keywords:    a.Keywords,
+			Description: a.Description,
+			CreatedAt:   a.CreatedAt,
+		}
+		if withBinaries {
+			asset.Path = assetArchivePath(a.ID, a.Filename)
+		}
+		manifest.Assets = append(manifest.Assets, asset)
+	}
+
+	for _, r := range snapshot.Relations {
+		manifest.Relations = append(manifest.Relations, ArchiveRelation{
+			SourceID:  r.SourceID,
+			TargetID:  r.TargetID,
+			Type:      string(r.Type),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return manifest
+}
+
+// knowledgeDocument renders the markdown file of an item
+func knowledgeDocument(k *domain.Knowledge, version int64) ([]byte, error) {
+	return frontmatter.Format(&frontmatter.Metadata{
+		ID:      k.ID,
+		Type:    string(k.Type),
+		Title:   k.Title,
+		Summary: k.Summary,
+		Scope:   k.Scope,
+		Tags:    k.Tags,
+		Status:  string(k.Status),
+		Version: version,
+	}, k.BodyMD)
+}
+
+// versionDocument renders the markdown file of a version
+func versionDocument(v *domain.KnowledgeVersion) ([]byte, error) {
+	return frontmatter.Format(&frontmatter.Metadata{
+		ID:      v.KnowledgeID,
+		Title:   v.Title,
+		Summary: v.Summary,
+		Version: v.VersionNumber,
+	}, v.BodyMD)
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func writeArchiveJSON(tw *tar.Writer, name string, v interface{}, modTime time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeArchiveFile(tw, name, data, modTime)
+}
+
+// readArchiveFile reads a tar entry, refusing entries larger than limit
+func readArchiveFile(tr *tar.Reader, header *tar.Header, limit int64) ([]byte, error) {
+	if header.Size > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", header.Name, limit)
+	}
+	return io.ReadAll(io.LimitReader(tr, limit))
+}
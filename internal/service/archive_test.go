@@ -0,0 +1,314 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOrgArchiveRepository is a mock implementation of OrgArchiveRepositoryInterface
+type MockOrgArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrgArchiveRepository) Snapshot(ctx context.Context, orgID string) (*OrgSnapshot, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*OrgSnapshot), args.Error(1)
+}
+
+func (m *MockOrgArchiveRepository) ListProjects(ctx context.Context, orgID string) ([]*domain.Project, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Project), args.Error(1)
+}
+
+func (m *MockOrgArchiveRepository) Restore(ctx context.Context, snapshot *OrgSnapshot, jobs []*domain.EmbeddingJob) error {
+	args := m.Called(ctx, snapshot, jobs)
+	return args.Error(0)
+}
+
+// MockArchiveStorage is a mock implementation of ArchiveStorage
+type MockArchiveStorage struct {
+	mock.Mock
+}
+
+func (m *MockArchiveStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	data := args.Get(0).([]byte)
+	return io.NopCloser(bytes.NewReader(data)), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockArchiveStorage) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	data, _ := io.ReadAll(body)
+	args := m.Called(ctx, key, contentType, data, size)
+	return args.Error(0)
+}
+
+func (m *MockArchiveStorage) DeleteObject(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// sequentialUUIDGenerator returns id-1, id-2, ... so that remapped IDs stay distinct
+type sequentialUUIDGenerator struct {
+	n int
+}
+
+func (g *sequentialUUIDGenerator) NewString() string {
+	g.n++
+	return fmt.Sprintf("id-%d", g.n)
+}
+
+const (
+	archiveSourceOrgID = "11111111-1111-1111-1111-111111111111"
+	archiveTargetOrgID = "22222222-2222-2222-2222-222222222222"
+	archiveProjectID   = "33333333-3333-3333-3333-333333333333"
+	archiveOldID       = "44444444-4444-4444-4444-444444444444"
+	archiveNewID       = "55555555-5555-5555-5555-555555555555"
+	archiveAssetID     = "66666666-6666-6666-6666-666666666666"
+)
+
+var archiveAssetData = []byte("%PDF-1.4 diagram")
+
+func newArchiveSnapshot() *OrgSnapshot {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sum := sha256.Sum256(archiveAssetData)
+
+	oldItem := domain.NewKnowledge(archiveOldID, archiveSourceOrgID, archiveProjectID, domain.KnowledgeTypeGuideline,
+		domain.KnowledgeStatusDeprecated, "Deploy on Fridays", "Old rule", "Ship on Fridays.\n", created, created)
+	oldItem.SupersededBy = archiveNewID
+	oldItem.CreatedBy = domain.Author{APIKeyID: "key-1", Name: "jane"}
+	oldItem.UpdatedBy = oldItem.CreatedBy
+
+	newItem := domain.NewKnowledge(archiveNewID, archiveSourceOrgID, "", domain.KnowledgeType("runbook"),
+		domain.KnowledgeStatusApproved, "Deploy on Tuesdays", "New rule", "Ship on Tuesdays.\n", created, created.Add(time.Hour))
+	newItem.Scope = "services/api/"
+	newItem.Tags = []string{"ci", "release"}
+	newItem.TemplateID = "77777777-7777-7777-7777-777777777777"
+	newItem.TemplateVersion = 2
+
+	return &OrgSnapshot{
+		OrgID:    archiveSourceOrgID,
+		Projects: []*domain.Project{domain.NewProject(archiveProjectID, archiveSourceOrgID, "platform", created)},
+		Types: []*domain.KnowledgeTypeDefinition{{
+			ID: "type-1", OrgID: archiveSourceOrgID, Name: "runbook", DisplayName: "Runbook", SearchBoost: 1.5,
+		}},
+		Knowledge: []*domain.Knowledge{oldItem, newItem},
+		Versions: []*domain.KnowledgeVersion{
+			{ID: "v-1", KnowledgeID: archiveOldID, VersionNumber: 1, Title: "Deploy on Fridays", BodyMD: "Ship on Fridays.\n", CreatedAt: created, CreatedBy: domain.Author{Name: "jane"}},
+			{ID: "v-2", KnowledgeID: archiveNewID, VersionNumber: 1, Title: "Deploy", BodyMD: "Ship.\n", CreatedAt: created},
+			{ID: "v-3", KnowledgeID: archiveNewID, VersionNumber: 2, Title: "Deploy on Tuesdays", Summary: "New rule", BodyMD: "Ship on Tuesdays.\n", CreatedAt: created.Add(time.Hour)},
+		},
+		Assets: []*domain.Asset{domain.NewAsset(archiveAssetID, archiveSourceOrgID, archiveProjectID, "diagram.pdf",
+			"application/pdf", hex.EncodeToString(sum[:]), archiveSourceOrgID+"/"+archiveAssetID+"/diagram.pdf",
+			[]string{"architecture"}, "System diagram", created)},
+		Links: []KnowledgeAssetLink{{KnowledgeID: archiveNewID, AssetID: archiveAssetID}},
+		Relations: []*domain.KnowledgeRelation{
+			domain.NewKnowledgeRelation("rel-1", archiveSourceOrgID, archiveNewID, archiveOldID, domain.RelationTypeRefines, created),
+		},
+		LintConfig: &domain.LintConfig{Mode: domain.LintModeReject},
+	}
+}
+
+func exportArchive(t *testing.T, storage ArchiveStorage) []byte {
+	t.Helper()
+	repo := new(MockOrgArchiveRepository)
+	repo.On("Snapshot", mock.Anything, archiveSourceOrgID).Return(newArchiveSnapshot(), nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewArchiveService(repo, storage).Export(context.Background(), archiveSourceOrgID, &buf))
+	return buf.Bytes()
+}
+
+func TestArchiveService_Export(t *testing.T) {
+	storage := new(MockArchiveStorage)
+	storage.On("GetObject", mock.Anything, archiveSourceOrgID+"/"+archiveAssetID+"/diagram.pdf").
+		Return(archiveAssetData, int64(len(archiveAssetData)), nil)
+
+	contents, err := readArchive(bytes.NewReader(exportArchive(t, storage)))
+	require.NoError(t, err)
+	defer contents.close()
+
+	manifest := contents.manifest
+	assert.Equal(t, archiveSourceOrgID, manifest.OrgID)
+	require.Len(t, manifest.Knowledge, 2)
+	assert.Equal(t, archiveNewID, manifest.Knowledge[0].SupersededBy)
+	assert.Equal(t, "jane", manifest.Knowledge[0].CreatedBy)
+	assert.Len(t, manifest.Knowledge[1].Versions, 2)
+	assert.Equal(t, "assets/"+archiveAssetID+"/diagram.pdf", manifest.Assets[0].Path)
+	assert.Equal(t, []KnowledgeAssetLink{{KnowledgeID: archiveNewID, AssetID: archiveAssetID}}, manifest.Links)
+	assert.Equal(t, "refines", manifest.Relations[0].Type)
+	assert.Equal(t, &domain.LintConfig{Mode: domain.LintModeReject}, manifest.LintConfig)
+
+	assert.Equal(t, "---\nid: "+archiveNewID+"\ntype: runbook\ntitle: Deploy on Tuesdays\nsummary: New rule\nscope: services/api/\ntags:\n    - ci\n    - release\nstatus: approved\nversion: 2\n---\n\nShip on Tuesdays.\n",
+		string(contents.files["knowledge/"+archiveNewID+".md"]))
+	assert.Contains(t, string(contents.files["knowledge/"+archiveNewID+"/v1.md"]), "title: Deploy\nversion: 1\n")
+	spooled := contents.assets["assets/"+archiveAssetID+"/diagram.pdf"]
+	require.NotNil(t, spooled)
+	data, err := os.ReadFile(spooled.path)
+	require.NoError(t, err)
+	assert.Equal(t, archiveAssetData, data)
+	assert.Equal(t, int64(len(archiveAssetData)), spooled.size)
+
+	contents.close()
+	_, err = os.Stat(spooled.path)
+	assert.True(t, os.IsNotExist(err), "closing removes spooled binaries")
+	storage.AssertExpectations(t)
+}
+
+func TestArchiveService_Restore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("restores into another org with remapped IDs and embedding jobs", func(t *testing.T) {
+		storage := new(MockArchiveStorage)
+		storage.On("GetObject", mock.Anything, mock.Anything).Return(archiveAssetData, int64(len(archiveAssetData)), nil)
+		archive := exportArchive(t, storage)
+
+		repo := new(MockOrgArchiveRepository)
+		repo.On("ListProjects", mock.Anything, archiveTargetOrgID).Return([]*domain.Project{}, nil)
+		var restored *OrgSnapshot
+		var jobs []*domain.EmbeddingJob
+		repo.On("Restore", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			restored = args.Get(1).(*OrgSnapshot)
+			jobs = args.Get(2).([]*domain.EmbeddingJob)
+		}).Return(nil)
+		storage.On("PutObject", mock.Anything, mock.Anything, "application/pdf", archiveAssetData, int64(len(archiveAssetData))).Return(nil)
+
+		service := NewArchiveServiceWithUUIDGen(repo, storage, &sequentialUUIDGenerator{})
+		result, err := service.Restore(ctx, RestoreInput{OrgID: archiveTargetOrgID, Archive: bytes.NewReader(archive), RemapIDs: true, QueueEmbeddings: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Projects)
+		assert.Equal(t, 2, result.Knowledge)
+		assert.Equal(t, 3, result.Versions)
+		assert.Equal(t, 1, result.Assets)
+		assert.Equal(t, 1, result.Links)
+		assert.Equal(t, 1, result.Relations)
+		assert.Equal(t, 3, result.EmbeddingJobs)
+		assert.Len(t, result.IDs, 4)
+
+		require.NotNil(t, restored)
+		assert.Equal(t, archiveTargetOrgID, restored.OrgID)
+		newProjectID := result.IDs[archiveProjectID]
+		assert.Equal(t, newProjectID, restored.Projects[0].ID)
+		assert.Equal(t, "platform", restored.Projects[0].Name)
+
+		oldItem, newItem := restored.Knowledge[0], restored.Knowledge[1]
+		assert.Equal(t, result.IDs[archiveOldID], oldItem.ID)
+		assert.Equal(t, archiveTargetOrgID, oldItem.OrgID)
+		assert.Equal(t, newProjectID, oldItem.ProjectID)
+		assert.Equal(t, domain.KnowledgeStatusDeprecated, oldItem.Status)
+		assert.Equal(t, newItem.ID, oldItem.SupersededBy)
+		assert.Equal(t, domain.Author{Name: "jane"}, oldItem.CreatedBy)
+		assert.Equal(t, []string{"ci", "release"}, newItem.Tags)
+		assert.Equal(t, "services/api/", newItem.Scope)
+		assert.Empty(t, newItem.TemplateID, "the template is not part of the archive")
+		assert.Zero(t, newItem.TemplateVersion)
+
+		assert.Equal(t, int64(2), restored.Versions[2].VersionNumber)
+		assert.Equal(t, newItem.ID, restored.Versions[2].KnowledgeID)
+		assert.Equal(t, "Ship on Tuesdays.\n", restored.Versions[2].BodyMD)
+
+		asset := restored.Assets[0]
+		assert.Equal(t, result.IDs[archiveAssetID], asset.ID)
+		assert.Equal(t, archiveTargetOrgID+"/"+asset.ID+"/diagram.pdf", asset.StorageKey)
+		assert.Equal(t, []KnowledgeAssetLink{{KnowledgeID: newItem.ID, AssetID: asset.ID}}, restored.Links)
+		assert.Equal(t, newItem.ID, restored.Relations[0].SourceID)
+		assert.Equal(t, oldItem.ID, restored.Relations[0].TargetID)
+		assert.Equal(t, &domain.LintConfig{Mode: domain.LintModeReject}, restored.LintConfig)
+		assert.Equal(t, asset.ID, jobs[2].AssetID)
+		storage.AssertCalled(t, "PutObject", mock.Anything, asset.StorageKey, "application/pdf", archiveAssetData, int64(len(archiveAssetData)))
+	})
+
+	t.Run("keeps IDs and reuses a project with the same name", func(t *testing.T) {
+		archive := exportArchive(t, nil)
+
+		repo := new(MockOrgArchiveRepository)
+		repo.On("ListProjects", mock.Anything, archiveTargetOrgID).Return([]*domain.Project{
+			domain.NewProject("88888888-8888-8888-8888-888888888888", archiveTargetOrgID, "platform", time.Now()),
+		}, nil)
+		repo.On("Restore", mock.Anything, mock.MatchedBy(func(s *OrgSnapshot) bool {
+			return len(s.Projects) == 0 &&
+				s.Knowledge[0].ID == archiveOldID &&
+				s.Knowledge[0].ProjectID == "88888888-8888-8888-8888-888888888888" &&
+				len(s.Assets) == 0 && len(s.Links) == 0
+		}), []*domain.EmbeddingJob(nil)).Return(nil)
+
+		result, err := NewArchiveService(repo, nil).Restore(ctx, RestoreInput{OrgID: archiveTargetOrgID, Archive: bytes.NewReader(archive)})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.SkippedAssets, "the binary was not exported without storage")
+		assert.Equal(t, map[string]string{archiveProjectID: "88888888-8888-8888-8888-888888888888"}, result.IDs)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("deletes uploaded binaries when the restore fails", func(t *testing.T) {
+		storage := new(MockArchiveStorage)
+		storage.On("GetObject", mock.Anything, mock.Anything).Return(archiveAssetData, int64(len(archiveAssetData)), nil)
+		archive := exportArchive(t, storage)
+
+		repo := new(MockOrgArchiveRepository)
+		repo.On("ListProjects", mock.Anything, archiveTargetOrgID).Return([]*domain.Project{}, nil)
+		repo.On("Restore", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrArchiveIDConflict)
+		key := archiveTargetOrgID + "/" + archiveAssetID + "/diagram.pdf"
+		storage.On("PutObject", mock.Anything, key, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		storage.On("DeleteObject", mock.Anything, key).Return(nil)
+
+		_, err := NewArchiveService(repo, storage).Restore(ctx, RestoreInput{OrgID: archiveTargetOrgID, Archive: bytes.NewReader(archive)})
+
+		require.ErrorIs(t, err, domain.ErrArchiveIDConflict)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("rejects a binary that does not match its checksum", func(t *testing.T) {
+		storage := new(MockArchiveStorage)
+		storage.On("GetObject", mock.Anything, mock.Anything).Return([]byte("tampered"), int64(8), nil)
+		archive := exportArchive(t, storage)
+
+		repo := new(MockOrgArchiveRepository)
+		repo.On("ListProjects", mock.Anything, archiveTargetOrgID).Return([]*domain.Project{}, nil)
+
+		_, err := NewArchiveService(repo, storage).Restore(ctx, RestoreInput{OrgID: archiveTargetOrgID, Archive: bytes.NewReader(archive)})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+		repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+		storage.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects data that is not an archive", func(t *testing.T) {
+		repo := new(MockOrgArchiveRepository)
+
+		_, err := NewArchiveService(repo, nil).Restore(ctx, RestoreInput{OrgID: archiveTargetOrgID, Archive: bytes.NewReader([]byte("not gzip"))})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	})
+
+	t.Run("returns not found for a malformed org ID", func(t *testing.T) {
+		_, err := NewArchiveService(new(MockOrgArchiveRepository), nil).Restore(ctx, RestoreInput{OrgID: "org", Archive: bytes.NewReader(nil)})
+		require.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+	})
+}
@@ -142,8 +142,17 @@ func (s *AuthService) CreateAPIKeyWithToken(ctx context.Context, orgID, name, to
 }
 
 func (s *AuthService) ValidateAPIKey(ctx context.Context, token string) (string, error) {
+	key, err := s.AuthenticateAPIKey(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return key.OrgID, nil
+}
+
+// AuthenticateAPIKey returns the active API key for a token, so callers can record which key acted
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, token string) (*domain.APIKey, error) {
 	if !IsValidAPIToken(token) {
-		return "", domain.ErrInvalidAPIKey
+		return nil, domain.ErrInvalidAPIKey
 	}
 
 	hash := hashToken(token)
@@ -151,16 +160,16 @@ func (s *AuthService) ValidateAPIKey(ctx context.Context, token string) (string,
 	key, err := s.keyRepo.GetByHash(ctx, hash)
 	if err != nil {
 		if err == domain.ErrAPIKeyNotFound {
-			return "", domain.ErrInvalidAPIKey
+			return nil, domain.ErrInvalidAPIKey
 		}
-		return "", err
+		return nil, err
 	}
 
 	if key.IsRevoked() {
-		return "", domain.ErrAPIKeyRevoked
+		return nil, domain.ErrAPIKeyRevoked
 	}
 
-	return key.OrgID, nil
+	return key, nil
 }
 
 func (s *AuthService) RevokeAPIKey(ctx context.Context, keyID string) error {
@@ -265,6 +265,29 @@ func TestAuthService_ValidateAPIKey_RevokedKey(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrAPIKeyRevoked)
 }
 
+func TestAuthService_AuthenticateAPIKey_ReturnsKey(t *testing.T) {
+	ctx := context.Background()
+	mockOrgRepo := new(MockOrgRepository)
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockUUIDGen := NewMockUUIDGenerator()
+
+	mockAPIKeyRepo.On("GetByHash", ctx, mock.Anything).Return(&domain.APIKey{
+		ID:        "key-123",
+		OrgID:     "org-123",
+		Name:      "ci-agent",
+		KeyHash:   "somehash",
+		CreatedAt: time.Now().UTC(),
+	}, nil)
+
+	service := NewAuthService(mockOrgRepo, mockAPIKeyRepo, mockUUIDGen)
+	key, err := service.AuthenticateAPIKey(ctx, "ntx_0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-123", key.ID)
+	assert.Equal(t, "ci-agent", key.Name)
+	assert.Equal(t, "org-123", key.OrgID)
+}
+
 func TestAuthService_RevokeAPIKey(t *testing.T) {
 	ctx := context.Background()
 	mockOrgRepo := new(MockOrgRepository)
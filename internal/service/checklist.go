@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// ChecklistRunRepositoryInterface defines the repository interface for checklist runs
+type ChecklistRunRepositoryInterface interface {
+	Create(ctx context.Context, run *domain.ChecklistRun) error
+	GetByID(ctx context.Context, id string) (*domain.ChecklistRun, error)
+	ListByKnowledge(ctx context.Context, knowledgeID string) ([]*domain.ChecklistRun, error)
+	UpdateItem(ctx context.Context, runID string, item *domain.ChecklistRunItem) error
+	Complete(ctx context.Context, run *domain.ChecklistRun) error
+}
+
+// ChecklistKnowledgeRepo provides knowledge lookups for the checklist service
+type ChecklistKnowledgeRepo interface {
+	GetByID(ctx context.Context, id string) (*domain.Knowledge, error)
+	GetVersion(ctx context.Context, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error)
+	GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error)
+}
+
+// ChecklistService parses checklist-type knowledge into items and tracks runs through them
+type ChecklistService struct {
+	runRepo       ChecklistRunRepositoryInterface
+	knowledgeRepo ChecklistKnowledgeRepo
+	uuidGen       UUIDGenerator
+}
+
+// NewChecklistService creates a new ChecklistService instance
+func NewChecklistService(runRepo ChecklistRunRepositoryInterface, knowledgeRepo ChecklistKnowledgeRepo) *ChecklistService {
+	return NewChecklistServiceWithUUIDGen(runRepo, knowledgeRepo, &DefaultUUIDGenerator{})
+}
+
+// NewChecklistServiceWithUUIDGen creates a new ChecklistService with custom UUID generator (for testing)
+func NewChecklistServiceWithUUIDGen(
+	runRepo ChecklistRunRepositoryInterface,
+	knowledgeRepo ChecklistKnowledgeRepo,
+	uuidGen UUIDGenerator,
+) *ChecklistService {
+	return &ChecklistService{
+		runRepo:       runRepo,
+		knowledgeRepo: knowledgeRepo,
+		uuidGen:       uuidGen,
+	}
+}
+
+// StartChecklistRunInput represents the input for starting a checklist run.
+// A zero Version starts the run from the latest version.
+type StartChecklistRunInput struct {
+	OrgID       string
+	KnowledgeID string
+	Version     int64
+	StartedBy   string
+	Note        string
+}
+
+// CheckChecklistItemInput represents the input for ticking off (or unticking) an item of a run
+type CheckChecklistItemInput struct {
+	OrgID   string
+	RunID   string
+	Number  int
+	Checked bool
+	By      string
+	Note    string
+}
+
+// CompleteChecklistRunInput represents the input for completing a checklist run
+type CompleteChecklistRunInput struct {
+	OrgID string
+	RunID string
+	By    string
+}
+
+// GetChecklist parses a version of a checklist-type knowledge item into its items.
+// A zero version parses the latest version.
+func (s *ChecklistService) GetChecklist(ctx context.Context, orgID, knowledgeID string, version int64) (*domain.Checklist, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.GetChecklist", telemetry.SpanAttributes{
+		OrgID:       orgID,
+		KnowledgeID: knowledgeID,
+		Operation:   "get_checklist",
+	})
+	defer span.End()
+
+	return s.getChecklist(ctx, orgID, knowledgeID, version)
+}
+
+// StartRun starts a run over the items of a checklist version
+func (s *ChecklistService) StartRun(ctx context.Context, input StartChecklistRunInput) (*domain.ChecklistRun, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.StartRun", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "start_checklist_run",
+	})
+	defer span.End()
+
+	if strings.TrimSpace(input.StartedBy) == "" {
+		return nil, domain.ErrActorRequired
+	}
+
+	checklist, err := s.getChecklist(ctx, input.OrgID, input.KnowledgeID, input.Version)
+	if err != nil {
+		return nil, err
+	}
+	if len(checklist.Items) == 0 {
+		return nil, domain.ErrChecklistEmpty
+	}
+
+	run := domain.NewChecklistRun(
+		s.uuidGen.NewString(),
+		input.OrgID,
+		checklist,
+		strings.TrimSpace(input.StartedBy),
+		input.Note,
+		time.Now().UTC(),
+	)
+	if err := domain.ValidateChecklistRun(run); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid checklist run", err)
+	}
+
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// GetRun returns a checklist run with its items
+func (s *ChecklistService) GetRun(ctx context.Context, orgID, runID string) (*domain.ChecklistRun, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.GetRun", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "get_checklist_run",
+	})
+	defer span.End()
+
+	return s.getOrgRun(ctx, orgID, runID)
+}
+
+// ListRuns returns the runs of a checklist, newest first
+func (s *ChecklistService) ListRuns(ctx context.Context, orgID, knowledgeID string) ([]*domain.ChecklistRun, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.ListRuns", telemetry.SpanAttributes{
+		OrgID:       orgID,
+		KnowledgeID: knowledgeID,
+		Operation:   "list_checklist_runs",
+	})
+	defer span.End()
+
+	if _, err := s.getOrgChecklist(ctx, orgID, knowledgeID); err != nil {
+		return nil, err
+	}
+
+	return s.runRepo.ListByKnowledge(ctx, knowledgeID)
+}
+
+// CheckItem ticks off an item of a run in progress, or unticks it when Checked is false
+func (s *ChecklistService) CheckItem(ctx context.Context, input CheckChecklistItemInput) (*domain.ChecklistRun, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.CheckItem", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "check_checklist_item",
+	})
+	defer span.End()
+
+	if input.Checked && strings.TrimSpace(input.By) == "" {
+		return nil, domain.ErrActorRequired
+	}
+
+	run, err := s.getOrgRun(ctx, input.OrgID, input.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if run.IsCompleted() {
+		return nil, domain.ErrChecklistRunCompleted
+	}
+
+	item := run.Item(input.Number)
+	if item == nil {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+
+	if input.Checked {
+		now := time.Now().UTC()
+		item.CheckedBy = strings.TrimSpace(input.By)
+		item.CheckedAt = &now
+	} else {
+		item.CheckedBy = ""
+		item.CheckedAt = nil
+	}
+	item.Note = input.Note
+
+	if err := s.runRepo.UpdateItem(ctx, run.ID, item); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// CompleteRun completes a run once every item has been checked
+func (s *ChecklistService) CompleteRun(ctx context.Context, input CompleteChecklistRunInput) (*domain.ChecklistRun, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ChecklistService.CompleteRun", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "complete_checklist_run",
+	})
+	defer span.End()
+
+	if strings.TrimSpace(input.By) == "" {
+		return nil, domain.ErrActorRequired
+	}
+
+	run, err := s.getOrgRun(ctx, input.OrgID, input.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if run.IsCompleted() {
+		return nil, domain.ErrChecklistRunCompleted
+	}
+	if unchecked := run.UncheckedItems(); len(unchecked) > 0 {
+		return nil, domain.NewChecklistRunIncompleteError(unchecked)
+	}
+
+	now := time.Now().UTC()
+	run.Status = domain.ChecklistRunStatusCompleted
+	run.CompletedBy = strings.TrimSpace(input.By)
+	run.CompletedAt = &now
+
+	if err := s.runRepo.Complete(ctx, run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *ChecklistService) getChecklist(ctx context.Context, orgID, knowledgeID string, version int64) (*domain.Checklist, error) {
+	knowledge, err := s.getOrgChecklist(ctx, orgID, knowledgeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *domain.KnowledgeVersion
+	if version > 0 {
+		v, err = s.knowledgeRepo.GetVersion(ctx, knowledge.ID, version)
+	} else {
+		v, err = s.knowledgeRepo.GetLatestVersion(ctx, knowledge.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := domain.ParseChecklistItems(v.BodyMD)
+	if items == nil {
+		items = []domain.ChecklistItem{}
+	}
+
+	return &domain.Checklist{
+		KnowledgeID:   knowledge.ID,
+		VersionNumber: v.VersionNumber,
+		Title:         v.Title,
+		Items:         items,
+	}, nil
+}
+
+// getOrgChecklist loads a checklist-type knowledge item, hiding items that belong to another organization
+func (s *ChecklistService) getOrgChecklist(ctx context.Context, orgID, id string) (*domain.Knowledge, error) {
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if knowledge.OrgID != orgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	if knowledge.Type != domain.KnowledgeTypeChecklist {
+		return nil, domain.ErrNotAChecklist
+	}
+	return knowledge, nil
+}
+
+// getOrgRun loads a checklist run, hiding runs that belong to another organization
+func (s *ChecklistService) getOrgRun(ctx context.Context, orgID, runID string) (*domain.ChecklistRun, error) {
+	if _, err := uuid.Parse(runID); err != nil {
+		return nil, domain.ErrChecklistRunNotFound
+	}
+
+	run, err := s.runRepo.GetByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.OrgID != orgID {
+		return nil, domain.ErrChecklistRunNotFound
+	}
+	return run, nil
+}
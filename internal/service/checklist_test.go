@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockChecklistRunRepository is a mock implementation of ChecklistRunRepositoryInterface
+type MockChecklistRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockChecklistRunRepository) Create(ctx context.Context, run *domain.ChecklistRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRunRepository) GetByID(ctx context.Context, id string) (*domain.ChecklistRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistRunRepository) ListByKnowledge(ctx context.Context, knowledgeID string) ([]*domain.ChecklistRun, error) {
+	args := m.Called(ctx, knowledgeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ChecklistRun), args.Error(1)
+}
+
+func (m *MockChecklistRunRepository) UpdateItem(ctx context.Context, runID string, item *domain.ChecklistRunItem) error {
+	args := m.Called(ctx, runID, item)
+	return args.Error(0)
+}
+
+func (m *MockChecklistRunRepository) Complete(ctx context.Context, run *domain.ChecklistRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+const (
+	checklistID    = "44444444-4444-4444-4444-444444444444"
+	checklistRunID = "55555555-5555-5555-5555-555555555555"
+)
+
+const releaseChecklistBody = "# Release\n\n## Before\n- [ ] Freeze main\n- [x] Bump version\n\n## After\n- [ ] Tag the release\n"
+
+func newReleaseChecklist() *domain.Knowledge {
+	return &domain.Knowledge{
+		ID:     checklistID,
+		OrgID:  "org-1",
+		Type:   domain.KnowledgeTypeChecklist,
+		Status: domain.KnowledgeStatusApproved,
+		Title:  "Release checklist",
+		BodyMD: releaseChecklistBody,
+	}
+}
+
+func newReleaseRun() *domain.ChecklistRun {
+	return domain.NewChecklistRun(checklistRunID, "org-1", &domain.Checklist{
+		KnowledgeID:   checklistID,
+		VersionNumber: 2,
+		Items:         domain.ParseChecklistItems(releaseChecklistBody),
+	}, "alice", "", time.Now().UTC())
+}
+
+func TestChecklistService_GetChecklist(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("parses the latest version", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistService(new(MockChecklistRunRepository), knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, checklistID).
+			Return(&domain.KnowledgeVersion{VersionNumber: 2, Title: "Release checklist", BodyMD: releaseChecklistBody}, nil)
+
+		checklist, err := svc.GetChecklist(ctx, "org-1", checklistID, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), checklist.VersionNumber)
+		assert.Equal(t, "Release checklist", checklist.Title)
+		require.Len(t, checklist.Items, 3)
+		assert.Equal(t, "Freeze main", checklist.Items[0].Text)
+		assert.Equal(t, "Before", checklist.Items[0].Section)
+		assert.True(t, checklist.Items[1].Checked)
+		assert.Equal(t, "After", checklist.Items[2].Section)
+	})
+
+	t.Run("parses a pinned version", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistService(new(MockChecklistRunRepository), knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+		knowledgeRepo.On("GetVersion", mock.Anything, checklistID, int64(1)).
+			Return(&domain.KnowledgeVersion{VersionNumber: 1, BodyMD: "- [ ] Ship it"}, nil)
+
+		checklist, err := svc.GetChecklist(ctx, "org-1", checklistID, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), checklist.VersionNumber)
+		assert.Equal(t, []domain.ChecklistItem{{Number: 1, Text: "Ship it"}}, checklist.Items)
+	})
+
+	t.Run("rejects items that are not checklists", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistService(new(MockChecklistRunRepository), knowledgeRepo)
+
+		guideline := newReleaseChecklist()
+		guideline.Type = domain.KnowledgeTypeGuideline
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(guideline, nil)
+
+		_, err := svc.GetChecklist(ctx, "org-1", checklistID, 0)
+
+		require.ErrorIs(t, err, domain.ErrNotAChecklist)
+	})
+
+	t.Run("hides checklists of other orgs", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistService(new(MockChecklistRunRepository), knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+
+		_, err := svc.GetChecklist(ctx, "org-2", checklistID, 0)
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+	})
+}
+
+func TestChecklistService_StartRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records the version the run was started from", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistServiceWithUUIDGen(runRepo, knowledgeRepo, NewMockUUIDGenerator(checklistRunID))
+
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, checklistID).
+			Return(&domain.KnowledgeVersion{VersionNumber: 2, BodyMD: releaseChecklistBody}, nil)
+		runRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.ChecklistRun) bool {
+			return r.ID == checklistRunID && r.VersionNumber == 2 && len(r.Items) == 3
+		})).Return(nil)
+
+		run, err := svc.StartRun(ctx, StartChecklistRunInput{
+			OrgID:       "org-1",
+			KnowledgeID: checklistID,
+			StartedBy:   " alice ",
+			Note:        "v1.2.0",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ChecklistRunStatusInProgress, run.Status)
+		assert.Equal(t, "alice", run.StartedBy)
+		assert.Equal(t, "v1.2.0", run.Note)
+		assert.Equal(t, []int{1, 2, 3}, run.UncheckedItems())
+		runRepo.AssertExpectations(t)
+	})
+
+	t.Run("requires the person starting the run", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		_, err := svc.StartRun(ctx, StartChecklistRunInput{OrgID: "org-1", KnowledgeID: checklistID})
+
+		require.ErrorIs(t, err, domain.ErrActorRequired)
+		runRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects checklists without items", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewChecklistService(runRepo, knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, checklistID).
+			Return(&domain.KnowledgeVersion{VersionNumber: 1, BodyMD: "Just prose"}, nil)
+
+		_, err := svc.StartRun(ctx, StartChecklistRunInput{OrgID: "org-1", KnowledgeID: checklistID, StartedBy: "alice"})
+
+		require.ErrorIs(t, err, domain.ErrChecklistEmpty)
+		runRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChecklistService_CheckItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ticks off an item", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(newReleaseRun(), nil)
+		runRepo.On("UpdateItem", mock.Anything, checklistRunID, mock.MatchedBy(func(item *domain.ChecklistRunItem) bool {
+			return item.Number == 2 && item.CheckedBy == "bob" && item.CheckedAt != nil && item.Note == "1.2.0"
+		})).Return(nil)
+
+		run, err := svc.CheckItem(ctx, CheckChecklistItemInput{
+			OrgID:   "org-1",
+			RunID:   checklistRunID,
+			Number:  2,
+			Checked: true,
+			By:      "bob",
+			Note:    "1.2.0",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, run.UncheckedItems())
+		runRepo.AssertExpectations(t)
+	})
+
+	t.Run("unticks an item", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		existing := newReleaseRun()
+		now := time.Now().UTC()
+		existing.Items[0].CheckedBy = "bob"
+		existing.Items[0].CheckedAt = &now
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(existing, nil)
+		runRepo.On("UpdateItem", mock.Anything, checklistRunID, mock.MatchedBy(func(item *domain.ChecklistRunItem) bool {
+			return item.Number == 1 && item.CheckedBy == "" && item.CheckedAt == nil
+		})).Return(nil)
+
+		run, err := svc.CheckItem(ctx, CheckChecklistItemInput{OrgID: "org-1", RunID: checklistRunID, Number: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, run.UncheckedItems())
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(newReleaseRun(), nil)
+
+		_, err := svc.CheckItem(ctx, CheckChecklistItemInput{OrgID: "org-1", RunID: checklistRunID, Number: 7, Checked: true, By: "bob"})
+
+		require.ErrorIs(t, err, domain.ErrChecklistItemNotFound)
+	})
+
+	t.Run("completed runs are read-only", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		completed := newReleaseRun()
+		completed.Status = domain.ChecklistRunStatusCompleted
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(completed, nil)
+
+		_, err := svc.CheckItem(ctx, CheckChecklistItemInput{OrgID: "org-1", RunID: checklistRunID, Number: 1, Checked: true, By: "bob"})
+
+		require.ErrorIs(t, err, domain.ErrChecklistRunCompleted)
+		runRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides runs of other orgs", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(newReleaseRun(), nil)
+
+		_, err := svc.CheckItem(ctx, CheckChecklistItemInput{OrgID: "org-2", RunID: checklistRunID, Number: 1, Checked: true, By: "bob"})
+
+		require.ErrorIs(t, err, domain.ErrChecklistRunNotFound)
+	})
+}
+
+func TestChecklistService_CompleteRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("completes a fully checked run", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		run := newReleaseRun()
+		now := time.Now().UTC()
+		for _, item := range run.Items {
+			item.CheckedBy = "bob"
+			item.CheckedAt = &now
+		}
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(run, nil)
+		runRepo.On("Complete", mock.Anything, mock.AnythingOfType("*domain.ChecklistRun")).Return(nil)
+
+		completed, err := svc.CompleteRun(ctx, CompleteChecklistRunInput{OrgID: "org-1", RunID: checklistRunID, By: "carol"})
+
+		require.NoError(t, err)
+		assert.True(t, completed.IsCompleted())
+		assert.Equal(t, "carol", completed.CompletedBy)
+		assert.NotNil(t, completed.CompletedAt)
+	})
+
+	t.Run("refuses runs with unchecked items", func(t *testing.T) {
+		runRepo := new(MockChecklistRunRepository)
+		svc := NewChecklistService(runRepo, new(MockKnowledgeRepository))
+
+		run := newReleaseRun()
+		now := time.Now().UTC()
+		run.Items[0].CheckedAt = &now
+		runRepo.On("GetByID", mock.Anything, checklistRunID).Return(run, nil)
+
+		_, err := svc.CompleteRun(ctx, CompleteChecklistRunInput{OrgID: "org-1", RunID: checklistRunID, By: "carol"})
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeInvalidOperation, domainErr.Code)
+		assert.Contains(t, err.Error(), "checklist items not checked: 2, 3")
+		runRepo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChecklistService_ListRuns(t *testing.T) {
+	ctx := context.Background()
+	runRepo := new(MockChecklistRunRepository)
+	knowledgeRepo := new(MockKnowledgeRepository)
+	svc := NewChecklistService(runRepo, knowledgeRepo)
+
+	knowledgeRepo.On("GetByID", mock.Anything, checklistID).Return(newReleaseChecklist(), nil)
+	runRepo.On("ListByKnowledge", mock.Anything, checklistID).Return([]*domain.ChecklistRun{newReleaseRun()}, nil)
+
+	runs, err := svc.ListRuns(ctx, "org-1", checklistID)
+
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, checklistRunID, runs[0].ID)
+
+	_, err = svc.GetRun(ctx, "org-1", "not-a-uuid")
+	require.ErrorIs(t, err, domain.ErrChecklistRunNotFound)
+}
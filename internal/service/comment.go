@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// Comment thread statuses accepted when listing comments
+const (
+	CommentStatusOpen     = "open"
+	CommentStatusResolved = "resolved"
+	CommentStatusAll      = "all"
+)
+
+// KnowledgeCommentRepositoryInterface defines the repository interface for knowledge comments
+type KnowledgeCommentRepositoryInterface interface {
+	Create(ctx context.Context, comment *domain.KnowledgeComment) error
+	GetByID(ctx context.Context, id string) (*domain.KnowledgeComment, error)
+	ListByKnowledge(ctx context.Context, knowledgeID string, resolved *bool) ([]*domain.KnowledgeComment, error)
+	UpdateResolution(ctx context.Context, comment *domain.KnowledgeComment) error
+	Delete(ctx context.Context, id string) error
+}
+
+// CommentKnowledgeRepo provides knowledge lookups for the comment service
+type CommentKnowledgeRepo interface {
+	GetByID(ctx context.Context, id string) (*domain.Knowledge, error)
+	GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error)
+}
+
+// CommentChunkRepo provides the chunks a thread can be anchored to
+type CommentChunkRepo interface {
+	GetByKnowledgeID(ctx context.Context, knowledgeID string) ([]*domain.KnowledgeChunk, error)
+}
+
+// CommentService manages discussion threads on knowledge items
+type CommentService struct {
+	commentRepo   KnowledgeCommentRepositoryInterface
+	knowledgeRepo CommentKnowledgeRepo
+	chunkRepo     CommentChunkRepo
+	uuidGen       UUIDGenerator
+}
+
+// NewCommentService creates a new CommentService instance
+func NewCommentService(
+	commentRepo KnowledgeCommentRepositoryInterface,
+	knowledgeRepo CommentKnowledgeRepo,
+	chunkRepo CommentChunkRepo,
+) *CommentService {
+	return NewCommentServiceWithUUIDGen(commentRepo, knowledgeRepo, chunkRepo, &DefaultUUIDGenerator{})
+}
+
+// NewCommentServiceWithUUIDGen creates a new CommentService with custom UUID generator (for testing)
+func NewCommentServiceWithUUIDGen(
+	commentRepo KnowledgeCommentRepositoryInterface,
+	knowledgeRepo CommentKnowledgeRepo,
+	chunkRepo CommentChunkRepo,
+	uuidGen UUIDGenerator,
+) *CommentService {
+	return &CommentService{
+		commentRepo:   commentRepo,
+		knowledgeRepo: knowledgeRepo,
+		chunkRepo:     chunkRepo,
+		uuidGen:       uuidGen,
+	}
+}
+
+// CreateCommentInput represents the input for commenting on a knowledge item.
+// Without a ParentID the comment opens a thread, optionally anchored to body lines
+// [LineStart, LineEnd) of the latest version or to one of its chunks. Replying to a
+// reply adds to the same thread.
+type CreateCommentInput struct {
+	OrgID       string
+	KnowledgeID string
+	ParentID    string
+	Body        string
+	LineStart   int
+	LineEnd     int
+	ChunkID     string
+	Author      domain.Author
+}
+
+// ListCommentsInput represents the input for listing the threads on a knowledge item.
+// Status is open, resolved or all; it defaults to open.
+type ListCommentsInput struct {
+	OrgID       string
+	KnowledgeID string
+	Status      string
+}
+
+// ResolveCommentInput represents the input for resolving a thread, or reopening it when Resolved is false
+type ResolveCommentInput struct {
+	OrgID     string
+	CommentID string
+	Resolved  bool
+	Author    domain.Author
+}
+
+// DeleteCommentInput represents the input for deleting a comment
+type DeleteCommentInput struct {
+	OrgID     string
+	CommentID string
+}
+
+// Create adds a comment to a knowledge item
+func (s *CommentService) Create(ctx context.Context, input CreateCommentInput) (*domain.KnowledgeComment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CommentService.Create", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "create_comment",
+	})
+	defer span.End()
+
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, domain.ErrCommentBodyRequired
+	}
+	if len(body) > domain.MaxCommentBodyLength {
+		return nil, domain.ErrCommentTooLong
+	}
+
+	knowledge, err := s.getOrgKnowledge(ctx, input.OrgID, input.KnowledgeID)
+	if err != nil {
+		return nil, err
+	}
+
+	anchored := input.LineStart != 0 || input.LineEnd != 0 || input.ChunkID != ""
+	parentID := ""
+	if input.ParentID != "" {
+		if anchored {
+			return nil, domain.ErrCommentIsReply
+		}
+		parent, err := s.getOrgComment(ctx, input.OrgID, input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.KnowledgeID != knowledge.ID {
+			return nil, domain.ErrCommentNotFound
+		}
+		parentID = parent.ID
+		if parent.IsReply() {
+			parentID = parent.ParentID
+		}
+	}
+
+	if input.LineStart != 0 || input.LineEnd != 0 {
+		if input.LineStart < 0 || input.LineEnd <= input.LineStart || input.LineEnd > countLines(knowledge.BodyMD) {
+			return nil, domain.ErrInvalidCommentAnchor
+		}
+	}
+	if input.ChunkID != "" {
+		if err := s.checkChunkAnchor(ctx, knowledge.ID, input.ChunkID); err != nil {
+			return nil, err
+		}
+	}
+
+	version, err := s.knowledgeRepo.GetLatestVersion(ctx, knowledge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := domain.NewKnowledgeComment(
+		s.uuidGen.NewString(),
+		input.OrgID,
+		knowledge.ID,
+		parentID,
+		version.VersionNumber,
+		body,
+		input.Author,
+		time.Now().UTC(),
+	)
+	comment.LineStart = input.LineStart
+	comment.LineEnd = input.LineEnd
+	comment.ChunkID = input.ChunkID
+	if err := domain.ValidateKnowledgeComment(comment); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid comment", err)
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// List returns the threads on a knowledge item with their replies, oldest first
+func (s *CommentService) List(ctx context.Context, input ListCommentsInput) ([]*domain.CommentThread, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CommentService.List", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "list_comments",
+	})
+	defer span.End()
+
+	resolved, err := parseCommentStatus(input.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOrgKnowledge(ctx, input.OrgID, input.KnowledgeID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListByKnowledge(ctx, input.KnowledgeID, resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.GroupCommentThreads(comments), nil
+}
+
+// Resolve resolves the thread opened by a comment, or reopens it when Resolved is false
+func (s *CommentService) Resolve(ctx context.Context, input ResolveCommentInput) (*domain.KnowledgeComment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "CommentService.Resolve", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "resolve_comment",
+	})
+	defer span.End()
+
+	comment, err := s.getOrgComment(ctx, input.OrgID, input.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsReply() {
+		return nil, domain.ErrCommentIsReply
+	}
+
+	if input.Resolved {
+		now := time.Now().UTC()
+		comment.ResolvedBy = input.Author
+		comment.ResolvedAt = &now
+	} else {
+		comment.ResolvedBy = domain.Author{}
+		comment.ResolvedAt = nil
+	}
+
+	if err := s.commentRepo.UpdateResolution(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Delete removes a comment; deleting the comment that opened a thread removes the whole thread
+func (s *CommentService) Delete(ctx context.Context, input DeleteCommentInput) error {
+	ctx, span := telemetry.StartSpan(ctx, "CommentService.Delete", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "delete_comment",
+	})
+	defer span.End()
+
+	comment, err := s.getOrgComment(ctx, input.OrgID, input.CommentID)
+	if err != nil {
+		return err
+	}
+
+	return s.commentRepo.Delete(ctx, comment.ID)
+}
+
+// checkChunkAnchor verifies that a chunk belongs to the current chunks of the item
+func (s *CommentService) checkChunkAnchor(ctx context.Context, knowledgeID, chunkID string) error {
+	if s.chunkRepo == nil {
+		return domain.ErrInvalidCommentAnchor
+	}
+	chunks, err := s.chunkRepo.GetByKnowledgeID(ctx, knowledgeID)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if chunk.ID == chunkID {
+			return nil
+		}
+	}
+	return domain.ErrInvalidCommentAnchor
+}
+
+// getOrgKnowledge loads a knowledge item, hiding items that belong to another organization
+func (s *CommentService) getOrgKnowledge(ctx context.Context, orgID, id string) (*domain.Knowledge, error) {
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if knowledge.OrgID != orgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	return knowledge, nil
+}
+
+// getOrgComment loads a comment, hiding comments that belong to another organization
+func (s *CommentService) getOrgComment(ctx context.Context, orgID, id string) (*domain.KnowledgeComment, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.OrgID != orgID {
+		return nil, domain.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// parseCommentStatus maps a thread status to the resolved filter of the repository
+func parseCommentStatus(status string) (*bool, error) {
+	var resolved bool
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "", CommentStatusOpen:
+		resolved = false
+	case CommentStatusResolved:
+		resolved = true
+	case CommentStatusAll:
+		return nil, nil
+	default:
+		return nil, domain.ErrInvalidCommentStatus
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKnowledgeCommentRepository is a mock implementation of KnowledgeCommentRepositoryInterface
+type MockKnowledgeCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockKnowledgeCommentRepository) Create(ctx context.Context, comment *domain.KnowledgeComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgeCommentRepository) GetByID(ctx context.Context, id string) (*domain.KnowledgeComment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeComment), args.Error(1)
+}
+
+func (m *MockKnowledgeCommentRepository) ListByKnowledge(ctx context.Context, knowledgeID string, resolved *bool) ([]*domain.KnowledgeComment, error) {
+	args := m.Called(ctx, knowledgeID, resolved)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeComment), args.Error(1)
+}
+
+func (m *MockKnowledgeCommentRepository) UpdateResolution(ctx context.Context, comment *domain.KnowledgeComment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgeCommentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+const (
+	commentedKnowledgeID = "88888888-8888-8888-8888-888888888888"
+	threadID             = "99999999-9999-9999-9999-999999999999"
+	replyID              = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+)
+
+func newCommentedKnowledge() *domain.Knowledge {
+	return &domain.Knowledge{
+		ID:     commentedKnowledgeID,
+		OrgID:  "org-1",
+		Type:   domain.KnowledgeTypeGuideline,
+		Status: domain.KnowledgeStatusApproved,
+		Title:  "Retry policy",
+		BodyMD: "# Retry policy\n\nRetry three times.\nWait 30 seconds between attempts.\n",
+	}
+}
+
+func newThread() *domain.KnowledgeComment {
+	return domain.NewKnowledgeComment(threadID, "org-1", commentedKnowledgeID, "", 2, "Why 30 seconds?", domain.Author{Name: "alice"}, time.Now().UTC())
+}
+
+func newReply() *domain.KnowledgeComment {
+	return domain.NewKnowledgeComment(replyID, "org-1", commentedKnowledgeID, threadID, 2, "Load balancer timeout", domain.Author{Name: "bob"}, time.Now().UTC())
+}
+
+func TestCommentService_Create(t *testing.T) {
+	ctx := context.Background()
+	author := domain.Author{APIKeyID: "key-1", Name: "alice"}
+
+	t.Run("opens a thread anchored to a line range", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentServiceWithUUIDGen(commentRepo, knowledgeRepo, new(MockVFSChunkRepo), NewMockUUIDGenerator("comment-1"))
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, commentedKnowledgeID).Return(&domain.KnowledgeVersion{VersionNumber: 2}, nil)
+		commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.KnowledgeComment) bool {
+			return c.ID == "comment-1" &&
+				c.OrgID == "org-1" &&
+				c.VersionNumber == 2 &&
+				c.Body == "Why 30 seconds?" &&
+				c.Author == author &&
+				c.LineStart == 3 && c.LineEnd == 4
+		})).Return(nil)
+
+		comment, err := svc.Create(ctx, CreateCommentInput{
+			OrgID:       "org-1",
+			KnowledgeID: commentedKnowledgeID,
+			Body:        "  Why 30 seconds?\n",
+			LineStart:   3,
+			LineEnd:     4,
+			Author:      author,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, comment.IsReply())
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("anchors to a chunk of the item", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		chunkRepo := new(MockVFSChunkRepo)
+		svc := NewCommentServiceWithUUIDGen(commentRepo, knowledgeRepo, chunkRepo, NewMockUUIDGenerator("comment-1"))
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, commentedKnowledgeID).Return(&domain.KnowledgeVersion{VersionNumber: 2}, nil)
+		chunkRepo.On("GetByKnowledgeID", mock.Anything, commentedKnowledgeID).Return([]*domain.KnowledgeChunk{{ID: "chunk-1"}, {ID: "chunk-2"}}, nil)
+		commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.KnowledgeComment) bool {
+			return c.ChunkID == "chunk-2"
+		})).Return(nil)
+
+		_, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Body: "Typo", ChunkID: "chunk-2"})
+
+		require.NoError(t, err)
+
+		_, err = svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Body: "Typo", ChunkID: "chunk-9"})
+		require.ErrorIs(t, err, domain.ErrInvalidCommentAnchor)
+	})
+
+	t.Run("adds a reply to a reply to the same thread", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentServiceWithUUIDGen(commentRepo, knowledgeRepo, nil, NewMockUUIDGenerator("comment-3"))
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, commentedKnowledgeID).Return(&domain.KnowledgeVersion{VersionNumber: 2}, nil)
+		commentRepo.On("GetByID", mock.Anything, replyID).Return(newReply(), nil)
+		commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.KnowledgeComment) bool {
+			return c.ParentID == threadID
+		})).Return(nil)
+
+		comment, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, ParentID: replyID, Body: "Thanks"})
+
+		require.NoError(t, err)
+		assert.True(t, comment.IsReply())
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects anchored replies", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(commentRepo, knowledgeRepo, nil)
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+
+		_, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, ParentID: threadID, Body: "Here", LineEnd: 2})
+
+		require.ErrorIs(t, err, domain.ErrCommentIsReply)
+		commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a parent on another item", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(commentRepo, knowledgeRepo, nil)
+
+		parent := newThread()
+		parent.KnowledgeID = checklistID
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		commentRepo.On("GetByID", mock.Anything, threadID).Return(parent, nil)
+
+		_, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, ParentID: threadID, Body: "Here"})
+
+		require.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+
+	t.Run("rejects line ranges outside the body", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(new(MockKnowledgeCommentRepository), knowledgeRepo, nil)
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+
+		for _, lines := range [][2]int{{4, 9}, {3, 3}, {-1, 2}} {
+			_, err := svc.Create(ctx, CreateCommentInput{
+				OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Body: "Here", LineStart: lines[0], LineEnd: lines[1],
+			})
+			require.ErrorIs(t, err, domain.ErrInvalidCommentAnchor, "lines %v", lines)
+		}
+	})
+
+	t.Run("requires a body", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(new(MockKnowledgeCommentRepository), knowledgeRepo, nil)
+
+		_, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Body: " \n "})
+
+		require.ErrorIs(t, err, domain.ErrCommentBodyRequired)
+		knowledgeRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides items of another organization", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(new(MockKnowledgeCommentRepository), knowledgeRepo, nil)
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+
+		_, err := svc.Create(ctx, CreateCommentInput{OrgID: "org-2", KnowledgeID: commentedKnowledgeID, Body: "Here"})
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+	})
+}
+
+func TestCommentService_List(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("groups open threads with their replies", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(commentRepo, knowledgeRepo, nil)
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		commentRepo.On("ListByKnowledge", mock.Anything, commentedKnowledgeID, mock.MatchedBy(func(resolved *bool) bool {
+			return resolved != nil && !*resolved
+		})).Return([]*domain.KnowledgeComment{newThread(), newReply()}, nil)
+
+		threads, err := svc.List(ctx, ListCommentsInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID})
+
+		require.NoError(t, err)
+		require.Len(t, threads, 1)
+		assert.Equal(t, threadID, threads[0].ID)
+		require.Len(t, threads[0].Replies, 1)
+		assert.Equal(t, replyID, threads[0].Replies[0].ID)
+	})
+
+	t.Run("lists all threads", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		svc := NewCommentService(commentRepo, knowledgeRepo, nil)
+
+		knowledgeRepo.On("GetByID", mock.Anything, commentedKnowledgeID).Return(newCommentedKnowledge(), nil)
+		commentRepo.On("ListByKnowledge", mock.Anything, commentedKnowledgeID, (*bool)(nil)).Return([]*domain.KnowledgeComment{}, nil)
+
+		threads, err := svc.List(ctx, ListCommentsInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Status: "all"})
+
+		require.NoError(t, err)
+		assert.Empty(t, threads)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects unknown statuses", func(t *testing.T) {
+		svc := NewCommentService(new(MockKnowledgeCommentRepository), new(MockKnowledgeRepository), nil)
+
+		_, err := svc.List(ctx, ListCommentsInput{OrgID: "org-1", KnowledgeID: commentedKnowledgeID, Status: "closed"})
+
+		require.ErrorIs(t, err, domain.ErrInvalidCommentStatus)
+	})
+}
+
+func TestCommentService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	author := domain.Author{APIKeyID: "key-1", Name: "carol"}
+
+	t.Run("resolves and reopens a thread", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		svc := NewCommentService(commentRepo, new(MockKnowledgeRepository), nil)
+
+		commentRepo.On("GetByID", mock.Anything, threadID).Return(newThread(), nil)
+		commentRepo.On("UpdateResolution", mock.Anything, mock.Anything).Return(nil)
+
+		comment, err := svc.Resolve(ctx, ResolveCommentInput{OrgID: "org-1", CommentID: threadID, Resolved: true, Author: author})
+
+		require.NoError(t, err)
+		assert.True(t, comment.IsResolved())
+		assert.Equal(t, author, comment.ResolvedBy)
+
+		comment, err = svc.Resolve(ctx, ResolveCommentInput{OrgID: "org-1", CommentID: threadID, Resolved: false, Author: author})
+
+		require.NoError(t, err)
+		assert.False(t, comment.IsResolved())
+		assert.True(t, comment.ResolvedBy.IsZero())
+	})
+
+	t.Run("rejects replies", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		svc := NewCommentService(commentRepo, new(MockKnowledgeRepository), nil)
+
+		commentRepo.On("GetByID", mock.Anything, replyID).Return(newReply(), nil)
+
+		_, err := svc.Resolve(ctx, ResolveCommentInput{OrgID: "org-1", CommentID: replyID, Resolved: true, Author: author})
+
+		require.ErrorIs(t, err, domain.ErrCommentIsReply)
+		commentRepo.AssertNotCalled(t, "UpdateResolution", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides comments of another organization", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		svc := NewCommentService(commentRepo, new(MockKnowledgeRepository), nil)
+
+		commentRepo.On("GetByID", mock.Anything, threadID).Return(newThread(), nil)
+
+		_, err := svc.Resolve(ctx, ResolveCommentInput{OrgID: "org-2", CommentID: threadID, Resolved: true})
+
+		require.ErrorIs(t, err, domain.ErrCommentNotFound)
+	})
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes a comment", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		svc := NewCommentService(commentRepo, new(MockKnowledgeRepository), nil)
+
+		commentRepo.On("GetByID", mock.Anything, threadID).Return(newThread(), nil)
+		commentRepo.On("Delete", mock.Anything, threadID).Return(nil)
+
+		require.NoError(t, svc.Delete(ctx, DeleteCommentInput{OrgID: "org-1", CommentID: threadID}))
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for a malformed ID", func(t *testing.T) {
+		commentRepo := new(MockKnowledgeCommentRepository)
+		svc := NewCommentService(commentRepo, new(MockKnowledgeRepository), nil)
+
+		err := svc.Delete(ctx, DeleteCommentInput{OrgID: "org-1", CommentID: "not-a-uuid"})
+
+		require.ErrorIs(t, err, domain.ErrCommentNotFound)
+		commentRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
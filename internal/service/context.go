@@ -19,7 +19,9 @@ type KnowledgeManifestItem struct {
 	Title   string
 	Summary string
 	Type    domain.KnowledgeType
-	Scope   string
+	// TypeName is the display name of the type from the org registry
+	TypeName string
+	Scope    string
 }
 
 // SearchFilters represents filters for knowledge search
@@ -31,8 +33,26 @@ type SearchFilters struct {
 	PathPrefix string
 	// SourceType filters results to "knowledge" or "asset"
 	SourceType string
+	// Tags filters knowledge by tag and assets by keyword; TagMatch decides whether any or all must match
+	Tags     []string
+	TagMatch TagMatch
+	// Language limits results to knowledge in one code language; assets have none and are left out
+	Language string
+	// Author limits results to knowledge created or last updated by this author name, case-insensitively;
+	// assets have no author and are left out
+	Author string
+	// DemoteStale lowers the score of knowledge flagged as overdue for review instead of filtering it out
+	DemoteStale bool
 }
 
+// TagMatch controls how multiple tag filters combine
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
 // SearchMode controls retrieval strategy.
 type SearchMode string
 
@@ -57,6 +77,16 @@ type SearchResult struct {
 	ChunkID string
 	// ChunkIndex is the position within the knowledge item (-1 if not applicable)
 	ChunkIndex int
+	// SupersededBy is set when the item was deprecated in favor of another one
+	SupersededBy *SupersessionRef
+	// Replaces is the ID of the superseded item this result was substituted for
+	Replaces string
+	// Related holds one-hop neighbours when relation expansion was requested
+	Related []*RelatedKnowledge
+	// Stale is set when the knowledge item is overdue for review
+	Stale bool
+	// TypeBoost is the search boost of the item's knowledge type in the org registry
+	TypeBoost float32
 }
 
 // ChunkSearchResult represents a chunk-level knowledge hit.
@@ -70,6 +100,8 @@ type ChunkSearchResult struct {
 	Content     string
 	UpdatedAt   time.Time
 	Score       float32
+	Stale       bool
+	TypeBoost   float32
 }
 
 // SearchInput represents input for search operation
@@ -80,6 +112,10 @@ type SearchInput struct {
 	Exact   bool
 	Limit   int
 	Cursor  string
+	// SubstituteSuperseded replaces superseded results with their successor
+	SubstituteSuperseded bool
+	// ExpandRelations attaches each knowledge hit's directly related items
+	ExpandRelations bool
 }
 
 // SearchOutput represents output from search operation
@@ -119,6 +155,7 @@ type ContextRepositoryInterface interface {
 	SearchAssetsLexical(ctx context.Context, query string, filters SearchFilters, limit int) ([]*SearchResult, error)
 	GetByIDs(ctx context.Context, ids []string) ([]*domain.Knowledge, error)
 	GetAssetsByIDs(ctx context.Context, ids []string) ([]*domain.Asset, error)
+	ListRelated(ctx context.Context, knowledgeIDs []string) ([]*RelatedKnowledge, error)
 }
 
 // EmbeddingServiceInterface defines the interface for embedding generation
@@ -131,6 +168,7 @@ type ContextService struct {
 	repo      ContextRepositoryInterface
 	embedding EmbeddingServiceInterface
 	cfg       ContextServiceConfig
+	types     KnowledgeTypeResolver
 }
 
 // AgenticSearchConfig controls iterative search behavior.
@@ -171,11 +209,23 @@ func NewContextServiceWithConfig(
 	repo ContextRepositoryInterface,
 	embedding EmbeddingServiceInterface,
 	cfg ContextServiceConfig,
+) *ContextService {
+	return NewContextServiceWithTypes(repo, embedding, cfg, nil)
+}
+
+// NewContextServiceWithTypes creates a new ContextService that checks type filters against
+// the org type registry.
+func NewContextServiceWithTypes(
+	repo ContextRepositoryInterface,
+	embedding EmbeddingServiceInterface,
+	cfg ContextServiceConfig,
+	types KnowledgeTypeResolver,
 ) *ContextService {
 	return &ContextService{
 		repo:      repo,
 		embedding: embedding,
 		cfg:       cfg,
+		types:     types,
 	}
 }
 
@@ -202,6 +252,19 @@ func (s *ContextService) Search(ctx context.Context, input SearchInput) (*Search
 
 	input.Mode = normalizeSearchMode(input.Mode)
 	input.Filters.SourceType = normalizeSourceTypeFilter(input.Filters.SourceType)
+	input.Filters.Tags = domain.NormalizeTags(input.Filters.Tags)
+	input.Filters.Language = domain.NormalizeLanguage(input.Filters.Language)
+	input.Filters.Author = domain.NormalizeAuthorName(input.Filters.Author)
+	if !domain.IsValidLanguage(input.Filters.Language) {
+		return nil, domain.ErrInvalidLanguage
+	}
+
+	// An unknown type would silently match nothing
+	if input.Filters.Type != "" && s.types != nil {
+		if _, err := s.types.Resolve(ctx, input.Filters.OrgID, input.Filters.Type); err != nil {
+			return nil, err
+		}
+	}
 
 	limit := input.Limit
 	if limit <= 0 {
@@ -223,16 +286,26 @@ func (s *ContextService) Search(ctx context.Context, input SearchInput) (*Search
 		return nil, err
 	}
 
-	if !s.shouldAgentic(input, results, fetchLimit) {
-		return s.buildSearchOutput(results, offset, limit), nil
+	if s.shouldAgentic(input, results, fetchLimit) {
+		results, err = s.agenticSearch(ctx, input, results, fetchLimit)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	allResults, err := s.agenticSearch(ctx, input, results, fetchLimit)
+	output := s.buildSearchOutput(results, offset, limit)
+	output.Results, err = annotateSuperseded(ctx, s.repo.GetByIDs, output.Results, input.SubstituteSuperseded)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildSearchOutput(allResults, offset, limit), nil
+	if input.ExpandRelations {
+		if err := attachRelated(ctx, s.repo.ListRelated, output.Results); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
 }
 
 func (s *ContextService) buildSearchOutput(results []*SearchResult, offset, limit int) *SearchOutput {
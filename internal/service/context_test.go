@@ -88,6 +88,14 @@ func (m *MockContextRepository) GetAssetsByIDs(ctx context.Context, ids []string
 	return args.Get(0).([]*domain.Asset), args.Error(1)
 }
 
+func (m *MockContextRepository) ListRelated(ctx context.Context, knowledgeIDs []string) ([]*RelatedKnowledge, error) {
+	args := m.Called(ctx, knowledgeIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*RelatedKnowledge), args.Error(1)
+}
+
 // MockEmbeddingService is a mock implementation of EmbeddingServiceInterface
 type MockEmbeddingService struct {
 	mock.Mock
@@ -207,6 +215,7 @@ func TestContextService_GetManifest(t *testing.T) {
 		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return(initialResults, nil)
 		mockEmbedding.On("GenerateEmbedding", mock.Anything, "auth").Return(expandedEmbedding, nil)
 		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, expandedEmbedding, filters, mock.Anything).Return(expandedResults, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k2", "k1"}).Return([]*domain.Knowledge{{ID: "k2"}, {ID: "k1"}}, nil)
 
 		input := SearchInput{
 			Query:   "auth and tokens",
@@ -252,6 +261,7 @@ func TestContextService_Search(t *testing.T) {
 
 		mockEmbedding.On("GenerateEmbedding", mock.Anything, "how to code").Return(queryEmbedding, nil)
 		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return(expectedResults, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k1", "k2"}).Return([]*domain.Knowledge{{ID: "k1"}, {ID: "k2"}}, nil)
 
 		input := SearchInput{
 			Query:   "how to code",
@@ -313,6 +323,7 @@ func TestContextService_Search(t *testing.T) {
 
 		mockEmbedding.On("GenerateEmbedding", mock.Anything, "api design").Return(queryEmbedding, nil)
 		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return(expectedResults, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k1"}).Return([]*domain.Knowledge{{ID: "k1"}}, nil)
 
 		input := SearchInput{
 			Query:   "api design",
@@ -327,6 +338,277 @@ func TestContextService_Search(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("annotates superseded results with their successor", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "old", Title: "Deploy with Jenkins", Score: 0.9},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"old"}).Return([]*domain.Knowledge{
+			{ID: "old", Status: domain.KnowledgeStatusDeprecated, SupersededBy: "mid"},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"mid"}).Return([]*domain.Knowledge{
+			{ID: "mid", Status: domain.KnowledgeStatusDeprecated, SupersededBy: "new"},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"new"}).Return([]*domain.Knowledge{
+			{ID: "new", Title: "Deploy with Actions", Status: domain.KnowledgeStatusApproved},
+		}, nil)
+
+		result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, "old", result.Results[0].ID)
+		require.NotNil(t, result.Results[0].SupersededBy)
+		assert.Equal(t, "new", result.Results[0].SupersededBy.ID)
+		assert.Equal(t, "Deploy with Actions", result.Results[0].SupersededBy.Title)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("substitutes superseded results when requested", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "old", Title: "Deploy with Jenkins", Score: 0.9},
+			{KnowledgeID: "other", Title: "Rollbacks", Score: 0.8},
+			{KnowledgeID: "older", Title: "Deploy by hand", Score: 0.7},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"old", "other", "older"}).Return([]*domain.Knowledge{
+			{ID: "old", Status: domain.KnowledgeStatusDeprecated, SupersededBy: "new"},
+			{ID: "other", Status: domain.KnowledgeStatusApproved},
+			{ID: "older", Status: domain.KnowledgeStatusDeprecated, SupersededBy: "new"},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"new"}).Return([]*domain.Knowledge{
+			{ID: "new", Title: "Deploy with Actions", Summary: "Use the deploy workflow", Status: domain.KnowledgeStatusApproved},
+		}, nil)
+
+		result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic, SubstituteSuperseded: true})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "new", result.Results[0].ID)
+		assert.Equal(t, "Deploy with Actions", result.Results[0].Title)
+		assert.Equal(t, "old", result.Results[0].Replaces)
+		assert.Nil(t, result.Results[0].SupersededBy)
+		assert.Equal(t, "other", result.Results[1].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("does not substitute successors awaiting review", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "old", Title: "Deploy with Jenkins", Score: 0.9},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"old"}).Return([]*domain.Knowledge{
+			{ID: "old", Status: domain.KnowledgeStatusDeprecated, SupersededBy: "new"},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"new"}).Return([]*domain.Knowledge{
+			{ID: "new", Title: "Deploy with Actions", Status: domain.KnowledgeStatusDraft},
+		}, nil)
+
+		result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic, SubstituteSuperseded: true})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, "old", result.Results[0].ID)
+		assert.Empty(t, result.Results[0].Replaces)
+		require.NotNil(t, result.Results[0].SupersededBy)
+		assert.Equal(t, "new", result.Results[0].SupersededBy.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expands knowledge results with related items", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "k1", Title: "Deploy guideline", Score: 0.9},
+			{KnowledgeID: "k2", Title: "Rollbacks", Score: 0.8},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k1", "k2"}).Return([]*domain.Knowledge{
+			{ID: "k1", Status: domain.KnowledgeStatusApproved},
+			{ID: "k2", Status: domain.KnowledgeStatusApproved},
+		}, nil)
+		mockRepo.On("ListRelated", mock.Anything, []string{"k1", "k2"}).Return([]*RelatedKnowledge{
+			{RelationID: "r1", Type: domain.RelationTypeDependsOn, Direction: RelationDirectionOutgoing, FromID: "k1", ID: "d1", Title: "Use Actions"},
+			{RelationID: "r2", Type: domain.RelationTypeRefines, Direction: RelationDirectionIncoming, FromID: "k1", ID: "g2", Title: "Canary deploys"},
+		}, nil)
+
+		result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic, ExpandRelations: true})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		require.Len(t, result.Results[0].Related, 2)
+		assert.Equal(t, "d1", result.Results[0].Related[0].ID)
+		assert.Equal(t, RelationDirectionIncoming, result.Results[0].Related[1].Direction)
+		assert.Empty(t, result.Results[1].Related)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("marks stale results and demotes them when requested", func(t *testing.T) {
+		for _, demote := range []bool{false, true} {
+			mockRepo := new(MockContextRepository)
+			mockEmbedding := new(MockEmbeddingService)
+			service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+			queryEmbedding := make([]float32, 1536)
+			filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge", DemoteStale: demote}
+
+			mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+			mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+				{KnowledgeID: "k1", Title: "Old deploy guideline", Score: 0.9, Stale: true},
+				{KnowledgeID: "k2", Title: "Deploy guideline", Score: 0.8},
+			}, nil)
+			mockRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*domain.Knowledge{}, nil)
+
+			result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic})
+
+			require.NoError(t, err)
+			require.Len(t, result.Results, 2)
+			if demote {
+				assert.Equal(t, "k2", result.Results[0].ID)
+				assert.True(t, result.Results[1].Stale)
+			} else {
+				assert.Equal(t, "k1", result.Results[0].ID)
+				assert.True(t, result.Results[0].Stale)
+			}
+		}
+	})
+
+	t.Run("applies the org-defined type boost", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		filters := SearchFilters{OrgID: "org-1", SourceType: "knowledge"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploys").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, filters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "k1", Title: "Deploy guideline", Score: 0.9, TypeBoost: 1},
+			{KnowledgeID: "k2", Title: "Deploy runbook", Score: 0.8, TypeBoost: 2},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*domain.Knowledge{}, nil)
+
+		result, err := service.Search(ctx, SearchInput{Query: "deploys", Filters: filters, Mode: SearchModeSemantic})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "k2", result.Results[0].ID)
+	})
+
+	t.Run("rejects a type filter the org has not registered", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		typeRepo := new(MockKnowledgeTypeRepository)
+		cfg := DefaultContextServiceConfig()
+		cfg.AgenticSearch.Enabled = false
+		service := NewContextServiceWithTypes(mockRepo, mockEmbedding, cfg, NewKnowledgeTypeService(typeRepo))
+
+		typeRepo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbok")).Return(nil, domain.ErrKnowledgeTypeNotFound)
+
+		_, err := service.Search(ctx, SearchInput{
+			Query:   "deploys",
+			Filters: SearchFilters{OrgID: "org-1", Type: "runbok"},
+			Mode:    SearchModeSemantic,
+		})
+
+		require.ErrorIs(t, err, domain.ErrInvalidKnowledgeType)
+		mockEmbedding.AssertNotCalled(t, "GenerateEmbedding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("filters by language and leaves out assets", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		expectedFilters := SearchFilters{OrgID: "org-1", Language: "go"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "retry backoff").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, expectedFilters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "k1", Title: "Retry with backoff", Score: 0.9},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k1"}).Return([]*domain.Knowledge{{ID: "k1", Language: "go"}}, nil)
+
+		result, err := service.Search(ctx, SearchInput{
+			Query:   "retry backoff",
+			Filters: SearchFilters{OrgID: "org-1", Language: " Golang "},
+			Mode:    SearchModeSemantic,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, "k1", result.Results[0].ID)
+		mockRepo.AssertNotCalled(t, "SearchAssetsSemantic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("filters by author and leaves out assets", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		queryEmbedding := make([]float32, 1536)
+		expectedFilters := SearchFilters{OrgID: "org-1", Author: "release-bot"}
+
+		mockEmbedding.On("GenerateEmbedding", mock.Anything, "deploy").Return(queryEmbedding, nil)
+		mockRepo.On("SearchKnowledgeChunksSemantic", mock.Anything, queryEmbedding, expectedFilters, mock.Anything).Return([]*ChunkSearchResult{
+			{KnowledgeID: "k1", Title: "Deploy runbook", Score: 0.9},
+		}, nil)
+		mockRepo.On("GetByIDs", mock.Anything, []string{"k1"}).Return([]*domain.Knowledge{{ID: "k1"}}, nil)
+
+		result, err := service.Search(ctx, SearchInput{
+			Query:   "deploy",
+			Filters: SearchFilters{OrgID: "org-1", Author: " release-bot "},
+			Mode:    SearchModeSemantic,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		mockRepo.AssertNotCalled(t, "SearchAssetsSemantic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid language filter", func(t *testing.T) {
+		mockRepo := new(MockContextRepository)
+		mockEmbedding := new(MockEmbeddingService)
+		service := newContextServiceWithAgenticDisabled(mockRepo, mockEmbedding)
+
+		_, err := service.Search(ctx, SearchInput{
+			Query:   "retry backoff",
+			Filters: SearchFilters{OrgID: "org-1", Language: "go lang"},
+			Mode:    SearchModeSemantic,
+		})
+
+		require.ErrorIs(t, err, domain.ErrInvalidLanguage)
+		mockEmbedding.AssertNotCalled(t, "GenerateEmbedding", mock.Anything, mock.Anything)
+	})
+
 	t.Run("returns error on embedding generation failure", func(t *testing.T) {
 		mockRepo := new(MockContextRepository)
 		mockEmbedding := new(MockEmbeddingService)
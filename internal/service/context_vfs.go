@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -16,6 +17,8 @@ type OpenInput struct {
 	ChunkID    string // optional: specific chunk to retrieve
 	Range      *ContentRange
 	IncludeURL bool // for assets: include presigned download URL
+	// IncludeComments adds the unresolved comment threads of a knowledge item
+	IncludeComments bool
 }
 
 // ContentRange specifies a portion of content to retrieve
@@ -37,6 +40,13 @@ type OpenResult struct {
 	ChunkIndex int
 	ChunkCount int
 	UpdatedAt  time.Time
+	// SupersededBy is set when the knowledge item was deprecated in favor of another one
+	SupersededBy *SupersessionRef
+	// StaleSince is set when the knowledge item is overdue for review; Owner is who to ask about it
+	StaleSince *time.Time
+	Owner      string
+	// Comments holds the unresolved threads on the item when IncludeComments was set
+	Comments []*domain.CommentThread
 	// Asset-specific fields
 	Filename    string
 	MimeType    string
@@ -54,6 +64,9 @@ type ListInput struct {
 	Type         domain.KnowledgeType
 	Status       domain.KnowledgeStatus
 	SourceType   string // "knowledge", "asset", or "all"
+	Tags         []string
+	TagMatch     TagMatch
+	Author       string // knowledge created or last updated by this name; leaves out assets
 	UpdatedSince *time.Time
 	Limit        int
 	Cursor       string
@@ -69,6 +82,11 @@ type ListItem struct {
 	SourceType string
 	UpdatedAt  time.Time
 	ChunkCount int
+	// Tags holds knowledge tags, or keywords for assets
+	Tags []string
+	// CreatedBy and UpdatedBy name the authors of knowledge items
+	CreatedBy string
+	UpdatedBy string
 	// Asset-specific fields
 	Filename string
 	MimeType string
@@ -103,6 +121,11 @@ type VFSStorage interface {
 	GenerateDownloadURL(ctx context.Context, key string) (string, error)
 }
 
+// VFSCommentRepo provides comment threads for the VFS service
+type VFSCommentRepo interface {
+	ListByKnowledge(ctx context.Context, knowledgeID string, resolved *bool) ([]*domain.KnowledgeComment, error)
+}
+
 // VFSListRepo provides listing capabilities for the VFS service
 type VFSListRepo interface {
 	ListKnowledge(ctx context.Context, input ListInput) ([]*ListItem, error)
@@ -116,6 +139,7 @@ type VFSService struct {
 	assetRepo     VFSAssetRepo
 	storage       VFSStorage
 	listRepo      VFSListRepo
+	commentRepo   VFSCommentRepo
 }
 
 // NewVFSService creates a new VFSService
@@ -125,6 +149,18 @@ func NewVFSService(
 	assetRepo VFSAssetRepo,
 	storage VFSStorage,
 	listRepo VFSListRepo,
+) *VFSService {
+	return NewVFSServiceWithComments(knowledgeRepo, chunkRepo, assetRepo, storage, listRepo, nil)
+}
+
+// NewVFSServiceWithComments creates a new VFSService that can include comment threads when opening knowledge
+func NewVFSServiceWithComments(
+	knowledgeRepo VFSKnowledgeRepo,
+	chunkRepo VFSChunkRepo,
+	assetRepo VFSAssetRepo,
+	storage VFSStorage,
+	listRepo VFSListRepo,
+	commentRepo VFSCommentRepo,
 ) *VFSService {
 	return &VFSService{
 		knowledgeRepo: knowledgeRepo,
@@ -132,6 +168,7 @@ func NewVFSService(
 		assetRepo:     assetRepo,
 		storage:       storage,
 		listRepo:      listRepo,
+		commentRepo:   commentRepo,
 	}
 }
 
@@ -163,9 +200,10 @@ func (s *VFSService) openKnowledge(ctx context.Context, input OpenInput) (*OpenR
 	// If chunk_id is provided, open that specific chunk
 	if input.ChunkID != "" {
 		return s.openChunk(ctx, OpenInput{
-			ID:         input.ChunkID,
-			SourceType: "chunk",
-			Range:      input.Range,
+			ID:              input.ChunkID,
+			SourceType:      "chunk",
+			Range:           input.Range,
+			IncludeComments: input.IncludeComments,
 		})
 	}
 
@@ -186,16 +224,27 @@ func (s *VFSService) openKnowledge(ctx context.Context, input OpenInput) (*OpenR
 	// Get chunk count
 	chunkCount, _ := s.chunkRepo.CountByKnowledgeID(ctx, knowledge.ID)
 
+	var comments []*domain.CommentThread
+	if input.IncludeComments {
+		if comments, err = s.openThreads(ctx, knowledge.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return &OpenResult{
-		ID:         knowledge.ID,
-		SourceType: "knowledge",
-		Title:      knowledge.Title,
-		Content:    content,
-		TotalLines: totalLines,
-		TotalChars: totalChars,
-		ChunkCount: chunkCount,
-		ChunkIndex: -1,
-		UpdatedAt:  knowledge.UpdatedAt,
+		ID:           knowledge.ID,
+		SourceType:   "knowledge",
+		Title:        knowledge.Title,
+		Content:      content,
+		TotalLines:   totalLines,
+		TotalChars:   totalChars,
+		ChunkCount:   chunkCount,
+		ChunkIndex:   -1,
+		UpdatedAt:    knowledge.UpdatedAt,
+		SupersededBy: s.successorRef(ctx, knowledge),
+		StaleSince:   knowledge.StaleSince,
+		Owner:        knowledge.Owner,
+		Comments:     comments,
 	}, nil
 }
 
@@ -217,17 +266,38 @@ func (s *VFSService) openChunk(ctx context.Context, input OpenInput) (*OpenResul
 	// Get chunk count for the parent knowledge
 	chunkCount, _ := s.chunkRepo.CountByKnowledgeID(ctx, chunk.KnowledgeID)
 
+	// Supersession and staleness live on the parent item
+	var supersededBy *SupersessionRef
+	var staleSince *time.Time
+	var owner string
+	if parent, err := s.knowledgeRepo.GetByID(ctx, chunk.KnowledgeID); err == nil {
+		supersededBy = s.successorRef(ctx, parent)
+		staleSince = parent.StaleSince
+		owner = parent.Owner
+	}
+
+	var comments []*domain.CommentThread
+	if input.IncludeComments {
+		if comments, err = s.openThreads(ctx, chunk.KnowledgeID); err != nil {
+			return nil, err
+		}
+	}
+
 	return &OpenResult{
-		ID:         chunk.KnowledgeID,
-		SourceType: "knowledge",
-		Title:      chunk.Title,
-		Content:    content,
-		TotalLines: totalLines,
-		TotalChars: totalChars,
-		ChunkID:    chunk.ID,
-		ChunkIndex: chunk.ChunkIndex,
-		ChunkCount: chunkCount,
-		UpdatedAt:  chunk.UpdatedAt,
+		ID:           chunk.KnowledgeID,
+		SourceType:   "knowledge",
+		Title:        chunk.Title,
+		Content:      content,
+		TotalLines:   totalLines,
+		TotalChars:   totalChars,
+		ChunkID:      chunk.ID,
+		ChunkIndex:   chunk.ChunkIndex,
+		ChunkCount:   chunkCount,
+		UpdatedAt:    chunk.UpdatedAt,
+		SupersededBy: supersededBy,
+		StaleSince:   staleSince,
+		Owner:        owner,
+		Comments:     comments,
 	}, nil
 }
 
@@ -261,6 +331,51 @@ func (s *VFSService) openAsset(ctx context.Context, input OpenInput) (*OpenResul
 	return result, nil
 }
 
+// openThreads returns the unresolved comment threads on an item
+func (s *VFSService) openThreads(ctx context.Context, knowledgeID string) ([]*domain.CommentThread, error) {
+	if s.commentRepo == nil {
+		return []*domain.CommentThread{}, nil
+	}
+	resolved := false
+	comments, err := s.commentRepo.ListByKnowledge(ctx, knowledgeID, &resolved)
+	if err != nil {
+		return nil, err
+	}
+	return domain.GroupCommentThreads(comments), nil
+}
+
+// successorRef resolves the current replacement of a superseded item; lookups are best effort
+func (s *VFSService) successorRef(ctx context.Context, knowledge *domain.Knowledge) *SupersessionRef {
+	if !knowledge.IsSuperseded() {
+		return nil
+	}
+
+	successors, err := resolveSuccessors(ctx, s.getKnowledgeByIDs, []*domain.Knowledge{knowledge})
+	if err != nil {
+		return nil
+	}
+	successor, ok := successors[knowledge.ID]
+	if !ok {
+		return nil
+	}
+	return &SupersessionRef{ID: successor.ID, Title: successor.Title}
+}
+
+func (s *VFSService) getKnowledgeByIDs(ctx context.Context, ids []string) ([]*domain.Knowledge, error) {
+	items := make([]*domain.Knowledge, 0, len(ids))
+	for _, id := range ids {
+		item, err := s.knowledgeRepo.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrKnowledgeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
 // List retrieves metadata for knowledge items and/or assets
 func (s *VFSService) List(ctx context.Context, input ListInput) (*ListOutput, error) {
 	ctx, span := telemetry.StartSpan(ctx, "VFSService.List", telemetry.SpanAttributes{
@@ -273,8 +388,14 @@ func (s *VFSService) List(ctx context.Context, input ListInput) (*ListOutput, er
 	if input.Limit <= 0 {
 		input.Limit = 50
 	}
+	input.Tags = domain.NormalizeTags(input.Tags)
+	input.Author = domain.NormalizeAuthorName(input.Author)
 
 	sourceType := normalizeSourceTypeFilter(input.SourceType)
+	if input.Author != "" {
+		// Assets have no author, so an author filter only matches knowledge
+		sourceType = "knowledge"
+	}
 
 	var items []*ListItem
 	var err error
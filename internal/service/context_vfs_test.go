@@ -216,6 +216,39 @@ func TestVFSService_Open_Knowledge(t *testing.T) {
 		require.Error(t, err)
 		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
 	})
+
+	t.Run("includes unresolved comment threads", func(t *testing.T) {
+		knowledgeRepo := new(MockVFSKnowledgeRepo)
+		chunkRepo := new(MockVFSChunkRepo)
+		commentRepo := new(MockKnowledgeCommentRepository)
+
+		svc := NewVFSServiceWithComments(knowledgeRepo, chunkRepo, new(MockVFSAssetRepo), new(MockVFSStorage), new(MockVFSListRepo), commentRepo)
+
+		knowledge := &domain.Knowledge{ID: "k-123", Title: "Test Knowledge", BodyMD: "Line 1", UpdatedAt: time.Now()}
+		thread := domain.NewKnowledgeComment("c-1", "org-1", "k-123", "", 1, "Is this still true?", domain.Author{Name: "alice"}, time.Now())
+		reply := domain.NewKnowledgeComment("c-2", "org-1", "k-123", "c-1", 1, "Yes", domain.Author{Name: "bob"}, time.Now())
+
+		knowledgeRepo.On("GetByID", mock.Anything, "k-123").Return(knowledge, nil)
+		chunkRepo.On("CountByKnowledgeID", mock.Anything, "k-123").Return(1, nil)
+		commentRepo.On("ListByKnowledge", mock.Anything, "k-123", mock.MatchedBy(func(resolved *bool) bool {
+			return resolved != nil && !*resolved
+		})).Return([]*domain.KnowledgeComment{thread, reply}, nil)
+
+		result, err := svc.Open(context.Background(), OpenInput{ID: "k-123", IncludeComments: true})
+
+		require.NoError(t, err)
+		require.Len(t, result.Comments, 1)
+		assert.Equal(t, "c-1", result.Comments[0].ID)
+		require.Len(t, result.Comments[0].Replies, 1)
+		assert.Equal(t, "Yes", result.Comments[0].Replies[0].Body)
+
+		// Comments are only loaded on request
+		result, err = svc.Open(context.Background(), OpenInput{ID: "k-123"})
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Comments)
+		commentRepo.AssertNumberOfCalls(t, "ListByKnowledge", 1)
+	})
 }
 
 func TestVFSService_Open_Chunk(t *testing.T) {
@@ -239,6 +272,13 @@ func TestVFSService_Open_Chunk(t *testing.T) {
 
 		chunkRepo.On("GetByID", mock.Anything, "c-456").Return(chunk, nil)
 		chunkRepo.On("CountByKnowledgeID", mock.Anything, "k-123").Return(5, nil)
+		staleSince := time.Now().Add(-time.Hour)
+		knowledgeRepo.On("GetByID", mock.Anything, "k-123").Return(&domain.Knowledge{
+			ID:         "k-123",
+			Status:     domain.KnowledgeStatusApproved,
+			Owner:      "platform-team",
+			StaleSince: &staleSince,
+		}, nil)
 
 		result, err := svc.Open(context.Background(), OpenInput{
 			ID:         "c-456",
@@ -253,6 +293,8 @@ func TestVFSService_Open_Chunk(t *testing.T) {
 		assert.Equal(t, "c-456", result.ChunkID)
 		assert.Equal(t, 2, result.ChunkIndex)
 		assert.Equal(t, 5, result.ChunkCount)
+		assert.NotNil(t, result.StaleSince)
+		assert.Equal(t, "platform-team", result.Owner)
 
 		chunkRepo.AssertExpectations(t)
 	})
@@ -277,6 +319,7 @@ func TestVFSService_Open_Chunk(t *testing.T) {
 
 		chunkRepo.On("GetByID", mock.Anything, "c-456").Return(chunk, nil)
 		chunkRepo.On("CountByKnowledgeID", mock.Anything, "k-123").Return(3, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, "k-123").Return(&domain.Knowledge{ID: "k-123", Status: domain.KnowledgeStatusApproved}, nil)
 
 		result, err := svc.Open(context.Background(), OpenInput{
 			ID:         "k-123",
@@ -443,6 +486,30 @@ func TestVFSService_List(t *testing.T) {
 		assert.False(t, result.HasMore)
 	})
 
+	t.Run("author filter leaves out assets", func(t *testing.T) {
+		knowledgeRepo := new(MockVFSKnowledgeRepo)
+		chunkRepo := new(MockVFSChunkRepo)
+		assetRepo := new(MockVFSAssetRepo)
+		storage := new(MockVFSStorage)
+		listRepo := new(MockVFSListRepo)
+
+		svc := NewVFSService(knowledgeRepo, chunkRepo, assetRepo, storage, listRepo)
+
+		listRepo.On("ListKnowledge", mock.Anything, mock.MatchedBy(func(input ListInput) bool {
+			return input.Author == "release-bot"
+		})).Return([]*ListItem{{ID: "k-1", SourceType: "knowledge", UpdatedBy: "release-bot"}}, nil)
+
+		result, err := svc.List(context.Background(), ListInput{
+			OrgID:  "org-1",
+			Author: " release-bot ",
+			Limit:  50,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+		listRepo.AssertNotCalled(t, "ListAssets", mock.Anything, mock.Anything)
+	})
+
 	t.Run("lists assets only", func(t *testing.T) {
 		knowledgeRepo := new(MockVFSKnowledgeRepo)
 		chunkRepo := new(MockVFSChunkRepo)
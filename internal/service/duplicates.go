@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+)
+
+// DefaultDuplicateThreshold is the cosine similarity above which new knowledge is
+// reported as a likely duplicate of an existing item
+const DefaultDuplicateThreshold = 0.9
+
+// maxDuplicateCandidates bounds how many similar items are reported for a new item
+const maxDuplicateCandidates = 5
+
+// DuplicateCandidate is an existing knowledge item whose content is similar to a new one
+type DuplicateCandidate struct {
+	ID         string
+	Type       domain.KnowledgeType
+	Title      string
+	Summary    string
+	Scope      string
+	Similarity float64
+}
+
+// SimilarKnowledgeRepository finds knowledge items by embedding similarity
+type SimilarKnowledgeRepository interface {
+	FindSimilar(ctx context.Context, orgID, projectID string, embedding []float32, minSimilarity float64, limit int) ([]*DuplicateCandidate, error)
+}
+
+// DuplicateKnowledgeError is returned when a new item is too similar to existing ones.
+// It matches domain.ErrDuplicateKnowledge with errors.Is.
+type DuplicateKnowledgeError struct {
+	Candidates []*DuplicateCandidate
+}
+
+func (e *DuplicateKnowledgeError) Error() string {
+	return domain.ErrDuplicateKnowledge.Error()
+}
+
+func (e *DuplicateKnowledgeError) Unwrap() error {
+	return domain.ErrDuplicateKnowledge
+}
+
+// DuplicateDetector compares new knowledge against the embedded items of the same
+// organization and project
+type DuplicateDetector struct {
+	client    EmbeddingClient
+	repo      SimilarKnowledgeRepository
+	threshold float64
+}
+
+// NewDuplicateDetector creates a DuplicateDetector that reports items with a cosine
+// similarity of at least threshold
+func NewDuplicateDetector(client EmbeddingClient, repo SimilarKnowledgeRepository, threshold float64) *DuplicateDetector {
+	return &DuplicateDetector{
+		client:    client,
+		repo:      repo,
+		threshold: threshold,
+	}
+}
+
+// Find returns the existing items similar to k, most similar first. Items that have not
+// been embedded yet cannot be found.
+func (d *DuplicateDetector) Find(ctx context.Context, k *domain.Knowledge) ([]*DuplicateCandidate, error) {
+	embedding, err := d.client.GenerateEmbedding(ctx, buildEmbeddingText(k))
+	if err != nil {
+		return nil, err
+	}
+	return d.repo.FindSimilar(ctx, k.OrgID, k.ProjectID, embedding, d.threshold, maxDuplicateCandidates)
+}
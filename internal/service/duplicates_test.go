@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSimilarKnowledgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockSimilarKnowledgeRepository) FindSimilar(ctx context.Context, orgID, projectID string, embedding []float32, minSimilarity float64, limit int) ([]*DuplicateCandidate, error) {
+	args := m.Called(ctx, orgID, projectID, embedding, minSimilarity, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*DuplicateCandidate), args.Error(1)
+}
+
+func newDuplicateTestService() (*KnowledgeService, *MockKnowledgeRepository, *MockEmbeddingJobRepository, *MockEmbeddingClient, *MockSimilarKnowledgeRepository) {
+	knowledgeRepo := new(MockKnowledgeRepository)
+	jobRepo := new(MockEmbeddingJobRepository)
+	client := new(MockEmbeddingClient)
+	similarRepo := new(MockSimilarKnowledgeRepository)
+	detector := NewDuplicateDetector(client, similarRepo, 0.9)
+	return NewKnowledgeServiceWithDuplicates(knowledgeRepo, jobRepo, nil, nil, detector), knowledgeRepo, jobRepo, client, similarRepo
+}
+
+func duplicateTestInput() CreateInput {
+	return CreateInput{
+		OrgID:     "org-1",
+		ProjectID: "proj-1",
+		Type:      domain.KnowledgeTypeLearning,
+		Title:     "Retry flaky tests",
+		BodyMD:    "Retry once before failing the build.",
+	}
+}
+
+func TestKnowledgeService_Create_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	embedding := []float32{0.1, 0.2}
+
+	t.Run("rejects an item similar to existing knowledge", func(t *testing.T) {
+		svc, knowledgeRepo, _, client, similarRepo := newDuplicateTestService()
+
+		client.On("GenerateEmbedding", mock.Anything, "Retry flaky tests\n\nRetry once before failing the build.").Return(embedding, nil)
+		similarRepo.On("FindSimilar", mock.Anything, "org-1", "proj-1", embedding, 0.9, maxDuplicateCandidates).Return([]*DuplicateCandidate{
+			{ID: "k-1", Title: "Retry flaky tests once", Similarity: 0.95},
+		}, nil)
+
+		out, err := svc.Create(ctx, duplicateTestInput())
+
+		assert.Nil(t, out)
+		assert.ErrorIs(t, err, domain.ErrDuplicateKnowledge)
+		var dupErr *DuplicateKnowledgeError
+		require.True(t, errors.As(err, &dupErr))
+		require.Len(t, dupErr.Candidates, 1)
+		assert.Equal(t, "k-1", dupErr.Candidates[0].ID)
+		knowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates the item when nothing is similar", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, client, similarRepo := newDuplicateTestService()
+
+		client.On("GenerateEmbedding", mock.Anything, mock.Anything).Return(embedding, nil)
+		similarRepo.On("FindSimilar", mock.Anything, "org-1", "proj-1", embedding, 0.9, maxDuplicateCandidates).Return([]*DuplicateCandidate{}, nil)
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.Create(ctx, duplicateTestInput())
+
+		require.NoError(t, err)
+		created := out.Knowledge
+		assert.Equal(t, "Retry flaky tests", created.Title)
+	})
+
+	t.Run("force skips the check", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, client, _ := newDuplicateTestService()
+
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		input := duplicateTestInput()
+		input.Force = true
+		_, err := svc.Create(ctx, input)
+
+		require.NoError(t, err)
+		client.AssertNotCalled(t, "GenerateEmbedding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("an embedding failure does not block the write", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, client, _ := newDuplicateTestService()
+
+		client.On("GenerateEmbedding", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited"))
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := svc.Create(ctx, duplicateTestInput())
+
+		require.NoError(t, err)
+		knowledgeRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_BatchCreate_Duplicates(t *testing.T) {
+	ctx := context.Background()
+	embedding := []float32{0.1, 0.2}
+
+	newService := func() (*KnowledgeService, *MockKnowledgeRepository, *MockEmbeddingJobRepository, *MockEmbeddingClient, *MockSimilarKnowledgeRepository) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		jobRepo := new(MockEmbeddingJobRepository)
+		client := new(MockEmbeddingClient)
+		similarRepo := new(MockSimilarKnowledgeRepository)
+		txRunner := &testTxRunner{repos: &testTxRepos{knowledge: knowledgeRepo, embeddingJobs: jobRepo}}
+		detector := NewDuplicateDetector(client, similarRepo, 0.9)
+		return NewKnowledgeServiceWithDuplicates(knowledgeRepo, jobRepo, txRunner, nil, detector), knowledgeRepo, jobRepo, client, similarRepo
+	}
+
+	items := func() []CreateInput {
+		unique := duplicateTestInput()
+		unique.Title = "Pin tool versions"
+		unique.BodyMD = "Pin every tool in go.mod."
+		return []CreateInput{duplicateTestInput(), unique}
+	}
+
+	t.Run("fails the items similar to existing knowledge", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, client, similarRepo := newService()
+
+		client.On("GenerateEmbedding", mock.Anything, "Retry flaky tests\n\nRetry once before failing the build.").Return(embedding, nil)
+		client.On("GenerateEmbedding", mock.Anything, "Pin tool versions\n\nPin every tool in go.mod.").Return([]float32{0.3, 0.4}, nil)
+		similarRepo.On("FindSimilar", mock.Anything, "org-1", "proj-1", embedding, 0.9, maxDuplicateCandidates).Return([]*DuplicateCandidate{
+			{ID: "k-1", Title: "Retry flaky tests once", Similarity: 0.95},
+		}, nil)
+		similarRepo.On("FindSimilar", mock.Anything, "org-1", "proj-1", []float32{0.3, 0.4}, 0.9, maxDuplicateCandidates).Return([]*DuplicateCandidate{}, nil)
+		knowledgeRepo.On("Create", mock.Anything, withTitle("Pin tool versions")).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchCreate(ctx, BatchCreateInput{OrgID: "org-1", Items: items()})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, out.Succeeded)
+		assert.Equal(t, BatchItemFailed, out.Results[0].Status)
+		var dupErr *DuplicateKnowledgeError
+		require.True(t, errors.As(out.Results[0].Err, &dupErr))
+		assert.Equal(t, "k-1", dupErr.Candidates[0].ID)
+		assert.Equal(t, BatchItemCreated, out.Results[1].Status)
+		knowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, withTitle("Retry flaky tests"))
+	})
+
+	t.Run("batch force skips the check", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, client, _ := newService()
+
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchCreate(ctx, BatchCreateInput{OrgID: "org-1", Force: true, Items: items()})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, out.Succeeded)
+		client.AssertNotCalled(t, "GenerateEmbedding", mock.Anything, mock.Anything)
+	})
+}
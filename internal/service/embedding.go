@@ -117,6 +117,10 @@ func (s *EmbeddingService) GenerateEmbedding(ctx context.Context, knowledgeID st
 				Title:       knowledge.Title,
 				Summary:     knowledge.Summary,
 				Scope:       knowledge.Scope,
+				Tags:        knowledge.Tags,
+				Language:    knowledge.Language,
+				CreatedBy:   knowledge.CreatedBy.Name,
+				UpdatedBy:   knowledge.UpdatedBy.Name,
 				ChunkIndex:  i,
 				Content:     chunk,
 				Embedding:   chunkEmbedding,
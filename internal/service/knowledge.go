@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/cloo-solutions/neotexai/internal/domain"
@@ -16,12 +18,27 @@ type KnowledgeRepositoryInterface interface {
 	GetByID(ctx context.Context, id string) (*domain.Knowledge, error)
 	ListByOrg(ctx context.Context, orgID string) ([]*domain.Knowledge, error)
 	ListByProject(ctx context.Context, projectID string) ([]*domain.Knowledge, error)
+	ListByScopePrefix(ctx context.Context, orgID, projectID, prefix string) ([]*domain.Knowledge, error)
+	ListWithSource(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error)
 	ListByOrgWithCursor(ctx context.Context, orgID string, cursor *pagination.Cursor, limit int) (*KnowledgePageResult, error)
 	ListByProjectWithCursor(ctx context.Context, projectID string, cursor *pagination.Cursor, limit int) (*KnowledgePageResult, error)
+	ListWithCursor(ctx context.Context, filter KnowledgeListFilter, cursor *pagination.Cursor, limit int) (*KnowledgePageResult, error)
 	Update(ctx context.Context, k *domain.Knowledge) error
 	CreateVersion(ctx context.Context, v *domain.KnowledgeVersion) error
 	GetLatestVersion(ctx context.Context, knowledgeID string) (*domain.KnowledgeVersion, error)
 	GetVersions(ctx context.Context, knowledgeID string) ([]*domain.KnowledgeVersion, error)
+	GetVersion(ctx context.Context, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error)
+}
+
+// KnowledgeListFilter narrows a cursor listing of knowledge items.
+// Empty fields are not filtered on; OrgID is always required.
+type KnowledgeListFilter struct {
+	OrgID     string
+	ProjectID string
+	Status    domain.KnowledgeStatus
+	Owner     string
+	// Stale limits the listing to items flagged by the stale knowledge job
+	Stale bool
 }
 
 type KnowledgePageResult struct {
@@ -54,6 +71,9 @@ type KnowledgeService struct {
 	embeddingJobRepo EmbeddingJobRepositoryInterface
 	uuidGen          UUIDGenerator
 	txRunner         TxRunner
+	types            KnowledgeTypeResolver
+	duplicates       *DuplicateDetector
+	lint             *LintService
 }
 
 // NewKnowledgeService creates a new KnowledgeService instance
@@ -83,12 +103,51 @@ func NewKnowledgeServiceWithTx(
 	knowledgeRepo KnowledgeRepositoryInterface,
 	embeddingJobRepo EmbeddingJobRepositoryInterface,
 	txRunner TxRunner,
+) *KnowledgeService {
+	return NewKnowledgeServiceWithTypes(knowledgeRepo, embeddingJobRepo, txRunner, nil)
+}
+
+// NewKnowledgeServiceWithTypes creates a new KnowledgeService that validates types against
+// the org type registry. Without a registry only the built-in types are accepted.
+func NewKnowledgeServiceWithTypes(
+	knowledgeRepo KnowledgeRepositoryInterface,
+	embeddingJobRepo EmbeddingJobRepositoryInterface,
+	txRunner TxRunner,
+	types KnowledgeTypeResolver,
+) *KnowledgeService {
+	return NewKnowledgeServiceWithDuplicates(knowledgeRepo, embeddingJobRepo, txRunner, types, nil)
+}
+
+// NewKnowledgeServiceWithDuplicates creates a new KnowledgeService that rejects new items
+// similar to existing ones unless forced. Without a detector nothing is checked.
+func NewKnowledgeServiceWithDuplicates(
+	knowledgeRepo KnowledgeRepositoryInterface,
+	embeddingJobRepo EmbeddingJobRepositoryInterface,
+	txRunner TxRunner,
+	types KnowledgeTypeResolver,
+	duplicates *DuplicateDetector,
+) *KnowledgeService {
+	return NewKnowledgeServiceWithLint(knowledgeRepo, embeddingJobRepo, txRunner, types, duplicates, nil)
+}
+
+// NewKnowledgeServiceWithLint creates a new KnowledgeService that lints items on create
+// and update with the rules of their org. Without a lint service nothing is linted.
+func NewKnowledgeServiceWithLint(
+	knowledgeRepo KnowledgeRepositoryInterface,
+	embeddingJobRepo EmbeddingJobRepositoryInterface,
+	txRunner TxRunner,
+	types KnowledgeTypeResolver,
+	duplicates *DuplicateDetector,
+	lint *LintService,
 ) *KnowledgeService {
 	return &KnowledgeService{
 		knowledgeRepo:    knowledgeRepo,
 		embeddingJobRepo: embeddingJobRepo,
 		uuidGen:          &DefaultUUIDGenerator{},
 		txRunner:         txRunner,
+		types:            types,
+		duplicates:       duplicates,
+		lint:             lint,
 	}
 }
 
@@ -101,15 +160,44 @@ type CreateInput struct {
 	Summary   string
 	BodyMD    string
 	Scope     string
+	Tags      []string
+	// ReviewAfter is the optional review-by date, Owner who is responsible for the item
+	ReviewAfter *time.Time
+	Owner       string
+	// Language is the code language of a snippet; detected from the first code fence when empty
+	Language string
+	// TemplateID and TemplateVersion record the template the item was instantiated from
+	TemplateID      string
+	TemplateVersion int64
+	// Author is recorded as the creator of the item and its first version
+	Author domain.Author
+	// Force creates the item even when similar knowledge already exists
+	Force bool
+	// SourcePath and SourceHash record the file an imported item was read from
+	SourcePath string
+	SourceHash string
 }
 
-// UpdateInput represents the input for updating a knowledge item
+// UpdateInput represents the input for updating a knowledge item.
+// When ExpectedVersion is set, the update fails with ErrVersionConflict
+// unless it matches the latest version number. A nil Tags, ReviewAfter, Owner or
+// Language keeps the current value; a zero ReviewAfter removes the review-by date.
+// An empty SourcePath or SourceHash keeps the recorded source.
+// Author is recorded as the creator of the new version.
 type UpdateInput struct {
-	KnowledgeID string
-	Title       string
-	Summary     string
-	BodyMD      string
-	Scope       string
+	KnowledgeID     string
+	Title           string
+	Summary         string
+	BodyMD          string
+	Scope           string
+	Tags            []string
+	ReviewAfter     *time.Time
+	Owner           *string
+	Language        *string
+	SourcePath      string
+	SourceHash      string
+	ExpectedVersion int64
+	Author          domain.Author
 }
 
 type ListKnowledgeInput struct {
@@ -125,8 +213,31 @@ type ListKnowledgeOutput struct {
 	HasMore bool
 }
 
+// ReviewInput represents the input for approving or rejecting a knowledge item. Reviewer
+// is the authenticated caller, never a name taken from the request body.
+type ReviewInput struct {
+	OrgID       string
+	KnowledgeID string
+	Reviewer    domain.Author
+	Note        string
+}
+
+// CreateOutput is a created knowledge item with the lint findings it was stored with
+type CreateOutput struct {
+	Knowledge    *domain.Knowledge
+	LintFindings []domain.LintFinding
+}
+
+// UpdateOutput is an updated knowledge item with its new version and the lint findings
+// it was stored with
+type UpdateOutput struct {
+	Knowledge    *domain.Knowledge
+	Version      *domain.KnowledgeVersion
+	LintFindings []domain.LintFinding
+}
+
 // Create creates a new knowledge item with its first version and queues an embedding job
-func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*domain.Knowledge, error) {
+func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*CreateOutput, error) {
 	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Create", telemetry.SpanAttributes{
 		OrgID:     input.OrgID,
 		ProjectID: input.ProjectID,
@@ -134,6 +245,63 @@ func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*doma
 	})
 	defer span.End()
 
+	records, err := s.newKnowledgeRecords(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.Force {
+		if err := s.checkDuplicates(ctx, records.knowledge); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.txRunner != nil {
+		if err := s.txRunner.WithTx(ctx, func(repos TxRepositories) error {
+			return writeKnowledgeRecords(ctx, repos.Knowledge(), repos.EmbeddingJobs(), records)
+		}); err != nil {
+			return nil, err
+		}
+		return &CreateOutput{Knowledge: records.knowledge, LintFindings: records.findings}, nil
+	}
+
+	if err := writeKnowledgeRecords(ctx, s.knowledgeRepo, s.embeddingJobRepo, records); err != nil {
+		return nil, err
+	}
+
+	return &CreateOutput{Knowledge: records.knowledge, LintFindings: records.findings}, nil
+}
+
+// checkDuplicates returns a DuplicateKnowledgeError when k is too similar to existing
+// knowledge. It does nothing without a duplicate detector.
+func (s *KnowledgeService) checkDuplicates(ctx context.Context, k *domain.Knowledge) error {
+	if s.duplicates == nil {
+		return nil
+	}
+	candidates, err := s.duplicates.Find(ctx, k)
+	if err != nil {
+		// The check is advisory; an embedding outage must not block writes
+		telemetry.CaptureError(ctx, err)
+		return nil
+	}
+	if len(candidates) > 0 {
+		return &DuplicateKnowledgeError{Candidates: candidates}
+	}
+	return nil
+}
+
+// knowledgeRecords are the rows written when a knowledge item is created, with the
+// lint findings of the item
+type knowledgeRecords struct {
+	knowledge *domain.Knowledge
+	version   *domain.KnowledgeVersion
+	job       *domain.EmbeddingJob
+	findings  []domain.LintFinding
+}
+
+// newKnowledgeRecords builds and validates a new knowledge item with its first version
+// and embedding job
+func (s *KnowledgeService) newKnowledgeRecords(ctx context.Context, input CreateInput) (*knowledgeRecords, error) {
 	now := time.Now().UTC()
 	knowledgeID := s.uuidGen.NewString()
 	versionID := s.uuidGen.NewString()
@@ -150,16 +318,39 @@ func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*doma
 		Summary:   input.Summary,
 		BodyMD:    input.BodyMD,
 		Scope:     input.Scope,
+		Tags:      domain.NormalizeTags(input.Tags),
+		Owner:     strings.TrimSpace(input.Owner),
 		CreatedAt: now,
 		UpdatedAt: now,
+		CreatedBy: input.Author,
+		UpdatedBy: input.Author,
+
+		TemplateID:      input.TemplateID,
+		TemplateVersion: input.TemplateVersion,
+
+		SourcePath: input.SourcePath,
+		SourceHash: input.SourceHash,
+	}
+	if input.ReviewAfter != nil && !input.ReviewAfter.IsZero() {
+		reviewAfter := input.ReviewAfter.UTC()
+		knowledge.ReviewAfter = &reviewAfter
 	}
 
 	// Validate knowledge
+	if err := applyLanguage(knowledge, &input.Language); err != nil {
+		return nil, err
+	}
+	if err := s.checkType(ctx, knowledge); err != nil {
+		return nil, err
+	}
 	if err := domain.ValidateKnowledge(knowledge); err != nil {
 		return nil, err
 	}
+	findings, err := s.lintKnowledge(ctx, knowledge)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create knowledge in repository
 	version := &domain.KnowledgeVersion{
 		ID:            versionID,
 		KnowledgeID:   knowledgeID,
@@ -168,6 +359,7 @@ func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*doma
 		Summary:       input.Summary,
 		BodyMD:        input.BodyMD,
 		CreatedAt:     now,
+		CreatedBy:     input.Author,
 	}
 
 	// Queue embedding job
@@ -181,41 +373,23 @@ func (s *KnowledgeService) Create(ctx context.Context, input CreateInput) (*doma
 		ProcessedAt: nil,
 	}
 
-	if s.txRunner != nil {
-		if err := s.txRunner.WithTx(ctx, func(repos TxRepositories) error {
-			knowledgeRepo := repos.Knowledge()
-			embeddingJobRepo := repos.EmbeddingJobs()
-
-			if err := knowledgeRepo.Create(ctx, knowledge); err != nil {
-				return err
-			}
-			if err := knowledgeRepo.CreateVersion(ctx, version); err != nil {
-				return err
-			}
-			if err := embeddingJobRepo.Create(ctx, job); err != nil {
-				return err
-			}
-			return nil
-		}); err != nil {
-			return nil, err
-		}
-		return knowledge, nil
-	}
+	return &knowledgeRecords{knowledge: knowledge, version: version, job: job, findings: findings}, nil
+}
 
-	// Create knowledge in repository
-	if err := s.knowledgeRepo.Create(ctx, knowledge); err != nil {
-		return nil, err
+// writeKnowledgeRecords persists a new knowledge item, its first version and its embedding job
+func writeKnowledgeRecords(
+	ctx context.Context,
+	knowledgeRepo KnowledgeRepositoryInterface,
+	embeddingJobRepo EmbeddingJobRepositoryInterface,
+	records *knowledgeRecords,
+) error {
+	if err := knowledgeRepo.Create(ctx, records.knowledge); err != nil {
+		return err
 	}
-
-	if err := s.knowledgeRepo.CreateVersion(ctx, version); err != nil {
-		return nil, err
+	if err := knowledgeRepo.CreateVersion(ctx, records.version); err != nil {
+		return err
 	}
-
-	if err := s.embeddingJobRepo.Create(ctx, job); err != nil {
-		return nil, err
-	}
-
-	return knowledge, nil
+	return embeddingJobRepo.Create(ctx, records.job)
 }
 
 // GetByID retrieves a knowledge item by ID
@@ -235,7 +409,7 @@ func (s *KnowledgeService) GetLatestVersion(ctx context.Context, knowledgeID str
 }
 
 // Update creates a new version of a knowledge item (immutable versioning) and queues an embedding job
-func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*domain.Knowledge, *domain.KnowledgeVersion, error) {
+func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*UpdateOutput, error) {
 	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Update", telemetry.SpanAttributes{
 		KnowledgeID: input.KnowledgeID,
 		Operation:   "update",
@@ -247,6 +421,7 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 	if s.txRunner != nil {
 		var updatedKnowledge *domain.Knowledge
 		var newVersion *domain.KnowledgeVersion
+		var findings []domain.LintFinding
 
 		if err := s.txRunner.WithTx(ctx, func(repos TxRepositories) error {
 			knowledgeRepo := repos.Knowledge()
@@ -268,13 +443,32 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 			if err != nil {
 				return err
 			}
+			if input.ExpectedVersion > 0 && latestVersion.VersionNumber != input.ExpectedVersion {
+				return domain.ErrVersionConflict
+			}
 
 			// Update knowledge record
-			knowledge.Title = input.Title
-			knowledge.Summary = input.Summary
+			contentChanged := knowledge.Title != input.Title || knowledge.Summary != input.Summary ||
+				knowledge.BodyMD != input.BodyMD
+			applyTitleAndSummary(knowledge, input.Title, input.Summary)
 			knowledge.BodyMD = input.BodyMD
 			knowledge.Scope = input.Scope
+			if input.Tags != nil {
+				knowledge.Tags = domain.NormalizeTags(input.Tags)
+			}
+			applyReviewSchedule(knowledge, input.ReviewAfter, input.Owner, now)
+			if err := applyLanguage(knowledge, input.Language); err != nil {
+				return err
+			}
+			applySource(knowledge, input.SourcePath, input.SourceHash)
 			knowledge.UpdatedAt = now
+			knowledge.UpdatedBy = input.Author
+
+			if findings, err = s.lintKnowledge(ctx, knowledge); err != nil {
+				return err
+			}
+
+			sendBackForReview(knowledge, contentChanged)
 
 			if err := knowledgeRepo.Update(ctx, knowledge); err != nil {
 				return err
@@ -290,6 +484,7 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 				Summary:       input.Summary,
 				BodyMD:        input.BodyMD,
 				CreatedAt:     now,
+				CreatedBy:     input.Author,
 			}
 
 			if err := knowledgeRepo.CreateVersion(ctx, newVersion); err != nil {
@@ -315,38 +510,58 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 			updatedKnowledge = knowledge
 			return nil
 		}); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		return updatedKnowledge, newVersion, nil
+		return &UpdateOutput{Knowledge: updatedKnowledge, Version: newVersion, LintFindings: findings}, nil
 	}
 
 	// Get existing knowledge
 	knowledge, err := s.knowledgeRepo.GetByID(ctx, input.KnowledgeID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Cannot modify deprecated knowledge
 	if knowledge.Status == domain.KnowledgeStatusDeprecated {
-		return nil, nil, domain.ErrCannotModifyDeprecated
+		return nil, domain.ErrCannotModifyDeprecated
 	}
 
 	// Get the latest version to determine next version number
 	latestVersion, err := s.knowledgeRepo.GetLatestVersion(ctx, input.KnowledgeID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	if input.ExpectedVersion > 0 && latestVersion.VersionNumber != input.ExpectedVersion {
+		return nil, domain.ErrVersionConflict
 	}
 
 	// Update knowledge record
-	knowledge.Title = input.Title
-	knowledge.Summary = input.Summary
+	contentChanged := knowledge.Title != input.Title || knowledge.Summary != input.Summary ||
+		knowledge.BodyMD != input.BodyMD
+	applyTitleAndSummary(knowledge, input.Title, input.Summary)
 	knowledge.BodyMD = input.BodyMD
 	knowledge.Scope = input.Scope
+	if input.Tags != nil {
+		knowledge.Tags = domain.NormalizeTags(input.Tags)
+	}
+	applyReviewSchedule(knowledge, input.ReviewAfter, input.Owner, now)
+	if err := applyLanguage(knowledge, input.Language); err != nil {
+		return nil, err
+	}
+	applySource(knowledge, input.SourcePath, input.SourceHash)
 	knowledge.UpdatedAt = now
+	knowledge.UpdatedBy = input.Author
+
+	findings, err := s.lintKnowledge(ctx, knowledge)
+	if err != nil {
+		return nil, err
+	}
+
+	sendBackForReview(knowledge, contentChanged)
 
 	if err := s.knowledgeRepo.Update(ctx, knowledge); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Create new version (immutable)
@@ -359,10 +574,11 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 		Summary:       input.Summary,
 		BodyMD:        input.BodyMD,
 		CreatedAt:     now,
+		CreatedBy:     input.Author,
 	}
 
 	if err := s.knowledgeRepo.CreateVersion(ctx, newVersion); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Queue embedding job
@@ -378,30 +594,134 @@ func (s *KnowledgeService) Update(ctx context.Context, input UpdateInput) (*doma
 	}
 
 	if err := s.embeddingJobRepo.Create(ctx, job); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return knowledge, newVersion, nil
+	return &UpdateOutput{Knowledge: knowledge, Version: newVersion, LintFindings: findings}, nil
+}
+
+// DeprecateInput represents the input for deprecating a knowledge item.
+// SupersededBy optionally names the item that replaces it.
+type DeprecateInput struct {
+	KnowledgeID  string
+	SupersededBy string
 }
 
-// Deprecate sets the status of a knowledge item to deprecated
-func (s *KnowledgeService) Deprecate(ctx context.Context, knowledgeID string) (*domain.Knowledge, error) {
+// Deprecate sets the status of a knowledge item to deprecated, optionally linking its successor
+func (s *KnowledgeService) Deprecate(ctx context.Context, input DeprecateInput) (*domain.Knowledge, error) {
 	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Deprecate", telemetry.SpanAttributes{
-		KnowledgeID: knowledgeID,
+		KnowledgeID: input.KnowledgeID,
 		Operation:   "delete",
 	})
 	defer span.End()
 
 	// Get existing knowledge
-	knowledge, err := s.knowledgeRepo.GetByID(ctx, knowledgeID)
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, input.KnowledgeID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := deprecateKnowledge(ctx, s.knowledgeRepo, knowledge, input.SupersededBy); err != nil {
+		return nil, err
+	}
+
+	return knowledge, nil
+}
+
+// deprecateKnowledge marks an item deprecated, linking the successor when one is given
+func deprecateKnowledge(ctx context.Context, repo KnowledgeRepositoryInterface, knowledge *domain.Knowledge, supersededBy string) error {
+	if supersededBy != "" {
+		if err := validateSuccessor(ctx, repo, knowledge, supersededBy); err != nil {
+			return err
+		}
+		knowledge.SupersededBy = supersededBy
+	}
+
 	// Update status to deprecated
 	knowledge.Status = domain.KnowledgeStatusDeprecated
 	knowledge.UpdatedAt = time.Now().UTC()
 
+	return repo.Update(ctx, knowledge)
+}
+
+// validateSuccessor checks that successorID names another active item in the same organization.
+// A successor may still await review; search only substitutes approved successors.
+func validateSuccessor(ctx context.Context, repo KnowledgeRepositoryInterface, knowledge *domain.Knowledge, successorID string) error {
+	if successorID == knowledge.ID {
+		return domain.ErrSupersedeSelf
+	}
+	if _, err := uuid.Parse(successorID); err != nil {
+		return domain.ErrSuccessorNotFound
+	}
+
+	successor, err := repo.GetByID(ctx, successorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrKnowledgeNotFound) {
+			return domain.ErrSuccessorNotFound
+		}
+		return err
+	}
+	if successor.OrgID != knowledge.OrgID {
+		return domain.ErrSuccessorNotFound
+	}
+	if successor.Status == domain.KnowledgeStatusDeprecated {
+		return domain.ErrSuccessorDeprecated
+	}
+
+	return nil
+}
+
+// Approve marks a pending knowledge item as approved
+func (s *KnowledgeService) Approve(ctx context.Context, input ReviewInput) (*domain.Knowledge, error) {
+	return s.review(ctx, input, domain.KnowledgeStatusApproved, "approve")
+}
+
+// Reject marks a pending knowledge item as rejected; a note explaining why is required
+func (s *KnowledgeService) Reject(ctx context.Context, input ReviewInput) (*domain.Knowledge, error) {
+	if input.Note == "" {
+		return nil, domain.ErrReviewNoteRequired
+	}
+	return s.review(ctx, input, domain.KnowledgeStatusRejected, "reject")
+}
+
+func (s *KnowledgeService) review(ctx context.Context, input ReviewInput, status domain.KnowledgeStatus, operation string) (*domain.Knowledge, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Review", telemetry.SpanAttributes{
+		KnowledgeID: input.KnowledgeID,
+		Operation:   operation,
+	})
+	defer span.End()
+
+	if input.Reviewer.IsZero() {
+		return nil, domain.ErrReviewerRequired
+	}
+
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, input.KnowledgeID)
+	if err != nil {
+		return nil, err
+	}
+	if knowledge.OrgID != input.OrgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+
+	if knowledge.Status == domain.KnowledgeStatusDeprecated {
+		return nil, domain.ErrCannotModifyDeprecated
+	}
+	if !knowledge.IsPendingReview() {
+		return nil, domain.ErrKnowledgeNotPending
+	}
+	// A draft only becomes team guidance once someone other than its writer signs off
+	if status == domain.KnowledgeStatusApproved && knowledge.CreatedBy.APIKeyID != "" &&
+		knowledge.CreatedBy.APIKeyID == input.Reviewer.APIKeyID {
+		return nil, domain.ErrSelfApproval
+	}
+
+	now := time.Now().UTC()
+	knowledge.Status = status
+	knowledge.ReviewedBy = input.Reviewer
+	knowledge.ReviewedAt = &now
+	knowledge.ReviewNote = input.Note
+	knowledge.UpdatedAt = now
+
 	if err := s.knowledgeRepo.Update(ctx, knowledge); err != nil {
 		return nil, err
 	}
@@ -409,6 +729,197 @@ func (s *KnowledgeService) Deprecate(ctx context.Context, knowledgeID string) (*
 	return knowledge, nil
 }
 
+// ListPendingReview lists draft knowledge items awaiting review, newest first
+func (s *KnowledgeService) ListPendingReview(ctx context.Context, input ListKnowledgeInput) (*ListKnowledgeOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.ListPendingReview", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		ProjectID: input.ProjectID,
+		Operation: "list",
+	})
+	defer span.End()
+
+	cursor, _ := pagination.DecodeCursor(input.Cursor)
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	result, err := s.knowledgeRepo.ListWithCursor(ctx, KnowledgeListFilter{
+		OrgID:     input.OrgID,
+		ProjectID: input.ProjectID,
+		Status:    domain.KnowledgeStatusDraft,
+	}, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListKnowledgeOutput{
+		Items:   result.Items,
+		Cursor:  result.NextCursor,
+		HasMore: result.HasMore,
+	}, nil
+}
+
+// ListStaleInput represents the input for listing knowledge flagged as overdue for review
+type ListStaleInput struct {
+	OrgID     string
+	ProjectID string
+	Owner     string
+	Cursor    string
+	Limit     int
+}
+
+// ListStale lists knowledge items flagged by the stale knowledge job, newest first
+func (s *KnowledgeService) ListStale(ctx context.Context, input ListStaleInput) (*ListKnowledgeOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.ListStale", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		ProjectID: input.ProjectID,
+		Operation: "list",
+	})
+	defer span.End()
+
+	cursor, _ := pagination.DecodeCursor(input.Cursor)
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	result, err := s.knowledgeRepo.ListWithCursor(ctx, KnowledgeListFilter{
+		OrgID:     input.OrgID,
+		ProjectID: input.ProjectID,
+		Owner:     strings.TrimSpace(input.Owner),
+		Stale:     true,
+	}, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListKnowledgeOutput{
+		Items:   result.Items,
+		Cursor:  result.NextCursor,
+		HasMore: result.HasMore,
+	}, nil
+}
+
+// ListSources lists the items of a project that were imported from files, ordered by
+// source path, including deprecated ones
+func (s *KnowledgeService) ListSources(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.ListSources", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		ProjectID: projectID,
+		Operation: "list",
+	})
+	defer span.End()
+
+	return s.knowledgeRepo.ListWithSource(ctx, orgID, projectID)
+}
+
+// checkType makes sure a new item's type is known to its org. The sections the type
+// requires are checked by the required-sections lint rule.
+func (s *KnowledgeService) checkType(ctx context.Context, k *domain.Knowledge) error {
+	if s.types == nil {
+		if !domain.IsBuiltinKnowledgeType(k.Type) {
+			return domain.ErrInvalidKnowledgeType
+		}
+		return nil
+	}
+
+	_, err := s.types.Resolve(ctx, k.OrgID, k.Type)
+	return err
+}
+
+// lintKnowledge runs the lint rules of the item's org. In reject mode findings of
+// severity error fail the write with a LintError; otherwise the findings are returned so
+// they can be reported with the response.
+func (s *KnowledgeService) lintKnowledge(ctx context.Context, k *domain.Knowledge) ([]domain.LintFinding, error) {
+	if s.lint == nil {
+		return nil, nil
+	}
+
+	mode, findings, err := s.lint.Lint(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	if mode == domain.LintModeReject && domain.HasLintErrors(findings) {
+		return nil, &LintError{Findings: findings}
+	}
+	return findings, nil
+}
+
+// applyLanguage sets the code language of an item when one is given. Snippets without
+// a language take the one declared by their first code fence.
+func applyLanguage(k *domain.Knowledge, language *string) error {
+	if language != nil {
+		k.Language = domain.NormalizeLanguage(*language)
+	}
+	if k.Language == "" && k.Type == domain.KnowledgeTypeSnippet {
+		k.Language = domain.DetectCodeLanguage(k.BodyMD)
+	}
+	if !domain.IsValidLanguage(k.Language) {
+		return domain.ErrInvalidLanguage
+	}
+	return nil
+}
+
+// applyTitleAndSummary sets the title and summary of an update. A generated summary is
+// only marked as generated while it is unchanged, and a suggested title is dropped once
+// the title is edited, whether or not the suggestion was taken.
+func applyTitleAndSummary(k *domain.Knowledge, title, summary string) {
+	if summary != k.Summary {
+		k.SummaryGeneratedBy = ""
+	}
+	if title != k.Title {
+		k.SuggestedTitle = ""
+	}
+	k.Title = title
+	k.Summary = summary
+}
+
+// sendBackForReview makes an edited item a draft again: a rejected item on any edit and
+// an approved one when its title, summary or body changed, so the new content is reviewed
+// before it reaches the team
+func sendBackForReview(k *domain.Knowledge, contentChanged bool) {
+	switch k.Status {
+	case domain.KnowledgeStatusRejected:
+		k.Status = domain.KnowledgeStatusDraft
+	case domain.KnowledgeStatusApproved:
+		if contentChanged {
+			k.Status = domain.KnowledgeStatusDraft
+		}
+	}
+}
+
+// applySource records the file an item was imported from; empty values keep the current source
+func applySource(k *domain.Knowledge, path, hash string) {
+	if path != "" {
+		k.SourcePath = path
+	}
+	if hash != "" {
+		k.SourceHash = hash
+	}
+}
+
+// applyReviewSchedule applies the review-by date and owner of an update. Moving the
+// date forward (or removing it) clears the stale flag right away instead of waiting
+// for the next stale knowledge check.
+func applyReviewSchedule(k *domain.Knowledge, reviewAfter *time.Time, owner *string, now time.Time) {
+	if owner != nil {
+		k.Owner = strings.TrimSpace(*owner)
+	}
+	if reviewAfter == nil {
+		return
+	}
+	if reviewAfter.IsZero() {
+		k.ReviewAfter = nil
+	} else {
+		t := reviewAfter.UTC()
+		k.ReviewAfter = &t
+	}
+	if !k.IsReviewOverdue(now) {
+		k.StaleSince = nil
+	}
+}
+
 // ListByOrg retrieves all knowledge items for an organization
 func (s *KnowledgeService) ListByOrg(ctx context.Context, orgID string) ([]*domain.Knowledge, error) {
 	return s.knowledgeRepo.ListByOrg(ctx, orgID)
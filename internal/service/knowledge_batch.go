@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize is the largest number of items accepted by a knowledge batch
+const MaxBatchSize = 100
+
+// Batch modes. An atomic batch is written all-or-nothing; a best-effort batch writes every
+// item that succeeds and reports the ones that failed.
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// Batch item statuses
+const (
+	BatchItemCreated    = "created"
+	BatchItemDeprecated = "deprecated"
+	BatchItemFailed     = "failed"
+	// BatchItemRolledBack marks the items of an atomic batch that were undone because another item failed
+	BatchItemRolledBack = "rolled_back"
+)
+
+// BatchCreateInput represents the input for creating knowledge items in one transaction.
+// Mode defaults to best effort; every item is created in OrgID. Items similar to existing
+// knowledge fail unless Force is set for the batch or the item.
+type BatchCreateInput struct {
+	OrgID string
+	Mode  string
+	Force bool
+	Items []CreateInput
+}
+
+// BatchDeprecateInput represents the input for deprecating knowledge items in one transaction.
+// Items of another organization are reported as not found.
+type BatchDeprecateInput struct {
+	OrgID string
+	Mode  string
+	Items []DeprecateInput
+}
+
+// BatchItemResult is the outcome of one item of a batch, in input order
+type BatchItemResult struct {
+	Index     int
+	Status    string
+	Knowledge *domain.Knowledge
+	Err       error
+}
+
+// BatchOutput reports the outcome of a batch. RolledBack is set when an atomic batch
+// failed and nothing was written.
+type BatchOutput struct {
+	Mode       string
+	Results    []*BatchItemResult
+	Succeeded  int
+	Failed     int
+	RolledBack bool
+}
+
+// BatchCreate creates knowledge items with their first versions and embedding jobs in a single transaction
+func (s *KnowledgeService) BatchCreate(ctx context.Context, input BatchCreateInput) (*BatchOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.BatchCreate", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "batch_create",
+	})
+	defer span.End()
+
+	out, err := newBatchOutput(input.Mode, len(input.Items))
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate every item before anything is written
+	records := make([]*knowledgeRecords, len(input.Items))
+	for i, item := range input.Items {
+		if field := missingCreateField(item); field != "" {
+			out.fail(i, domain.NewDomainError(domain.ErrCodeValidation, field+" is required"))
+			continue
+		}
+		item.OrgID = input.OrgID
+		rec, err := s.newKnowledgeRecords(ctx, item)
+		if err != nil {
+			out.fail(i, err)
+			continue
+		}
+		if !input.Force && !item.Force {
+			if err := s.checkDuplicates(ctx, rec.knowledge); err != nil {
+				out.fail(i, err)
+				continue
+			}
+		}
+		records[i] = rec
+	}
+
+	err = s.runBatch(ctx, out, BatchItemCreated, func(repos TxRepositories, i int) (*domain.Knowledge, error) {
+		if err := writeKnowledgeRecords(ctx, repos.Knowledge(), repos.EmbeddingJobs(), records[i]); err != nil {
+			return nil, err
+		}
+		return records[i].knowledge, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// BatchDeprecate deprecates knowledge items in a single transaction, optionally linking their successors
+func (s *KnowledgeService) BatchDeprecate(ctx context.Context, input BatchDeprecateInput) (*BatchOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.BatchDeprecate", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "batch_delete",
+	})
+	defer span.End()
+
+	out, err := newBatchOutput(input.Mode, len(input.Items))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range input.Items {
+		if _, err := uuid.Parse(item.KnowledgeID); err != nil {
+			out.fail(i, domain.ErrKnowledgeNotFound)
+		}
+	}
+
+	err = s.runBatch(ctx, out, BatchItemDeprecated, func(repos TxRepositories, i int) (*domain.Knowledge, error) {
+		item := input.Items[i]
+		knowledgeRepo := repos.Knowledge()
+
+		knowledge, err := knowledgeRepo.GetByID(ctx, item.KnowledgeID)
+		if err != nil {
+			return nil, err
+		}
+		if knowledge.OrgID != input.OrgID {
+			return nil, domain.ErrKnowledgeNotFound
+		}
+
+		if err := deprecateKnowledge(ctx, knowledgeRepo, knowledge, item.SupersededBy); err != nil {
+			return nil, err
+		}
+		return knowledge, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// runBatch writes the items of a batch that passed validation in one transaction. In
+// best-effort mode each item gets its own savepoint so a failing write only undoes that
+// item; in atomic mode the first failure rolls back the whole batch.
+func (s *KnowledgeService) runBatch(
+	ctx context.Context,
+	out *BatchOutput,
+	status string,
+	write func(repos TxRepositories, index int) (*domain.Knowledge, error),
+) error {
+	if out.Mode == BatchModeAtomic && out.Failed > 0 {
+		out.rollBack()
+		return nil
+	}
+
+	failedAt := -1
+	err := s.withTx(ctx, func(repos TxRepositories) error {
+		for _, result := range out.Results {
+			if result.Status == BatchItemFailed {
+				continue
+			}
+
+			var knowledge *domain.Knowledge
+			var err error
+			if out.Mode == BatchModeAtomic {
+				knowledge, err = write(repos, result.Index)
+			} else {
+				err = repos.Savepoint(ctx, func(sp TxRepositories) error {
+					var writeErr error
+					knowledge, writeErr = write(sp, result.Index)
+					return writeErr
+				})
+			}
+			if err != nil {
+				out.fail(result.Index, err)
+				if out.Mode == BatchModeAtomic {
+					failedAt = result.Index
+					return err
+				}
+				continue
+			}
+
+			result.Status = status
+			result.Knowledge = knowledge
+			out.Succeeded++
+		}
+		return nil
+	})
+	if err != nil {
+		if failedAt < 0 {
+			return err
+		}
+		out.rollBack()
+	}
+	return nil
+}
+
+// withTx runs a multi-item write in a transaction. Without a TxRunner the service repositories
+// are used directly and items written before a failing one cannot be undone.
+func (s *KnowledgeService) withTx(ctx context.Context, fn func(repos TxRepositories) error) error {
+	if s.txRunner != nil {
+		return s.txRunner.WithTx(ctx, fn)
+	}
+	return fn(&serviceRepos{s: s})
+}
+
+// serviceRepos exposes the service repositories as TxRepositories when no TxRunner is configured
+type serviceRepos struct {
+	s *KnowledgeService
+}
+
+func (r *serviceRepos) Knowledge() KnowledgeRepositoryInterface {
+	return r.s.knowledgeRepo
+}
+
+func (r *serviceRepos) EmbeddingJobs() EmbeddingJobRepositoryInterface {
+	return r.s.embeddingJobRepo
+}
+
+func (r *serviceRepos) Assets() AssetRepositoryInterface {
+	return nil
+}
+
+func (r *serviceRepos) Merges() KnowledgeMergeRepositoryInterface {
+	return nil
+}
+
+func (r *serviceRepos) Savepoint(ctx context.Context, fn func(repos TxRepositories) error) error {
+	return fn(r)
+}
+
+// missingCreateField names the first required field that a batch item leaves empty
+func missingCreateField(input CreateInput) string {
+	switch {
+	case input.Type == "":
+		return "type"
+	case input.Title == "":
+		return "title"
+	case input.BodyMD == "":
+		return "body_md"
+	}
+	return ""
+}
+
+// newBatchOutput checks the mode and size of a batch and prepares one result per item
+func newBatchOutput(mode string, size int) (*BatchOutput, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", BatchModeBestEffort:
+		mode = BatchModeBestEffort
+	case BatchModeAtomic:
+		mode = BatchModeAtomic
+	default:
+		return nil, domain.ErrInvalidBatchMode
+	}
+
+	if size == 0 {
+		return nil, domain.ErrBatchEmpty
+	}
+	if size > MaxBatchSize {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	out := &BatchOutput{Mode: mode, Results: make([]*BatchItemResult, size)}
+	for i := range out.Results {
+		out.Results[i] = &BatchItemResult{Index: i}
+	}
+	return out, nil
+}
+
+func (o *BatchOutput) fail(index int, err error) {
+	o.Results[index].Status = BatchItemFailed
+	o.Results[index].Err = err
+	o.Failed++
+}
+
+// rollBack marks every item that did not fail as rolled back
+func (o *BatchOutput) rollBack() {
+	for _, result := range o.Results {
+		if result.Status != BatchItemFailed {
+			result.Status = BatchItemRolledBack
+			result.Knowledge = nil
+		}
+	}
+	o.Succeeded = 0
+	o.RolledBack = true
+}
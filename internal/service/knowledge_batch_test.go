@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestService() (*KnowledgeService, *MockKnowledgeRepository, *MockEmbeddingJobRepository, *testTxRunner) {
+	mockKnowledgeRepo := new(MockKnowledgeRepository)
+	mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+	txRunner := &testTxRunner{repos: &testTxRepos{
+		knowledge:     mockKnowledgeRepo,
+		embeddingJobs: mockEmbeddingJobRepo,
+	}}
+	return NewKnowledgeServiceWithTx(mockKnowledgeRepo, mockEmbeddingJobRepo, txRunner), mockKnowledgeRepo, mockEmbeddingJobRepo, txRunner
+}
+
+func batchCreateItems(titles ...string) []CreateInput {
+	items := make([]CreateInput, len(titles))
+	for i, title := range titles {
+		items[i] = CreateInput{Type: domain.KnowledgeTypeGuideline, Title: title, BodyMD: "Body"}
+	}
+	return items
+}
+
+func withTitle(title string) interface{} {
+	return mock.MatchedBy(func(k *domain.Knowledge) bool { return k.Title == title })
+}
+
+func TestKnowledgeService_BatchCreate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("best effort writes the items that succeed", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, txRunner := newBatchTestService()
+
+		knowledgeRepo.On("Create", mock.Anything, withTitle("A")).Return(nil)
+		knowledgeRepo.On("Create", mock.Anything, withTitle("C")).Return(errors.New("duplicate key"))
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchCreate(ctx, BatchCreateInput{
+			OrgID: "org-1",
+			Items: batchCreateItems("A", "", "C"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, BatchModeBestEffort, out.Mode)
+		assert.False(t, out.RolledBack)
+		assert.Equal(t, 1, out.Succeeded)
+		assert.Equal(t, 2, out.Failed)
+		require.Len(t, out.Results, 3)
+		assert.Equal(t, BatchItemCreated, out.Results[0].Status)
+		assert.Equal(t, "org-1", out.Results[0].Knowledge.OrgID)
+		assert.Equal(t, BatchItemFailed, out.Results[1].Status)
+		assert.Contains(t, out.Results[1].Err.Error(), "title is required")
+		assert.Equal(t, BatchItemFailed, out.Results[2].Status)
+		assert.EqualError(t, out.Results[2].Err, "duplicate key")
+
+		// One savepoint per item that passed validation
+		assert.Equal(t, 2, txRunner.repos.(*testTxRepos).savepoints)
+		jobRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("atomic batch with an invalid item writes nothing", func(t *testing.T) {
+		svc, knowledgeRepo, _, txRunner := newBatchTestService()
+
+		out, err := svc.BatchCreate(ctx, BatchCreateInput{
+			OrgID: "org-1",
+			Mode:  BatchModeAtomic,
+			Items: batchCreateItems("A", ""),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, out.RolledBack)
+		assert.Equal(t, 0, out.Succeeded)
+		assert.Equal(t, 1, out.Failed)
+		assert.Equal(t, BatchItemRolledBack, out.Results[0].Status)
+		assert.Nil(t, out.Results[0].Knowledge)
+		assert.Equal(t, BatchItemFailed, out.Results[1].Status)
+		assert.False(t, txRunner.called)
+		knowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("atomic batch rolls back when a write fails", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, txRunner := newBatchTestService()
+
+		knowledgeRepo.On("Create", mock.Anything, withTitle("A")).Return(nil)
+		knowledgeRepo.On("Create", mock.Anything, withTitle("B")).Return(errors.New("project not found"))
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchCreate(ctx, BatchCreateInput{
+			OrgID: "org-1",
+			Mode:  BatchModeAtomic,
+			Items: batchCreateItems("A", "B", "C"),
+		})
+
+		require.NoError(t, err)
+		assert.True(t, txRunner.called)
+		assert.True(t, out.RolledBack)
+		assert.Equal(t, []string{BatchItemRolledBack, BatchItemFailed, BatchItemRolledBack},
+			[]string{out.Results[0].Status, out.Results[1].Status, out.Results[2].Status})
+		assert.Equal(t, 0, txRunner.repos.(*testTxRepos).savepoints)
+		knowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, withTitle("C"))
+	})
+
+	t.Run("rejects invalid batches", func(t *testing.T) {
+		svc, _, _, _ := newBatchTestService()
+
+		_, err := svc.BatchCreate(ctx, BatchCreateInput{OrgID: "org-1"})
+		assert.ErrorIs(t, err, domain.ErrBatchEmpty)
+
+		_, err = svc.BatchCreate(ctx, BatchCreateInput{OrgID: "org-1", Items: make([]CreateInput, MaxBatchSize+1)})
+		assert.ErrorIs(t, err, domain.ErrBatchTooLarge)
+
+		_, err = svc.BatchCreate(ctx, BatchCreateInput{OrgID: "org-1", Mode: "sometimes", Items: batchCreateItems("A")})
+		assert.ErrorIs(t, err, domain.ErrInvalidBatchMode)
+	})
+}
+
+func TestKnowledgeService_BatchDeprecate(t *testing.T) {
+	ctx := context.Background()
+	ownID := "11111111-1111-1111-1111-111111111111"
+	otherOrgID := "22222222-2222-2222-2222-222222222222"
+	successorID := "33333333-3333-3333-3333-333333333333"
+
+	t.Run("deprecates the items of the org", func(t *testing.T) {
+		svc, knowledgeRepo, _, _ := newBatchTestService()
+
+		knowledgeRepo.On("GetByID", mock.Anything, ownID).Return(&domain.Knowledge{ID: ownID, OrgID: "org-1", Status: domain.KnowledgeStatusApproved}, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, otherOrgID).Return(&domain.Knowledge{ID: otherOrgID, OrgID: "org-2"}, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, successorID).Return(&domain.Knowledge{ID: successorID, OrgID: "org-1", Status: domain.KnowledgeStatusApproved}, nil)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchDeprecate(ctx, BatchDeprecateInput{
+			OrgID: "org-1",
+			Items: []DeprecateInput{
+				{KnowledgeID: ownID, SupersededBy: successorID},
+				{KnowledgeID: otherOrgID},
+				{KnowledgeID: "not-a-uuid"},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, out.Succeeded)
+		assert.Equal(t, 2, out.Failed)
+		assert.Equal(t, BatchItemDeprecated, out.Results[0].Status)
+		assert.Equal(t, domain.KnowledgeStatusDeprecated, out.Results[0].Knowledge.Status)
+		assert.Equal(t, successorID, out.Results[0].Knowledge.SupersededBy)
+		assert.ErrorIs(t, out.Results[1].Err, domain.ErrKnowledgeNotFound)
+		assert.ErrorIs(t, out.Results[2].Err, domain.ErrKnowledgeNotFound)
+		knowledgeRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("atomic batch rolls back on a missing item", func(t *testing.T) {
+		svc, knowledgeRepo, _, _ := newBatchTestService()
+
+		knowledgeRepo.On("GetByID", mock.Anything, ownID).Return(&domain.Knowledge{ID: ownID, OrgID: "org-1"}, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, otherOrgID).Return(nil, domain.ErrKnowledgeNotFound)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.BatchDeprecate(ctx, BatchDeprecateInput{
+			OrgID: "org-1",
+			Mode:  BatchModeAtomic,
+			Items: []DeprecateInput{{KnowledgeID: ownID}, {KnowledgeID: otherOrgID}},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, out.RolledBack)
+		assert.Equal(t, BatchItemRolledBack, out.Results[0].Status)
+		assert.Equal(t, BatchItemFailed, out.Results[1].Status)
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// VersionDiffInput represents the input for diffing two versions of a knowledge item.
+// Zero values select defaults: To defaults to the latest version and From to the one before To.
+type VersionDiffInput struct {
+	OrgID       string
+	KnowledgeID string
+	From        int64
+	To          int64
+}
+
+// VersionDiff is a unified diff between two versions of a knowledge item
+type VersionDiff struct {
+	KnowledgeID string
+	From        int64
+	To          int64
+	Diff        string
+}
+
+// RevertInput represents the input for reverting a knowledge item to an earlier version
+type RevertInput struct {
+	OrgID         string
+	KnowledgeID   string
+	VersionNumber int64
+	Author        domain.Author
+}
+
+// ListVersions returns all versions of a knowledge item of the org, newest first
+func (s *KnowledgeService) ListVersions(ctx context.Context, orgID, knowledgeID string) ([]*domain.KnowledgeVersion, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.ListVersions", telemetry.SpanAttributes{
+		OrgID:       orgID,
+		KnowledgeID: knowledgeID,
+		Operation:   "list_versions",
+	})
+	defer span.End()
+
+	if _, err := s.getOrgKnowledge(ctx, orgID, knowledgeID); err != nil {
+		return nil, err
+	}
+
+	return s.knowledgeRepo.GetVersions(ctx, knowledgeID)
+}
+
+// GetVersion returns a single version of a knowledge item of the org
+func (s *KnowledgeService) GetVersion(ctx context.Context, orgID, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error) {
+	if _, err := s.getOrgKnowledge(ctx, orgID, knowledgeID); err != nil {
+		return nil, err
+	}
+	return s.knowledgeRepo.GetVersion(ctx, knowledgeID, versionNumber)
+}
+
+// DiffVersions returns a unified diff between two versions of a knowledge item
+func (s *KnowledgeService) DiffVersions(ctx context.Context, input VersionDiffInput) (*VersionDiff, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.DiffVersions", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "diff",
+	})
+	defer span.End()
+
+	if _, err := s.getOrgKnowledge(ctx, input.OrgID, input.KnowledgeID); err != nil {
+		return nil, err
+	}
+
+	var to *domain.KnowledgeVersion
+	var err error
+	if input.To > 0 {
+		to, err = s.knowledgeRepo.GetVersion(ctx, input.KnowledgeID, input.To)
+	} else {
+		to, err = s.knowledgeRepo.GetLatestVersion(ctx, input.KnowledgeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fromNumber := input.From
+	if fromNumber <= 0 {
+		fromNumber = to.VersionNumber - 1
+	}
+	if fromNumber <= 0 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "no earlier version to diff against")
+	}
+
+	from, err := s.knowledgeRepo.GetVersion(ctx, input.KnowledgeID, fromNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(renderVersion(from)),
+		B:        difflib.SplitLines(renderVersion(to)),
+		FromFile: fmt.Sprintf("v%d", from.VersionNumber),
+		ToFile:   fmt.Sprintf("v%d", to.VersionNumber),
+		Context:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionDiff{
+		KnowledgeID: input.KnowledgeID,
+		From:        from.VersionNumber,
+		To:          to.VersionNumber,
+		Diff:        diff,
+	}, nil
+}
+
+// Revert restores the content of an earlier version as a new version and queues an embedding job
+func (s *KnowledgeService) Revert(ctx context.Context, input RevertInput) (*domain.Knowledge, *domain.KnowledgeVersion, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Revert", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "revert",
+	})
+	defer span.End()
+
+	knowledge, err := s.getOrgKnowledge(ctx, input.OrgID, input.KnowledgeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := s.knowledgeRepo.GetVersion(ctx, input.KnowledgeID, input.VersionNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Versions do not track scope, so the current scope is kept
+	out, err := s.Update(ctx, UpdateInput{
+		KnowledgeID: input.KnowledgeID,
+		Title:       target.Title,
+		Summary:     target.Summary,
+		BodyMD:      target.BodyMD,
+		Scope:       knowledge.Scope,
+		Author:      input.Author,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.Knowledge, out.Version, nil
+}
+
+// getOrgKnowledge loads a knowledge item, hiding items that belong to another organization
+func (s *KnowledgeService) getOrgKnowledge(ctx context.Context, orgID, id string) (*domain.Knowledge, error) {
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if knowledge.OrgID != orgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	return knowledge, nil
+}
+
+// renderVersion flattens a version into the text compared by DiffVersions
+func renderVersion(v *domain.KnowledgeVersion) string {
+	return fmt.Sprintf("title: %s\nsummary: %s\n\n%s\n", v.Title, v.Summary, v.BodyMD)
+}
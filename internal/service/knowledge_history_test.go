@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVersion(n int64, title, body string) *domain.KnowledgeVersion {
+	return &domain.KnowledgeVersion{
+		ID:            "version-" + title,
+		KnowledgeID:   "knowledge-1",
+		VersionNumber: n,
+		Title:         title,
+		Summary:       "summary",
+		BodyMD:        body,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestKnowledgeService_ListVersions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns versions", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		versions := []*domain.KnowledgeVersion{newTestVersion(2, "b", "two"), newTestVersion(1, "a", "one")}
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetVersions", mock.Anything, "knowledge-1").Return(versions, nil)
+
+		result, err := service.ListVersions(ctx, "org-1", "knowledge-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, versions, result)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for unknown knowledge", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrKnowledgeNotFound)
+
+		result, err := service.ListVersions(ctx, "org-1", "missing")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetVersions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides knowledge of another org", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-2"}, nil)
+
+		result, err := service.ListVersions(ctx, "org-1", "knowledge-1")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetVersions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_GetVersion(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		version := newTestVersion(2, "b", "two")
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(2)).Return(version, nil)
+
+		result, err := service.GetVersion(ctx, "org-1", "knowledge-1", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, version, result)
+	})
+
+	t.Run("hides knowledge of another org", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-2"}, nil)
+
+		result, err := service.GetVersion(ctx, "org-1", "knowledge-1", 2)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetVersion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_DiffVersions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to latest against previous", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(newTestVersion(3, "Title", "line one\nline three"), nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(2)).Return(newTestVersion(2, "Title", "line one\nline two"), nil)
+
+		result, err := service.DiffVersions(ctx, VersionDiffInput{OrgID: "org-1", KnowledgeID: "knowledge-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.From)
+		assert.Equal(t, int64(3), result.To)
+		assert.Contains(t, result.Diff, "--- v2")
+		assert.Contains(t, result.Diff, "+++ v3")
+		assert.Contains(t, result.Diff, "-line two")
+		assert.Contains(t, result.Diff, "+line three")
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("diffs explicit versions", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(3)).Return(newTestVersion(3, "New", "body"), nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(1)).Return(newTestVersion(1, "Old", "body"), nil)
+
+		result, err := service.DiffVersions(ctx, VersionDiffInput{OrgID: "org-1", KnowledgeID: "knowledge-1", From: 1, To: 3})
+
+		require.NoError(t, err)
+		assert.Contains(t, result.Diff, "-title: Old")
+		assert.Contains(t, result.Diff, "+title: New")
+		mockKnowledgeRepo.AssertNotCalled(t, "GetLatestVersion", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails with a single version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(newTestVersion(1, "Title", "body"), nil)
+
+		result, err := service.DiffVersions(ctx, VersionDiffInput{OrgID: "org-1", KnowledgeID: "knowledge-1"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("returns not found for unknown version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(9)).Return(nil, domain.ErrVersionNotFound)
+
+		result, err := service.DiffVersions(ctx, VersionDiffInput{OrgID: "org-1", KnowledgeID: "knowledge-1", From: 1, To: 9})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrVersionNotFound, err)
+	})
+
+	t.Run("hides knowledge of another org", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-2"}, nil)
+
+		result, err := service.DiffVersions(ctx, VersionDiffInput{OrgID: "org-1", KnowledgeID: "knowledge-1"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetLatestVersion", mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_Revert(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates new version from old content and queues embedding", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+		mockUUIDGen := NewMockUUIDGenerator("version-id-4", "job-id-4")
+		service := NewKnowledgeServiceWithUUIDGen(mockKnowledgeRepo, mockEmbeddingJobRepo, mockUUIDGen)
+
+		existing := &domain.Knowledge{
+			ID:     "knowledge-1",
+			OrgID:  "org-1",
+			Status: domain.KnowledgeStatusApproved,
+			Title:  "Current",
+			BodyMD: "current body",
+			Scope:  "/backend",
+		}
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(1)).Return(newTestVersion(1, "Original", "original body"), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(newTestVersion(3, "Current", "current body"), nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Title == "Original" && k.BodyMD == "original body" && k.Scope == "/backend" &&
+				k.Status == domain.KnowledgeStatusDraft
+		})).Return(nil)
+		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.KnowledgeVersion) bool {
+			return v.VersionNumber == 4 && v.Title == "Original"
+		})).Return(nil)
+		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.MatchedBy(func(job *domain.EmbeddingJob) bool {
+			return job.KnowledgeID == "knowledge-1"
+		})).Return(nil)
+
+		knowledge, version, err := service.Revert(ctx, RevertInput{OrgID: "org-1", KnowledgeID: "knowledge-1", VersionNumber: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Original", knowledge.Title)
+		assert.Equal(t, domain.KnowledgeStatusDraft, knowledge.Status, "reverted content is reviewed again")
+		assert.Equal(t, int64(4), version.VersionNumber)
+		mockKnowledgeRepo.AssertExpectations(t)
+		mockEmbeddingJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for unknown version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+		mockKnowledgeRepo.On("GetVersion", mock.Anything, "knowledge-1", int64(7)).Return(nil, domain.ErrVersionNotFound)
+
+		_, _, err := service.Revert(ctx, RevertInput{OrgID: "org-1", KnowledgeID: "knowledge-1", VersionNumber: 7})
+
+		assert.Equal(t, domain.ErrVersionNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses knowledge of another org", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-2"}, nil)
+
+		_, _, err := service.Revert(ctx, RevertInput{OrgID: "org-1", KnowledgeID: "knowledge-1", VersionNumber: 1})
+
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetVersion", mock.Anything, mock.Anything, mock.Anything)
+		mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
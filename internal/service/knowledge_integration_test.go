@@ -71,10 +71,11 @@ func TestKnowledgeServiceIntegration_Create(t *testing.T) {
 		}
 
 		// Execute
-		knowledge, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.NoError(t, err)
+		knowledge := out.Knowledge
 		assert.NotEmpty(t, knowledge.ID)
 		assert.Equal(t, org.ID, knowledge.OrgID)
 		assert.Equal(t, project.ID, knowledge.ProjectID)
@@ -120,9 +121,10 @@ func TestKnowledgeServiceIntegration_Create(t *testing.T) {
 			BodyMD:  "# Org Learning",
 		}
 
-		knowledge, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		require.NoError(t, err)
+		knowledge := out.Knowledge
 		assert.NotEmpty(t, knowledge.ID)
 		assert.Equal(t, org.ID, knowledge.OrgID)
 		assert.Empty(t, knowledge.ProjectID)
@@ -154,8 +156,9 @@ func TestKnowledgeServiceIntegration_GetByID(t *testing.T) {
 			Summary: "Test decision summary",
 			BodyMD:  "# Decision\n\nWe decided to...",
 		}
-		created, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 		require.NoError(t, err)
+		created := out.Knowledge
 
 		// Retrieve it
 		retrieved, err := service.GetByID(ctx, created.ID)
@@ -198,8 +201,9 @@ func TestKnowledgeServiceIntegration_Update(t *testing.T) {
 			Summary: "Original summary",
 			BodyMD:  "# Original Body",
 		}
-		created, err := service.Create(ctx, createInput)
+		out, err := service.Create(ctx, createInput)
 		require.NoError(t, err)
+		created := out.Knowledge
 
 		// Update it
 		updateInput := UpdateInput{
@@ -209,10 +213,11 @@ func TestKnowledgeServiceIntegration_Update(t *testing.T) {
 			BodyMD:      "# Updated Body\n\nWith more content.",
 			Scope:       "/templates/new.go",
 		}
-		updated, newVersion, err := service.Update(ctx, updateInput)
+		updateOut, err := service.Update(ctx, updateInput)
 
 		// Assert
 		require.NoError(t, err)
+		updated, newVersion := updateOut.Knowledge, updateOut.Version
 		assert.Equal(t, created.ID, updated.ID)
 		assert.Equal(t, "Updated Template", updated.Title)
 		assert.Equal(t, "Updated summary", updated.Summary)
@@ -256,10 +261,11 @@ func TestKnowledgeServiceIntegration_Update(t *testing.T) {
 			Summary: "Will be deprecated",
 			BodyMD:  "# Deprecated",
 		}
-		created, err := service.Create(ctx, createInput)
+		out, err := service.Create(ctx, createInput)
 		require.NoError(t, err)
+		created := out.Knowledge
 
-		_, err = service.Deprecate(ctx, created.ID)
+		_, err = service.Deprecate(ctx, DeprecateInput{KnowledgeID: created.ID})
 		require.NoError(t, err)
 
 		// Try to update
@@ -269,7 +275,7 @@ func TestKnowledgeServiceIntegration_Update(t *testing.T) {
 			Summary:     "This should fail",
 			BodyMD:      "# Fail",
 		}
-		_, _, err = service.Update(ctx, updateInput)
+		_, err = service.Update(ctx, updateInput)
 
 		require.Error(t, err)
 		assert.Equal(t, domain.ErrCannotModifyDeprecated, err)
@@ -300,12 +306,13 @@ func TestKnowledgeServiceIntegration_Deprecate(t *testing.T) {
 			Summary: "Will be deprecated",
 			BodyMD:  "# Code Snippet",
 		}
-		created, err := service.Create(ctx, createInput)
+		out, err := service.Create(ctx, createInput)
 		require.NoError(t, err)
+		created := out.Knowledge
 		assert.Equal(t, domain.KnowledgeStatusDraft, created.Status)
 
 		// Deprecate it
-		deprecated, err := service.Deprecate(ctx, created.ID)
+		deprecated, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: created.ID})
 
 		require.NoError(t, err)
 		assert.Equal(t, domain.KnowledgeStatusDeprecated, deprecated.Status)
@@ -446,8 +453,9 @@ func TestKnowledgeServiceIntegration_GetLatestVersion(t *testing.T) {
 			Summary: "Initial checklist",
 			BodyMD:  "- [ ] Item 1",
 		}
-		created, err := service.Create(ctx, createInput)
+		out, err := service.Create(ctx, createInput)
 		require.NoError(t, err)
+		created := out.Knowledge
 
 		// Update twice
 		for i := 2; i <= 3; i++ {
@@ -457,7 +465,7 @@ func TestKnowledgeServiceIntegration_GetLatestVersion(t *testing.T) {
 				Summary:     "Checklist version " + string(rune('0'+i)),
 				BodyMD:      "- [x] Item 1\n- [ ] Item " + string(rune('0'+i)),
 			}
-			_, _, err = service.Update(ctx, updateInput)
+			_, err = service.Update(ctx, updateInput)
 			require.NoError(t, err)
 		}
 
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// KnowledgeMergeRepositoryInterface re-points everything that references one knowledge item
+// at another
+type KnowledgeMergeRepositoryInterface interface {
+	MoveReferences(ctx context.Context, orgID, sourceID, targetID string) (*MovedReferences, error)
+}
+
+// MovedReferences counts the references moved from a merged item to its target
+type MovedReferences struct {
+	AssetLinks int
+	Relations  int
+	SearchLogs int
+}
+
+// MergeMode controls how the source body is combined with the target body
+type MergeMode string
+
+const (
+	// MergeModeAppend adds the source body below the target body
+	MergeModeAppend MergeMode = "append"
+	// MergeModeReplace replaces the target body with the source body
+	MergeModeReplace MergeMode = "replace"
+)
+
+// errMergeRequiresTx is returned when the service has no TxRunner to merge with
+var errMergeRequiresTx = errors.New("merging knowledge requires a transaction runner")
+
+// MergeInput represents the input for merging a source item into a target item.
+// ExpectedVersion optionally guards against concurrent edits of the target.
+type MergeInput struct {
+	OrgID           string
+	TargetID        string
+	SourceID        string
+	Mode            MergeMode
+	ExpectedVersion int64
+	Author          domain.Author
+}
+
+// MergeOutput holds the merged target, the deprecated source, what was moved and the lint
+// findings of the merged body
+type MergeOutput struct {
+	Target       *domain.Knowledge
+	Version      *domain.KnowledgeVersion
+	Source       *domain.Knowledge
+	Moved        MovedReferences
+	LintFindings []domain.LintFinding
+}
+
+// Merge folds the source item into the target in a single transaction. The target gets a new
+// version with the combined body and is re-embedded; asset links, relations and search-log
+// references move to it; the source is deprecated as superseded by the target. The merged
+// body is checked like an update, and an approved target goes back to draft for review. The
+// version histories of both items are kept as they are.
+func (s *KnowledgeService) Merge(ctx context.Context, input MergeInput) (*MergeOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Merge", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.TargetID,
+		Operation:   "merge",
+	})
+	defer span.End()
+
+	mode := input.Mode
+	if mode == "" {
+		mode = MergeModeAppend
+	}
+	if mode != MergeModeAppend && mode != MergeModeReplace {
+		return nil, domain.ErrInvalidMergeMode
+	}
+	if input.SourceID == input.TargetID {
+		return nil, domain.ErrMergeSelf
+	}
+	if _, err := uuid.Parse(input.TargetID); err != nil {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	if _, err := uuid.Parse(input.SourceID); err != nil {
+		return nil, domain.ErrMergeSourceNotFound
+	}
+	if s.txRunner == nil {
+		return nil, errMergeRequiresTx
+	}
+
+	out := &MergeOutput{}
+	now := time.Now().UTC()
+	err := s.txRunner.WithTx(ctx, func(repos TxRepositories) error {
+		knowledgeRepo := repos.Knowledge()
+
+		target, err := knowledgeRepo.GetByID(ctx, input.TargetID)
+		if err != nil {
+			return err
+		}
+		if target.OrgID != input.OrgID {
+			return domain.ErrKnowledgeNotFound
+		}
+		if target.Status == domain.KnowledgeStatusDeprecated {
+			return domain.ErrCannotModifyDeprecated
+		}
+
+		source, err := knowledgeRepo.GetByID(ctx, input.SourceID)
+		if err != nil {
+			if errors.Is(err, domain.ErrKnowledgeNotFound) {
+				return domain.ErrMergeSourceNotFound
+			}
+			return err
+		}
+		if source.OrgID != input.OrgID {
+			return domain.ErrMergeSourceNotFound
+		}
+		if source.Status == domain.KnowledgeStatusDeprecated {
+			return domain.ErrCannotModifyDeprecated
+		}
+
+		latestVersion, err := knowledgeRepo.GetLatestVersion(ctx, target.ID)
+		if err != nil {
+			return err
+		}
+		if input.ExpectedVersion > 0 && latestVersion.VersionNumber != input.ExpectedVersion {
+			return domain.ErrVersionConflict
+		}
+
+		body := mergeBodies(target.BodyMD, source.BodyMD, mode)
+		contentChanged := body != target.BodyMD
+		target.BodyMD = body
+		target.UpdatedAt = now
+		target.UpdatedBy = input.Author
+
+		findings, err := s.lintKnowledge(ctx, target)
+		if err != nil {
+			return err
+		}
+
+		sendBackForReview(target, contentChanged)
+
+		if err := knowledgeRepo.Update(ctx, target); err != nil {
+			return err
+		}
+
+		version := &domain.KnowledgeVersion{
+			ID:            s.uuidGen.NewString(),
+			KnowledgeID:   target.ID,
+			VersionNumber: latestVersion.VersionNumber + 1,
+			Title:         target.Title,
+			Summary:       target.Summary,
+			BodyMD:        target.BodyMD,
+			CreatedAt:     now,
+			CreatedBy:     input.Author,
+		}
+		if err := knowledgeRepo.CreateVersion(ctx, version); err != nil {
+			return err
+		}
+
+		job := &domain.EmbeddingJob{
+			ID:          s.uuidGen.NewString(),
+			KnowledgeID: target.ID,
+			Status:      domain.EmbeddingJobStatusPending,
+			CreatedAt:   now,
+		}
+		if err := repos.EmbeddingJobs().Create(ctx, job); err != nil {
+			return err
+		}
+
+		moved, err := repos.Merges().MoveReferences(ctx, input.OrgID, source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+
+		source.UpdatedBy = input.Author
+		if err := deprecateKnowledge(ctx, knowledgeRepo, source, target.ID); err != nil {
+			return err
+		}
+
+		out.Target = target
+		out.Version = version
+		out.Source = source
+		out.Moved = *moved
+		out.LintFindings = findings
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// mergeBodies combines the target and source bodies for the given mode
+func mergeBodies(target, source string, mode MergeMode) string {
+	if mode == MergeModeReplace {
+		return source
+	}
+	target = strings.TrimRight(target, "\n")
+	source = strings.TrimLeft(source, "\n")
+	if target == "" {
+		return source
+	}
+	return target + "\n\n" + source
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockKnowledgeMergeRepository struct {
+	mock.Mock
+}
+
+func (m *MockKnowledgeMergeRepository) MoveReferences(ctx context.Context, orgID, sourceID, targetID string) (*MovedReferences, error) {
+	args := m.Called(ctx, orgID, sourceID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MovedReferences), args.Error(1)
+}
+
+const (
+	mergeTargetID = "11111111-1111-1111-1111-111111111111"
+	mergeSourceID = "22222222-2222-2222-2222-222222222222"
+)
+
+func newMergeTestService() (*KnowledgeService, *MockKnowledgeRepository, *MockEmbeddingJobRepository, *MockKnowledgeMergeRepository) {
+	knowledgeRepo := new(MockKnowledgeRepository)
+	jobRepo := new(MockEmbeddingJobRepository)
+	mergeRepo := new(MockKnowledgeMergeRepository)
+	txRunner := &testTxRunner{repos: &testTxRepos{
+		knowledge:     knowledgeRepo,
+		embeddingJobs: jobRepo,
+		merges:        mergeRepo,
+	}}
+	return NewKnowledgeServiceWithTx(knowledgeRepo, jobRepo, txRunner), knowledgeRepo, jobRepo, mergeRepo
+}
+
+func mergeTestItems() (*domain.Knowledge, *domain.Knowledge) {
+	target := &domain.Knowledge{
+		ID:     mergeTargetID,
+		OrgID:  "org-1",
+		Status: domain.KnowledgeStatusApproved,
+		Title:  "Retry flaky tests",
+		BodyMD: "Retry once before failing the build.\n",
+	}
+	source := &domain.Knowledge{
+		ID:     mergeSourceID,
+		OrgID:  "org-1",
+		Status: domain.KnowledgeStatusDraft,
+		Title:  "Flaky test retries",
+		BodyMD: "Quarantine tests that fail twice.",
+	}
+	return target, source
+}
+
+func TestKnowledgeService_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("appends the source and deprecates it", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, mergeRepo := newMergeTestService()
+		target, source := mergeTestItems()
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, mergeTargetID).Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.KnowledgeVersion) bool {
+			return v.KnowledgeID == mergeTargetID && v.VersionNumber == 4
+		})).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j *domain.EmbeddingJob) bool {
+			return j.KnowledgeID == mergeTargetID
+		})).Return(nil)
+		mergeRepo.On("MoveReferences", mock.Anything, "org-1", mergeSourceID, mergeTargetID).Return(&MovedReferences{AssetLinks: 1, Relations: 2}, nil)
+
+		out, err := svc.Merge(ctx, MergeInput{
+			OrgID:    "org-1",
+			TargetID: mergeTargetID,
+			SourceID: mergeSourceID,
+			Author:   domain.Author{Name: "jane"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Retry once before failing the build.\n\nQuarantine tests that fail twice.", out.Target.BodyMD)
+		assert.Equal(t, int64(4), out.Version.VersionNumber)
+		assert.Equal(t, domain.KnowledgeStatusDraft, out.Target.Status, "merged content is reviewed again")
+		assert.Equal(t, domain.KnowledgeStatusDeprecated, out.Source.Status)
+		assert.Equal(t, mergeTargetID, out.Source.SupersededBy)
+		assert.Equal(t, 2, out.Moved.Relations)
+		knowledgeRepo.AssertNumberOfCalls(t, "CreateVersion", 1)
+	})
+
+	t.Run("replace uses the source body", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, mergeRepo := newMergeTestService()
+		target, source := mergeTestItems()
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, mergeTargetID).Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		mergeRepo.On("MoveReferences", mock.Anything, "org-1", mergeSourceID, mergeTargetID).Return(&MovedReferences{}, nil)
+
+		out, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID, Mode: MergeModeReplace})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Quarantine tests that fail twice.", out.Target.BodyMD)
+	})
+
+	t.Run("rejects a stale expected version", func(t *testing.T) {
+		svc, knowledgeRepo, _, mergeRepo := newMergeTestService()
+		target, source := mergeTestItems()
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, mergeTargetID).Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+
+		_, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID, ExpectedVersion: 2})
+
+		assert.ErrorIs(t, err, domain.ErrVersionConflict)
+		knowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mergeRepo.AssertNotCalled(t, "MoveReferences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a source from another organization", func(t *testing.T) {
+		svc, knowledgeRepo, _, _ := newMergeTestService()
+		target, source := mergeTestItems()
+		source.OrgID = "org-2"
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+
+		_, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID})
+
+		assert.ErrorIs(t, err, domain.ErrMergeSourceNotFound)
+	})
+
+	t.Run("rejects a deprecated source", func(t *testing.T) {
+		svc, knowledgeRepo, _, _ := newMergeTestService()
+		target, source := mergeTestItems()
+		source.Status = domain.KnowledgeStatusDeprecated
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+
+		_, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID})
+
+		assert.ErrorIs(t, err, domain.ErrCannotModifyDeprecated)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		svc, _, _, _ := newMergeTestService()
+
+		_, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeTargetID})
+		assert.ErrorIs(t, err, domain.ErrMergeSelf)
+
+		_, err = svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID, Mode: "interleave"})
+		assert.ErrorIs(t, err, domain.ErrInvalidMergeMode)
+
+		_, err = svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: "not-a-uuid"})
+		assert.ErrorIs(t, err, domain.ErrMergeSourceNotFound)
+	})
+
+	t.Run("reject mode refuses a merged body with lint errors", func(t *testing.T) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		mergeRepo := new(MockKnowledgeMergeRepository)
+		txRunner := &testTxRunner{repos: &testTxRepos{knowledge: knowledgeRepo, merges: mergeRepo}}
+		configs := new(MockLintConfigRepository)
+		configs.On("Get", mock.Anything, "org-1").Return(&domain.LintConfig{Mode: domain.LintModeReject}, nil)
+		typeRepo := new(MockKnowledgeTypeRepository)
+		typeRepo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeTypeDecision).Return(&domain.KnowledgeTypeDefinition{
+			OrgID:            "org-1",
+			Name:             domain.KnowledgeTypeDecision,
+			RequiredSections: []string{"Context", "Decision"},
+		}, nil)
+		lint := NewLintService(configs, knowledgeRepo, new(MockAssetRepository), NewKnowledgeTypeService(typeRepo))
+		svc := NewKnowledgeServiceWithLint(knowledgeRepo, new(MockEmbeddingJobRepository), txRunner, nil, nil, lint)
+
+		target, source := mergeTestItems()
+		target.Type = domain.KnowledgeTypeDecision
+		target.Summary = "Retries"
+		target.BodyMD = "## Context\n\nTests are flaky.\n\n## Decision\n\nRetry once.\n"
+		source.BodyMD = "## Context\n\nQuarantine tests that fail twice.\n"
+
+		knowledgeRepo.On("GetByID", mock.Anything, mergeTargetID).Return(target, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, mergeSourceID).Return(source, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, mergeTargetID).Return(&domain.KnowledgeVersion{VersionNumber: 2}, nil)
+
+		out, err := svc.Merge(ctx, MergeInput{OrgID: "org-1", TargetID: mergeTargetID, SourceID: mergeSourceID, Mode: MergeModeReplace})
+
+		assert.Nil(t, out)
+		assert.ErrorIs(t, err, domain.ErrLintFailed)
+		knowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mergeRepo.AssertNotCalled(t, "MoveReferences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
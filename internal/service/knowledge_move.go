@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+)
+
+// MoveScopeInput represents the input for rewriting the scope prefix of knowledge items.
+// ProjectID optionally limits the move to one project; DryRun lists the affected items
+// without changing them.
+type MoveScopeInput struct {
+	OrgID     string
+	ProjectID string
+	From      string
+	To        string
+	DryRun    bool
+	Author    domain.Author
+}
+
+// ScopeMove describes the new scope of one knowledge item. Version is the version
+// recorded for the move and is zero on a dry run.
+type ScopeMove struct {
+	Knowledge *domain.Knowledge
+	OldScope  string
+	NewScope  string
+	Version   int64
+}
+
+// MoveScopeOutput lists the items whose scope was, or on a dry run would be, moved
+type MoveScopeOutput struct {
+	From   string
+	To     string
+	DryRun bool
+	Moves  []*ScopeMove
+}
+
+// MoveScope rewrites the scope of every item at or below the From prefix to the To prefix in
+// a single transaction. Each moved item gets a new version with unchanged content, so no
+// embedding job is queued; the scope stored with its search chunks is updated in place.
+func (s *KnowledgeService) MoveScope(ctx context.Context, input MoveScopeInput) (*MoveScopeOutput, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.MoveScope", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "move_scope",
+	})
+	defer span.End()
+
+	from := domain.NormalizeScopePrefix(input.From)
+	to := domain.NormalizeScopePrefix(input.To)
+	if from == "" || to == "" || from == to {
+		return nil, domain.ErrInvalidScopeMove
+	}
+
+	out := &MoveScopeOutput{From: from, To: to, DryRun: input.DryRun}
+
+	if input.DryRun {
+		items, err := s.knowledgeRepo.ListByScopePrefix(ctx, input.OrgID, input.ProjectID, from)
+		if err != nil {
+			return nil, err
+		}
+		out.Moves = planScopeMoves(items, from, to)
+		return out, nil
+	}
+
+	now := time.Now().UTC()
+	err := s.withTx(ctx, func(repos TxRepositories) error {
+		knowledgeRepo := repos.Knowledge()
+
+		items, err := knowledgeRepo.ListByScopePrefix(ctx, input.OrgID, input.ProjectID, from)
+		if err != nil {
+			return err
+		}
+		moves := planScopeMoves(items, from, to)
+
+		for _, move := range moves {
+			knowledge := move.Knowledge
+
+			latestVersion, err := knowledgeRepo.GetLatestVersion(ctx, knowledge.ID)
+			if err != nil {
+				return err
+			}
+
+			knowledge.Scope = move.NewScope
+			knowledge.UpdatedAt = now
+			knowledge.UpdatedBy = input.Author
+			if err := knowledgeRepo.Update(ctx, knowledge); err != nil {
+				return err
+			}
+
+			version := &domain.KnowledgeVersion{
+				ID:            s.uuidGen.NewString(),
+				KnowledgeID:   knowledge.ID,
+				VersionNumber: latestVersion.VersionNumber + 1,
+				Title:         knowledge.Title,
+				Summary:       knowledge.Summary,
+				BodyMD:        knowledge.BodyMD,
+				CreatedAt:     now,
+				CreatedBy:     input.Author,
+			}
+			if err := knowledgeRepo.CreateVersion(ctx, version); err != nil {
+				return err
+			}
+			move.Version = version.VersionNumber
+		}
+
+		out.Moves = moves
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// planScopeMoves computes the new scope of each item below the from prefix
+func planScopeMoves(items []*domain.Knowledge, from, to string) []*ScopeMove {
+	moves := make([]*ScopeMove, 0, len(items))
+	for _, item := range items {
+		newScope, ok := domain.MoveScope(item.Scope, from, to)
+		if !ok {
+			continue
+		}
+		moves = append(moves, &ScopeMove{Knowledge: item, OldScope: item.Scope, NewScope: newScope})
+	}
+	return moves
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func scopedKnowledge(id, scope string) *domain.Knowledge {
+	return &domain.Knowledge{ID: id, OrgID: "org-1", Title: "Title " + id, BodyMD: "Body " + id, Scope: scope, Status: domain.KnowledgeStatusApproved}
+}
+
+func TestKnowledgeService_MoveScope(t *testing.T) {
+	ctx := context.Background()
+	author := domain.Author{APIKeyID: "key-1", Name: "alice"}
+
+	t.Run("moves items and records a version without re-embedding", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo, txRunner := newBatchTestService()
+
+		knowledgeRepo.On("ListByScopePrefix", mock.Anything, "org-1", "", "services/auth").Return([]*domain.Knowledge{
+			scopedKnowledge("k-1", "services/auth"),
+			scopedKnowledge("k-2", "services/auth/handlers/*.go"),
+		}, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, "k-1").Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, "k-2").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.KnowledgeVersion) bool {
+			return v.CreatedBy == author && v.BodyMD == "Body "+v.KnowledgeID
+		})).Return(nil)
+
+		out, err := svc.MoveScope(ctx, MoveScopeInput{
+			OrgID:  "org-1",
+			From:   "services/auth/",
+			To:     "platform/identity",
+			Author: author,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, txRunner.called)
+		assert.Equal(t, "services/auth", out.From)
+		require.Len(t, out.Moves, 2)
+		assert.Equal(t, "platform/identity", out.Moves[0].NewScope)
+		assert.Equal(t, int64(4), out.Moves[0].Version)
+		assert.Equal(t, "services/auth/handlers/*.go", out.Moves[1].OldScope)
+		assert.Equal(t, "platform/identity/handlers/*.go", out.Moves[1].Knowledge.Scope)
+		assert.Equal(t, author, out.Moves[1].Knowledge.UpdatedBy)
+		assert.Equal(t, int64(2), out.Moves[1].Version)
+		knowledgeRepo.AssertNumberOfCalls(t, "CreateVersion", 2)
+		jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run lists items without writing", func(t *testing.T) {
+		svc, knowledgeRepo, _, txRunner := newBatchTestService()
+
+		knowledgeRepo.On("ListByScopePrefix", mock.Anything, "org-1", "proj-1", "apps/web").Return([]*domain.Knowledge{
+			scopedKnowledge("k-1", "apps/web/src"),
+		}, nil)
+
+		out, err := svc.MoveScope(ctx, MoveScopeInput{
+			OrgID:     "org-1",
+			ProjectID: "proj-1",
+			From:      "apps/web",
+			To:        "apps/site",
+			DryRun:    true,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, txRunner.called)
+		assert.True(t, out.DryRun)
+		require.Len(t, out.Moves, 1)
+		assert.Equal(t, "apps/site/src", out.Moves[0].NewScope)
+		assert.Equal(t, "apps/web/src", out.Moves[0].Knowledge.Scope)
+		assert.Zero(t, out.Moves[0].Version)
+		knowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects empty or identical prefixes", func(t *testing.T) {
+		svc, _, _, _ := newBatchTestService()
+
+		for _, input := range []MoveScopeInput{
+			{OrgID: "org-1", From: "", To: "b"},
+			{OrgID: "org-1", From: "a", To: "/"},
+			{OrgID: "org-1", From: "a/", To: "a"},
+		} {
+			_, err := svc.MoveScope(ctx, input)
+			assert.ErrorIs(t, err, domain.ErrInvalidScopeMove)
+		}
+	})
+
+	t.Run("a failing write fails the move", func(t *testing.T) {
+		svc, knowledgeRepo, _, _ := newBatchTestService()
+
+		knowledgeRepo.On("ListByScopePrefix", mock.Anything, "org-1", "", "a").Return([]*domain.Knowledge{
+			scopedKnowledge("k-1", "a/x"),
+		}, nil)
+		knowledgeRepo.On("GetLatestVersion", mock.Anything, "k-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		knowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		out, err := svc.MoveScope(ctx, MoveScopeInput{OrgID: "org-1", From: "a", To: "b"})
+
+		assert.Nil(t, out)
+		assert.EqualError(t, err, "connection reset")
+	})
+}
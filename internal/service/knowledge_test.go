@@ -47,6 +47,22 @@ func (m *MockKnowledgeRepository) ListByProject(ctx context.Context, projectID s
 	return args.Get(0).([]*domain.Knowledge), args.Error(1)
 }
 
+func (m *MockKnowledgeRepository) ListByScopePrefix(ctx context.Context, orgID, projectID, prefix string) ([]*domain.Knowledge, error) {
+	args := m.Called(ctx, orgID, projectID, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Knowledge), args.Error(1)
+}
+
+func (m *MockKnowledgeRepository) ListWithSource(ctx context.Context, orgID, projectID string) ([]*domain.Knowledge, error) {
+	args := m.Called(ctx, orgID, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Knowledge), args.Error(1)
+}
+
 func (m *MockKnowledgeRepository) Update(ctx context.Context, k *domain.Knowledge) error {
 	args := m.Called(ctx, k)
 	return args.Error(0)
@@ -73,6 +89,14 @@ func (m *MockKnowledgeRepository) GetVersions(ctx context.Context, knowledgeID s
 	return args.Get(0).([]*domain.KnowledgeVersion), args.Error(1)
 }
 
+func (m *MockKnowledgeRepository) GetVersion(ctx context.Context, knowledgeID string, versionNumber int64) (*domain.KnowledgeVersion, error) {
+	args := m.Called(ctx, knowledgeID, versionNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeVersion), args.Error(1)
+}
+
 func (m *MockKnowledgeRepository) ListByOrgWithCursor(ctx context.Context, orgID string, cursor *pagination.Cursor, limit int) (*KnowledgePageResult, error) {
 	args := m.Called(ctx, orgID, cursor, limit)
 	if args.Get(0) == nil {
@@ -89,6 +113,14 @@ func (m *MockKnowledgeRepository) ListByProjectWithCursor(ctx context.Context, p
 	return args.Get(0).(*KnowledgePageResult), args.Error(1)
 }
 
+func (m *MockKnowledgeRepository) ListWithCursor(ctx context.Context, filter KnowledgeListFilter, cursor *pagination.Cursor, limit int) (*KnowledgePageResult, error) {
+	args := m.Called(ctx, filter, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*KnowledgePageResult), args.Error(1)
+}
+
 // MockEmbeddingJobRepository is a mock implementation of EmbeddingJobRepositoryInterface
 type MockEmbeddingJobRepository struct {
 	mock.Mock
@@ -170,10 +202,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		})).Return(nil)
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.NoError(t, err)
+		result := out.Knowledge
 		assert.NotNil(t, result)
 		assert.Equal(t, "knowledge-id-1", result.ID)
 		assert.Equal(t, "org-1", result.OrgID)
@@ -203,11 +236,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		}
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.Contains(t, err.Error(), "Title")
 	})
 
@@ -228,11 +261,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		}
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.Contains(t, err.Error(), "BodyMD")
 	})
 
@@ -256,11 +289,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		mockKnowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(expectedErr)
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.Equal(t, expectedErr, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
@@ -286,11 +319,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(expectedErr)
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.Equal(t, expectedErr, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
@@ -317,11 +350,11 @@ func TestKnowledgeService_Create(t *testing.T) {
 		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(expectedErr)
 
 		// Execute
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.Equal(t, expectedErr, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 		mockEmbeddingJobRepo.AssertExpectations(t)
@@ -349,10 +382,10 @@ func TestKnowledgeService_Create(t *testing.T) {
 		mockKnowledgeRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Knowledge")).Return(nil)
 		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*domain.KnowledgeVersion")).Return(errors.New("version error"))
 
-		result, err := service.Create(ctx, input)
+		out, err := service.Create(ctx, input)
 
 		assert.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, out)
 		assert.True(t, txRunner.called)
 		mockEmbeddingJobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 		mockKnowledgeRepo.AssertExpectations(t)
@@ -529,10 +562,11 @@ func TestKnowledgeService_Update(t *testing.T) {
 		})).Return(nil)
 
 		// Execute
-		knowledge, version, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		// Assert
 		require.NoError(t, err)
+		knowledge, version := out.Knowledge, out.Version
 		assert.NotNil(t, knowledge)
 		assert.NotNil(t, version)
 		assert.Equal(t, "Updated Title", knowledge.Title)
@@ -542,6 +576,53 @@ func TestKnowledgeService_Update(t *testing.T) {
 		mockEmbeddingJobRepo.AssertExpectations(t)
 	})
 
+	t.Run("normalizes tags and keeps them when omitted", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			tags     []string
+			expected []string
+		}{
+			{name: "replaced", tags: []string{" Deploy ", "deploy", "CI"}, expected: []string{"deploy", "ci"}},
+			{name: "kept", tags: nil, expected: []string{"infra"}},
+			{name: "cleared", tags: []string{}, expected: []string{}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				mockKnowledgeRepo := new(MockKnowledgeRepository)
+				mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+				mockUUIDGen := NewMockUUIDGenerator("version-id-2", "job-id-2")
+
+				service := NewKnowledgeServiceWithUUIDGen(mockKnowledgeRepo, mockEmbeddingJobRepo, mockUUIDGen)
+
+				existingKnowledge := &domain.Knowledge{
+					ID:     "knowledge-1",
+					OrgID:  "org-1",
+					Type:   domain.KnowledgeTypeGuideline,
+					Status: domain.KnowledgeStatusDraft,
+					Title:  "Original Title",
+					BodyMD: "# Original Body",
+					Tags:   []string{"infra"},
+				}
+
+				mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existingKnowledge, nil)
+				mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+				mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+				mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+				out, err := service.Update(ctx, UpdateInput{
+					KnowledgeID: "knowledge-1",
+					Title:       "Original Title",
+					BodyMD:      "# Original Body",
+					Tags:        tc.tags,
+				})
+
+				require.NoError(t, err)
+				knowledge := out.Knowledge
+				assert.Equal(t, tc.expected, knowledge.Tags)
+			})
+		}
+	})
+
 	t.Run("returns error when knowledge not found", func(t *testing.T) {
 		mockKnowledgeRepo := new(MockKnowledgeRepository)
 		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
@@ -558,12 +639,11 @@ func TestKnowledgeService_Update(t *testing.T) {
 		mockKnowledgeRepo.On("GetByID", mock.Anything, "non-existent").Return(nil, domain.ErrKnowledgeNotFound)
 
 		// Execute
-		knowledge, version, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, knowledge)
-		assert.Nil(t, version)
+		assert.Nil(t, out)
 		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
@@ -597,12 +677,11 @@ func TestKnowledgeService_Update(t *testing.T) {
 		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(deprecatedKnowledge, nil)
 
 		// Execute
-		knowledge, version, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, knowledge)
-		assert.Nil(t, version)
+		assert.Nil(t, out)
 		assert.Equal(t, domain.ErrCannotModifyDeprecated, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
@@ -644,12 +723,11 @@ func TestKnowledgeService_Update(t *testing.T) {
 		mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr)
 
 		// Execute
-		knowledge, version, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		// Assert
 		require.Error(t, err)
-		assert.Nil(t, knowledge)
-		assert.Nil(t, version)
+		assert.Nil(t, out)
 		assert.Equal(t, expectedErr, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
@@ -686,11 +764,10 @@ func TestKnowledgeService_Update(t *testing.T) {
 			BodyMD:      "Body",
 		}
 
-		updated, version, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		assert.Error(t, err)
-		assert.Nil(t, updated)
-		assert.Nil(t, version)
+		assert.Nil(t, out)
 		assert.True(t, txRunner.called)
 		mockEmbeddingJobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 		mockKnowledgeRepo.AssertExpectations(t)
@@ -726,7 +803,7 @@ func TestKnowledgeService_Deprecate(t *testing.T) {
 		})).Return(nil)
 
 		// Execute
-		result, err := service.Deprecate(ctx, "knowledge-1")
+		result, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: "knowledge-1"})
 
 		// Assert
 		require.NoError(t, err)
@@ -744,7 +821,7 @@ func TestKnowledgeService_Deprecate(t *testing.T) {
 		mockKnowledgeRepo.On("GetByID", mock.Anything, "non-existent").Return(nil, domain.ErrKnowledgeNotFound)
 
 		// Execute
-		result, err := service.Deprecate(ctx, "non-existent")
+		result, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: "non-existent"})
 
 		// Assert
 		require.Error(t, err)
@@ -774,7 +851,7 @@ func TestKnowledgeService_Deprecate(t *testing.T) {
 		mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(expectedErr)
 
 		// Execute
-		result, err := service.Deprecate(ctx, "knowledge-1")
+		result, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: "knowledge-1"})
 
 		// Assert
 		require.Error(t, err)
@@ -782,6 +859,60 @@ func TestKnowledgeService_Deprecate(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		mockKnowledgeRepo.AssertExpectations(t)
 	})
+
+	t.Run("links successor", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+		successorID := "5f0c6c1e-6f59-4d43-9e3c-2d1b8a7e4b10"
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1", Status: domain.KnowledgeStatusApproved}, nil)
+		mockKnowledgeRepo.On("GetByID", mock.Anything, successorID).Return(&domain.Knowledge{ID: successorID, OrgID: "org-1", Status: domain.KnowledgeStatusApproved}, nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Status == domain.KnowledgeStatusDeprecated && k.SupersededBy == successorID
+		})).Return(nil)
+
+		result, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: "knowledge-1", SupersededBy: successorID})
+
+		require.NoError(t, err)
+		assert.Equal(t, successorID, result.SupersededBy)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid successors", func(t *testing.T) {
+		otherOrgID := "0d7a3f7e-2b7c-4a53-8c55-7f3e0c9b1a21"
+		deprecatedID := "8b1e4f3c-9a62-4e0f-b7d5-3c2a1f6e9d84"
+		missingID := "c3a9d2e1-4b5f-4c6a-8d7e-9f0a1b2c3d4e"
+
+		tests := []struct {
+			name        string
+			successorID string
+			expected    error
+		}{
+			{"self", "knowledge-1", domain.ErrSupersedeSelf},
+			{"malformed id", "not-a-uuid", domain.ErrSuccessorNotFound},
+			{"missing", missingID, domain.ErrSuccessorNotFound},
+			{"other organization", otherOrgID, domain.ErrSuccessorNotFound},
+			{"deprecated", deprecatedID, domain.ErrSuccessorDeprecated},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockKnowledgeRepo := new(MockKnowledgeRepository)
+				service := NewKnowledgeService(mockKnowledgeRepo, new(MockEmbeddingJobRepository))
+
+				mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{ID: "knowledge-1", OrgID: "org-1"}, nil)
+				mockKnowledgeRepo.On("GetByID", mock.Anything, missingID).Return(nil, domain.ErrKnowledgeNotFound).Maybe()
+				mockKnowledgeRepo.On("GetByID", mock.Anything, otherOrgID).Return(&domain.Knowledge{ID: otherOrgID, OrgID: "org-2"}, nil).Maybe()
+				mockKnowledgeRepo.On("GetByID", mock.Anything, deprecatedID).Return(&domain.Knowledge{ID: deprecatedID, OrgID: "org-1", Status: domain.KnowledgeStatusDeprecated}, nil).Maybe()
+
+				result, err := service.Deprecate(ctx, DeprecateInput{KnowledgeID: "knowledge-1", SupersededBy: tt.successorID})
+
+				assert.Nil(t, result)
+				assert.Equal(t, tt.expected, err)
+				mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+		}
+	})
 }
 
 // TestKnowledgeService_ListByOrg tests the ListByOrg method
@@ -1025,10 +1156,11 @@ func TestKnowledgeService_Update_CreatesNewVersion(t *testing.T) {
 		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 		// Execute
-		_, newVersion, err := service.Update(ctx, input)
+		out, err := service.Update(ctx, input)
 
 		// Assert
 		require.NoError(t, err)
+		newVersion := out.Version
 		require.NotNil(t, capturedVersion)
 
 		// Verify new version was created with incremented version number
@@ -1046,3 +1178,660 @@ func TestKnowledgeService_Update_CreatesNewVersion(t *testing.T) {
 		mockKnowledgeRepo.AssertNotCalled(t, "UpdateVersion", mock.Anything, mock.Anything)
 	})
 }
+
+// TestKnowledgeService_Review tests the Approve and Reject methods
+func TestKnowledgeService_Review(t *testing.T) {
+	ctx := context.Background()
+	reviewer := domain.Author{APIKeyID: "key-reviewer", Name: "alice"}
+
+	newKnowledge := func(status domain.KnowledgeStatus) *domain.Knowledge {
+		return &domain.Knowledge{
+			ID:        "knowledge-1",
+			OrgID:     "org-1",
+			Type:      domain.KnowledgeTypeGuideline,
+			Status:    status,
+			Title:     "Test Knowledge",
+			BodyMD:    "# Test Body",
+			CreatedAt: time.Now().Add(-24 * time.Hour),
+			UpdatedAt: time.Now().Add(-24 * time.Hour),
+			CreatedBy: domain.Author{APIKeyID: "key-agent", Name: "claude"},
+		}
+	}
+
+	t.Run("approves pending knowledge", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusDraft), nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Status == domain.KnowledgeStatusApproved &&
+				k.ReviewedBy == reviewer &&
+				k.ReviewedAt != nil
+		})).Return(nil)
+
+		result, err := service.Approve(ctx, ReviewInput{OrgID: "org-1", KnowledgeID: "knowledge-1", Reviewer: reviewer})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.KnowledgeStatusApproved, result.Status)
+		assert.Equal(t, reviewer, result.ReviewedBy)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects pending knowledge with note", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusDraft), nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.Reject(ctx, ReviewInput{OrgID: "org-1", KnowledgeID: "knowledge-1", Reviewer: reviewer, Note: "Too vague"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.KnowledgeStatusRejected, result.Status)
+		assert.Equal(t, "Too vague", result.ReviewNote)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("reject requires note", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		result, err := service.Reject(ctx, ReviewInput{OrgID: "org-1", KnowledgeID: "knowledge-1", Reviewer: reviewer})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrReviewNoteRequired, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires reviewer", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		result, err := service.Approve(ctx, ReviewInput{OrgID: "org-1", KnowledgeID: "knowledge-1"})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrReviewerRequired, err)
+	})
+
+	t.Run("refuses approval with the key that wrote the draft", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusDraft), nil)
+
+		result, err := service.Approve(ctx, ReviewInput{
+			OrgID:       "org-1",
+			KnowledgeID: "knowledge-1",
+			Reviewer:    domain.Author{APIKeyID: "key-agent", Name: "alice"},
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrSelfApproval, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides knowledge of another org", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusDraft), nil)
+
+		result, err := service.Approve(ctx, ReviewInput{OrgID: "org-2", KnowledgeID: "knowledge-1", Reviewer: reviewer})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrKnowledgeNotFound, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects transition from non-pending status", func(t *testing.T) {
+		tests := []struct {
+			status domain.KnowledgeStatus
+			want   error
+		}{
+			{domain.KnowledgeStatusApproved, domain.ErrKnowledgeNotPending},
+			{domain.KnowledgeStatusRejected, domain.ErrKnowledgeNotPending},
+			{domain.KnowledgeStatusDeprecated, domain.ErrCannotModifyDeprecated},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.status), func(t *testing.T) {
+				mockKnowledgeRepo := new(MockKnowledgeRepository)
+				mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+				service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+				mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(tt.status), nil)
+
+				result, err := service.Approve(ctx, ReviewInput{OrgID: "org-1", KnowledgeID: "knowledge-1", Reviewer: reviewer})
+
+				require.Error(t, err)
+				assert.Nil(t, result)
+				assert.Equal(t, tt.want, err)
+				mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("update of rejected knowledge returns it to review", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusRejected), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Status == domain.KnowledgeStatusDraft
+		})).Return(nil)
+		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := service.Update(ctx, UpdateInput{KnowledgeID: "knowledge-1", Title: "Fixed", BodyMD: "# Fixed"})
+
+		require.NoError(t, err)
+		result := out.Knowledge
+		assert.Equal(t, domain.KnowledgeStatusDraft, result.Status)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("content edit of approved knowledge returns it to review", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusApproved), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Status == domain.KnowledgeStatusDraft
+		})).Return(nil)
+		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := service.Update(ctx, UpdateInput{KnowledgeID: "knowledge-1", Title: "Test Knowledge", BodyMD: "# Changed Body"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.KnowledgeStatusDraft, out.Knowledge.Status)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+
+	t.Run("metadata edit of approved knowledge keeps it approved", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(newKnowledge(domain.KnowledgeStatusApproved), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+			return k.Status == domain.KnowledgeStatusApproved
+		})).Return(nil)
+		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := service.Update(ctx, UpdateInput{
+			KnowledgeID: "knowledge-1",
+			Title:       "Test Knowledge",
+			BodyMD:      "# Test Body",
+			Scope:       "/src",
+			Tags:        []string{"go"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.KnowledgeStatusApproved, out.Knowledge.Status)
+		mockKnowledgeRepo.AssertExpectations(t)
+	})
+}
+
+// TestKnowledgeService_ListPendingReview tests the ListPendingReview method
+func TestKnowledgeService_ListPendingReview(t *testing.T) {
+	ctx := context.Background()
+
+	mockKnowledgeRepo := new(MockKnowledgeRepository)
+	mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+	service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+	expectedFilter := KnowledgeListFilter{OrgID: "org-1", ProjectID: "project-1", Status: domain.KnowledgeStatusDraft}
+	mockKnowledgeRepo.On("ListWithCursor", mock.Anything, expectedFilter, (*pagination.Cursor)(nil), 20).Return(&KnowledgePageResult{
+		Items: []*domain.Knowledge{{ID: "knowledge-1", Status: domain.KnowledgeStatusDraft}},
+	}, nil)
+
+	result, err := service.ListPendingReview(ctx, ListKnowledgeInput{OrgID: "org-1", ProjectID: "project-1"})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.False(t, result.HasMore)
+	mockKnowledgeRepo.AssertExpectations(t)
+}
+
+func TestKnowledgeService_ListStale(t *testing.T) {
+	ctx := context.Background()
+
+	mockKnowledgeRepo := new(MockKnowledgeRepository)
+	mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+	service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+	staleSince := time.Now().Add(-time.Hour)
+	expectedFilter := KnowledgeListFilter{OrgID: "org-1", Owner: "platform-team", Stale: true}
+	mockKnowledgeRepo.On("ListWithCursor", mock.Anything, expectedFilter, (*pagination.Cursor)(nil), 20).Return(&KnowledgePageResult{
+		Items: []*domain.Knowledge{{ID: "knowledge-1", Owner: "platform-team", StaleSince: &staleSince}},
+	}, nil)
+
+	result, err := service.ListStale(ctx, ListStaleInput{OrgID: "org-1", Owner: " platform-team "})
+
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.True(t, result.Items[0].IsStale())
+	mockKnowledgeRepo.AssertExpectations(t)
+}
+
+// TestKnowledgeService_Update_ReviewSchedule tests review-by dates and stale flags on Update
+func TestKnowledgeService_Update_ReviewSchedule(t *testing.T) {
+	ctx := context.Background()
+
+	past := time.Now().Add(-48 * time.Hour).UTC()
+	future := time.Now().Add(30 * 24 * time.Hour).UTC()
+	owner := "platform-team"
+
+	for _, tc := range []struct {
+		name          string
+		reviewAfter   *time.Time
+		owner         *string
+		expectedAfter *time.Time
+		expectedOwner string
+		expectStale   bool
+	}{
+		{name: "kept when omitted", expectedAfter: &past, expectedOwner: "docs-team", expectStale: true},
+		{name: "moved forward clears stale flag", reviewAfter: &future, owner: &owner, expectedAfter: &future, expectedOwner: owner},
+		{name: "removed clears stale flag", reviewAfter: &time.Time{}, expectedAfter: nil, expectedOwner: "docs-team"},
+		{name: "still overdue stays stale", reviewAfter: &past, expectedAfter: &past, expectedOwner: "docs-team", expectStale: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKnowledgeRepo := new(MockKnowledgeRepository)
+			mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+			service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+			reviewAfter := past
+			staleSince := past
+			existing := &domain.Knowledge{
+				ID:          "knowledge-1",
+				OrgID:       "org-1",
+				Status:      domain.KnowledgeStatusApproved,
+				Title:       "Title",
+				BodyMD:      "Body",
+				ReviewAfter: &reviewAfter,
+				Owner:       "docs-team",
+				StaleSince:  &staleSince,
+			}
+
+			mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+			mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+			mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+			mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			out, err := service.Update(ctx, UpdateInput{
+				KnowledgeID: "knowledge-1",
+				Title:       "Title",
+				BodyMD:      "Body",
+				ReviewAfter: tc.reviewAfter,
+				Owner:       tc.owner,
+			})
+
+			require.NoError(t, err)
+			knowledge := out.Knowledge
+			assert.Equal(t, tc.expectedAfter, knowledge.ReviewAfter)
+			assert.Equal(t, tc.expectedOwner, knowledge.Owner)
+			assert.Equal(t, tc.expectStale, knowledge.IsStale())
+		})
+	}
+}
+
+func TestKnowledgeService_Create_Language(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name             string
+		knowledgeType    domain.KnowledgeType
+		language         string
+		body             string
+		expectedLanguage string
+	}{
+		{name: "snippet language detected from fence", knowledgeType: domain.KnowledgeTypeSnippet, body: "Retry:\n\n```golang\nfor {}\n```", expectedLanguage: "go"},
+		{name: "explicit language wins over fence", knowledgeType: domain.KnowledgeTypeSnippet, language: "TS", body: "```js\nx()\n```", expectedLanguage: "typescript"},
+		{name: "snippet without fence has no language", knowledgeType: domain.KnowledgeTypeSnippet, body: "SELECT 1", expectedLanguage: ""},
+		{name: "guideline is not detected", knowledgeType: domain.KnowledgeTypeGuideline, body: "```python\npass\n```", expectedLanguage: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKnowledgeRepo := new(MockKnowledgeRepository)
+			mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+			mockUUIDGen := NewMockUUIDGenerator("knowledge-id-1", "version-id-1", "job-id-1")
+
+			service := NewKnowledgeServiceWithUUIDGen(mockKnowledgeRepo, mockEmbeddingJobRepo, mockUUIDGen)
+
+			mockKnowledgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+				return k.Language == tc.expectedLanguage
+			})).Return(nil)
+			mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+			mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			out, err := service.Create(ctx, CreateInput{
+				OrgID:    "org-1",
+				Type:     tc.knowledgeType,
+				Title:    "Title",
+				BodyMD:   tc.body,
+				Language: tc.language,
+			})
+
+			require.NoError(t, err)
+			result := out.Knowledge
+			assert.Equal(t, tc.expectedLanguage, result.Language)
+			mockKnowledgeRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("rejects invalid language", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		out, err := service.Create(ctx, CreateInput{
+			OrgID:    "org-1",
+			Type:     domain.KnowledgeTypeSnippet,
+			Title:    "Title",
+			BodyMD:   "x",
+			Language: "not a language",
+		})
+
+		require.ErrorIs(t, err, domain.ErrInvalidLanguage)
+		assert.Nil(t, out)
+		mockKnowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_Update_Language(t *testing.T) {
+	ctx := context.Background()
+
+	python := "Python"
+	empty := ""
+
+	for _, tc := range []struct {
+		name             string
+		language         *string
+		expectedLanguage string
+	}{
+		{name: "kept when omitted", expectedLanguage: "go"},
+		{name: "replaced", language: &python, expectedLanguage: "python"},
+		{name: "cleared falls back to fence", language: &empty, expectedLanguage: "bash"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKnowledgeRepo := new(MockKnowledgeRepository)
+			mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+			service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+			existing := &domain.Knowledge{
+				ID:       "knowledge-1",
+				OrgID:    "org-1",
+				Type:     domain.KnowledgeTypeSnippet,
+				Status:   domain.KnowledgeStatusDraft,
+				Title:    "Title",
+				BodyMD:   "Body",
+				Language: "go",
+			}
+
+			mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+			mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+			mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+			mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			out, err := service.Update(ctx, UpdateInput{
+				KnowledgeID: "knowledge-1",
+				Title:       "Title",
+				BodyMD:      "```sh\necho hi\n```",
+				Language:    tc.language,
+			})
+
+			require.NoError(t, err)
+			knowledge := out.Knowledge
+			assert.Equal(t, tc.expectedLanguage, knowledge.Language)
+		})
+	}
+}
+
+func TestKnowledgeService_Update_Source(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name         string
+		path, hash   string
+		expectedPath string
+		expectedHash string
+	}{
+		{name: "kept when omitted", expectedPath: "docs/deploys.md", expectedHash: "old"},
+		{name: "replaced", path: "docs/release/deploys.md", hash: "new", expectedPath: "docs/release/deploys.md", expectedHash: "new"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKnowledgeRepo := new(MockKnowledgeRepository)
+			mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+			service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+			existing := &domain.Knowledge{
+				ID:         "knowledge-1",
+				OrgID:      "org-1",
+				Type:       domain.KnowledgeTypeGuideline,
+				Status:     domain.KnowledgeStatusApproved,
+				Title:      "Deploys",
+				BodyMD:     "Body",
+				SourcePath: "docs/deploys.md",
+				SourceHash: "old",
+			}
+
+			mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+			mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+			mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+			mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			out, err := service.Update(ctx, UpdateInput{
+				KnowledgeID: "knowledge-1",
+				Title:       "Deploys",
+				BodyMD:      "New body",
+				SourcePath:  tc.path,
+				SourceHash:  tc.hash,
+			})
+
+			require.NoError(t, err)
+			knowledge := out.Knowledge
+			assert.Equal(t, tc.expectedPath, knowledge.SourcePath)
+			assert.Equal(t, tc.expectedHash, knowledge.SourceHash)
+		})
+	}
+}
+
+func TestKnowledgeService_Update_GeneratedMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name              string
+		title, summary    string
+		expectedGenerated string
+		expectedSuggested string
+	}{
+		{name: "kept when unchanged", title: "Deploys", summary: "Deploys go out on Tuesdays.", expectedGenerated: "gpt-4o-mini", expectedSuggested: "Tuesday deploys"},
+		{name: "summary edited", title: "Deploys", summary: "We deploy on Tuesdays.", expectedSuggested: "Tuesday deploys"},
+		{name: "title edited", title: "Tuesday deploys", summary: "Deploys go out on Tuesdays.", expectedGenerated: "gpt-4o-mini"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKnowledgeRepo := new(MockKnowledgeRepository)
+			mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+			service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+			existing := &domain.Knowledge{
+				ID:                 "knowledge-1",
+				OrgID:              "org-1",
+				Type:               domain.KnowledgeTypeGuideline,
+				Status:             domain.KnowledgeStatusApproved,
+				Title:              "Deploys",
+				Summary:            "Deploys go out on Tuesdays.",
+				BodyMD:             "Body",
+				SummaryGeneratedBy: "gpt-4o-mini",
+				SuggestedTitle:     "Tuesday deploys",
+			}
+
+			mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+			mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+			mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+			mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			out, err := service.Update(ctx, UpdateInput{
+				KnowledgeID: "knowledge-1",
+				Title:       tc.title,
+				Summary:     tc.summary,
+				BodyMD:      "New body",
+			})
+
+			require.NoError(t, err)
+			knowledge := out.Knowledge
+			assert.Equal(t, tc.expectedGenerated, knowledge.SummaryGeneratedBy)
+			assert.Equal(t, tc.expectedSuggested, knowledge.SuggestedTitle)
+		})
+	}
+}
+
+func TestKnowledgeService_Create_RecordsAuthor(t *testing.T) {
+	ctx := context.Background()
+	mockKnowledgeRepo := new(MockKnowledgeRepository)
+	mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+	mockUUIDGen := NewMockUUIDGenerator("knowledge-id-1", "version-id-1", "job-id-1")
+
+	service := NewKnowledgeServiceWithUUIDGen(mockKnowledgeRepo, mockEmbeddingJobRepo, mockUUIDGen)
+
+	author := domain.Author{APIKeyID: "key-1", Name: "release-bot"}
+	mockKnowledgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(k *domain.Knowledge) bool {
+		return k.CreatedBy == author && k.UpdatedBy == author
+	})).Return(nil)
+	mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.KnowledgeVersion) bool {
+		return v.CreatedBy == author
+	})).Return(nil)
+	mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.Create(ctx, CreateInput{
+		OrgID:  "org-1",
+		Type:   domain.KnowledgeTypeGuideline,
+		Title:  "Title",
+		BodyMD: "Body",
+		Author: author,
+	})
+
+	require.NoError(t, err)
+	mockKnowledgeRepo.AssertExpectations(t)
+}
+
+func TestKnowledgeService_Update_RecordsAuthor(t *testing.T) {
+	ctx := context.Background()
+	mockKnowledgeRepo := new(MockKnowledgeRepository)
+	mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+	service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+	creator := domain.Author{APIKeyID: "key-1", Name: "alice"}
+	editor := domain.Author{APIKeyID: "key-2", Name: "release-bot"}
+	existing := &domain.Knowledge{
+		ID:        "knowledge-1",
+		OrgID:     "org-1",
+		Type:      domain.KnowledgeTypeGuideline,
+		Status:    domain.KnowledgeStatusDraft,
+		Title:     "Title",
+		BodyMD:    "Body",
+		CreatedBy: creator,
+		UpdatedBy: creator,
+	}
+
+	mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing, nil)
+	mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+	mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.MatchedBy(func(v *domain.KnowledgeVersion) bool {
+		return v.VersionNumber == 2 && v.CreatedBy == editor
+	})).Return(nil)
+	mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	out, err := service.Update(ctx, UpdateInput{
+		KnowledgeID: "knowledge-1",
+		Title:       "Title",
+		BodyMD:      "New body",
+		Author:      editor,
+	})
+
+	require.NoError(t, err)
+	knowledge := out.Knowledge
+	assert.Equal(t, creator, knowledge.CreatedBy)
+	assert.Equal(t, editor, knowledge.UpdatedBy)
+	mockKnowledgeRepo.AssertExpectations(t)
+}
+
+// TestKnowledgeService_Update_ExpectedVersion tests optimistic concurrency on Update
+func TestKnowledgeService_Update_ExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+
+	existing := func() *domain.Knowledge {
+		return &domain.Knowledge{ID: "knowledge-1", OrgID: "org-1", Status: domain.KnowledgeStatusDraft, Title: "Title", BodyMD: "Body"}
+	}
+
+	t.Run("rejects stale expected version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing(), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+
+		_, err := service.Update(ctx, UpdateInput{KnowledgeID: "knowledge-1", Title: "New", BodyMD: "New", ExpectedVersion: 2})
+
+		assert.Equal(t, domain.ErrVersionConflict, err)
+		mockKnowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockEmbeddingJobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts matching expected version", func(t *testing.T) {
+		mockKnowledgeRepo := new(MockKnowledgeRepository)
+		mockEmbeddingJobRepo := new(MockEmbeddingJobRepository)
+
+		service := NewKnowledgeService(mockKnowledgeRepo, mockEmbeddingJobRepo)
+
+		mockKnowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(existing(), nil)
+		mockKnowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 3}, nil)
+		mockKnowledgeRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		mockKnowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		mockEmbeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := service.Update(ctx, UpdateInput{KnowledgeID: "knowledge-1", Title: "New", BodyMD: "New", ExpectedVersion: 3})
+
+		require.NoError(t, err)
+		version := out.Version
+		assert.Equal(t, int64(4), version.VersionNumber)
+	})
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+)
+
+// KnowledgeTypeRepositoryInterface defines the repository interface for the org type registry
+type KnowledgeTypeRepositoryInterface interface {
+	Create(ctx context.Context, d *domain.KnowledgeTypeDefinition) error
+	GetByName(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error)
+	ListByOrg(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error)
+	Update(ctx context.Context, d *domain.KnowledgeTypeDefinition) error
+	Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error
+	CountKnowledge(ctx context.Context, orgID string, name domain.KnowledgeType) (int, error)
+}
+
+// KnowledgeTypeResolver looks up the definition that applies to a type within an org
+type KnowledgeTypeResolver interface {
+	Resolve(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error)
+}
+
+// KnowledgeTypeService manages the per-org knowledge type registry
+type KnowledgeTypeService struct {
+	repo    KnowledgeTypeRepositoryInterface
+	uuidGen UUIDGenerator
+}
+
+// NewKnowledgeTypeService creates a new KnowledgeTypeService instance
+func NewKnowledgeTypeService(repo KnowledgeTypeRepositoryInterface) *KnowledgeTypeService {
+	return NewKnowledgeTypeServiceWithUUIDGen(repo, &DefaultUUIDGenerator{})
+}
+
+// NewKnowledgeTypeServiceWithUUIDGen creates a new KnowledgeTypeService with custom UUID generator (for testing)
+func NewKnowledgeTypeServiceWithUUIDGen(repo KnowledgeTypeRepositoryInterface, uuidGen UUIDGenerator) *KnowledgeTypeService {
+	return &KnowledgeTypeService{
+		repo:    repo,
+		uuidGen: uuidGen,
+	}
+}
+
+// CreateKnowledgeTypeInput represents the input for registering a knowledge type.
+// A zero SearchBoost uses the default of 1.
+type CreateKnowledgeTypeInput struct {
+	OrgID            string
+	Name             domain.KnowledgeType
+	DisplayName      string
+	RequiredSections []string
+	SearchBoost      float32
+}
+
+// UpdateKnowledgeTypeInput represents the input for changing a registered type.
+// Nil fields keep their current value; an empty RequiredSections removes the schema.
+type UpdateKnowledgeTypeInput struct {
+	OrgID            string
+	Name             domain.KnowledgeType
+	DisplayName      *string
+	RequiredSections []string
+	SearchBoost      *float32
+}
+
+// Create registers a new type for the org, or customizes a built-in one
+func (s *KnowledgeTypeService) Create(ctx context.Context, input CreateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeTypeService.Create", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "create_type",
+	})
+	defer span.End()
+
+	name := domain.KnowledgeType(strings.TrimSpace(string(input.Name)))
+	if !domain.IsValidKnowledgeTypeName(name) {
+		return nil, domain.ErrInvalidKnowledgeTypeName
+	}
+
+	displayName := strings.TrimSpace(input.DisplayName)
+	if displayName == "" {
+		displayName = domain.DefaultKnowledgeTypeDefinition(name).DisplayName
+	}
+	boost := input.SearchBoost
+	if boost == 0 {
+		boost = domain.DefaultSearchBoost
+	}
+
+	now := time.Now().UTC()
+	def := &domain.KnowledgeTypeDefinition{
+		ID:               s.uuidGen.NewString(),
+		OrgID:            input.OrgID,
+		Name:             name,
+		DisplayName:      displayName,
+		RequiredSections: domain.NormalizeSections(input.RequiredSections),
+		SearchBoost:      boost,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := domain.ValidateKnowledgeTypeDefinition(def); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid knowledge type", err)
+	}
+
+	if err := s.repo.Create(ctx, def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Get returns the registered definition, or the default for an uncustomized built-in type
+func (s *KnowledgeTypeService) Get(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error) {
+	def, err := s.repo.GetByName(ctx, orgID, name)
+	if err == nil {
+		return def, nil
+	}
+	if errors.Is(err, domain.ErrKnowledgeTypeNotFound) && domain.IsBuiltinKnowledgeType(name) {
+		return domain.DefaultKnowledgeTypeDefinition(name), nil
+	}
+	return nil, err
+}
+
+// Resolve implements KnowledgeTypeResolver; unknown types fail with ErrInvalidKnowledgeType
+func (s *KnowledgeTypeService) Resolve(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error) {
+	def, err := s.Get(ctx, orgID, name)
+	if errors.Is(err, domain.ErrKnowledgeTypeNotFound) {
+		return nil, domain.ErrInvalidKnowledgeType
+	}
+	return def, err
+}
+
+// List returns the built-in types merged with the org's registered types, sorted by name
+func (s *KnowledgeTypeService) List(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeTypeService.List", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "list_types",
+	})
+	defer span.End()
+
+	registered, err := s.repo.ListByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[domain.KnowledgeType]*domain.KnowledgeTypeDefinition, len(registered))
+	for _, def := range registered {
+		byName[def.Name] = def
+	}
+	for _, name := range domain.BuiltinKnowledgeTypes() {
+		if _, ok := byName[name]; !ok {
+			byName[name] = domain.DefaultKnowledgeTypeDefinition(name)
+		}
+	}
+
+	results := make([]*domain.KnowledgeTypeDefinition, 0, len(byName))
+	for _, def := range byName {
+		results = append(results, def)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results, nil
+}
+
+// Update changes a registered type; built-in types must be registered before they can be changed
+func (s *KnowledgeTypeService) Update(ctx context.Context, input UpdateKnowledgeTypeInput) (*domain.KnowledgeTypeDefinition, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeTypeService.Update", telemetry.SpanAttributes{
+		OrgID:     input.OrgID,
+		Operation: "update_type",
+	})
+	defer span.End()
+
+	def, err := s.repo.GetByName(ctx, input.OrgID, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DisplayName != nil {
+		def.DisplayName = strings.TrimSpace(*input.DisplayName)
+	}
+	if input.RequiredSections != nil {
+		def.RequiredSections = domain.NormalizeSections(input.RequiredSections)
+	}
+	if input.SearchBoost != nil {
+		def.SearchBoost = *input.SearchBoost
+	}
+	def.UpdatedAt = time.Now().UTC()
+
+	if err := domain.ValidateKnowledgeTypeDefinition(def); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid knowledge type", err)
+	}
+
+	if err := s.repo.Update(ctx, def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Delete removes a registered type. Custom types still used by knowledge cannot be removed;
+// removing a built-in override restores the defaults.
+func (s *KnowledgeTypeService) Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeTypeService.Delete", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "delete_type",
+	})
+	defer span.End()
+
+	if !domain.IsBuiltinKnowledgeType(name) {
+		count, err := s.repo.CountKnowledge(ctx, orgID, name)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrKnowledgeTypeInUse
+		}
+	}
+
+	return s.repo.Delete(ctx, orgID, name)
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKnowledgeTypeRepository is a mock implementation of KnowledgeTypeRepositoryInterface
+type MockKnowledgeTypeRepository struct {
+	mock.Mock
+}
+
+func (m *MockKnowledgeTypeRepository) Create(ctx context.Context, d *domain.KnowledgeTypeDefinition) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgeTypeRepository) GetByName(ctx context.Context, orgID string, name domain.KnowledgeType) (*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, orgID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeRepository) ListByOrg(ctx context.Context, orgID string) ([]*domain.KnowledgeTypeDefinition, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeTypeDefinition), args.Error(1)
+}
+
+func (m *MockKnowledgeTypeRepository) Update(ctx context.Context, d *domain.KnowledgeTypeDefinition) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgeTypeRepository) Delete(ctx context.Context, orgID string, name domain.KnowledgeType) error {
+	args := m.Called(ctx, orgID, name)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgeTypeRepository) CountKnowledge(ctx context.Context, orgID string, name domain.KnowledgeType) (int, error) {
+	args := m.Called(ctx, orgID, name)
+	return args.Int(0), args.Error(1)
+}
+
+func newRunbookType() *domain.KnowledgeTypeDefinition {
+	return &domain.KnowledgeTypeDefinition{
+		ID:               "kt-1",
+		OrgID:            "org-1",
+		Name:             "runbook",
+		DisplayName:      "Runbook",
+		RequiredSections: []string{"Steps", "Rollback"},
+		SearchBoost:      1.5,
+	}
+}
+
+func TestKnowledgeTypeService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies defaults and normalizes sections", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeServiceWithUUIDGen(repo, NewMockUUIDGenerator("kt-1"))
+
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.KnowledgeTypeDefinition")).Return(nil)
+
+		def, err := svc.Create(ctx, CreateKnowledgeTypeInput{
+			OrgID:            "org-1",
+			Name:             " runbook ",
+			RequiredSections: []string{"## Steps", "steps", "Rollback"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "kt-1", def.ID)
+		assert.Equal(t, domain.KnowledgeType("runbook"), def.Name)
+		assert.Equal(t, "Runbook", def.DisplayName)
+		assert.Equal(t, []string{"Steps", "Rollback"}, def.RequiredSections)
+		assert.Equal(t, domain.DefaultSearchBoost, def.SearchBoost)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid name", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		_, err := svc.Create(ctx, CreateKnowledgeTypeInput{OrgID: "org-1", Name: "Run Book"})
+
+		require.ErrorIs(t, err, domain.ErrInvalidKnowledgeTypeName)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an out of range boost", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		_, err := svc.Create(ctx, CreateKnowledgeTypeInput{OrgID: "org-1", Name: "runbook", SearchBoost: -1})
+
+		require.Error(t, err)
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	})
+}
+
+func TestKnowledgeTypeService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockKnowledgeTypeRepository)
+	svc := NewKnowledgeTypeService(repo)
+
+	repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(newRunbookType(), nil)
+	repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeTypeGuideline).Return(nil, domain.ErrKnowledgeTypeNotFound)
+	repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("unknown")).Return(nil, domain.ErrKnowledgeTypeNotFound)
+
+	def, err := svc.Resolve(ctx, "org-1", "runbook")
+	require.NoError(t, err)
+	assert.Equal(t, float32(1.5), def.SearchBoost)
+
+	def, err = svc.Resolve(ctx, "org-1", domain.KnowledgeTypeGuideline)
+	require.NoError(t, err)
+	assert.Equal(t, "Guideline", def.DisplayName)
+
+	_, err = svc.Resolve(ctx, "org-1", "unknown")
+	require.ErrorIs(t, err, domain.ErrInvalidKnowledgeType)
+
+	_, err = svc.Get(ctx, "org-1", "unknown")
+	require.ErrorIs(t, err, domain.ErrKnowledgeTypeNotFound)
+}
+
+func TestKnowledgeTypeService_List(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockKnowledgeTypeRepository)
+	svc := NewKnowledgeTypeService(repo)
+
+	override := domain.DefaultKnowledgeTypeDefinition(domain.KnowledgeTypeDecision)
+	override.ID = "kt-2"
+	override.DisplayName = "ADR"
+	repo.On("ListByOrg", mock.Anything, "org-1").Return([]*domain.KnowledgeTypeDefinition{override, newRunbookType()}, nil)
+
+	defs, err := svc.List(ctx, "org-1")
+
+	require.NoError(t, err)
+	require.Len(t, defs, len(domain.BuiltinKnowledgeTypes())+1)
+	for i := 1; i < len(defs); i++ {
+		assert.Less(t, defs[i-1].Name, defs[i].Name)
+	}
+	for _, d := range defs {
+		if d.Name == domain.KnowledgeTypeDecision {
+			assert.Equal(t, "ADR", d.DisplayName)
+		}
+	}
+}
+
+func TestKnowledgeTypeService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("changes only the given fields", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(newRunbookType(), nil)
+		repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.KnowledgeTypeDefinition")).Return(nil)
+
+		boost := float32(3)
+		def, err := svc.Update(ctx, UpdateKnowledgeTypeInput{OrgID: "org-1", Name: "runbook", SearchBoost: &boost})
+
+		require.NoError(t, err)
+		assert.Equal(t, float32(3), def.SearchBoost)
+		assert.Equal(t, "Runbook", def.DisplayName)
+		assert.Equal(t, []string{"Steps", "Rollback"}, def.RequiredSections)
+	})
+
+	t.Run("empty sections remove the schema", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(newRunbookType(), nil)
+		repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.KnowledgeTypeDefinition")).Return(nil)
+
+		def, err := svc.Update(ctx, UpdateKnowledgeTypeInput{OrgID: "org-1", Name: "runbook", RequiredSections: []string{}})
+
+		require.NoError(t, err)
+		assert.Empty(t, def.RequiredSections)
+	})
+
+	t.Run("rejects a blank display name", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		repo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(newRunbookType(), nil)
+
+		blank := " "
+		_, err := svc.Update(ctx, UpdateKnowledgeTypeInput{OrgID: "org-1", Name: "runbook", DisplayName: &blank})
+
+		require.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeTypeService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refuses to delete a custom type still in use", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		repo.On("CountKnowledge", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(2, nil)
+
+		err := svc.Delete(ctx, "org-1", "runbook")
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeTypeInUse)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("removes a built-in override without counting usage", func(t *testing.T) {
+		repo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeTypeService(repo)
+
+		repo.On("Delete", mock.Anything, "org-1", domain.KnowledgeTypeDecision).Return(nil)
+
+		require.NoError(t, svc.Delete(ctx, "org-1", domain.KnowledgeTypeDecision))
+		repo.AssertNotCalled(t, "CountKnowledge", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestKnowledgeService_Create_WithTypeRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func() (*KnowledgeService, *MockKnowledgeRepository, *MockKnowledgeTypeRepository) {
+		knowledgeRepo := new(MockKnowledgeRepository)
+		embeddingJobRepo := new(MockEmbeddingJobRepository)
+		typeRepo := new(MockKnowledgeTypeRepository)
+		svc := NewKnowledgeServiceWithTypes(knowledgeRepo, embeddingJobRepo, nil, NewKnowledgeTypeService(typeRepo))
+
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil).Maybe()
+		embeddingJobRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+		typeRepo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("runbook")).Return(newRunbookType(), nil)
+		typeRepo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeType("unknown")).Return(nil, domain.ErrKnowledgeTypeNotFound)
+		return svc, knowledgeRepo, typeRepo
+	}
+
+	t.Run("accepts a registered type with its sections", func(t *testing.T) {
+		svc, knowledgeRepo, _ := newService()
+
+		out, err := svc.Create(ctx, CreateInput{
+			OrgID:  "org-1",
+			Type:   "runbook",
+			Title:  "Restart the API",
+			BodyMD: "# Restart\n\n## Steps\n1. Drain\n\n## Rollback\nRedeploy.",
+		})
+
+		require.NoError(t, err)
+		k := out.Knowledge
+		assert.Equal(t, domain.KnowledgeType("runbook"), k.Type)
+		knowledgeRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("leaves required sections to the lint rules", func(t *testing.T) {
+		svc, knowledgeRepo, _ := newService()
+
+		_, err := svc.Create(ctx, CreateInput{
+			OrgID:  "org-1",
+			Type:   "runbook",
+			Title:  "Restart the API",
+			BodyMD: "# Restart\n\n## Steps\n1. Drain",
+		})
+
+		require.NoError(t, err)
+		knowledgeRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unregistered type", func(t *testing.T) {
+		svc, _, _ := newService()
+
+		_, err := svc.Create(ctx, CreateInput{OrgID: "org-1", Type: "unknown", Title: "T", BodyMD: "body"})
+
+		require.ErrorIs(t, err, domain.ErrInvalidKnowledgeType)
+	})
+}
+
+func TestKnowledgeService_Create_WithoutTypeRegistry(t *testing.T) {
+	svc := NewKnowledgeService(new(MockKnowledgeRepository), new(MockEmbeddingJobRepository))
+
+	_, err := svc.Create(context.Background(), CreateInput{OrgID: "org-1", Type: "runbook", Title: "T", BodyMD: "body"})
+
+	require.ErrorIs(t, err, domain.ErrInvalidKnowledgeType)
+}
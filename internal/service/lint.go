@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+)
+
+// LintConfigRepositoryInterface defines the repository interface for lint configurations
+type LintConfigRepositoryInterface interface {
+	// Get returns nil when the org has not set a configuration
+	Get(ctx context.Context, orgID string) (*domain.LintConfig, error)
+	Save(ctx context.Context, orgID string, config *domain.LintConfig, updatedAt time.Time) error
+}
+
+// LintError is returned when knowledge breaks rules of severity error in reject mode.
+// It matches domain.ErrLintFailed with errors.Is.
+type LintError struct {
+	Findings []domain.LintFinding
+}
+
+func (e *LintError) Error() string {
+	var messages []string
+	for _, f := range e.Findings {
+		if f.Severity == domain.LintSeverityError {
+			messages = append(messages, f.Rule+": "+f.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return domain.ErrLintFailed.Error()
+	}
+	return domain.ErrLintFailed.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *LintError) Unwrap() error {
+	return domain.ErrLintFailed
+}
+
+// LintService manages the lint configuration of organizations and lints knowledge
+// against it
+type LintService struct {
+	configs       LintConfigRepositoryInterface
+	knowledgeRepo KnowledgeRepositoryInterface
+	assetRepo     AssetRepositoryInterface
+	types         KnowledgeTypeResolver
+}
+
+// NewLintService creates a new LintService instance. The knowledge and asset
+// repositories are used to check that references point at something that exists, and
+// the type registry supplies the sections each type requires. Without a registry
+// required sections are not checked.
+func NewLintService(
+	configs LintConfigRepositoryInterface,
+	knowledgeRepo KnowledgeRepositoryInterface,
+	assetRepo AssetRepositoryInterface,
+	types KnowledgeTypeResolver,
+) *LintService {
+	return &LintService{
+		configs:       configs,
+		knowledgeRepo: knowledgeRepo,
+		assetRepo:     assetRepo,
+		types:         types,
+	}
+}
+
+// GetConfig returns the lint configuration of an org, or the default one if it has not
+// set any
+func (s *LintService) GetConfig(ctx context.Context, orgID string) (*domain.LintConfig, error) {
+	config, err := s.configs.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return domain.DefaultLintConfig(), nil
+	}
+	return config, nil
+}
+
+// UpdateConfig validates and stores the lint configuration of an org
+func (s *LintService) UpdateConfig(ctx context.Context, orgID string, config *domain.LintConfig) (*domain.LintConfig, error) {
+	ctx, span := telemetry.StartSpan(ctx, "LintService.UpdateConfig", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "update_lint_config",
+	})
+	defer span.End()
+
+	if err := domain.ValidateLintConfig(config); err != nil {
+		return nil, err
+	}
+	if err := s.configs.Save(ctx, orgID, config, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Lint checks k against the lint rules of its org and returns the org's mode with the
+// findings. Required sections come from the org's type registry, and besides the checks
+// of domain.LintKnowledge, references must point at knowledge or assets of the same org.
+// In off mode nothing is checked.
+func (s *LintService) Lint(ctx context.Context, k *domain.Knowledge) (domain.LintMode, []domain.LintFinding, error) {
+	config, err := s.GetConfig(ctx, k.OrgID)
+	if err != nil {
+		return "", nil, err
+	}
+	if config.Mode == domain.LintModeOff {
+		return config.Mode, nil, nil
+	}
+
+	def, err := s.typeDefinition(ctx, config, k)
+	if err != nil {
+		return "", nil, err
+	}
+
+	findings := domain.LintKnowledge(config, def, k)
+	if config.Enabled(domain.LintRuleBrokenReferences) {
+		broken, err := s.brokenReferences(ctx, config, k)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(broken) > 0 {
+			findings = append(findings, broken...)
+			domain.SortLintFindings(findings)
+		}
+	}
+	return config.Mode, findings, nil
+}
+
+// typeDefinition returns the registry definition of k's type when required sections are
+// checked. An unknown type has no sections to check.
+func (s *LintService) typeDefinition(ctx context.Context, config *domain.LintConfig, k *domain.Knowledge) (*domain.KnowledgeTypeDefinition, error) {
+	if s.types == nil || !config.Enabled(domain.LintRuleRequiredSections) {
+		return nil, nil
+	}
+	def, err := s.types.Resolve(ctx, k.OrgID, k.Type)
+	if errors.Is(err, domain.ErrInvalidKnowledgeType) {
+		return nil, nil
+	}
+	return def, err
+}
+
+// brokenReferences reports the well-formed references of k whose target does not exist
+// or belongs to another org. A reference of an item to itself is not checked, since a
+// new item is not stored yet.
+func (s *LintService) brokenReferences(ctx context.Context, config *domain.LintConfig, k *domain.Knowledge) ([]domain.LintFinding, error) {
+	var findings []domain.LintFinding
+	exists := make(map[string]bool)
+	for _, ref := range domain.LintReferences(k.BodyMD) {
+		if ref.Kind == "knowledge" && ref.ID == k.ID {
+			continue
+		}
+		key := ref.Kind + "://" + ref.ID
+		found, checked := exists[key]
+		if !checked {
+			var err error
+			if found, err = s.referenceExists(ctx, k.OrgID, ref); err != nil {
+				return nil, err
+			}
+			exists[key] = found
+		}
+		if !found {
+			findings = append(findings, domain.NewBrokenReferenceFinding(config, ref, "does not exist"))
+		}
+	}
+	return findings, nil
+}
+
+func (s *LintService) referenceExists(ctx context.Context, orgID string, ref domain.LintReference) (bool, error) {
+	if ref.Kind == "asset" {
+		asset, err := s.assetRepo.GetByID(ctx, ref.ID)
+		if errors.Is(err, domain.ErrAssetNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return asset.OrgID == orgID, nil
+	}
+
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, ref.ID)
+	if errors.Is(err, domain.ErrKnowledgeNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return knowledge.OrgID == orgID, nil
+}
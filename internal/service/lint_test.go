@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLintConfigRepository is a mock implementation of LintConfigRepositoryInterface
+type MockLintConfigRepository struct {
+	mock.Mock
+}
+
+func (m *MockLintConfigRepository) Get(ctx context.Context, orgID string) (*domain.LintConfig, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LintConfig), args.Error(1)
+}
+
+func (m *MockLintConfigRepository) Save(ctx context.Context, orgID string, config *domain.LintConfig, updatedAt time.Time) error {
+	args := m.Called(ctx, orgID, config, updatedAt)
+	return args.Error(0)
+}
+
+const (
+	lintAssetID     = "0b0e5f4e-8a1e-4c5b-9b1a-2f0c6d1e7a3b"
+	lintKnowledgeID = "11111111-1111-1111-1111-111111111111"
+	lintOtherID     = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestLintService_GetConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default when the org has none", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+		configs.On("Get", mock.Anything, "org-1").Return(nil, nil)
+
+		config, err := NewLintService(configs, nil, nil, nil).GetConfig(ctx, "org-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultLintConfig(), config)
+	})
+
+	t.Run("stored config", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+		configs.On("Get", mock.Anything, "org-1").Return(&domain.LintConfig{Mode: domain.LintModeReject}, nil)
+
+		config, err := NewLintService(configs, nil, nil, nil).GetConfig(ctx, "org-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LintModeReject, config.Mode)
+	})
+}
+
+func TestLintService_UpdateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves a valid config", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+		config := &domain.LintConfig{Mode: domain.LintModeReject, Rules: map[string]domain.LintRule{
+			domain.LintRuleSummaryRequired: {Severity: domain.LintSeverityError},
+		}}
+		configs.On("Save", mock.Anything, "org-1", config, mock.AnythingOfType("time.Time")).Return(nil)
+
+		saved, err := NewLintService(configs, nil, nil, nil).UpdateConfig(ctx, "org-1", config)
+
+		require.NoError(t, err)
+		assert.Equal(t, config, saved)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid config", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+
+		_, err := NewLintService(configs, nil, nil, nil).UpdateConfig(ctx, "org-1", &domain.LintConfig{Mode: "strict"})
+
+		var domainErr *domain.DomainError
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+		configs.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLintService_Lint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports references to missing or foreign items", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+		knowledgeRepo := new(MockKnowledgeRepository)
+		assetRepo := new(MockAssetRepository)
+		configs.On("Get", mock.Anything, "org-1").Return(nil, nil)
+		assetRepo.On("GetByID", mock.Anything, lintAssetID).Return(nil, domain.ErrAssetNotFound).Once()
+		knowledgeRepo.On("GetByID", mock.Anything, lintOtherID).Return(&domain.Knowledge{ID: lintOtherID, OrgID: "org-2"}, nil).Once()
+
+		k := &domain.Knowledge{
+			ID:      lintKnowledgeID,
+			OrgID:   "org-1",
+			Type:    domain.KnowledgeTypeGuideline,
+			Title:   "Deploys",
+			Summary: "When we deploy.",
+			BodyMD: "See knowledge://" + lintKnowledgeID + " and knowledge://" + lintOtherID + ".\n" +
+				"![diagram](asset://" + lintAssetID + ")\n" +
+				"Again: asset://" + lintAssetID + "\n",
+		}
+
+		mode, findings, err := NewLintService(configs, knowledgeRepo, assetRepo, nil).Lint(ctx, k)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LintModeWarn, mode)
+		assert.Equal(t, []domain.LintFinding{
+			{Rule: domain.LintRuleBrokenReferences, Severity: domain.LintSeverityError, Message: "knowledge://" + lintOtherID + " does not exist", Line: 1},
+			{Rule: domain.LintRuleBrokenReferences, Severity: domain.LintSeverityError, Message: "asset://" + lintAssetID + " does not exist", Line: 2},
+			{Rule: domain.LintRuleBrokenReferences, Severity: domain.LintSeverityError, Message: "asset://" + lintAssetID + " does not exist", Line: 3},
+		}, findings)
+		knowledgeRepo.AssertExpectations(t)
+		assetRepo.AssertExpectations(t)
+	})
+
+	t.Run("off mode checks nothing", func(t *testing.T) {
+		configs := new(MockLintConfigRepository)
+		configs.On("Get", mock.Anything, "org-1").Return(&domain.LintConfig{Mode: domain.LintModeOff}, nil)
+
+		mode, findings, err := NewLintService(configs, nil, nil, nil).Lint(ctx, &domain.Knowledge{OrgID: "org-1", BodyMD: "TODO"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.LintModeOff, mode)
+		assert.Empty(t, findings)
+	})
+}
+
+func newLintTestService(mode domain.LintMode) (*KnowledgeService, *MockKnowledgeRepository, *MockEmbeddingJobRepository) {
+	knowledgeRepo := new(MockKnowledgeRepository)
+	jobRepo := new(MockEmbeddingJobRepository)
+	configs := new(MockLintConfigRepository)
+	configs.On("Get", mock.Anything, "org-1").Return(&domain.LintConfig{Mode: mode}, nil)
+	typeRepo := new(MockKnowledgeTypeRepository)
+	typeRepo.On("GetByName", mock.Anything, "org-1", domain.KnowledgeTypeDecision).Return(&domain.KnowledgeTypeDefinition{
+		OrgID:            "org-1",
+		Name:             domain.KnowledgeTypeDecision,
+		RequiredSections: []string{"Context", "Decision", "Consequences"},
+	}, nil).Maybe()
+	typeRepo.On("GetByName", mock.Anything, "org-1", mock.Anything).Return(nil, domain.ErrKnowledgeTypeNotFound).Maybe()
+
+	lint := NewLintService(configs, knowledgeRepo, new(MockAssetRepository), NewKnowledgeTypeService(typeRepo))
+	return NewKnowledgeServiceWithLint(knowledgeRepo, jobRepo, nil, nil, nil, lint), knowledgeRepo, jobRepo
+}
+
+func TestKnowledgeService_Create_Lint(t *testing.T) {
+	ctx := context.Background()
+	input := CreateInput{
+		OrgID:     "org-1",
+		ProjectID: "proj-1",
+		Type:      domain.KnowledgeTypeDecision,
+		Title:     "Use Postgres",
+		BodyMD:    "## Context\n\nWe need a database. TODO\n",
+	}
+
+	t.Run("reject mode refuses errors", func(t *testing.T) {
+		svc, knowledgeRepo, _ := newLintTestService(domain.LintModeReject)
+
+		out, err := svc.Create(ctx, input)
+
+		assert.Nil(t, out)
+		assert.ErrorIs(t, err, domain.ErrLintFailed)
+		var lintErr *LintError
+		require.True(t, errors.As(err, &lintErr))
+		assert.Len(t, lintErr.Findings, 3)
+		assert.Contains(t, err.Error(), "required-sections: decision is missing sections: Decision, Consequences")
+		knowledgeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("warn mode stores the item with its findings", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo := newLintTestService(domain.LintModeWarn)
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		out, err := svc.Create(ctx, input)
+
+		require.NoError(t, err)
+		require.Len(t, out.LintFindings, 3)
+		assert.Equal(t, domain.LintRuleRequiredSections, out.LintFindings[0].Rule)
+		assert.Equal(t, domain.LintRuleNoPlaceholders, out.LintFindings[2].Rule)
+	})
+
+	t.Run("reject mode stores items with only warnings", func(t *testing.T) {
+		svc, knowledgeRepo, jobRepo := newLintTestService(domain.LintModeReject)
+		knowledgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		knowledgeRepo.On("CreateVersion", mock.Anything, mock.Anything).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		clean := input
+		clean.BodyMD = "## Context\n\nWe need a database.\n\n## Decision\n\nPostgres.\n\n## Consequences\n\nNone.\n"
+		out, err := svc.Create(ctx, clean)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.LintFinding{
+			{Rule: domain.LintRuleSummaryRequired, Severity: domain.LintSeverityWarning, Message: "summary is empty"},
+		}, out.LintFindings)
+	})
+}
+
+func TestKnowledgeService_Update_Lint(t *testing.T) {
+	ctx := context.Background()
+	svc, knowledgeRepo, _ := newLintTestService(domain.LintModeReject)
+
+	knowledgeRepo.On("GetByID", mock.Anything, "knowledge-1").Return(&domain.Knowledge{
+		ID:      "knowledge-1",
+		OrgID:   "org-1",
+		Type:    domain.KnowledgeTypeGuideline,
+		Status:  domain.KnowledgeStatusApproved,
+		Title:   "Deploys",
+		Summary: "When we deploy.",
+		BodyMD:  "Deploy on Tuesdays.",
+	}, nil)
+	knowledgeRepo.On("GetLatestVersion", mock.Anything, "knowledge-1").Return(&domain.KnowledgeVersion{VersionNumber: 1}, nil)
+
+	_, err := svc.Update(ctx, UpdateInput{
+		KnowledgeID: "knowledge-1",
+		Title:       "Deploys",
+		Summary:     "When we deploy.",
+		BodyMD:      "Deploy on Tuesdays, see asset://not-an-id.",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrLintFailed)
+	knowledgeRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// KnowledgePurgeRepositoryInterface defines the repository interface for hard purges
+type KnowledgePurgeRepositoryInterface interface {
+	Purge(ctx context.Context, tombstone *domain.KnowledgeTombstone) error
+	ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error)
+}
+
+// PurgeService hard-deletes knowledge for compliance erasure. Unlike deprecation it
+// leaves nothing of the item behind except a content-free tombstone. It is only
+// reachable from operator tooling, never with an org API key.
+type PurgeService struct {
+	repo    KnowledgePurgeRepositoryInterface
+	uuidGen UUIDGenerator
+}
+
+// NewPurgeService creates a new PurgeService instance
+func NewPurgeService(repo KnowledgePurgeRepositoryInterface) *PurgeService {
+	return NewPurgeServiceWithUUIDGen(repo, &DefaultUUIDGenerator{})
+}
+
+// NewPurgeServiceWithUUIDGen creates a new PurgeService with custom UUID generator (for testing)
+func NewPurgeServiceWithUUIDGen(repo KnowledgePurgeRepositoryInterface, uuidGen UUIDGenerator) *PurgeService {
+	return &PurgeService{
+		repo:    repo,
+		uuidGen: uuidGen,
+	}
+}
+
+// PurgeKnowledgeInput represents the input for purging a knowledge item
+type PurgeKnowledgeInput struct {
+	KnowledgeID string
+	By          string
+	Reason      string
+}
+
+// PurgeKnowledge removes a knowledge item, its versions, chunks, asset links, embedding
+// jobs and search log references, and returns the tombstone recorded for it
+func (s *PurgeService) PurgeKnowledge(ctx context.Context, input PurgeKnowledgeInput) (*domain.KnowledgeTombstone, error) {
+	ctx, span := telemetry.StartSpan(ctx, "PurgeService.PurgeKnowledge", telemetry.SpanAttributes{
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "purge_knowledge",
+	})
+	defer span.End()
+
+	if _, err := uuid.Parse(input.KnowledgeID); err != nil {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+
+	tombstone := domain.NewKnowledgeTombstone(
+		s.uuidGen.NewString(),
+		input.KnowledgeID,
+		strings.TrimSpace(input.By),
+		strings.TrimSpace(input.Reason),
+		time.Now().UTC(),
+	)
+	if tombstone.PurgedBy == "" {
+		return nil, domain.ErrActorRequired
+	}
+	if err := domain.ValidateKnowledgeTombstone(tombstone); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid knowledge tombstone", err)
+	}
+
+	if err := s.repo.Purge(ctx, tombstone); err != nil {
+		return nil, err
+	}
+
+	return tombstone, nil
+}
+
+// ListTombstones returns the purge audit trail of an org, newest first
+func (s *PurgeService) ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error) {
+	ctx, span := telemetry.StartSpan(ctx, "PurgeService.ListTombstones", telemetry.SpanAttributes{
+		OrgID:     orgID,
+		Operation: "list_tombstones",
+	})
+	defer span.End()
+
+	if _, err := uuid.Parse(orgID); err != nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	return s.repo.ListTombstones(ctx, orgID)
+}
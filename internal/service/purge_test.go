@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKnowledgePurgeRepository is a mock implementation of KnowledgePurgeRepositoryInterface
+type MockKnowledgePurgeRepository struct {
+	mock.Mock
+}
+
+func (m *MockKnowledgePurgeRepository) Purge(ctx context.Context, tombstone *domain.KnowledgeTombstone) error {
+	args := m.Called(ctx, tombstone)
+	return args.Error(0)
+}
+
+func (m *MockKnowledgePurgeRepository) ListTombstones(ctx context.Context, orgID string) ([]*domain.KnowledgeTombstone, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KnowledgeTombstone), args.Error(1)
+}
+
+const purgedKnowledgeID = "66666666-6666-6666-6666-666666666666"
+
+func TestPurgeService_PurgeKnowledge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("purges and returns the tombstone", func(t *testing.T) {
+		repo := new(MockKnowledgePurgeRepository)
+		service := NewPurgeServiceWithUUIDGen(repo, NewMockUUIDGenerator("tombstone-1"))
+
+		repo.On("Purge", mock.Anything, mock.MatchedBy(func(tombstone *domain.KnowledgeTombstone) bool {
+			return tombstone.ID == "tombstone-1" &&
+				tombstone.KnowledgeID == purgedKnowledgeID &&
+				tombstone.PurgedBy == "dpo@example.com" &&
+				tombstone.Reason == "ERASURE-42" &&
+				!tombstone.PurgedAt.IsZero()
+		})).Run(func(args mock.Arguments) {
+			tombstone := args.Get(1).(*domain.KnowledgeTombstone)
+			tombstone.OrgID = "org-1"
+			tombstone.Versions = 3
+		}).Return(nil)
+
+		tombstone, err := service.PurgeKnowledge(ctx, PurgeKnowledgeInput{
+			KnowledgeID: purgedKnowledgeID,
+			By:          " dpo@example.com ",
+			Reason:      "ERASURE-42",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "org-1", tombstone.OrgID)
+		assert.Equal(t, 3, tombstone.Versions)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("requires who purged", func(t *testing.T) {
+		repo := new(MockKnowledgePurgeRepository)
+		service := NewPurgeService(repo)
+
+		_, err := service.PurgeKnowledge(ctx, PurgeKnowledgeInput{KnowledgeID: purgedKnowledgeID, By: "  "})
+
+		require.ErrorIs(t, err, domain.ErrActorRequired)
+		repo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns not found for a malformed ID", func(t *testing.T) {
+		repo := new(MockKnowledgePurgeRepository)
+		service := NewPurgeService(repo)
+
+		_, err := service.PurgeKnowledge(ctx, PurgeKnowledgeInput{KnowledgeID: "not-a-uuid", By: "dpo@example.com"})
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+		repo.AssertNotCalled(t, "Purge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("passes through not found from the repository", func(t *testing.T) {
+		repo := new(MockKnowledgePurgeRepository)
+		service := NewPurgeService(repo)
+
+		repo.On("Purge", mock.Anything, mock.Anything).Return(domain.ErrKnowledgeNotFound)
+
+		tombstone, err := service.PurgeKnowledge(ctx, PurgeKnowledgeInput{KnowledgeID: purgedKnowledgeID, By: "dpo@example.com"})
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+		assert.Nil(t, tombstone)
+	})
+}
+
+func TestPurgeService_ListTombstones(t *testing.T) {
+	ctx := context.Background()
+	orgID := "77777777-7777-7777-7777-777777777777"
+
+	repo := new(MockKnowledgePurgeRepository)
+	service := NewPurgeService(repo)
+
+	repo.On("ListTombstones", mock.Anything, orgID).Return([]*domain.KnowledgeTombstone{{ID: "tombstone-1"}}, nil)
+
+	tombstones, err := service.ListTombstones(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 1)
+
+	_, err = service.ListTombstones(ctx, "acme")
+	require.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+	repo.AssertExpectations(t)
+}
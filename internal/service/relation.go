@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// Relation directions as seen from the item being inspected
+const (
+	RelationDirectionOutgoing = "outgoing"
+	RelationDirectionIncoming = "incoming"
+)
+
+// RelatedKnowledge is a knowledge item one hop away from FromID.
+// Outgoing entries are items FromID points at; incoming entries are backlinks.
+type RelatedKnowledge struct {
+	RelationID    string
+	Type          domain.RelationType
+	Direction     string
+	FromID        string
+	ID            string
+	Title         string
+	Summary       string
+	Scope         string
+	KnowledgeType domain.KnowledgeType
+	Status        domain.KnowledgeStatus
+	UpdatedAt     time.Time
+}
+
+// RelationRepositoryInterface defines the repository interface for knowledge relations
+type RelationRepositoryInterface interface {
+	Create(ctx context.Context, r *domain.KnowledgeRelation) error
+	GetByID(ctx context.Context, id string) (*domain.KnowledgeRelation, error)
+	Delete(ctx context.Context, id string) error
+	ListRelated(ctx context.Context, knowledgeIDs []string) ([]*RelatedKnowledge, error)
+}
+
+// RelationKnowledgeRepo provides knowledge lookups for the relation service
+type RelationKnowledgeRepo interface {
+	GetByID(ctx context.Context, id string) (*domain.Knowledge, error)
+}
+
+// RelationService manages typed links between knowledge items
+type RelationService struct {
+	relationRepo  RelationRepositoryInterface
+	knowledgeRepo RelationKnowledgeRepo
+	uuidGen       UUIDGenerator
+}
+
+// NewRelationService creates a new RelationService instance
+func NewRelationService(relationRepo RelationRepositoryInterface, knowledgeRepo RelationKnowledgeRepo) *RelationService {
+	return NewRelationServiceWithUUIDGen(relationRepo, knowledgeRepo, &DefaultUUIDGenerator{})
+}
+
+// NewRelationServiceWithUUIDGen creates a new RelationService with custom UUID generator (for testing)
+func NewRelationServiceWithUUIDGen(
+	relationRepo RelationRepositoryInterface,
+	knowledgeRepo RelationKnowledgeRepo,
+	uuidGen UUIDGenerator,
+) *RelationService {
+	return &RelationService{
+		relationRepo:  relationRepo,
+		knowledgeRepo: knowledgeRepo,
+		uuidGen:       uuidGen,
+	}
+}
+
+// CreateRelationInput represents the input for linking two knowledge items
+type CreateRelationInput struct {
+	OrgID    string
+	SourceID string
+	TargetID string
+	Type     domain.RelationType
+}
+
+// DeleteRelationInput identifies a relation through the item it is attached to
+type DeleteRelationInput struct {
+	OrgID       string
+	KnowledgeID string
+	RelationID  string
+}
+
+// ListRelationsInput represents the input for listing an item's relations.
+// Direction is RelationDirectionOutgoing, RelationDirectionIncoming or empty for both.
+type ListRelationsInput struct {
+	OrgID       string
+	KnowledgeID string
+	Direction   string
+}
+
+// Create links SourceID to TargetID; both items must belong to the caller's organization
+func (s *RelationService) Create(ctx context.Context, input CreateRelationInput) (*domain.KnowledgeRelation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "RelationService.Create", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.SourceID,
+		Operation:   "create_relation",
+	})
+	defer span.End()
+
+	if !domain.IsValidRelationType(input.Type) {
+		return nil, domain.ErrInvalidRelationType
+	}
+	if input.SourceID == input.TargetID {
+		return nil, domain.ErrRelateSelf
+	}
+
+	if _, err := s.getOrgKnowledge(ctx, input.OrgID, input.SourceID); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(input.TargetID); err != nil {
+		return nil, domain.ErrRelationTargetNotFound
+	}
+	if _, err := s.getOrgKnowledge(ctx, input.OrgID, input.TargetID); err != nil {
+		if errors.Is(err, domain.ErrKnowledgeNotFound) {
+			return nil, domain.ErrRelationTargetNotFound
+		}
+		return nil, err
+	}
+
+	relation := domain.NewKnowledgeRelation(
+		s.uuidGen.NewString(),
+		input.OrgID,
+		input.SourceID,
+		input.TargetID,
+		input.Type,
+		time.Now().UTC(),
+	)
+	if err := domain.ValidateKnowledgeRelation(relation); err != nil {
+		return nil, domain.NewDomainErrorWithCause(domain.ErrCodeValidation, "invalid relation", err)
+	}
+
+	if err := s.relationRepo.Create(ctx, relation); err != nil {
+		return nil, err
+	}
+
+	return relation, nil
+}
+
+// Delete removes a relation attached to KnowledgeID, either as source or as target
+func (s *RelationService) Delete(ctx context.Context, input DeleteRelationInput) error {
+	ctx, span := telemetry.StartSpan(ctx, "RelationService.Delete", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "delete_relation",
+	})
+	defer span.End()
+
+	if _, err := uuid.Parse(input.RelationID); err != nil {
+		return domain.ErrRelationNotFound
+	}
+
+	relation, err := s.relationRepo.GetByID(ctx, input.RelationID)
+	if err != nil {
+		return err
+	}
+	if relation.OrgID != input.OrgID {
+		return domain.ErrRelationNotFound
+	}
+	if relation.SourceID != input.KnowledgeID && relation.TargetID != input.KnowledgeID {
+		return domain.ErrRelationNotFound
+	}
+
+	return s.relationRepo.Delete(ctx, relation.ID)
+}
+
+// ListRelations returns the items linked to KnowledgeID, optionally limited to one direction
+func (s *RelationService) ListRelations(ctx context.Context, input ListRelationsInput) ([]*RelatedKnowledge, error) {
+	ctx, span := telemetry.StartSpan(ctx, "RelationService.ListRelations", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.KnowledgeID,
+		Operation:   "list_relations",
+	})
+	defer span.End()
+
+	if _, err := s.getOrgKnowledge(ctx, input.OrgID, input.KnowledgeID); err != nil {
+		return nil, err
+	}
+
+	related, err := s.relationRepo.ListRelated(ctx, []string{input.KnowledgeID})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Direction == "" {
+		return related, nil
+	}
+
+	filtered := make([]*RelatedKnowledge, 0, len(related))
+	for _, r := range related {
+		if r.Direction == input.Direction {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// getOrgKnowledge loads a knowledge item, hiding items that belong to another organization
+func (s *RelationService) getOrgKnowledge(ctx context.Context, orgID, id string) (*domain.Knowledge, error) {
+	knowledge, err := s.knowledgeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if knowledge.OrgID != orgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	return knowledge, nil
+}
+
+// relatedFetcher loads the one-hop neighbours of the given knowledge items
+type relatedFetcher func(ctx context.Context, ids []string) ([]*RelatedKnowledge, error)
+
+// attachRelated fills in the one-hop neighbours of every knowledge result with a single lookup
+func attachRelated(ctx context.Context, list relatedFetcher, results []*SearchResult) error {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		if normalizeSourceType(r.SourceType) == "knowledge" {
+			ids = append(ids, r.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	related, err := list(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byFrom := make(map[string][]*RelatedKnowledge, len(ids))
+	for _, rel := range related {
+		byFrom[rel.FromID] = append(byFrom[rel.FromID], rel)
+	}
+	for _, r := range results {
+		if normalizeSourceType(r.SourceType) == "knowledge" {
+			r.Related = byFrom[r.ID]
+		}
+	}
+	return nil
+}
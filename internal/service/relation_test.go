@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRelationRepository is a mock implementation of RelationRepositoryInterface
+type MockRelationRepository struct {
+	mock.Mock
+}
+
+func (m *MockRelationRepository) Create(ctx context.Context, r *domain.KnowledgeRelation) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockRelationRepository) GetByID(ctx context.Context, id string) (*domain.KnowledgeRelation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.KnowledgeRelation), args.Error(1)
+}
+
+func (m *MockRelationRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRelationRepository) ListRelated(ctx context.Context, knowledgeIDs []string) ([]*RelatedKnowledge, error) {
+	args := m.Called(ctx, knowledgeIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*RelatedKnowledge), args.Error(1)
+}
+
+const (
+	relSourceID = "11111111-1111-1111-1111-111111111111"
+	relTargetID = "22222222-2222-2222-2222-222222222222"
+	relationID  = "33333333-3333-3333-3333-333333333333"
+)
+
+func TestRelationService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("links two items in the same org", func(t *testing.T) {
+		relationRepo := new(MockRelationRepository)
+		knowledgeRepo := new(MockVFSKnowledgeRepo)
+		svc := NewRelationServiceWithUUIDGen(relationRepo, knowledgeRepo, NewMockUUIDGenerator(relationID))
+
+		knowledgeRepo.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-1"}, nil)
+		knowledgeRepo.On("GetByID", mock.Anything, relTargetID).Return(&domain.Knowledge{ID: relTargetID, OrgID: "org-1"}, nil)
+		relationRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.KnowledgeRelation) bool {
+			return r.ID == relationID && r.SourceID == relSourceID && r.TargetID == relTargetID && r.Type == domain.RelationTypeDependsOn
+		})).Return(nil)
+
+		relation, err := svc.Create(ctx, CreateRelationInput{
+			OrgID:    "org-1",
+			SourceID: relSourceID,
+			TargetID: relTargetID,
+			Type:     domain.RelationTypeDependsOn,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, relationID, relation.ID)
+		assert.Equal(t, "org-1", relation.OrgID)
+		relationRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name    string
+		input   CreateRelationInput
+		setup   func(k *MockVFSKnowledgeRepo)
+		wantErr error
+	}{
+		{
+			name:    "invalid type",
+			input:   CreateRelationInput{OrgID: "org-1", SourceID: relSourceID, TargetID: relTargetID, Type: "blocks"},
+			setup:   func(k *MockVFSKnowledgeRepo) {},
+			wantErr: domain.ErrInvalidRelationType,
+		},
+		{
+			name:    "self link",
+			input:   CreateRelationInput{OrgID: "org-1", SourceID: relSourceID, TargetID: relSourceID, Type: domain.RelationTypeRelatesTo},
+			setup:   func(k *MockVFSKnowledgeRepo) {},
+			wantErr: domain.ErrRelateSelf,
+		},
+		{
+			name:  "source in another org",
+			input: CreateRelationInput{OrgID: "org-1", SourceID: relSourceID, TargetID: relTargetID, Type: domain.RelationTypeRelatesTo},
+			setup: func(k *MockVFSKnowledgeRepo) {
+				k.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-2"}, nil)
+			},
+			wantErr: domain.ErrKnowledgeNotFound,
+		},
+		{
+			name:  "malformed target",
+			input: CreateRelationInput{OrgID: "org-1", SourceID: relSourceID, TargetID: "not-a-uuid", Type: domain.RelationTypeRelatesTo},
+			setup: func(k *MockVFSKnowledgeRepo) {
+				k.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-1"}, nil)
+			},
+			wantErr: domain.ErrRelationTargetNotFound,
+		},
+		{
+			name:  "target in another org",
+			input: CreateRelationInput{OrgID: "org-1", SourceID: relSourceID, TargetID: relTargetID, Type: domain.RelationTypeRelatesTo},
+			setup: func(k *MockVFSKnowledgeRepo) {
+				k.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-1"}, nil)
+				k.On("GetByID", mock.Anything, relTargetID).Return(&domain.Knowledge{ID: relTargetID, OrgID: "org-2"}, nil)
+			},
+			wantErr: domain.ErrRelationTargetNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relationRepo := new(MockRelationRepository)
+			knowledgeRepo := new(MockVFSKnowledgeRepo)
+			tt.setup(knowledgeRepo)
+			svc := NewRelationService(relationRepo, knowledgeRepo)
+
+			_, err := svc.Create(ctx, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			relationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRelationService_Delete(t *testing.T) {
+	ctx := context.Background()
+	relation := &domain.KnowledgeRelation{ID: relationID, OrgID: "org-1", SourceID: relSourceID, TargetID: relTargetID}
+
+	t.Run("deletes from either end", func(t *testing.T) {
+		for _, knowledgeID := range []string{relSourceID, relTargetID} {
+			relationRepo := new(MockRelationRepository)
+			svc := NewRelationService(relationRepo, new(MockVFSKnowledgeRepo))
+
+			relationRepo.On("GetByID", mock.Anything, relationID).Return(relation, nil)
+			relationRepo.On("Delete", mock.Anything, relationID).Return(nil)
+
+			err := svc.Delete(ctx, DeleteRelationInput{OrgID: "org-1", KnowledgeID: knowledgeID, RelationID: relationID})
+
+			require.NoError(t, err)
+			relationRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("rejects relations not attached to the item", func(t *testing.T) {
+		relationRepo := new(MockRelationRepository)
+		svc := NewRelationService(relationRepo, new(MockVFSKnowledgeRepo))
+
+		relationRepo.On("GetByID", mock.Anything, relationID).Return(relation, nil)
+
+		err := svc.Delete(ctx, DeleteRelationInput{OrgID: "org-1", KnowledgeID: "other", RelationID: relationID})
+
+		assert.ErrorIs(t, err, domain.ErrRelationNotFound)
+		relationRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("hides relations from other orgs", func(t *testing.T) {
+		relationRepo := new(MockRelationRepository)
+		svc := NewRelationService(relationRepo, new(MockVFSKnowledgeRepo))
+
+		relationRepo.On("GetByID", mock.Anything, relationID).Return(relation, nil)
+
+		err := svc.Delete(ctx, DeleteRelationInput{OrgID: "org-2", KnowledgeID: relSourceID, RelationID: relationID})
+
+		assert.ErrorIs(t, err, domain.ErrRelationNotFound)
+	})
+}
+
+func TestRelationService_ListRelations(t *testing.T) {
+	ctx := context.Background()
+
+	related := []*RelatedKnowledge{
+		{RelationID: "r1", Direction: RelationDirectionOutgoing, FromID: relSourceID, ID: "a"},
+		{RelationID: "r2", Direction: RelationDirectionIncoming, FromID: relSourceID, ID: "b"},
+	}
+
+	t.Run("returns both directions by default", func(t *testing.T) {
+		relationRepo := new(MockRelationRepository)
+		knowledgeRepo := new(MockVFSKnowledgeRepo)
+		svc := NewRelationService(relationRepo, knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-1"}, nil)
+		relationRepo.On("ListRelated", mock.Anything, []string{relSourceID}).Return(related, nil)
+
+		result, err := svc.ListRelations(ctx, ListRelationsInput{OrgID: "org-1", KnowledgeID: relSourceID})
+
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("filters backlinks", func(t *testing.T) {
+		relationRepo := new(MockRelationRepository)
+		knowledgeRepo := new(MockVFSKnowledgeRepo)
+		svc := NewRelationService(relationRepo, knowledgeRepo)
+
+		knowledgeRepo.On("GetByID", mock.Anything, relSourceID).Return(&domain.Knowledge{ID: relSourceID, OrgID: "org-1"}, nil)
+		relationRepo.On("ListRelated", mock.Anything, []string{relSourceID}).Return(related, nil)
+
+		result, err := svc.ListRelations(ctx, ListRelationsInput{OrgID: "org-1", KnowledgeID: relSourceID, Direction: RelationDirectionIncoming})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "b", result[0].ID)
+	})
+}
@@ -21,6 +21,8 @@ const (
 	recencyMaxBoost   = 0.10
 	pathExactBoost   = 0.12
 	pathPrefixBoost  = 0.06
+	// staleDemotionFactor scales the score of stale knowledge when DemoteStale is set
+	staleDemotionFactor = 0.5
 )
 
 func normalizeSearchMode(mode SearchMode) SearchMode {
@@ -53,7 +55,7 @@ func (s *ContextService) searchOnce(ctx context.Context, input SearchInput, limi
 
 	mode := normalizeSearchMode(input.Mode)
 	includeKnowledge := input.Filters.SourceType == "" || input.Filters.SourceType == "knowledge"
-	includeAssets := input.Filters.SourceType == "" || input.Filters.SourceType == "asset"
+	includeAssets := (input.Filters.SourceType == "" || input.Filters.SourceType == "asset") && input.Filters.Language == "" && input.Filters.Author == ""
 
 	candidateLimit := limit * defaultCandidateMultiplier
 	if candidateLimit < defaultMinCandidates {
@@ -234,6 +236,8 @@ func aggregateChunkResults(chunks []*ChunkSearchResult) []*SearchResult {
 			SourceType: "knowledge",
 			ChunkID:    c.ChunkID,
 			ChunkIndex: c.ChunkIndex,
+			Stale:      c.Stale,
+			TypeBoost:  c.TypeBoost,
 		})
 	}
 	return results
@@ -351,6 +355,13 @@ func applySearchBoosts(results []*SearchResult, filters SearchFilters) {
 		boost += pathBoost(r.Scope, filters.PathPrefix)
 		boost += recencyBoost(r.UpdatedAt)
 		r.Score += boost
+		// Org-defined per-type boost; assets carry none
+		if r.TypeBoost > 0 {
+			r.Score *= r.TypeBoost
+		}
+		if filters.DemoteStale && r.Stale {
+			r.Score *= staleDemotionFactor
+		}
 	}
 }
 
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+)
+
+// maxSupersessionDepth bounds how many replacement hops are followed when resolving successors
+const maxSupersessionDepth = 5
+
+// SupersessionRef points at the knowledge item that replaces a deprecated one
+type SupersessionRef struct {
+	ID    string
+	Title string
+}
+
+// knowledgeFetcher loads knowledge items by ID; missing IDs are simply absent from the result
+type knowledgeFetcher func(ctx context.Context, ids []string) ([]*domain.Knowledge, error)
+
+// resolveSuccessors maps each superseded item to its current replacement, following chains of
+// supersession so that an agent always lands on the newest item. Items whose chain ends in a
+// missing item are left out.
+func resolveSuccessors(ctx context.Context, fetch knowledgeFetcher, items []*domain.Knowledge) (map[string]*domain.Knowledge, error) {
+	resolved := make(map[string]*domain.Knowledge)
+
+	// pending maps a superseded item ID to the next successor ID to look up
+	pending := make(map[string]string)
+	for _, item := range items {
+		if item != nil && item.IsSuperseded() {
+			pending[item.ID] = item.SupersededBy
+		}
+	}
+
+	for depth := 0; depth < maxSupersessionDepth && len(pending) > 0; depth++ {
+		ids := make([]string, 0, len(pending))
+		seen := make(map[string]bool, len(pending))
+		for _, next := range pending {
+			if !seen[next] {
+				seen[next] = true
+				ids = append(ids, next)
+			}
+		}
+
+		successors, err := fetch(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*domain.Knowledge, len(successors))
+		for _, successor := range successors {
+			if successor != nil {
+				byID[successor.ID] = successor
+			}
+		}
+
+		for id, next := range pending {
+			successor, ok := byID[next]
+			if !ok {
+				delete(pending, id)
+				continue
+			}
+			resolved[id] = successor
+			if successor.IsSuperseded() && successor.SupersededBy != id {
+				pending[id] = successor.SupersededBy
+			} else {
+				delete(pending, id)
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// annotateSuperseded marks knowledge results that have been replaced with their successor.
+// When substitute is true, those results are swapped for the successor instead; a successor
+// that already appears in the results is not repeated. A successor that is not approved is
+// only pointed at, so unreviewed content does not take the place of a result.
+func annotateSuperseded(ctx context.Context, fetch knowledgeFetcher, results []*SearchResult, substitute bool) ([]*SearchResult, error) {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		if normalizeSourceType(r.SourceType) == "knowledge" {
+			ids = append(ids, r.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	items, err := fetch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	successors, err := resolveSuccessors(ctx, fetch, items)
+	if err != nil {
+		return nil, err
+	}
+	if len(successors) == 0 {
+		return results, nil
+	}
+
+	present := make(map[string]bool, len(results))
+	for _, r := range results {
+		if _, superseded := successors[r.ID]; !superseded {
+			present[r.ID] = true
+		}
+	}
+
+	out := make([]*SearchResult, 0, len(results))
+	for _, r := range results {
+		successor, ok := successors[r.ID]
+		if !ok || normalizeSourceType(r.SourceType) != "knowledge" {
+			out = append(out, r)
+			continue
+		}
+
+		if !substitute || successor.Status != domain.KnowledgeStatusApproved {
+			r.SupersededBy = &SupersessionRef{ID: successor.ID, Title: successor.Title}
+			out = append(out, r)
+			continue
+		}
+
+		if present[successor.ID] {
+			continue
+		}
+		present[successor.ID] = true
+		out = append(out, &SearchResult{
+			ID:         successor.ID,
+			Title:      successor.Title,
+			Summary:    successor.Summary,
+			Scope:      successor.Scope,
+			UpdatedAt:  successor.UpdatedAt,
+			Score:      r.Score,
+			SourceType: "knowledge",
+			ChunkIndex: -1,
+			Replaces:   r.ID,
+			Stale:      successor.IsStale(),
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/cloo-solutions/neotexai/internal/telemetry"
+)
+
+// RenderTemplateInput represents the input for rendering a template-type knowledge item.
+// A zero Version renders the latest version.
+type RenderTemplateInput struct {
+	OrgID      string
+	TemplateID string
+	Version    int64
+	Variables  map[string]string
+}
+
+// RenderedTemplate is the markdown of a template with its variables filled in
+type RenderedTemplate struct {
+	TemplateID      string
+	TemplateVersion int64
+	Title           string
+	Summary         string
+	BodyMD          string
+	Variables       []domain.TemplateVariable
+}
+
+// InstantiateInput represents the input for creating a knowledge item from a template.
+// Title and Summary may use the template's variables as well.
+type InstantiateInput struct {
+	RenderTemplateInput
+	ProjectID string
+	Type      domain.KnowledgeType
+	Title     string
+	Summary   string
+	Scope     string
+	Tags      []string
+	Author    domain.Author
+}
+
+// RenderTemplate fills in the variables of a template version. Required variables without a value
+// fail validation; optional ones fall back to their default.
+func (s *KnowledgeService) RenderTemplate(ctx context.Context, input RenderTemplateInput) (*RenderedTemplate, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.RenderTemplate", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		KnowledgeID: input.TemplateID,
+		Operation:   "render_template",
+	})
+	defer span.End()
+
+	return s.renderTemplate(ctx, input, "", "")
+}
+
+// Instantiate renders a template and creates the result as a new draft knowledge item
+// that records the template version it came from
+func (s *KnowledgeService) Instantiate(ctx context.Context, input InstantiateInput) (*domain.Knowledge, error) {
+	ctx, span := telemetry.StartSpan(ctx, "KnowledgeService.Instantiate", telemetry.SpanAttributes{
+		OrgID:       input.OrgID,
+		ProjectID:   input.ProjectID,
+		KnowledgeID: input.TemplateID,
+		Operation:   "instantiate_template",
+	})
+	defer span.End()
+
+	rendered, err := s.renderTemplate(ctx, input.RenderTemplateInput, input.Title, input.Summary)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.Create(ctx, CreateInput{
+		OrgID:           input.OrgID,
+		ProjectID:       input.ProjectID,
+		Type:            input.Type,
+		Title:           rendered.Title,
+		Summary:         rendered.Summary,
+		BodyMD:          rendered.BodyMD,
+		Scope:           input.Scope,
+		Tags:            input.Tags,
+		TemplateID:      rendered.TemplateID,
+		TemplateVersion: rendered.TemplateVersion,
+		Author:          input.Author,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Knowledge, nil
+}
+
+func (s *KnowledgeService) renderTemplate(ctx context.Context, input RenderTemplateInput, title, summary string) (*RenderedTemplate, error) {
+	template, err := s.knowledgeRepo.GetByID(ctx, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.OrgID != input.OrgID {
+		return nil, domain.ErrKnowledgeNotFound
+	}
+	if template.Type != domain.KnowledgeTypeTemplate {
+		return nil, domain.ErrNotATemplate
+	}
+
+	var version *domain.KnowledgeVersion
+	if input.Version > 0 {
+		version, err = s.knowledgeRepo.GetVersion(ctx, template.ID, input.Version)
+	} else {
+		version, err = s.knowledgeRepo.GetLatestVersion(ctx, template.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	vars := domain.TemplateVariables(title, summary, version.BodyMD)
+	if missing := domain.MissingTemplateVariables(vars, input.Variables); len(missing) > 0 {
+		return nil, domain.NewMissingTemplateVariablesError(missing)
+	}
+
+	return &RenderedTemplate{
+		TemplateID:      template.ID,
+		TemplateVersion: version.VersionNumber,
+		Title:           domain.RenderTemplate(title, input.Variables),
+		Summary:         domain.RenderTemplate(summary, input.Variables),
+		BodyMD:          domain.RenderTemplate(version.BodyMD, input.Variables),
+		Variables:       vars,
+	}, nil
+}
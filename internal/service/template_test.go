@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloo-solutions/neotexai/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const postmortemTemplateBody = "# {{service}} outage\n\nSeverity: {{severity|SEV3}}\n\n## Timeline\n"
+
+func newPostmortemTemplate() *domain.Knowledge {
+	return &domain.Knowledge{
+		ID:     "tpl-1",
+		OrgID:  "org-1",
+		Type:   domain.KnowledgeTypeTemplate,
+		Status: domain.KnowledgeStatusApproved,
+		Title:  "Postmortem template",
+		BodyMD: postmortemTemplateBody,
+	}
+}
+
+func TestKnowledgeService_RenderTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("renders the latest version", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+		repo.On("GetLatestVersion", mock.Anything, "tpl-1").Return(&domain.KnowledgeVersion{VersionNumber: 4, BodyMD: postmortemTemplateBody}, nil)
+
+		rendered, err := svc.RenderTemplate(ctx, RenderTemplateInput{
+			OrgID:      "org-1",
+			TemplateID: "tpl-1",
+			Variables:  map[string]string{"service": "checkout"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tpl-1", rendered.TemplateID)
+		assert.Equal(t, int64(4), rendered.TemplateVersion)
+		assert.Equal(t, "# checkout outage\n\nSeverity: SEV3\n\n## Timeline\n", rendered.BodyMD)
+		assert.Len(t, rendered.Variables, 2)
+	})
+
+	t.Run("renders a pinned version", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+		repo.On("GetVersion", mock.Anything, "tpl-1", int64(1)).Return(&domain.KnowledgeVersion{VersionNumber: 1, BodyMD: "{{service}} v1"}, nil)
+
+		rendered, err := svc.RenderTemplate(ctx, RenderTemplateInput{
+			OrgID:      "org-1",
+			TemplateID: "tpl-1",
+			Version:    1,
+			Variables:  map[string]string{"service": "checkout"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), rendered.TemplateVersion)
+		assert.Equal(t, "checkout v1", rendered.BodyMD)
+	})
+
+	t.Run("fails on missing required variables", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+		repo.On("GetLatestVersion", mock.Anything, "tpl-1").Return(&domain.KnowledgeVersion{VersionNumber: 1, BodyMD: postmortemTemplateBody}, nil)
+
+		_, err := svc.RenderTemplate(ctx, RenderTemplateInput{OrgID: "org-1", TemplateID: "tpl-1"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing template variables: service")
+	})
+
+	t.Run("rejects items that are not templates", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		guideline := newPostmortemTemplate()
+		guideline.Type = domain.KnowledgeTypeGuideline
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(guideline, nil)
+
+		_, err := svc.RenderTemplate(ctx, RenderTemplateInput{OrgID: "org-1", TemplateID: "tpl-1"})
+
+		require.ErrorIs(t, err, domain.ErrNotATemplate)
+	})
+
+	t.Run("hides templates of other orgs", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+
+		_, err := svc.RenderTemplate(ctx, RenderTemplateInput{OrgID: "org-2", TemplateID: "tpl-1"})
+
+		require.ErrorIs(t, err, domain.ErrKnowledgeNotFound)
+	})
+}
+
+func TestKnowledgeService_Instantiate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates a draft with template provenance", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		jobRepo := new(MockEmbeddingJobRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, jobRepo, NewMockUUIDGenerator("k-new", "v-new", "job-new"))
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+		repo.On("GetLatestVersion", mock.Anything, "tpl-1").Return(&domain.KnowledgeVersion{VersionNumber: 4, BodyMD: postmortemTemplateBody}, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Knowledge")).Return(nil)
+		repo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*domain.KnowledgeVersion")).Return(nil)
+		jobRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		k, err := svc.Instantiate(ctx, InstantiateInput{
+			RenderTemplateInput: RenderTemplateInput{
+				OrgID:      "org-1",
+				TemplateID: "tpl-1",
+				Variables:  map[string]string{"service": "checkout", "severity": "SEV1"},
+			},
+			Type:  domain.KnowledgeTypeLearning,
+			Title: "{{service}} outage postmortem",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "k-new", k.ID)
+		assert.Equal(t, domain.KnowledgeTypeLearning, k.Type)
+		assert.Equal(t, domain.KnowledgeStatusDraft, k.Status)
+		assert.Equal(t, "checkout outage postmortem", k.Title)
+		assert.Equal(t, "# checkout outage\n\nSeverity: SEV1\n\n## Timeline\n", k.BodyMD)
+		assert.Equal(t, "tpl-1", k.TemplateID)
+		assert.Equal(t, int64(4), k.TemplateVersion)
+		assert.True(t, k.IsFromTemplate())
+	})
+
+	t.Run("variables in the title are required too", func(t *testing.T) {
+		repo := new(MockKnowledgeRepository)
+		svc := NewKnowledgeServiceWithUUIDGen(repo, new(MockEmbeddingJobRepository), NewMockUUIDGenerator())
+
+		repo.On("GetByID", mock.Anything, "tpl-1").Return(newPostmortemTemplate(), nil)
+		repo.On("GetLatestVersion", mock.Anything, "tpl-1").Return(&domain.KnowledgeVersion{VersionNumber: 1, BodyMD: postmortemTemplateBody}, nil)
+
+		_, err := svc.Instantiate(ctx, InstantiateInput{
+			RenderTemplateInput: RenderTemplateInput{
+				OrgID:      "org-1",
+				TemplateID: "tpl-1",
+				Variables:  map[string]string{"service": "checkout"},
+			},
+			Type:  domain.KnowledgeTypeLearning,
+			Title: "{{date}} postmortem",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing template variables: date")
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
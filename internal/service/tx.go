@@ -7,6 +7,9 @@ type TxRepositories interface {
 	Knowledge() KnowledgeRepositoryInterface
 	EmbeddingJobs() EmbeddingJobRepositoryInterface
 	Assets() AssetRepositoryInterface
+	Merges() KnowledgeMergeRepositoryInterface
+	// Savepoint runs fn in a nested transaction; when fn fails only its writes are rolled back
+	Savepoint(ctx context.Context, fn func(repos TxRepositories) error) error
 }
 
 // TxRunner executes a function within a transaction.
@@ -6,6 +6,8 @@ type testTxRepos struct {
 	knowledge     KnowledgeRepositoryInterface
 	embeddingJobs EmbeddingJobRepositoryInterface
 	assets        AssetRepositoryInterface
+	merges        KnowledgeMergeRepositoryInterface
+	savepoints    int
 }
 
 func (t *testTxRepos) Knowledge() KnowledgeRepositoryInterface {
@@ -20,6 +22,15 @@ func (t *testTxRepos) Assets() AssetRepositoryInterface {
 	return t.assets
 }
 
+func (t *testTxRepos) Merges() KnowledgeMergeRepositoryInterface {
+	return t.merges
+}
+
+func (t *testTxRepos) Savepoint(ctx context.Context, fn func(repos TxRepositories) error) error {
+	t.savepoints++
+	return fn(t)
+}
+
 type testTxRunner struct {
 	repos  TxRepositories
 	called bool
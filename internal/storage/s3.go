@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -123,6 +124,43 @@ func (c *S3Client) DeleteObject(ctx context.Context, key string) error {
 	return nil
 }
 
+// GetObject opens an object for reading and returns its size, or -1 when it is unknown
+func (c *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	}
+
+	output, err := c.client.GetObject(ctx, input)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	size := int64(-1)
+	if output.ContentLength != nil {
+		size = *output.ContentLength
+	}
+	return output.Body, size, nil
+}
+
+// PutObject stores size bytes read from body under key
+func (c *S3Client) PutObject(ctx context.Context, key string, contentType string, body io.Reader, size int64) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(c.bucket),
+		Key:           aws.String(key),
+		Body:          body,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	}
+
+	_, err := c.client.PutObject(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+
+	return nil
+}
+
 // HeadObject checks if an object exists and returns its metadata
 func (c *S3Client) HeadObject(ctx context.Context, key string) (*ObjectMetadata, error) {
 	input := &s3.HeadObjectInput{
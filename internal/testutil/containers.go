@@ -193,8 +193,15 @@ func RunMigrations(ctx context.Context, pool *pgxpool.Pool, migrationsDir string
 // TruncateAll truncates all tables in the database for test isolation
 func TruncateAll(ctx context.Context, pool *pgxpool.Pool) error {
 	tables := []string{
+		"knowledge_tombstones",
+		"knowledge_comments",
+		"idempotency_keys",
+		"checklist_run_items",
+		"checklist_runs",
 		"embedding_jobs",
 		"knowledge_assets",
+		"knowledge_relations",
+		"knowledge_types",
 		"knowledge_versions",
 		"knowledge",
 		"assets",
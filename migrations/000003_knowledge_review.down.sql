@@ -0,0 +1,12 @@
+-- Roll back review workflow for knowledge items
+
+DROP INDEX IF EXISTS idx_knowledge_org_status_updated_at;
+
+-- Without reviews every active item is a draft again
+UPDATE knowledge SET status = 'draft' WHERE status IN ('approved', 'rejected');
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS review_note,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by_key_id,
+    DROP COLUMN IF EXISTS reviewed_by;
@@ -0,0 +1,15 @@
+-- Review workflow for knowledge items (draft -> approved | rejected)
+
+ALTER TABLE knowledge
+    ADD COLUMN reviewed_by TEXT,
+    -- The API key the review was made with; reviewed_by is only the name the client sent
+    ADD COLUMN reviewed_by_key_id UUID,
+    ADD COLUMN reviewed_at TIMESTAMP,
+    ADD COLUMN review_note TEXT;
+
+-- Every item was a draft before reviews existed; keep existing knowledge visible in
+-- search and context by treating it as approved
+UPDATE knowledge SET status = 'approved' WHERE status = 'draft';
+
+-- Pending review queue lookups
+CREATE INDEX idx_knowledge_org_status_updated_at ON knowledge (org_id, status, updated_at DESC);
@@ -0,0 +1,3 @@
+-- Roll back unique knowledge version numbers
+
+DROP INDEX IF EXISTS idx_knowledge_versions_knowledge_id_version_number;
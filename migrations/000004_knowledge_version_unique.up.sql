@@ -0,0 +1,4 @@
+-- One row per (knowledge, version) so concurrent writers cannot both claim the next version
+
+CREATE UNIQUE INDEX idx_knowledge_versions_knowledge_id_version_number
+    ON knowledge_versions (knowledge_id, version_number);
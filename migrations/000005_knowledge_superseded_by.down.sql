@@ -0,0 +1,6 @@
+-- Roll back supersession links
+
+DROP INDEX IF EXISTS idx_knowledge_superseded_by;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS superseded_by;
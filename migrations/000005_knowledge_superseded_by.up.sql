@@ -0,0 +1,7 @@
+-- Supersession links: a deprecated knowledge item can point at its replacement
+
+ALTER TABLE knowledge
+    ADD COLUMN superseded_by UUID REFERENCES knowledge(id) ON DELETE SET NULL;
+
+-- Backlink lookups (what does this item replace?)
+CREATE INDEX idx_knowledge_superseded_by ON knowledge (superseded_by) WHERE superseded_by IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Roll back knowledge relations
+
+DROP INDEX IF EXISTS idx_knowledge_relations_target;
+
+DROP TABLE IF EXISTS knowledge_relations;
@@ -0,0 +1,15 @@
+-- Typed relations between knowledge items (relates_to, depends_on, refines, contradicts)
+
+CREATE TABLE knowledge_relations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    source_id UUID NOT NULL REFERENCES knowledge(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES knowledge(id) ON DELETE CASCADE,
+    relation_type TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (source_id <> target_id),
+    UNIQUE (source_id, target_id, relation_type)
+);
+
+-- Backlink lookups (which items point at this one?)
+CREATE INDEX idx_knowledge_relations_target ON knowledge_relations (target_id);
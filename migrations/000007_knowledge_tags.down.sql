@@ -0,0 +1,10 @@
+-- Roll back knowledge tags
+
+DROP INDEX IF EXISTS idx_knowledge_chunks_tags;
+DROP INDEX IF EXISTS idx_knowledge_tags;
+
+ALTER TABLE knowledge_chunks
+    DROP COLUMN IF EXISTS tags;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS tags;
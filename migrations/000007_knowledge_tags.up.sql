@@ -0,0 +1,11 @@
+-- Free-form tags on knowledge, mirrored onto chunks so search filters apply at chunk level
+
+ALTER TABLE knowledge
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE knowledge_chunks
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Tag filters use the array overlap (&&) and containment (@>) operators
+CREATE INDEX idx_knowledge_tags ON knowledge USING GIN (tags);
+CREATE INDEX idx_knowledge_chunks_tags ON knowledge_chunks USING GIN (tags);
@@ -0,0 +1,9 @@
+-- Roll back review-by dates and stale flags
+
+DROP INDEX IF EXISTS idx_knowledge_stale;
+DROP INDEX IF EXISTS idx_knowledge_review_after;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS stale_since,
+    DROP COLUMN IF EXISTS owner,
+    DROP COLUMN IF EXISTS review_after;
@@ -0,0 +1,13 @@
+-- Review-by dates and owners for knowledge; stale_since is set by the stale knowledge job
+-- once review_after has passed and cleared again when the date moves forward
+
+ALTER TABLE knowledge
+    ADD COLUMN review_after TIMESTAMPTZ,
+    ADD COLUMN owner TEXT,
+    ADD COLUMN stale_since TIMESTAMPTZ;
+
+-- The stale job scans for overdue items that are not flagged yet
+CREATE INDEX idx_knowledge_review_after ON knowledge (review_after) WHERE review_after IS NOT NULL;
+
+-- Stale listing per org
+CREATE INDEX idx_knowledge_stale ON knowledge (org_id, stale_since) WHERE stale_since IS NOT NULL;
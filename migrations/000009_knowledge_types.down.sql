@@ -0,0 +1,3 @@
+-- Roll back org-defined knowledge types
+
+DROP TABLE IF EXISTS knowledge_types;
@@ -0,0 +1,15 @@
+-- Org-defined knowledge types; a row for a built-in type name overrides its display name,
+-- required sections and search boost for that org
+
+CREATE TABLE knowledge_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    name TEXT NOT NULL,
+    display_name TEXT NOT NULL,
+    required_sections TEXT[] NOT NULL DEFAULT '{}',
+    search_boost REAL NOT NULL DEFAULT 1.0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (search_boost > 0),
+    UNIQUE (org_id, name)
+);
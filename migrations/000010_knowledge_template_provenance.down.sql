@@ -0,0 +1,7 @@
+-- Roll back template provenance
+
+DROP INDEX IF EXISTS idx_knowledge_template_id;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_id;
@@ -0,0 +1,9 @@
+-- Provenance for knowledge instantiated from a template: the template item and the
+-- version number it was rendered from
+
+ALTER TABLE knowledge
+    ADD COLUMN template_id UUID REFERENCES knowledge(id) ON DELETE SET NULL,
+    ADD COLUMN template_version INT;
+
+-- Lookup of items derived from a template
+CREATE INDEX idx_knowledge_template_id ON knowledge (template_id) WHERE template_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Roll back checklist runs
+
+DROP TABLE IF EXISTS checklist_run_items;
+DROP TABLE IF EXISTS checklist_runs;
@@ -0,0 +1,30 @@
+-- Checklist runs: a tracked pass through one version of a checklist-type knowledge item.
+-- Items are copied from that version so a run stays auditable after the checklist is edited.
+
+CREATE TABLE checklist_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    knowledge_id UUID NOT NULL REFERENCES knowledge(id) ON DELETE CASCADE,
+    version_number INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'in_progress',
+    started_by TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_by TEXT,
+    completed_at TIMESTAMP,
+    note TEXT NOT NULL DEFAULT '',
+    CHECK (status IN ('in_progress', 'completed'))
+);
+
+CREATE TABLE checklist_run_items (
+    run_id UUID NOT NULL REFERENCES checklist_runs(id) ON DELETE CASCADE,
+    number INT NOT NULL,
+    text TEXT NOT NULL,
+    section TEXT NOT NULL DEFAULT '',
+    checked_by TEXT,
+    checked_at TIMESTAMP,
+    note TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (run_id, number)
+);
+
+-- Run history of a checklist, newest first
+CREATE INDEX idx_checklist_runs_knowledge ON checklist_runs (knowledge_id, started_at DESC);
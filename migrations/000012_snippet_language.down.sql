@@ -0,0 +1,16 @@
+-- Roll back code-aware lexical search
+
+DROP INDEX IF EXISTS idx_knowledge_chunks_language;
+DROP INDEX IF EXISTS idx_knowledge_language;
+DROP INDEX IF EXISTS idx_knowledge_chunks_code_tsv;
+DROP INDEX IF EXISTS idx_knowledge_code_tsv;
+
+ALTER TABLE knowledge_chunks
+    DROP COLUMN IF EXISTS code_tsv,
+    DROP COLUMN IF EXISTS language;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS code_tsv,
+    DROP COLUMN IF EXISTS language;
+
+DROP FUNCTION IF EXISTS code_search_tsvector(TEXT, BOOLEAN);
@@ -0,0 +1,67 @@
+-- Code-aware lexical search: a language for snippets (mirrored onto chunks for search filters)
+-- and a 'simple'-config tsvector over code, so identifiers like ctx.Done() or pgxpool.New
+-- are indexed as written instead of being stemmed by the 'english' config
+
+ALTER TABLE knowledge
+    ADD COLUMN language TEXT;
+
+ALTER TABLE knowledge_chunks
+    ADD COLUMN language TEXT;
+
+-- Code of a markdown text: the contents of its fenced code blocks, or the whole text when
+-- whole_is_code is set and there are no fences. Tokens are indexed as written and again
+-- split on punctuation and camelCase boundaries, so both "pgxpool.New" and "pgxpool" match.
+CREATE FUNCTION code_search_tsvector(content TEXT, whole_is_code BOOLEAN) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+AS $$
+    SELECT to_tsvector('simple'::regconfig, code) ||
+           to_tsvector('simple'::regconfig, regexp_replace(
+               regexp_replace(code, '([[:lower:][:digit:]])([[:upper:]])', '\1 \2', 'g'),
+               '[^[:alnum:]]+', ' ', 'g'))
+    FROM (
+        SELECT coalesce(
+            (SELECT string_agg(m[1], E'\n')
+             FROM regexp_matches(coalesce(content, ''),
+                                 '(?:```|~~~)[^\n]*\n((?:[^`~]|`(?!``)|~(?!~~))*)(?:```|~~~|$)', 'g') AS m),
+            CASE WHEN whole_is_code THEN content END,
+            ''
+        ) AS code
+    ) c
+$$;
+
+ALTER TABLE knowledge
+    ADD COLUMN code_tsv tsvector GENERATED ALWAYS AS (code_search_tsvector(body_md, type = 'snippet')) STORED;
+
+ALTER TABLE knowledge_chunks
+    ADD COLUMN code_tsv tsvector GENERATED ALWAYS AS (code_search_tsvector(content, type = 'snippet')) STORED;
+
+CREATE INDEX idx_knowledge_code_tsv ON knowledge USING GIN (code_tsv);
+CREATE INDEX idx_knowledge_chunks_code_tsv ON knowledge_chunks USING GIN (code_tsv);
+
+-- lang: search filter
+CREATE INDEX idx_knowledge_language ON knowledge (org_id, language) WHERE language IS NOT NULL;
+CREATE INDEX idx_knowledge_chunks_language ON knowledge_chunks (org_id, language) WHERE language IS NOT NULL;
+
+-- Existing snippets take the language of their first tagged code fence
+UPDATE knowledge
+SET language = lower(substring(body_md FROM '(?n)^[ \t]*(?:```|~~~)[ \t]*([A-Za-z0-9+#._-]+)'))
+WHERE type = 'snippet' AND language IS NULL;
+
+-- Same aliases as domain.NormalizeLanguage
+UPDATE knowledge
+SET language = alias.name
+FROM (VALUES ('golang', 'go'), ('js', 'javascript'), ('ts', 'typescript'), ('py', 'python'),
+             ('rb', 'ruby'), ('sh', 'bash'), ('shell', 'bash'), ('yml', 'yaml'), ('rs', 'rust'),
+             ('kt', 'kotlin'), ('cs', 'csharp'), ('c#', 'csharp'), ('cpp', 'c++'), ('psql', 'sql'))
+     AS alias(short, name)
+WHERE knowledge.language = alias.short;
+
+-- Drop info strings that are not language names, as domain.IsValidLanguage does
+UPDATE knowledge
+SET language = NULL
+WHERE language IS NOT NULL AND language !~ '^[a-z0-9][a-z0-9+#._-]{0,31}$';
+
+UPDATE knowledge_chunks c
+SET language = k.language
+FROM knowledge k
+WHERE k.id = c.knowledge_id AND k.language IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Roll back knowledge tombstones
+
+DROP TABLE IF EXISTS knowledge_tombstones;
@@ -0,0 +1,21 @@
+-- Tombstones for purged knowledge: who removed which item and how many rows went with it.
+-- Purges exist for erasure requests, so no title, body or other content is kept here.
+
+CREATE TABLE knowledge_tombstones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    knowledge_id UUID NOT NULL,
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    project_id UUID,
+    type TEXT NOT NULL,
+    purged_by TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    purged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    version_count INT NOT NULL DEFAULT 0,
+    chunk_count INT NOT NULL DEFAULT 0,
+    asset_link_count INT NOT NULL DEFAULT 0,
+    embedding_job_count INT NOT NULL DEFAULT 0,
+    search_log_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_knowledge_tombstones_org ON knowledge_tombstones (org_id, purged_at DESC);
+CREATE INDEX idx_knowledge_tombstones_knowledge ON knowledge_tombstones (knowledge_id);
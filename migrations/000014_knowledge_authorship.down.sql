@@ -0,0 +1,20 @@
+-- Roll back knowledge authorship
+
+DROP INDEX IF EXISTS idx_knowledge_chunks_updated_by;
+DROP INDEX IF EXISTS idx_knowledge_chunks_created_by;
+DROP INDEX IF EXISTS idx_knowledge_updated_by;
+DROP INDEX IF EXISTS idx_knowledge_created_by;
+
+ALTER TABLE knowledge_chunks
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by;
+
+ALTER TABLE knowledge_versions
+    DROP COLUMN IF EXISTS created_by_key_id,
+    DROP COLUMN IF EXISTS created_by;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS updated_by_key_id,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by_key_id,
+    DROP COLUMN IF EXISTS created_by;
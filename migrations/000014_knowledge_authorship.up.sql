@@ -0,0 +1,23 @@
+-- Authorship: the API key a version was written with and the agent or user name the
+-- client sent (or the key's name). Key IDs have no foreign key so provenance survives
+-- key revocation. Names are mirrored onto chunks for the author: search filter.
+
+ALTER TABLE knowledge
+    ADD COLUMN created_by TEXT,
+    ADD COLUMN created_by_key_id UUID,
+    ADD COLUMN updated_by TEXT,
+    ADD COLUMN updated_by_key_id UUID;
+
+ALTER TABLE knowledge_versions
+    ADD COLUMN created_by TEXT,
+    ADD COLUMN created_by_key_id UUID;
+
+ALTER TABLE knowledge_chunks
+    ADD COLUMN created_by TEXT,
+    ADD COLUMN updated_by TEXT;
+
+-- author: filter matches either name case-insensitively
+CREATE INDEX idx_knowledge_created_by ON knowledge (org_id, lower(created_by)) WHERE created_by IS NOT NULL;
+CREATE INDEX idx_knowledge_updated_by ON knowledge (org_id, lower(updated_by)) WHERE updated_by IS NOT NULL;
+CREATE INDEX idx_knowledge_chunks_created_by ON knowledge_chunks (org_id, lower(created_by)) WHERE created_by IS NOT NULL;
+CREATE INDEX idx_knowledge_chunks_updated_by ON knowledge_chunks (org_id, lower(updated_by)) WHERE updated_by IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Roll back knowledge comments
+
+DROP TABLE IF EXISTS knowledge_comments;
@@ -0,0 +1,27 @@
+-- Comment threads on knowledge items. A comment without a parent opens a thread, which
+-- may be anchored to a line range or search chunk of the version it was written against
+-- and is resolved as a whole; replies belong to their thread. Chunk IDs have no foreign
+-- key since re-embedding replaces chunks.
+
+CREATE TABLE knowledge_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id UUID NOT NULL REFERENCES organizations(id),
+    knowledge_id UUID NOT NULL REFERENCES knowledge(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES knowledge_comments(id) ON DELETE CASCADE,
+    version_number INT NOT NULL,
+    body TEXT NOT NULL,
+    author TEXT,
+    author_key_id UUID,
+    line_start INT,
+    line_end INT,
+    chunk_id UUID,
+    resolved_by TEXT,
+    resolved_by_key_id UUID,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (line_end IS NULL OR (line_start >= 0 AND line_end > line_start))
+);
+
+-- Threads of an item in the order they were written
+CREATE INDEX idx_knowledge_comments_knowledge ON knowledge_comments (knowledge_id, created_at);
+CREATE INDEX idx_knowledge_comments_parent ON knowledge_comments (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Roll back idempotency keys
+
+DROP TABLE IF EXISTS idempotency_keys;
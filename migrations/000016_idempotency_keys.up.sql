@@ -0,0 +1,18 @@
+-- Idempotency keys: the response to a mutating request made with an Idempotency-Key
+-- header, replayed when the request is retried. A row without a status code is reserved
+-- by a request that is still running. Expired rows are removed by a periodic job.
+
+CREATE TABLE idempotency_keys (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    route TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INT,
+    response_headers JSONB NOT NULL DEFAULT '{}',
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,7 @@
+-- Roll back source tracking
+
+DROP INDEX IF EXISTS idx_knowledge_source_path;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS source_hash,
+    DROP COLUMN IF EXISTS source_path;
@@ -0,0 +1,9 @@
+-- Source tracking for knowledge imported from files: the path of the file relative to the
+-- project and a hash of its contents, so that re-importing a directory updates the items
+-- it created instead of duplicating them and skips files that did not change.
+
+ALTER TABLE knowledge
+    ADD COLUMN source_path TEXT,
+    ADD COLUMN source_hash TEXT;
+
+CREATE INDEX idx_knowledge_source_path ON knowledge (org_id, project_id, source_path) WHERE source_path IS NOT NULL;
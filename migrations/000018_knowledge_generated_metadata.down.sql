@@ -0,0 +1,8 @@
+-- Roll back machine-generated metadata
+
+DROP INDEX IF EXISTS idx_knowledge_missing_summary;
+
+ALTER TABLE knowledge
+    DROP COLUMN IF EXISTS generated_at,
+    DROP COLUMN IF EXISTS suggested_title,
+    DROP COLUMN IF EXISTS summary_generated_by;
@@ -0,0 +1,14 @@
+-- Machine-generated metadata: summaries written by the summary generation job for items
+-- that arrived without one, and title suggestions that are shown but never applied.
+-- summary_generated_by names the model that wrote the current summary and is cleared when
+-- a person edits it; generated_at records when the job last looked at the item, so that an
+-- item is only looked at again after it changes. It is a TIMESTAMP like updated_at, which
+-- it is compared with.
+
+ALTER TABLE knowledge
+    ADD COLUMN summary_generated_by TEXT,
+    ADD COLUMN suggested_title TEXT,
+    ADD COLUMN generated_at TIMESTAMP;
+
+CREATE INDEX idx_knowledge_missing_summary ON knowledge (updated_at)
+    WHERE coalesce(summary, '') = '' AND status <> 'deprecated';
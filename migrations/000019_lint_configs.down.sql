@@ -0,0 +1,3 @@
+-- Roll back lint configuration
+
+DROP TABLE IF EXISTS lint_configs;
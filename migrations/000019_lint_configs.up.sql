@@ -0,0 +1,9 @@
+-- Per-organization lint configuration: the mode (off, warn or reject) and the rules that
+-- differ from their defaults, as JSON. Organizations without a row lint in warn mode
+-- with the default rules.
+
+CREATE TABLE lint_configs (
+    org_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    config JSONB NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -4,6 +4,7 @@ package e2e
 
 import (
 	"encoding/json"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -639,6 +640,400 @@ func TestE2E_CLIBatchJSONL(t *testing.T) {
 	})
 }
 
+// TestE2E_KnowledgeBatch tests that batches are written in one transaction
+func TestE2E_KnowledgeBatch(t *testing.T) {
+	env := SetupE2EEnv(t)
+	defer env.Cleanup()
+	env.Bootstrap()
+
+	countByTitle := func(title string) int {
+		var count int
+		require.NoError(t, env.Pool.QueryRow(env.Ctx, `SELECT count(*) FROM knowledge WHERE title = $1`, title).Scan(&count))
+		return count
+	}
+
+	var batch struct {
+		Results []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"results"`
+		Succeeded  int  `json:"succeeded"`
+		Failed     int  `json:"failed"`
+		RolledBack bool `json:"rolled_back"`
+	}
+
+	t.Run("atomic batch rolls back every item", func(t *testing.T) {
+		resp, err := env.Post("/knowledge/batch", map[string]interface{}{
+			"mode": "atomic",
+			"items": []map[string]interface{}{
+				{"type": "guideline", "title": "Batch Atomic 1", "body_md": "# One"},
+				{"type": "guideline", "title": "Batch Atomic 2", "body_md": "# Two", "project_id": "00000000-0000-0000-0000-000000000000"},
+			},
+		}, env.AuthToken)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(resp.Data, &batch))
+
+		assert.True(t, batch.RolledBack)
+		assert.Equal(t, "rolled_back", batch.Results[0].Status)
+		assert.Equal(t, "failed", batch.Results[1].Status)
+		assert.Equal(t, 0, countByTitle("Batch Atomic 1"))
+	})
+
+	t.Run("best-effort batch keeps the items that succeed", func(t *testing.T) {
+		resp, err := env.Post("/knowledge/batch", map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"type": "guideline", "title": "Batch Best Effort 1", "body_md": "# One"},
+				{"type": "guideline", "title": "Batch Best Effort 2", "body_md": "# Two", "project_id": "00000000-0000-0000-0000-000000000000"},
+				{"type": "guideline", "title": "Batch Best Effort 3", "body_md": "# Three"},
+			},
+		}, env.AuthToken)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(resp.Data, &batch))
+
+		assert.False(t, batch.RolledBack)
+		assert.Equal(t, 2, batch.Succeeded)
+		assert.Equal(t, 1, batch.Failed)
+		assert.Equal(t, 1, countByTitle("Batch Best Effort 1"))
+		assert.Equal(t, 1, countByTitle("Batch Best Effort 3"))
+	})
+
+	t.Run("batch deprecate", func(t *testing.T) {
+		resp, err := env.Post("/knowledge/batch/deprecate", map[string]interface{}{
+			"mode":  "atomic",
+			"items": []map[string]interface{}{{"id": batch.Results[0].ID}, {"id": batch.Results[2].ID}},
+		}, env.AuthToken)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(resp.Data, &batch))
+
+		assert.Equal(t, 2, batch.Succeeded)
+		assert.Equal(t, "deprecated", batch.Results[0].Status)
+	})
+}
+
+// TestE2E_KnowledgeMove tests rewriting the scope prefix of knowledge items
+func TestE2E_KnowledgeMove(t *testing.T) {
+	env := SetupE2EEnv(t)
+	defer env.Cleanup()
+	env.Bootstrap()
+
+	var ids []string
+	for _, scope := range []string{"services/auth", "services/auth/handlers", "services/authz"} {
+		resp, err := env.Post("/knowledge", map[string]interface{}{
+			"type":    "guideline",
+			"title":   "Scoped " + scope,
+			"body_md": "# Scoped",
+			"scope":   scope,
+		}, env.AuthToken)
+		require.NoError(t, err)
+		var k struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &k))
+		ids = append(ids, k.ID)
+	}
+
+	scopeOf := func(id string) string {
+		var scope string
+		require.NoError(t, env.Pool.QueryRow(env.Ctx, `SELECT scope_path FROM knowledge WHERE id = $1`, id).Scan(&scope))
+		return scope
+	}
+
+	var moved struct {
+		DryRun bool `json:"dry_run"`
+		Total  int  `json:"total"`
+		Items  []struct {
+			ID       string `json:"id"`
+			NewScope string `json:"new_scope"`
+			Version  int64  `json:"version"`
+		} `json:"items"`
+	}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		resp, err := env.Post("/knowledge/move", map[string]interface{}{
+			"from": "services/auth", "to": "platform/identity", "dry_run": true,
+		}, env.AuthToken)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(resp.Data, &moved))
+
+		assert.True(t, moved.DryRun)
+		assert.Equal(t, 2, moved.Total)
+		assert.Equal(t, "services/auth", scopeOf(ids[0]))
+	})
+
+	t.Run("move records a version without re-embedding", func(t *testing.T) {
+		resp, err := env.Post("/knowledge/move", map[string]interface{}{
+			"from": "services/auth", "to": "platform/identity",
+		}, env.AuthToken)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(resp.Data, &moved))
+
+		assert.Equal(t, 2, moved.Total)
+		assert.Equal(t, int64(2), moved.Items[0].Version)
+		assert.Equal(t, "platform/identity", scopeOf(ids[0]))
+		assert.Equal(t, "platform/identity/handlers", scopeOf(ids[1]))
+		assert.Equal(t, "services/authz", scopeOf(ids[2]))
+
+		var jobs int
+		require.NoError(t, env.Pool.QueryRow(env.Ctx, `SELECT count(*) FROM embedding_jobs WHERE knowledge_id = $1`, ids[0]).Scan(&jobs))
+		assert.Equal(t, 1, jobs)
+	})
+}
+
+func TestE2E_KnowledgeMerge(t *testing.T) {
+	env := SetupE2EEnv(t)
+	defer env.Cleanup()
+	env.Bootstrap()
+
+	var ids []string
+	for _, body := range []string{"Retry once before failing the build.", "Quarantine tests that fail twice.", "Raise CI timeouts."} {
+		resp, err := env.Post("/knowledge", map[string]interface{}{
+			"type":    "learning",
+			"title":   "Flaky tests",
+			"body_md": body,
+		}, env.AuthToken)
+		require.NoError(t, err)
+		var k struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &k))
+		ids = append(ids, k.ID)
+	}
+	target, source, other := ids[0], ids[1], ids[2]
+
+	_, err := env.Post("/knowledge/"+source+"/relations", map[string]interface{}{
+		"target_id": other,
+		"type":      "relates_to",
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	resp, err := env.Post("/knowledge/"+target+"/merge", map[string]interface{}{
+		"source_id": source,
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	var merged struct {
+		Knowledge struct {
+			BodyMD  string `json:"body_md"`
+			Version int64  `json:"version"`
+		} `json:"knowledge"`
+		Source struct {
+			Status       string `json:"status"`
+			SupersededBy string `json:"superseded_by"`
+		} `json:"source"`
+		Moved struct {
+			Relations int `json:"relations"`
+		} `json:"moved"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &merged))
+
+	assert.Equal(t, "Retry once before failing the build.\n\nQuarantine tests that fail twice.", merged.Knowledge.BodyMD)
+	assert.Equal(t, int64(2), merged.Knowledge.Version)
+	assert.Equal(t, "deprecated", merged.Source.Status)
+	assert.Equal(t, target, merged.Source.SupersededBy)
+	assert.Equal(t, 1, merged.Moved.Relations)
+
+	var relations int
+	require.NoError(t, env.Pool.QueryRow(env.Ctx,
+		`SELECT count(*) FROM knowledge_relations WHERE source_id = $1 AND target_id = $2`, target, other,
+	).Scan(&relations))
+	assert.Equal(t, 1, relations)
+
+	// The source keeps its own history
+	var sourceVersions int
+	require.NoError(t, env.Pool.QueryRow(env.Ctx, `SELECT count(*) FROM knowledge_versions WHERE knowledge_id = $1`, source).Scan(&sourceVersions))
+	assert.Equal(t, 1, sourceVersions)
+
+	_, err = env.Post("/knowledge/"+target+"/merge", map[string]interface{}{
+		"source_id": source,
+	}, env.AuthToken)
+	assert.Error(t, err)
+}
+
+func TestE2E_KnowledgeSources(t *testing.T) {
+	env := SetupE2EEnv(t)
+	defer env.Cleanup()
+	env.Bootstrap()
+
+	resp, err := env.Post("/knowledge/batch", map[string]interface{}{
+		"mode": "atomic",
+		"items": []map[string]interface{}{
+			{"type": "guideline", "title": "Deploys", "body_md": "Ship on Tuesdays.", "source_path": "docs/deploys.md", "source_hash": "h1"},
+			{"type": "learning", "title": "Not imported", "body_md": "Typed by hand."},
+		},
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	var batch struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &batch))
+	require.Len(t, batch.Results, 2)
+	imported := batch.Results[0].ID
+
+	type sources struct {
+		Items []struct {
+			ID         string `json:"id"`
+			SourcePath string `json:"source_path"`
+			SourceHash string `json:"source_hash"`
+		} `json:"items"`
+	}
+
+	resp, err = env.Get("/knowledge/sources", env.AuthToken)
+	require.NoError(t, err)
+	var listed sources
+	require.NoError(t, json.Unmarshal(resp.Data, &listed))
+	require.Len(t, listed.Items, 1)
+	assert.Equal(t, imported, listed.Items[0].ID)
+	assert.Equal(t, "docs/deploys.md", listed.Items[0].SourcePath)
+	assert.Equal(t, "h1", listed.Items[0].SourceHash)
+
+	_, err = env.Put("/knowledge/"+imported, map[string]interface{}{
+		"title":       "Deploys",
+		"body_md":     "Ship on Wednesdays.",
+		"source_hash": "h2",
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	resp, err = env.Get("/knowledge/sources", env.AuthToken)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(resp.Data, &listed))
+	require.Len(t, listed.Items, 1)
+	assert.Equal(t, "docs/deploys.md", listed.Items[0].SourcePath)
+	assert.Equal(t, "h2", listed.Items[0].SourceHash)
+}
+
+// TestE2E_ExportRestore exports an org and restores the archive into a second org
+func TestE2E_ExportRestore(t *testing.T) {
+	env := SetupE2EEnv(t)
+	defer env.Cleanup()
+	env.Bootstrap()
+
+	var ids []string
+	for _, title := range []string{"Deploys", "Rollbacks"} {
+		resp, err := env.Post("/knowledge", map[string]interface{}{
+			"type":    "guideline",
+			"title":   title,
+			"body_md": title + " happen on Tuesdays.",
+			"scope":   "services/api/",
+		}, env.AuthToken)
+		require.NoError(t, err)
+		var k struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &k))
+		ids = append(ids, k.ID)
+	}
+	_, err := env.Post("/knowledge/"+ids[1]+"/relations", map[string]interface{}{
+		"target_id": ids[0],
+		"type":      "depends_on",
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	fileContent := []byte("rollback runbook")
+	resp, err := env.Post("/assets/init", map[string]interface{}{
+		"filename":  "runbook.txt",
+		"mime_type": "text/plain",
+	}, env.AuthToken)
+	require.NoError(t, err)
+	var upload struct {
+		AssetID    string `json:"asset_id"`
+		StorageKey string `json:"storage_key"`
+		UploadURL  string `json:"upload_url"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &upload))
+	require.NoError(t, env.UploadFile(upload.UploadURL, fileContent, "text/plain"))
+	_, err = env.Post("/assets/complete", map[string]interface{}{
+		"asset_id":     upload.AssetID,
+		"storage_key":  upload.StorageKey,
+		"filename":     "runbook.txt",
+		"mime_type":    "text/plain",
+		"sha256":       SHA256Sum(fileContent),
+		"knowledge_id": ids[1],
+	}, env.AuthToken)
+	require.NoError(t, err)
+
+	status, archive, err := env.GetRaw("/export", env.AuthToken)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status, string(archive))
+
+	// A second org to restore into
+	orgResp, err := env.Post("/orgs", map[string]string{"name": "E2E Restore Org"}, "")
+	require.NoError(t, err)
+	var org struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(orgResp.Data, &org))
+	keyResp, err := env.Post("/apikeys", map[string]string{"org_id": org.ID, "name": "restore-key"}, "")
+	require.NoError(t, err)
+	var key struct {
+		Token string `json:"token"`
+	}
+	require.NoError(t, json.Unmarshal(keyResp.Data, &key))
+
+	t.Run("restoring the same IDs conflicts", func(t *testing.T) {
+		status, body, err := env.PostRaw("/admin/orgs/"+org.ID+"/restore", archive, "application/gzip", e2eAdminToken)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, status, string(body))
+	})
+
+	t.Run("restores with remapped IDs", func(t *testing.T) {
+		status, body, err := env.PostRaw("/admin/orgs/"+org.ID+"/restore?remap_ids=true&embeddings=true", archive, "application/gzip", e2eAdminToken)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, status, string(body))
+
+		var result struct {
+			Data struct {
+				Knowledge     int               `json:"knowledge"`
+				Versions      int               `json:"versions"`
+				Assets        int               `json:"assets"`
+				Links         int               `json:"links"`
+				Relations     int               `json:"relations"`
+				EmbeddingJobs int               `json:"embedding_jobs"`
+				IDs           map[string]string `json:"ids"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 2, result.Data.Knowledge)
+		assert.Equal(t, 2, result.Data.Versions)
+		assert.Equal(t, 1, result.Data.Assets)
+		assert.Equal(t, 1, result.Data.Links)
+		assert.Equal(t, 1, result.Data.Relations)
+		assert.Equal(t, 2, result.Data.EmbeddingJobs)
+
+		restoredID := result.Data.IDs[ids[0]]
+		require.NotEmpty(t, restoredID)
+		resp, err := env.Get("/knowledge/"+restoredID, key.Token)
+		require.NoError(t, err)
+		var k struct {
+			Title  string `json:"title"`
+			BodyMD string `json:"body_md"`
+			Scope  string `json:"scope"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &k))
+		assert.Equal(t, "Deploys", k.Title)
+		assert.Equal(t, "Deploys happen on Tuesdays.", k.BodyMD)
+		assert.Equal(t, "services/api/", k.Scope)
+
+		resp, err = env.Get("/assets/"+result.Data.IDs[upload.AssetID]+"/download", key.Token)
+		require.NoError(t, err)
+		var download struct {
+			DownloadURL string `json:"download_url"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &download))
+		data, err := env.DownloadFile(download.DownloadURL)
+		require.NoError(t, err)
+		assert.Equal(t, fileContent, data)
+	})
+
+	t.Run("rejects data that is not an archive", func(t *testing.T) {
+		status, _, err := env.PostRaw("/admin/orgs/"+org.ID+"/restore", []byte("not an archive"), "application/gzip", e2eAdminToken)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
 // TestE2E_ContextVFS tests the virtual filesystem endpoints (open/list)
 func TestE2E_ContextVFS(t *testing.T) {
 	env := SetupE2EEnv(t)
@@ -754,8 +1149,10 @@ func TestE2E_ContextVFS(t *testing.T) {
 	})
 
 	t.Run("context list: returns knowledge items", func(t *testing.T) {
+		// New knowledge is a draft, which is only listed when asked for
 		resp, err := env.Post("/context/list", map[string]interface{}{
 			"source_type": "knowledge",
+			"status":      "draft",
 			"limit":       50,
 		}, env.AuthToken)
 		require.NoError(t, err)
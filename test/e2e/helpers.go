@@ -28,6 +28,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// e2eAdminToken authorizes the /admin routes of the test server
+const e2eAdminToken = "e2e-admin-token"
+
 // E2ETestEnv holds all resources needed for E2E tests
 type E2ETestEnv struct {
 	T            *testing.T
@@ -282,6 +285,43 @@ func (e *E2ETestEnv) doRequest(method, path string, body interface{}, authToken
 	return &apiResp, nil
 }
 
+// GetRaw performs a GET request and returns the status code and raw response body
+func (e *E2ETestEnv) GetRaw(path, authToken string) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, e.ServerURL+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
+
+// PostRaw performs a POST request with a raw body and returns the status code and raw response body
+func (e *E2ETestEnv) PostRaw(path string, body []byte, contentType, authToken string) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, e.ServerURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+authToken)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, respBody, err
+}
+
 // UploadFile uploads a file to the presigned URL
 func (e *E2ETestEnv) UploadFile(uploadURL string, content []byte, contentType string) error {
 	req, err := http.NewRequest("PUT", uploadURL, bytes.NewReader(content))
@@ -334,10 +374,20 @@ func startServer(t *testing.T, pool *pgxpool.Pool, s3Client *storage.S3Client, p
 	orgRepo := repository.NewOrgRepository(pool)
 	apiKeyRepo := repository.NewAPIKeyRepository(pool)
 	projectRepo := repository.NewProjectRepository(pool)
+	relationRepo := repository.NewKnowledgeRelationRepository(pool)
+	checklistRunRepo := repository.NewChecklistRunRepository(pool)
+	commentRepo := repository.NewKnowledgeCommentRepository(pool)
+	purgeRepo := repository.NewKnowledgePurgeRepository(pool)
+	idempotencyRepo := repository.NewIdempotencyRepository(pool)
+	knowledgeTypeRepo := repository.NewKnowledgeTypeRepository(pool)
+	lintConfigRepo := repository.NewLintConfigRepository(pool)
+	archiveRepo := repository.NewOrgArchiveRepository(pool)
 
 	// Initialize services
 	uuidGen := &service.DefaultUUIDGenerator{}
-	knowledgeSvc := service.NewKnowledgeService(knowledgeRepo, embeddingJobRepo)
+	knowledgeTypeSvc := service.NewKnowledgeTypeService(knowledgeTypeRepo)
+	lintSvc := service.NewLintService(lintConfigRepo, knowledgeRepo, assetRepo, knowledgeTypeSvc)
+	knowledgeSvc := service.NewKnowledgeServiceWithLint(knowledgeRepo, embeddingJobRepo, repository.NewTxRunner(pool), knowledgeTypeSvc, nil, lintSvc)
 	assetSvc := service.NewAssetService(assetRepo, &s3StorageAdapter{client: s3Client})
 	authSvc := service.NewAuthService(orgRepo, apiKeyRepo, uuidGen)
 
@@ -351,17 +401,34 @@ func startServer(t *testing.T, pool *pgxpool.Pool, s3Client *storage.S3Client, p
 	authHandler := handlers.NewAuthHandler(authSvc)
 
 	// Create VFS service for context handler
-	vfsSvc := service.NewVFSService(knowledgeRepo, knowledgeChunkRepo, assetRepo, &s3StorageAdapter{client: s3Client}, contextRepo)
+	vfsSvc := service.NewVFSServiceWithComments(knowledgeRepo, knowledgeChunkRepo, assetRepo, &s3StorageAdapter{client: s3Client}, contextRepo, commentRepo)
 	contextHandler := handlers.NewContextHandlerWithVFS(&simpleContextService{repo: knowledgeRepo}, vfsSvc, nil)
 	projectHandler := handlers.NewProjectHandler(projectRepo)
+	relationHandler := handlers.NewRelationHandler(service.NewRelationService(relationRepo, knowledgeRepo))
+	knowledgeTypeHandler := handlers.NewKnowledgeTypeHandler(knowledgeTypeSvc)
+	lintHandler := handlers.NewLintHandler(lintSvc)
+	checklistHandler := handlers.NewChecklistHandler(service.NewChecklistService(checklistRunRepo, knowledgeRepo))
+	commentHandler := handlers.NewCommentHandler(service.NewCommentService(commentRepo, knowledgeRepo, knowledgeChunkRepo))
+	adminHandler := handlers.NewAdminHandler(service.NewPurgeService(purgeRepo))
+	archiveHandler := handlers.NewArchiveHandler(service.NewArchiveService(archiveRepo, &s3StorageAdapter{client: s3Client}))
 
 	cfg := server.RouterConfig{
-		AuthValidator:    authSvc,
-		KnowledgeHandler: knowledgeHandler,
-		AssetHandler:     assetHandler,
-		ContextHandler:   contextHandler,
-		AuthHandler:      authHandler,
-		ProjectHandler:   projectHandler,
+		AuthValidator:        authSvc,
+		KnowledgeHandler:     knowledgeHandler,
+		AssetHandler:         assetHandler,
+		ContextHandler:       contextHandler,
+		AuthHandler:          authHandler,
+		ProjectHandler:       projectHandler,
+		RelationHandler:      relationHandler,
+		KnowledgeTypeHandler: knowledgeTypeHandler,
+		LintHandler:          lintHandler,
+		ChecklistHandler:     checklistHandler,
+		CommentHandler:       commentHandler,
+		AdminHandler:         adminHandler,
+		ArchiveHandler:       archiveHandler,
+		AdminToken:           e2eAdminToken,
+		IdempotencyStore:     idempotencyRepo,
+		IdempotencyTTL:       24 * time.Hour,
 	}
 
 	router := server.NewRouter(cfg)
@@ -435,6 +502,14 @@ func (a *s3StorageAdapter) DeleteObject(ctx context.Context, key string) error {
 	return a.client.DeleteObject(ctx, key)
 }
 
+func (a *s3StorageAdapter) GetObject(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	return a.client.GetObject(ctx, key)
+}
+
+func (a *s3StorageAdapter) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	return a.client.PutObject(ctx, key, contentType, body, size)
+}
+
 func (a *s3StorageAdapter) HeadObject(ctx context.Context, key string) (*service.ObjectMetadata, error) {
 	meta, err := a.client.HeadObject(ctx, key)
 	if err != nil {